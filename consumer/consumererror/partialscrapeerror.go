@@ -0,0 +1,42 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consumererror
+
+// PartialScrapeError can be used to signalize that a subset of metrics failed
+// to be scraped, while the metrics that were successfully scraped are still
+// returned alongside the error.
+type PartialScrapeError struct {
+	error
+	// Failed is the number of metrics that failed to be scraped.
+	Failed int
+}
+
+// NewPartialScrapeError creates a PartialScrapeError for the given number of
+// metrics that failed to be scraped.
+func NewPartialScrapeError(err error, failed int) error {
+	return PartialScrapeError{
+		error:  err,
+		Failed: failed,
+	}
+}
+
+// IsPartialScrapeError checks if an error was created with NewPartialScrapeError.
+func IsPartialScrapeError(err error) bool {
+	if err != nil {
+		_, isPartial := err.(PartialScrapeError)
+		return isPartial
+	}
+	return false
+}
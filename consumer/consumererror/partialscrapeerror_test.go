@@ -12,8 +12,24 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package obsreportscraper
+package consumererror
 
-func scrapeMetricsSpanName(typeStr string) string {
-	return typeStr + "scraper.ScrapeMetrics"
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPartialScrapeError(t *testing.T) {
+	err := errors.New("some error")
+	partialErr := NewPartialScrapeError(err, 2)
+	assert.Equal(t, err.Error(), partialErr.Error())
+	assert.Equal(t, 2, partialErr.(PartialScrapeError).Failed)
+}
+
+func TestIsPartialScrapeError(t *testing.T) {
+	assert.False(t, IsPartialScrapeError(nil))
+	assert.False(t, IsPartialScrapeError(errors.New("some error")))
+	assert.True(t, IsPartialScrapeError(NewPartialScrapeError(errors.New("some error"), 1)))
 }
@@ -18,6 +18,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	otlplogs "go.opentelemetry.io/collector/internal/data/opentelemetry-proto-gen/logs/v1"
 )
@@ -63,6 +64,31 @@ func TestLogRecordCountWithNils(t *testing.T) {
 	}).LogRecordCount())
 }
 
+func TestLogsSize(t *testing.T) {
+	ld := NewLogs()
+	assert.Equal(t, 0, ld.Size())
+	rls := ld.ResourceLogs()
+	rls.Resize(1)
+	rls.At(0).InstrumentationLibraryLogs().Resize(1)
+	rls.At(0).InstrumentationLibraryLogs().At(0).Logs().Resize(1)
+	rls.At(0).InstrumentationLibraryLogs().At(0).Logs().At(0).SetName("foo")
+	otlp := LogsToOtlp(ld)
+	size := 0
+	sizeBytes := 0
+	for _, rlogs := range otlp {
+		size += rlogs.Size()
+		bts, err := rlogs.Marshal()
+		require.NoError(t, err)
+		sizeBytes += len(bts)
+	}
+	assert.Equal(t, size, ld.Size())
+	assert.Equal(t, sizeBytes, ld.Size())
+}
+
+func TestLogsSizeWithNils(t *testing.T) {
+	assert.Equal(t, 0, LogsFromOtlp([]*otlplogs.ResourceLogs{nil, {}}).Size())
+}
+
 func TestToFromLogProto(t *testing.T) {
 	otlp := []*otlplogs.ResourceLogs(nil)
 	td := LogsFromOtlp(otlp)
@@ -25,6 +25,7 @@ import (
 	"go.opentelemetry.io/collector/component/componenterror"
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/internal/componentstatus"
 )
 
 var (
@@ -55,8 +56,13 @@ type request interface {
 	onPartialError(consumererror.PartialError) request
 	// Returns the count of spans/metric points or log records.
 	count() int
+	// marshal serializes the data of the request, used to store the request in the persistent queue.
+	marshal() ([]byte, error)
 }
 
+// requestUnmarshaler recreates a request from the bytes returned by request.marshal.
+type requestUnmarshaler func([]byte) (request, error)
+
 // requestSender is an abstraction of a sender for a request independent of the type of the data (traces, metrics, logs).
 type requestSender interface {
 	send(req request) (int, error)
@@ -164,7 +170,9 @@ type baseExporter struct {
 	shutdownOnce sync.Once
 }
 
-func newBaseExporter(cfg configmodels.Exporter, options ...ExporterOption) *baseExporter {
+// newBaseExporter creates a baseExporter for the given data type, reqUnmarshaler is used by the persistent
+// queue to replay stored requests and may be nil if the exporter does not support it.
+func newBaseExporter(cfg configmodels.Exporter, dataType configmodels.DataType, reqUnmarshaler requestUnmarshaler, options ...ExporterOption) *baseExporter {
 	opts := fromConfiguredOptions(options...)
 	be := &baseExporter{
 		cfg:      cfg,
@@ -172,7 +180,12 @@ func newBaseExporter(cfg configmodels.Exporter, options ...ExporterOption) *base
 		shutdown: opts.Shutdown,
 	}
 
-	be.qrSender = newQueuedRetrySender(opts.QueueSettings, opts.RetrySettings, &timeoutSender{cfg: opts.TimeoutSettings})
+	be.qrSender = newQueuedRetrySender(
+		cfg.Name()+"_"+string(dataType),
+		opts.QueueSettings,
+		opts.RetrySettings,
+		reqUnmarshaler,
+		&timeoutSender{cfg: opts.TimeoutSettings})
 	be.sender = be.qrSender
 
 	return be
@@ -196,7 +209,7 @@ func (be *baseExporter) Start(ctx context.Context, host component.Host) error {
 		}
 
 		// If no error then start the queuedRetrySender.
-		be.qrSender.start()
+		err = be.qrSender.start()
 	})
 	return err
 }
@@ -209,6 +222,9 @@ func (be *baseExporter) Shutdown(ctx context.Context) error {
 		be.qrSender.shutdown()
 		// Last shutdown the wrapped exporter itself.
 		err = be.shutdown(ctx)
+		// Forget the status of the exporter, e.g. a backend of the loadbalancing exporter that is
+		// no longer resolved, so its past failures do not affect the health of the collector.
+		componentstatus.Remove(componentstatus.KindExporter, be.cfg.Name())
 	})
 	return err
 }
@@ -24,6 +24,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/internal/componentstatus"
 )
 
 var defaultExporterCfg = &configmodels.ExporterSettings{
@@ -37,7 +38,7 @@ func TestErrorToStatus(t *testing.T) {
 }
 
 func TestBaseExporter(t *testing.T) {
-	be := newBaseExporter(defaultExporterCfg)
+	be := newBaseExporter(defaultExporterCfg, configmodels.TracesDataType, nil)
 	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
 	require.NoError(t, be.Shutdown(context.Background()))
 }
@@ -45,6 +46,8 @@ func TestBaseExporter(t *testing.T) {
 func TestBaseExporterWithOptions(t *testing.T) {
 	be := newBaseExporter(
 		defaultExporterCfg,
+		configmodels.TracesDataType,
+		nil,
 		WithStart(func(ctx context.Context, host component.Host) error { return errors.New("my error") }),
 		WithShutdown(func(ctx context.Context) error { return errors.New("my error") }))
 	require.Error(t, be.Start(context.Background(), componenttest.NewNopHost()))
@@ -57,3 +60,16 @@ func errToStatus(err error) trace.Status {
 	}
 	return okStatus
 }
+
+func TestBaseExporter_ShutdownRemovesStatus(t *testing.T) {
+	componentstatus.Reset()
+	defer componentstatus.Reset()
+
+	be := newBaseExporter(defaultExporterCfg, configmodels.TracesDataType, nil)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	componentstatus.RecordResult(componentstatus.KindExporter, defaultExporterCfg.Name(), errors.New("my_error"))
+	require.Len(t, componentstatus.Snapshot(), 1)
+
+	require.NoError(t, be.Shutdown(context.Background()))
+	require.Empty(t, componentstatus.Snapshot())
+}
@@ -21,6 +21,7 @@ import (
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/pdata"
+	otlplogs "go.opentelemetry.io/collector/internal/data/opentelemetry-proto-gen/collector/logs/v1"
 	"go.opentelemetry.io/collector/obsreport"
 )
 
@@ -55,6 +56,24 @@ func (req *logsRequest) count() int {
 	return req.ld.LogRecordCount()
 }
 
+func (req *logsRequest) marshal() ([]byte, error) {
+	otlpReq := otlplogs.ExportLogsServiceRequest{
+		ResourceLogs: pdata.LogsToOtlp(req.ld),
+	}
+	return otlpReq.Marshal()
+}
+
+func newLogsRequestUnmarshaler(exporterName string, pusher PushLogsData) requestUnmarshaler {
+	return func(buf []byte) (request, error) {
+		otlpReq := otlplogs.ExportLogsServiceRequest{}
+		if err := otlpReq.Unmarshal(buf); err != nil {
+			return nil, err
+		}
+		ctx := obsreport.ExporterContext(context.Background(), exporterName)
+		return newLogsRequest(ctx, pdata.LogsFromOtlp(otlpReq.ResourceLogs), pusher), nil
+	}
+}
+
 type logsExporter struct {
 	*baseExporter
 	pushLogsData PushLogsData
@@ -76,7 +95,7 @@ func NewLogsExporter(cfg configmodels.Exporter, pushLogsData PushLogsData, optio
 		return nil, errNilPushLogsData
 	}
 
-	be := newBaseExporter(cfg, options...)
+	be := newBaseExporter(cfg, configmodels.LogsDataType, newLogsRequestUnmarshaler(cfg.Name(), pushLogsData), options...)
 	be.wrapConsumerSender(func(nextSender requestSender) requestSender {
 		return &logsExporterWithObservability{
 			exporterName: cfg.Name(),
@@ -23,6 +23,8 @@ import (
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/consumer/pdatautil"
+	"go.opentelemetry.io/collector/internal/data"
+	otlpmetrics "go.opentelemetry.io/collector/internal/data/opentelemetry-proto-gen/collector/metrics/v1"
 	"go.opentelemetry.io/collector/obsreport"
 )
 
@@ -55,7 +57,7 @@ func NewMetricsExporterOld(cfg configmodels.Exporter, pushMetricsData PushMetric
 	pushMetricsData = pushMetricsWithObservabilityOld(pushMetricsData, cfg.Name())
 
 	return &metricsExporterOld{
-		baseExporter:    newBaseExporter(cfg, options...),
+		baseExporter:    newBaseExporter(cfg, configmodels.MetricsDataType, nil, options...),
 		pushMetricsData: pushMetricsData,
 	}, nil
 }
@@ -116,6 +118,25 @@ func (req *metricsRequest) count() int {
 	return numPoints
 }
 
+func (req *metricsRequest) marshal() ([]byte, error) {
+	otlpReq := otlpmetrics.ExportMetricsServiceRequest{
+		ResourceMetrics: data.MetricDataToOtlp(pdatautil.MetricsToInternalMetrics(req.md)),
+	}
+	return otlpReq.Marshal()
+}
+
+func newMetricsRequestUnmarshaler(exporterName string, pusher PushMetricsData) requestUnmarshaler {
+	return func(buf []byte) (request, error) {
+		otlpReq := otlpmetrics.ExportMetricsServiceRequest{}
+		if err := otlpReq.Unmarshal(buf); err != nil {
+			return nil, err
+		}
+		ctx := obsreport.ExporterContext(context.Background(), exporterName)
+		md := pdatautil.MetricsFromInternalMetrics(data.MetricDataFromOtlp(otlpReq.ResourceMetrics))
+		return newMetricsRequest(ctx, md, pusher), nil
+	}
+}
+
 type metricsExporter struct {
 	*baseExporter
 	pusher PushMetricsData
@@ -138,7 +159,7 @@ func NewMetricsExporter(cfg configmodels.Exporter, pushMetricsData PushMetricsDa
 		return nil, errNilPushMetricsData
 	}
 
-	be := newBaseExporter(cfg, options...)
+	be := newBaseExporter(cfg, configmodels.MetricsDataType, newMetricsRequestUnmarshaler(cfg.Name(), pushMetricsData), options...)
 	be.wrapConsumerSender(func(nextSender requestSender) requestSender {
 		return &metricsSenderWithObservability{
 			exporterName: cfg.Name(),
@@ -0,0 +1,249 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporterhelper
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	// walFileExt is the extension of the files holding one queued request each.
+	walFileExt = ".wal"
+	// tmpFileExt is the extension of the files being written, these are renamed to walFileExt once
+	// fully synced to disk, so a crash never leaves a partially written request in the log.
+	tmpFileExt = ".tmp"
+)
+
+// persistentItem is a request stored in the write-ahead log that was not yet taken by a consumer.
+type persistentItem struct {
+	seq  uint64
+	size int64
+}
+
+// persistentQueue is a queue that stores every request in a write-ahead log in a local directory.
+// Requests are removed from the log only after a consumer finished processing them, so requests
+// that are queued (or in flight) when the collector stops or crashes are replayed on the next start.
+// Delivery is at-least-once: a request in flight during shutdown may be sent again after a restart.
+type persistentQueue struct {
+	dir         string
+	capacity    int
+	maxBytes    int64
+	unmarshaler requestUnmarshaler
+	// stopCh is closed when the exporter starts shutting down, requests processed after that are
+	// kept in the log because their processing was most likely interrupted.
+	stopCh <-chan struct{}
+
+	mu        sync.Mutex
+	cond      *sync.Cond
+	pending   []persistentItem
+	usedBytes int64
+	nextSeq   uint64
+	opened    bool
+	stopped   bool
+	stopWG    sync.WaitGroup
+}
+
+func newPersistentQueue(dir string, capacity int, maxBytes int64, unmarshaler requestUnmarshaler, stopCh <-chan struct{}) *persistentQueue {
+	pq := &persistentQueue{
+		dir:         dir,
+		capacity:    capacity,
+		maxBytes:    maxBytes,
+		unmarshaler: unmarshaler,
+		stopCh:      stopCh,
+	}
+	pq.cond = sync.NewCond(&pq.mu)
+	return pq
+}
+
+// open creates the queue directory if needed and loads the requests left by a previous run.
+func (pq *persistentQueue) open() error {
+	if err := os.MkdirAll(pq.dir, 0700); err != nil {
+		return fmt.Errorf("cannot create persistent queue directory: %w", err)
+	}
+	files, err := ioutil.ReadDir(pq.dir)
+	if err != nil {
+		return fmt.Errorf("cannot read persistent queue directory: %w", err)
+	}
+
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+	for _, fi := range files {
+		name := fi.Name()
+		switch filepath.Ext(name) {
+		case tmpFileExt:
+			// Leftover of a write interrupted by a crash, the request was never acknowledged as queued.
+			_ = os.Remove(filepath.Join(pq.dir, name))
+		case walFileExt:
+			seq, err := strconv.ParseUint(strings.TrimSuffix(name, walFileExt), 10, 64)
+			if err != nil {
+				continue
+			}
+			pq.pending = append(pq.pending, persistentItem{seq: seq, size: fi.Size()})
+			pq.usedBytes += fi.Size()
+			if seq >= pq.nextSeq {
+				pq.nextSeq = seq + 1
+			}
+		}
+	}
+	sort.Slice(pq.pending, func(i, j int) bool { return pq.pending[i].seq < pq.pending[j].seq })
+	pq.opened = true
+	return nil
+}
+
+// StartConsumers starts the given number of goroutines consuming requests from the queue.
+func (pq *persistentQueue) StartConsumers(num int, consumer func(item interface{})) {
+	for i := 0; i < num; i++ {
+		pq.stopWG.Add(1)
+		go func() {
+			defer pq.stopWG.Done()
+			for {
+				item, ok := pq.take()
+				if !ok {
+					return
+				}
+				pq.consume(item, consumer)
+			}
+		}()
+	}
+}
+
+// Produce writes the request to the log, returns false if the request cannot be queued.
+func (pq *persistentQueue) Produce(item interface{}) bool {
+	buf, err := item.(request).marshal()
+	if err != nil {
+		return false
+	}
+	size := int64(len(buf))
+
+	pq.mu.Lock()
+	if !pq.opened || pq.stopped || len(pq.pending) >= pq.capacity ||
+		(pq.maxBytes > 0 && pq.usedBytes+size > pq.maxBytes) {
+		pq.mu.Unlock()
+		return false
+	}
+	seq := pq.nextSeq
+	pq.nextSeq++
+	// Reserve the space before writing so concurrent producers cannot exceed maxBytes.
+	pq.usedBytes += size
+	pq.mu.Unlock()
+
+	if err = pq.write(seq, buf); err != nil {
+		pq.mu.Lock()
+		pq.usedBytes -= size
+		pq.mu.Unlock()
+		return false
+	}
+
+	pq.mu.Lock()
+	pq.pending = append(pq.pending, persistentItem{seq: seq, size: size})
+	pq.mu.Unlock()
+	pq.cond.Signal()
+	return true
+}
+
+// Stop stops all consumers, requests left in the log are replayed on the next start.
+func (pq *persistentQueue) Stop() {
+	pq.mu.Lock()
+	pq.stopped = true
+	pq.mu.Unlock()
+	pq.cond.Broadcast()
+	pq.stopWG.Wait()
+}
+
+// Size returns the number of requests waiting to be consumed.
+func (pq *persistentQueue) Size() int {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+	return len(pq.pending)
+}
+
+// take blocks until a request is available, returns false if the queue was stopped.
+func (pq *persistentQueue) take() (persistentItem, bool) {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+	for len(pq.pending) == 0 && !pq.stopped {
+		pq.cond.Wait()
+	}
+	if pq.stopped || pq.shuttingDown() {
+		return persistentItem{}, false
+	}
+	item := pq.pending[0]
+	pq.pending = pq.pending[1:]
+	return item, true
+}
+
+func (pq *persistentQueue) consume(item persistentItem, consumer func(item interface{})) {
+	buf, err := ioutil.ReadFile(pq.path(item.seq, walFileExt))
+	if err == nil {
+		var req request
+		// Requests that cannot be decoded are corrupted and dropped.
+		if req, err = pq.unmarshaler(buf); err == nil {
+			consumer(req)
+			if pq.shuttingDown() {
+				// Processing was most likely interrupted, keep the request for the next start.
+				return
+			}
+		}
+	}
+	pq.remove(item)
+}
+
+func (pq *persistentQueue) shuttingDown() bool {
+	select {
+	case <-pq.stopCh:
+		return true
+	default:
+		return false
+	}
+}
+
+func (pq *persistentQueue) write(seq uint64, buf []byte) error {
+	tmpPath := pq.path(seq, tmpFileExt)
+	f, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err = f.Write(buf); err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmpPath, pq.path(seq, walFileExt))
+	}
+	if err != nil {
+		_ = os.Remove(tmpPath)
+	}
+	return err
+}
+
+func (pq *persistentQueue) remove(item persistentItem) {
+	_ = os.Remove(pq.path(item.seq, walFileExt))
+	pq.mu.Lock()
+	pq.usedBytes -= item.size
+	pq.mu.Unlock()
+}
+
+func (pq *persistentQueue) path(seq uint64, ext string) string {
+	return filepath.Join(pq.dir, fmt.Sprintf("%020d%s", seq, ext))
+}
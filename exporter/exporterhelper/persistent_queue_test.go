@@ -0,0 +1,166 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporterhelper
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/consumer/pdatautil"
+	"go.opentelemetry.io/collector/internal/data/testdata"
+)
+
+func newPersistentQueueSettings(t *testing.T) QueueSettings {
+	dir, err := ioutil.TempDir("", "persistent_queue")
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, os.RemoveAll(dir)) })
+
+	qCfg := CreateDefaultQueueSettings()
+	qCfg.NumConsumers = 1
+	qCfg.StorageDirectory = dir
+	return qCfg
+}
+
+func walFiles(t *testing.T, qCfg QueueSettings) []string {
+	files, err := filepath.Glob(filepath.Join(qCfg.StorageDirectory, "*", "*"+walFileExt))
+	require.NoError(t, err)
+	return files
+}
+
+func TestPersistentQueue_HappyPath(t *testing.T) {
+	qCfg := newPersistentQueueSettings(t)
+	var spans int64
+	te, err := NewTraceExporter(fakeTraceExporterConfig, func(ctx context.Context, td pdata.Traces) (int, error) {
+		atomic.AddInt64(&spans, int64(td.SpanCount()))
+		return 0, nil
+	}, WithQueue(qCfg))
+	require.NoError(t, err)
+	require.NoError(t, te.Start(context.Background(), componenttest.NewNopHost()))
+
+	for i := 0; i < 10; i++ {
+		require.NoError(t, te.ConsumeTraces(context.Background(), testdata.GenerateTraceDataTwoSpansSameResource()))
+	}
+	assert.Eventually(t, func() bool { return atomic.LoadInt64(&spans) == 20 }, time.Second, 10*time.Millisecond)
+	require.NoError(t, te.Shutdown(context.Background()))
+	assert.Len(t, walFiles(t, qCfg), 0)
+}
+
+func TestPersistentQueue_ReplayAfterRestart(t *testing.T) {
+	qCfg := newPersistentQueueSettings(t)
+	rCfg := CreateDefaultRetrySettings()
+	var attempts int64
+	te, err := NewTraceExporter(fakeTraceExporterConfig, func(ctx context.Context, td pdata.Traces) (int, error) {
+		atomic.AddInt64(&attempts, 1)
+		return td.SpanCount(), errors.New("transient error")
+	}, WithQueue(qCfg), WithRetry(rCfg))
+	require.NoError(t, err)
+	require.NoError(t, te.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, te.ConsumeTraces(context.Background(), testdata.GenerateTraceDataTwoSpansSameResource()))
+	assert.Eventually(t, func() bool { return atomic.LoadInt64(&attempts) == 1 }, time.Second, 10*time.Millisecond)
+
+	// Shutdown interrupts the retry, the request must stay on disk.
+	require.NoError(t, te.Shutdown(context.Background()))
+	assert.Len(t, walFiles(t, qCfg), 1)
+
+	var spans int64
+	te, err = NewTraceExporter(fakeTraceExporterConfig, func(ctx context.Context, td pdata.Traces) (int, error) {
+		atomic.AddInt64(&spans, int64(td.SpanCount()))
+		return 0, nil
+	}, WithQueue(qCfg), WithRetry(rCfg))
+	require.NoError(t, err)
+	require.NoError(t, te.Start(context.Background(), componenttest.NewNopHost()))
+	assert.Eventually(t, func() bool { return atomic.LoadInt64(&spans) == 2 }, time.Second, 10*time.Millisecond)
+	require.NoError(t, te.Shutdown(context.Background()))
+	assert.Len(t, walFiles(t, qCfg), 0)
+}
+
+func TestPersistentQueue_MaxStorageBytes(t *testing.T) {
+	qCfg := newPersistentQueueSettings(t)
+	qCfg.NumConsumers = 0
+	td := testdata.GenerateTraceDataTwoSpansSameResource()
+	buf, err := newTracesRequest(context.Background(), td, nil).marshal()
+	require.NoError(t, err)
+	qCfg.MaxStorageBytes = int64(len(buf))
+
+	te, err := NewTraceExporter(fakeTraceExporterConfig, func(ctx context.Context, td pdata.Traces) (int, error) {
+		return 0, nil
+	}, WithQueue(qCfg))
+	require.NoError(t, err)
+	require.NoError(t, te.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, te.Shutdown(context.Background()))
+	})
+
+	require.NoError(t, te.ConsumeTraces(context.Background(), td))
+	assert.Equal(t, errorRefused, te.ConsumeTraces(context.Background(), td))
+	assert.Len(t, walFiles(t, qCfg), 1)
+}
+
+func TestPersistentQueue_DropCorruptedRequest(t *testing.T) {
+	qCfg := newPersistentQueueSettings(t)
+	dir := filepath.Join(qCfg.StorageDirectory, queueDirName(fakeTraceExporterName+"_traces"))
+	require.NoError(t, os.MkdirAll(dir, 0700))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "00000000000000000001"+walFileExt), []byte("corrupted"), 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "00000000000000000002"+tmpFileExt), []byte("partial"), 0600))
+
+	te, err := NewTraceExporter(fakeTraceExporterConfig, func(ctx context.Context, td pdata.Traces) (int, error) {
+		return 0, nil
+	}, WithQueue(qCfg))
+	require.NoError(t, err)
+	require.NoError(t, te.Start(context.Background(), componenttest.NewNopHost()))
+	assert.Eventually(t, func() bool { return len(walFiles(t, qCfg)) == 0 }, time.Second, 10*time.Millisecond)
+	require.NoError(t, te.Shutdown(context.Background()))
+
+	files, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, files, 0)
+}
+
+func TestPersistentQueue_MarshalRequests(t *testing.T) {
+	td := testdata.GenerateTraceDataTwoSpansSameResource()
+	buf, err := newTracesRequest(context.Background(), td, nil).marshal()
+	require.NoError(t, err)
+	req, err := newTracesRequestUnmarshaler(fakeTraceExporterName, nil)(buf)
+	require.NoError(t, err)
+	assert.EqualValues(t, td, req.(*tracesRequest).td)
+
+	md := pdatautil.MetricsFromInternalMetrics(testdata.GenerateMetricDataTwoMetrics())
+	buf, err = newMetricsRequest(context.Background(), md, nil).marshal()
+	require.NoError(t, err)
+	req, err = newMetricsRequestUnmarshaler(fakeMetricsExporterName, nil)(buf)
+	require.NoError(t, err)
+	assert.EqualValues(t, pdatautil.MetricsToInternalMetrics(md), pdatautil.MetricsToInternalMetrics(req.(*metricsRequest).md))
+
+	ld := testdata.GenerateLogDataTwoLogsSameResource()
+	buf, err = newLogsRequest(context.Background(), ld, nil).marshal()
+	require.NoError(t, err)
+	req, err = newLogsRequestUnmarshaler(fakeLogsExporterName, nil)(buf)
+	require.NoError(t, err)
+	assert.EqualValues(t, ld, req.(*logsRequest).ld)
+
+	_, err = newTracesRequestUnmarshaler(fakeTraceExporterName, nil)([]byte("corrupted"))
+	assert.Error(t, err)
+}
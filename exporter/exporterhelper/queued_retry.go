@@ -17,6 +17,8 @@ package exporterhelper
 import (
 	"errors"
 	"fmt"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/cenkalti/backoff"
@@ -33,6 +35,12 @@ type QueueSettings struct {
 	NumConsumers int `mapstructure:"num_consumers"`
 	// QueueSize is the maximum number of batches allowed in queue at a given time.
 	QueueSize int `mapstructure:"queue_size"`
+	// StorageDirectory enables the persistent queue when set. Every batch is written to a write-ahead log
+	// in a sub-directory of this directory, so batches survive a restart and are replayed on startup.
+	StorageDirectory string `mapstructure:"storage_directory"`
+	// MaxStorageBytes is the maximum number of bytes the persistent queue can use on disk, 0 means no limit.
+	// Ignored if StorageDirectory is not set.
+	MaxStorageBytes int64 `mapstructure:"max_storage_bytes"`
 }
 
 // CreateDefaultQueueSettings returns the default settings for QueueSettings.
@@ -73,17 +81,36 @@ func CreateDefaultRetrySettings() RetrySettings {
 	}
 }
 
+// requestQueue is the queue used by the queuedRetrySender to buffer requests before sending them.
+// It is implemented by the in-memory queue.BoundedQueue and by the persistentQueue.
+type requestQueue interface {
+	StartConsumers(num int, consumer func(item interface{}))
+	Produce(item interface{}) bool
+	Stop()
+	Size() int
+}
+
 type queuedRetrySender struct {
 	cfg            QueueSettings
 	consumerSender requestSender
-	queue          *queue.BoundedQueue
+	queue          requestQueue
 	retryStopCh    chan struct{}
 }
 
 var errorRefused = errors.New("failed to add to the queue")
 
-func newQueuedRetrySender(qCfg QueueSettings, rCfg RetrySettings, nextSender requestSender) *queuedRetrySender {
+// newQueuedRetrySender creates a queuedRetrySender, queueName identifies the persistent queue directory
+// of this sender and reqUnmarshaler is used to replay persisted requests. If reqUnmarshaler is nil the
+// requests are always queued in memory.
+func newQueuedRetrySender(queueName string, qCfg QueueSettings, rCfg RetrySettings, reqUnmarshaler requestUnmarshaler, nextSender requestSender) *queuedRetrySender {
 	retryStopCh := make(chan struct{})
+	var q requestQueue
+	if qCfg.StorageDirectory != "" && reqUnmarshaler != nil {
+		dir := filepath.Join(qCfg.StorageDirectory, queueDirName(queueName))
+		q = newPersistentQueue(dir, qCfg.QueueSize, qCfg.MaxStorageBytes, reqUnmarshaler, retryStopCh)
+	} else {
+		q = queue.NewBoundedQueue(qCfg.QueueSize, func(item interface{}) {})
+	}
 	return &queuedRetrySender{
 		cfg: qCfg,
 		consumerSender: &retrySender{
@@ -91,17 +118,29 @@ func newQueuedRetrySender(qCfg QueueSettings, rCfg RetrySettings, nextSender req
 			nextSender: nextSender,
 			stopCh:     retryStopCh,
 		},
-		queue:       queue.NewBoundedQueue(qCfg.QueueSize, func(item interface{}) {}),
+		queue:       q,
 		retryStopCh: retryStopCh,
 	}
 }
 
+// queueDirName returns a directory name for the given queue name, that contains the exporter name
+// which may include a "/".
+func queueDirName(queueName string) string {
+	return strings.NewReplacer("/", "_", "\\", "_").Replace(queueName)
+}
+
 // start is invoked during service startup.
-func (qrs *queuedRetrySender) start() {
+func (qrs *queuedRetrySender) start() error {
+	if pq, ok := qrs.queue.(*persistentQueue); ok && qrs.cfg.Enabled {
+		if err := pq.open(); err != nil {
+			return err
+		}
+	}
 	qrs.queue.StartConsumers(qrs.cfg.NumConsumers, func(item interface{}) {
 		value := item.(request)
 		_, _ = qrs.consumerSender.send(value)
 	})
+	return nil
 }
 
 // send implements the requestSender interface
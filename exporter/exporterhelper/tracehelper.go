@@ -22,6 +22,7 @@ import (
 	"go.opentelemetry.io/collector/consumer/consumerdata"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/pdata"
+	otlptrace "go.opentelemetry.io/collector/internal/data/opentelemetry-proto-gen/collector/trace/v1"
 	"go.opentelemetry.io/collector/obsreport"
 )
 
@@ -58,7 +59,7 @@ func NewTraceExporterOld(
 	dataPusher = dataPusher.withObservability(cfg.Name())
 
 	return &traceExporterOld{
-		baseExporter: newBaseExporter(cfg, options...),
+		baseExporter: newBaseExporter(cfg, configmodels.TracesDataType, nil, options...),
 		dataPusher:   dataPusher,
 	}, nil
 }
@@ -110,6 +111,24 @@ func (req *tracesRequest) count() int {
 	return req.td.SpanCount()
 }
 
+func (req *tracesRequest) marshal() ([]byte, error) {
+	otlpReq := otlptrace.ExportTraceServiceRequest{
+		ResourceSpans: pdata.TracesToOtlp(req.td),
+	}
+	return otlpReq.Marshal()
+}
+
+func newTracesRequestUnmarshaler(exporterName string, pusher traceDataPusher) requestUnmarshaler {
+	return func(buf []byte) (request, error) {
+		otlpReq := otlptrace.ExportTraceServiceRequest{}
+		if err := otlpReq.Unmarshal(buf); err != nil {
+			return nil, err
+		}
+		ctx := obsreport.ExporterContext(context.Background(), exporterName)
+		return newTracesRequest(ctx, pdata.TracesFromOtlp(otlpReq.ResourceSpans), pusher), nil
+	}
+}
+
 type traceExporter struct {
 	*baseExporter
 	pusher traceDataPusher
@@ -137,7 +156,7 @@ func NewTraceExporter(
 		return nil, errNilPushTraceData
 	}
 
-	be := newBaseExporter(cfg, options...)
+	be := newBaseExporter(cfg, configmodels.TracesDataType, newTracesRequestUnmarshaler(cfg.Name(), dataPusher), options...)
 	be.wrapConsumerSender(func(nextSender requestSender) requestSender {
 		return &tracesExporterWithObservability{
 			exporterName: cfg.Name(),
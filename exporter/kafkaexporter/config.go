@@ -27,8 +27,14 @@ type Config struct {
 	Brokers []string `mapstructure:"brokers"`
 	// Kafka protocol version
 	ProtocolVersion string `mapstructure:"protocol_version"`
-	// The name of the kafka topic to export to (default "otlp_spans")
+	// The name of the kafka topic to export to (default "otlp_spans" for traces,
+	// "otlp_metrics" for metrics and "otlp_logs" for logs)
 	Topic string `mapstructure:"topic"`
+	// Encoding of the messages (default "otlp_proto")
+	Encoding string `mapstructure:"encoding"`
+	// MessageKey selects the key of the messages, "none" or "trace_id" (default "none").
+	// Keying by trace ID sends all the spans of a trace to the same partition.
+	MessageKey string `mapstructure:"message_key"`
 
 	// Metadata is the namespace for metadata management properties used by the
 	// Client, and shared by the Producer/Consumer.
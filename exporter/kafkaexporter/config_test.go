@@ -42,8 +42,10 @@ func TestLoadConfig(t *testing.T) {
 			NameVal: typeStr,
 			TypeVal: typeStr,
 		},
-		Topic:   "spans",
-		Brokers: []string{"foo:123", "bar:456"},
+		Topic:      "spans",
+		Encoding:   "otlp_json",
+		MessageKey: "trace_id",
+		Brokers:    []string{"foo:123", "bar:456"},
 		Metadata: Metadata{
 			Full: false,
 			Retry: MetadataRetry{
@@ -24,9 +24,12 @@ import (
 )
 
 const (
-	typeStr       = "kafka"
-	defaultTopic  = "otlp_spans"
-	defaultBroker = "localhost:9092"
+	typeStr             = "kafka"
+	defaultTracesTopic  = "otlp_spans"
+	defaultMetricsTopic = "otlp_metrics"
+	defaultLogsTopic    = "otlp_logs"
+	defaultEncoding     = encodingOTLPProto
+	defaultBroker       = "localhost:9092"
 	// default from sarama.NewConfig()
 	defaultMetadataRetryMax = 3
 	// default from sarama.NewConfig()
@@ -40,7 +43,9 @@ func NewFactory() component.ExporterFactory {
 	return exporterhelper.NewFactory(
 		typeStr,
 		createDefaultConfig,
-		exporterhelper.WithTraces(createTraceExporter))
+		exporterhelper.WithTraces(createTraceExporter),
+		exporterhelper.WithMetrics(createMetricsExporter),
+		exporterhelper.WithLogs(createLogsExporter))
 }
 
 func createDefaultConfig() configmodels.Exporter {
@@ -50,7 +55,10 @@ func createDefaultConfig() configmodels.Exporter {
 			NameVal: typeStr,
 		},
 		Brokers: []string{defaultBroker},
-		Topic:   defaultTopic,
+		// The topic is left empty so the default depends on the data type.
+		Topic:      "",
+		Encoding:   defaultEncoding,
+		MessageKey: messageKeyNone,
 		Metadata: Metadata{
 			Full: defaultMetadataFull,
 			Retry: MetadataRetry{
@@ -66,8 +74,11 @@ func createTraceExporter(
 	params component.ExporterCreateParams,
 	cfg configmodels.Exporter,
 ) (component.TraceExporter, error) {
-	c := cfg.(*Config)
-	exp, err := newExporter(*c, params)
+	c := *cfg.(*Config)
+	if c.Topic == "" {
+		c.Topic = defaultTracesTopic
+	}
+	exp, err := newTracesExporter(c, params)
 	if err != nil {
 		return nil, err
 	}
@@ -76,3 +87,41 @@ func createTraceExporter(
 		exp.traceDataPusher,
 		exporterhelper.WithShutdown(exp.Close))
 }
+
+func createMetricsExporter(
+	_ context.Context,
+	params component.ExporterCreateParams,
+	cfg configmodels.Exporter,
+) (component.MetricsExporter, error) {
+	c := *cfg.(*Config)
+	if c.Topic == "" {
+		c.Topic = defaultMetricsTopic
+	}
+	exp, err := newMetricsExporter(c, params)
+	if err != nil {
+		return nil, err
+	}
+	return exporterhelper.NewMetricsExporter(
+		cfg,
+		exp.metricsDataPusher,
+		exporterhelper.WithShutdown(exp.Close))
+}
+
+func createLogsExporter(
+	_ context.Context,
+	params component.ExporterCreateParams,
+	cfg configmodels.Exporter,
+) (component.LogsExporter, error) {
+	c := *cfg.(*Config)
+	if c.Topic == "" {
+		c.Topic = defaultLogsTopic
+	}
+	exp, err := newLogsExporter(c, params)
+	if err != nil {
+		return nil, err
+	}
+	return exporterhelper.NewLogsExporter(
+		cfg,
+		exp.logsDataPusher,
+		exporterhelper.WithShutdown(exp.Close))
+}
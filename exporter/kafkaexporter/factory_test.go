@@ -30,7 +30,10 @@ func TestCreateDefaultConfig(t *testing.T) {
 	assert.NotNil(t, cfg, "failed to create default config")
 	assert.NoError(t, configcheck.ValidateConfig(cfg))
 	assert.Equal(t, []string{defaultBroker}, cfg.Brokers)
-	assert.Equal(t, defaultTopic, cfg.Topic)
+	// The default topic depends on the data type.
+	assert.Equal(t, "", cfg.Topic)
+	assert.Equal(t, defaultEncoding, cfg.Encoding)
+	assert.Equal(t, messageKeyNone, cfg.MessageKey)
 }
 
 func TestCreateTracesExporter(t *testing.T) {
@@ -53,3 +56,47 @@ func TestCreateTracesExporter_err(t *testing.T) {
 	require.Error(t, err)
 	assert.Nil(t, r)
 }
+
+func TestCreateMetricsExporter(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Brokers = []string{"invalid:9092"}
+	cfg.ProtocolVersion = "2.0.0"
+	// this disables contacting the broker so we can successfully create the exporter
+	cfg.Metadata.Full = false
+	r, err := createMetricsExporter(context.Background(), component.ExporterCreateParams{}, cfg)
+	require.NoError(t, err)
+	assert.NotNil(t, r)
+	// The shared config is not modified.
+	assert.Equal(t, "", cfg.Topic)
+}
+
+func TestCreateMetricsExporter_err(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Brokers = []string{"invalid:9092"}
+	cfg.ProtocolVersion = "2.0.0"
+	// we get the error because the exporter
+	r, err := createMetricsExporter(context.Background(), component.ExporterCreateParams{}, cfg)
+	require.Error(t, err)
+	assert.Nil(t, r)
+}
+
+func TestCreateLogsExporter(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Brokers = []string{"invalid:9092"}
+	cfg.ProtocolVersion = "2.0.0"
+	// this disables contacting the broker so we can successfully create the exporter
+	cfg.Metadata.Full = false
+	r, err := createLogsExporter(context.Background(), component.ExporterCreateParams{}, cfg)
+	require.NoError(t, err)
+	assert.NotNil(t, r)
+}
+
+func TestCreateLogsExporter_err(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Brokers = []string{"invalid:9092"}
+	cfg.ProtocolVersion = "2.0.0"
+	// we get the error because the exporter
+	r, err := createLogsExporter(context.Background(), component.ExporterCreateParams{}, cfg)
+	require.Error(t, err)
+	assert.Nil(t, r)
+}
@@ -16,6 +16,7 @@ package kafkaexporter
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/Shopify/sarama"
 	"go.uber.org/zap"
@@ -23,18 +24,123 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/consumer/pdatautil"
+	"go.opentelemetry.io/collector/internal/batchpertrace"
 )
 
-// kafkaProducer uses sarama to produce messages to Kafka.
-type kafkaProducer struct {
+const (
+	messageKeyNone    = "none"
+	messageKeyTraceID = "trace_id"
+)
+
+var errUnrecognizedEncoding = fmt.Errorf("unrecognized encoding")
+
+// kafkaTracesProducer uses sarama to produce trace messages to Kafka.
+type kafkaTracesProducer struct {
+	producer   sarama.SyncProducer
+	topic      string
+	marshaller tracesMarshaller
+	messageKey string
+	logger     *zap.Logger
+}
+
+func (e *kafkaTracesProducer) traceDataPusher(_ context.Context, td pdata.Traces) (int, error) {
+	var messages []*sarama.ProducerMessage
+	if e.messageKey == messageKeyTraceID {
+		for _, batch := range batchpertrace.Split(td) {
+			msgs, err := e.messages(batch.Traces, sarama.StringEncoder(batch.TraceID.String()))
+			if err != nil {
+				return td.SpanCount(), consumererror.Permanent(err)
+			}
+			messages = append(messages, msgs...)
+		}
+	} else {
+		msgs, err := e.messages(td, nil)
+		if err != nil {
+			return td.SpanCount(), consumererror.Permanent(err)
+		}
+		messages = msgs
+	}
+	err := e.producer.SendMessages(messages)
+	if err != nil {
+		return td.SpanCount(), err
+	}
+	return 0, nil
+}
+
+func (e *kafkaTracesProducer) messages(td pdata.Traces, key sarama.Encoder) ([]*sarama.ProducerMessage, error) {
+	payloads, err := e.marshaller.Marshal(td)
+	if err != nil {
+		return nil, err
+	}
+	return producerMessages(e.topic, key, payloads), nil
+}
+
+func (e *kafkaTracesProducer) Close(context.Context) error {
+	return e.producer.Close()
+}
+
+// kafkaMetricsProducer uses sarama to produce metric messages to Kafka.
+type kafkaMetricsProducer struct {
 	producer   sarama.SyncProducer
 	topic      string
-	marshaller marshaller
+	marshaller metricsMarshaller
 	logger     *zap.Logger
 }
 
-// newExporter creates Kafka exporter.
-func newExporter(config Config, params component.ExporterCreateParams) (*kafkaProducer, error) {
+func (e *kafkaMetricsProducer) metricsDataPusher(_ context.Context, md pdata.Metrics) (int, error) {
+	payloads, err := e.marshaller.Marshal(md)
+	if err != nil {
+		return pdatautil.MetricCount(md), consumererror.Permanent(err)
+	}
+	err = e.producer.SendMessages(producerMessages(e.topic, nil, payloads))
+	if err != nil {
+		return pdatautil.MetricCount(md), err
+	}
+	return 0, nil
+}
+
+func (e *kafkaMetricsProducer) Close(context.Context) error {
+	return e.producer.Close()
+}
+
+// kafkaLogsProducer uses sarama to produce log messages to Kafka.
+type kafkaLogsProducer struct {
+	producer   sarama.SyncProducer
+	topic      string
+	marshaller logsMarshaller
+	logger     *zap.Logger
+}
+
+func (e *kafkaLogsProducer) logsDataPusher(_ context.Context, ld pdata.Logs) (int, error) {
+	payloads, err := e.marshaller.Marshal(ld)
+	if err != nil {
+		return ld.LogRecordCount(), consumererror.Permanent(err)
+	}
+	err = e.producer.SendMessages(producerMessages(e.topic, nil, payloads))
+	if err != nil {
+		return ld.LogRecordCount(), err
+	}
+	return 0, nil
+}
+
+func (e *kafkaLogsProducer) Close(context.Context) error {
+	return e.producer.Close()
+}
+
+func producerMessages(topic string, key sarama.Encoder, payloads [][]byte) []*sarama.ProducerMessage {
+	messages := make([]*sarama.ProducerMessage, 0, len(payloads))
+	for _, payload := range payloads {
+		messages = append(messages, &sarama.ProducerMessage{
+			Topic: topic,
+			Key:   key,
+			Value: sarama.ByteEncoder(payload),
+		})
+	}
+	return messages
+}
+
+func newSaramaProducer(config Config) (sarama.SyncProducer, error) {
 	c := sarama.NewConfig()
 	// These setting are required by the sarama.SyncProducer implementation.
 	c.Producer.Return.Successes = true
@@ -51,34 +157,77 @@ func newExporter(config Config, params component.ExporterCreateParams) (*kafkaPr
 		}
 		c.Version = version
 	}
-	producer, err := sarama.NewSyncProducer(config.Brokers, c)
+	return sarama.NewSyncProducer(config.Brokers, c)
+}
+
+func newTracesExporter(config Config, params component.ExporterCreateParams) (*kafkaTracesProducer, error) {
+	marshaller, ok := tracesMarshallers()[config.Encoding]
+	if !ok {
+		return nil, fmt.Errorf("%w %q for traces", errUnrecognizedEncoding, config.Encoding)
+	}
+	switch config.MessageKey {
+	case "", messageKeyNone, messageKeyTraceID:
+	default:
+		return nil, fmt.Errorf("unrecognized message key %q, must be one of %s or %s", config.MessageKey, messageKeyNone, messageKeyTraceID)
+	}
+	producer, err := newSaramaProducer(config)
 	if err != nil {
 		return nil, err
 	}
-	return &kafkaProducer{
+	return &kafkaTracesProducer{
 		producer:   producer,
 		topic:      config.Topic,
-		marshaller: &protoMarshaller{},
+		marshaller: marshaller,
+		messageKey: config.MessageKey,
 		logger:     params.Logger,
 	}, nil
 }
 
-func (e *kafkaProducer) traceDataPusher(_ context.Context, td pdata.Traces) (int, error) {
-	bts, err := e.marshaller.Marshal(td)
+func newMetricsExporter(config Config, params component.ExporterCreateParams) (*kafkaMetricsProducer, error) {
+	marshaller, ok := metricsMarshallers()[config.Encoding]
+	if !ok {
+		return nil, fmt.Errorf("%w %q for metrics", errUnrecognizedEncoding, config.Encoding)
+	}
+	if err := validateNoMessageKey(config, "metrics"); err != nil {
+		return nil, err
+	}
+	producer, err := newSaramaProducer(config)
 	if err != nil {
-		return td.SpanCount(), consumererror.Permanent(err)
+		return nil, err
+	}
+	return &kafkaMetricsProducer{
+		producer:   producer,
+		topic:      config.Topic,
+		marshaller: marshaller,
+		logger:     params.Logger,
+	}, nil
+}
+
+func newLogsExporter(config Config, params component.ExporterCreateParams) (*kafkaLogsProducer, error) {
+	marshaller, ok := logsMarshallers()[config.Encoding]
+	if !ok {
+		return nil, fmt.Errorf("%w %q for logs", errUnrecognizedEncoding, config.Encoding)
 	}
-	m := &sarama.ProducerMessage{
-		Topic: e.topic,
-		Value: sarama.ByteEncoder(bts),
+	if err := validateNoMessageKey(config, "logs"); err != nil {
+		return nil, err
 	}
-	_, _, err = e.producer.SendMessage(m)
+	producer, err := newSaramaProducer(config)
 	if err != nil {
-		return td.SpanCount(), err
+		return nil, err
 	}
-	return 0, nil
+	return &kafkaLogsProducer{
+		producer:   producer,
+		topic:      config.Topic,
+		marshaller: marshaller,
+		logger:     params.Logger,
+	}, nil
 }
 
-func (e *kafkaProducer) Close(context.Context) error {
-	return e.producer.Close()
+// validateNoMessageKey returns an error if a message key is configured for a data type that
+// does not support it.
+func validateNoMessageKey(config Config, dataType string) error {
+	if config.MessageKey != "" && config.MessageKey != messageKeyNone {
+		return fmt.Errorf("message key %q is not supported for %s", config.MessageKey, dataType)
+	}
+	return nil
 }
@@ -16,6 +16,7 @@ package kafkaexporter
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
 
@@ -27,14 +28,52 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/consumer/pdatautil"
 	"go.opentelemetry.io/collector/internal/data/testdata"
 )
 
 func TestNewExporter_wrong_version(t *testing.T) {
-	c := Config{ProtocolVersion: "0.0.0"}
-	exp, err := newExporter(c, component.ExporterCreateParams{})
+	c := Config{ProtocolVersion: "0.0.0", Encoding: defaultEncoding}
+	texp, err := newTracesExporter(c, component.ExporterCreateParams{})
 	assert.Error(t, err)
-	assert.Nil(t, exp)
+	assert.Nil(t, texp)
+	mexp, err := newMetricsExporter(c, component.ExporterCreateParams{})
+	assert.Error(t, err)
+	assert.Nil(t, mexp)
+	lexp, err := newLogsExporter(c, component.ExporterCreateParams{})
+	assert.Error(t, err)
+	assert.Nil(t, lexp)
+}
+
+func TestNewExporter_encoding(t *testing.T) {
+	c := Config{Encoding: "foo"}
+	texp, err := newTracesExporter(c, component.ExporterCreateParams{})
+	assert.True(t, errors.Is(err, errUnrecognizedEncoding))
+	assert.Nil(t, texp)
+
+	// Trace only encodings are not supported for metrics and logs.
+	c = Config{Encoding: encodingJaegerProto}
+	mexp, err := newMetricsExporter(c, component.ExporterCreateParams{})
+	assert.True(t, errors.Is(err, errUnrecognizedEncoding))
+	assert.Nil(t, mexp)
+	lexp, err := newLogsExporter(c, component.ExporterCreateParams{})
+	assert.True(t, errors.Is(err, errUnrecognizedEncoding))
+	assert.Nil(t, lexp)
+}
+
+func TestNewExporter_messageKey(t *testing.T) {
+	c := Config{Encoding: defaultEncoding, MessageKey: "foo"}
+	texp, err := newTracesExporter(c, component.ExporterCreateParams{})
+	assert.EqualError(t, err, `unrecognized message key "foo", must be one of none or trace_id`)
+	assert.Nil(t, texp)
+
+	c = Config{Encoding: defaultEncoding, MessageKey: messageKeyTraceID}
+	mexp, err := newMetricsExporter(c, component.ExporterCreateParams{})
+	assert.EqualError(t, err, `message key "trace_id" is not supported for metrics`)
+	assert.Nil(t, mexp)
+	lexp, err := newLogsExporter(c, component.ExporterCreateParams{})
+	assert.EqualError(t, err, `message key "trace_id" is not supported for logs`)
+	assert.Nil(t, lexp)
 }
 
 func TestTraceDataPusher(t *testing.T) {
@@ -42,9 +81,9 @@ func TestTraceDataPusher(t *testing.T) {
 	producer := mocks.NewSyncProducer(t, c)
 	producer.ExpectSendMessageAndSucceed()
 
-	p := kafkaProducer{
+	p := kafkaTracesProducer{
 		producer:   producer,
-		marshaller: &protoMarshaller{},
+		marshaller: tracesMarshallers()[defaultEncoding],
 	}
 	defer p.Close(context.Background())
 	droppedSpans, err := p.traceDataPusher(context.Background(), testdata.GenerateTraceDataTwoSpansSameResource())
@@ -52,27 +91,53 @@ func TestTraceDataPusher(t *testing.T) {
 	assert.Equal(t, 0, droppedSpans)
 }
 
+func TestTraceDataPusher_traceIDKey(t *testing.T) {
+	td := testdata.GenerateTraceDataTwoSpansSameResource()
+	spans := td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans()
+	spans.At(0).SetTraceID(pdata.NewTraceID([]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}))
+	spans.At(1).SetTraceID(pdata.NewTraceID([]byte{16, 15, 14, 13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1}))
+
+	producer := &recordingProducer{}
+	p := kafkaTracesProducer{
+		producer:   producer,
+		marshaller: tracesMarshallers()[defaultEncoding],
+		messageKey: messageKeyTraceID,
+	}
+	defer p.Close(context.Background())
+	droppedSpans, err := p.traceDataPusher(context.Background(), td)
+	require.NoError(t, err)
+	assert.Equal(t, 0, droppedSpans)
+	var keys []string
+	for _, msg := range producer.messages {
+		key, err := msg.Key.Encode()
+		require.NoError(t, err)
+		keys = append(keys, string(key))
+	}
+	assert.Equal(t, []string{"0102030405060708090a0b0c0d0e0f10", "100f0e0d0c0b0a090807060504030201"}, keys)
+}
+
 func TestTraceDataPusher_err(t *testing.T) {
 	c := sarama.NewConfig()
 	producer := mocks.NewSyncProducer(t, c)
 	expErr := fmt.Errorf("failed to send")
 	producer.ExpectSendMessageAndFail(expErr)
 
-	p := kafkaProducer{
+	p := kafkaTracesProducer{
 		producer:   producer,
-		marshaller: &protoMarshaller{},
+		marshaller: tracesMarshallers()[defaultEncoding],
 		logger:     zap.NewNop(),
 	}
 	defer p.Close(context.Background())
 	td := testdata.GenerateTraceDataTwoSpansSameResource()
 	droppedSpans, err := p.traceDataPusher(context.Background(), td)
-	assert.EqualError(t, err, expErr.Error())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), expErr.Error())
 	assert.Equal(t, td.SpanCount(), droppedSpans)
 }
 
 func TestTraceDataPusher_marshall_error(t *testing.T) {
 	expErr := fmt.Errorf("failed to marshall")
-	p := kafkaProducer{
+	p := kafkaTracesProducer{
 		marshaller: &errorMarshaller{err: expErr},
 		logger:     zap.NewNop(),
 	}
@@ -82,12 +147,105 @@ func TestTraceDataPusher_marshall_error(t *testing.T) {
 	assert.Equal(t, td.SpanCount(), droppedSpans)
 }
 
+func TestMetricsDataPusher(t *testing.T) {
+	c := sarama.NewConfig()
+	producer := mocks.NewSyncProducer(t, c)
+	producer.ExpectSendMessageAndSucceed()
+
+	p := kafkaMetricsProducer{
+		producer:   producer,
+		marshaller: metricsMarshallers()[defaultEncoding],
+	}
+	defer p.Close(context.Background())
+	dropped, err := p.metricsDataPusher(context.Background(), pdatautil.MetricsFromInternalMetrics(testdata.GenerateMetricDataTwoMetrics()))
+	require.NoError(t, err)
+	assert.Equal(t, 0, dropped)
+}
+
+func TestMetricsDataPusher_err(t *testing.T) {
+	c := sarama.NewConfig()
+	producer := mocks.NewSyncProducer(t, c)
+	expErr := fmt.Errorf("failed to send")
+	producer.ExpectSendMessageAndFail(expErr)
+
+	p := kafkaMetricsProducer{
+		producer:   producer,
+		marshaller: metricsMarshallers()[defaultEncoding],
+		logger:     zap.NewNop(),
+	}
+	defer p.Close(context.Background())
+	md := pdatautil.MetricsFromInternalMetrics(testdata.GenerateMetricDataTwoMetrics())
+	dropped, err := p.metricsDataPusher(context.Background(), md)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), expErr.Error())
+	assert.Equal(t, pdatautil.MetricCount(md), dropped)
+}
+
+func TestLogsDataPusher(t *testing.T) {
+	c := sarama.NewConfig()
+	producer := mocks.NewSyncProducer(t, c)
+	producer.ExpectSendMessageAndSucceed()
+
+	p := kafkaLogsProducer{
+		producer:   producer,
+		marshaller: logsMarshallers()[defaultEncoding],
+	}
+	defer p.Close(context.Background())
+	dropped, err := p.logsDataPusher(context.Background(), testdata.GenerateLogDataTwoLogsSameResource())
+	require.NoError(t, err)
+	assert.Equal(t, 0, dropped)
+}
+
+func TestLogsDataPusher_err(t *testing.T) {
+	c := sarama.NewConfig()
+	producer := mocks.NewSyncProducer(t, c)
+	expErr := fmt.Errorf("failed to send")
+	producer.ExpectSendMessageAndFail(expErr)
+
+	p := kafkaLogsProducer{
+		producer:   producer,
+		marshaller: logsMarshallers()[defaultEncoding],
+		logger:     zap.NewNop(),
+	}
+	defer p.Close(context.Background())
+	ld := testdata.GenerateLogDataTwoLogsSameResource()
+	dropped, err := p.logsDataPusher(context.Background(), ld)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), expErr.Error())
+	assert.Equal(t, ld.LogRecordCount(), dropped)
+}
+
 type errorMarshaller struct {
 	err error
 }
 
-var _ marshaller = (*errorMarshaller)(nil)
+var _ tracesMarshaller = (*errorMarshaller)(nil)
 
-func (e errorMarshaller) Marshal(pdata.Traces) ([]byte, error) {
+func (e errorMarshaller) Marshal(pdata.Traces) ([][]byte, error) {
 	return nil, e.err
 }
+
+func (e errorMarshaller) Encoding() string {
+	panic("implement me")
+}
+
+// recordingProducer records the sent messages.
+type recordingProducer struct {
+	messages []*sarama.ProducerMessage
+}
+
+var _ sarama.SyncProducer = (*recordingProducer)(nil)
+
+func (r *recordingProducer) SendMessage(msg *sarama.ProducerMessage) (int32, int64, error) {
+	r.messages = append(r.messages, msg)
+	return 0, 0, nil
+}
+
+func (r *recordingProducer) SendMessages(msgs []*sarama.ProducerMessage) error {
+	r.messages = append(r.messages, msgs...)
+	return nil
+}
+
+func (r *recordingProducer) Close() error {
+	return nil
+}
@@ -15,22 +15,230 @@
 package kafkaexporter
 
 import (
+	"bytes"
+
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/gogo/protobuf/proto"
+	zipkinmodel "github.com/openzipkin/zipkin-go/model"
+	zipkinreporter "github.com/openzipkin/zipkin-go/reporter"
+
 	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/consumer/pdatautil"
+	"go.opentelemetry.io/collector/internal/data"
+	otlplogs "go.opentelemetry.io/collector/internal/data/opentelemetry-proto-gen/collector/logs/v1"
+	otlpmetrics "go.opentelemetry.io/collector/internal/data/opentelemetry-proto-gen/collector/metrics/v1"
 	otlptrace "go.opentelemetry.io/collector/internal/data/opentelemetry-proto-gen/collector/trace/v1"
+	"go.opentelemetry.io/collector/translator/internaldata"
+	"go.opentelemetry.io/collector/translator/trace/jaeger"
+	"go.opentelemetry.io/collector/translator/trace/zipkin"
+)
+
+const (
+	encodingOTLPProto   = "otlp_proto"
+	encodingOTLPJSON    = "otlp_json"
+	encodingJaegerProto = "jaeger_proto"
+	encodingZipkinJSON  = "zipkin_json"
+
+	// Same as the default of the zipkin exporter.
+	zipkinDefaultServiceName = "<missing service name>"
 )
 
-// marshaller encodes traces into a byte array to be sent to Kafka.
-type marshaller interface {
-	// Marshal serializes spans into byte array
-	Marshal(traces pdata.Traces) ([]byte, error)
+// tracesMarshaller serializes traces into the payloads of Kafka messages.
+type tracesMarshaller interface {
+	// Marshal serializes spans into one or more message payloads.
+	Marshal(traces pdata.Traces) ([][]byte, error)
+
+	// Encoding returns the encoding name used in the configuration.
+	Encoding() string
 }
 
-type protoMarshaller struct {
+// metricsMarshaller serializes metrics into the payloads of Kafka messages.
+type metricsMarshaller interface {
+	// Marshal serializes metrics into one or more message payloads.
+	Marshal(metrics pdata.Metrics) ([][]byte, error)
+
+	// Encoding returns the encoding name used in the configuration.
+	Encoding() string
 }
 
-func (m *protoMarshaller) Marshal(traces pdata.Traces) ([]byte, error) {
-	request := otlptrace.ExportTraceServiceRequest{
+// logsMarshaller serializes logs into the payloads of Kafka messages.
+type logsMarshaller interface {
+	// Marshal serializes logs into one or more message payloads.
+	Marshal(logs pdata.Logs) ([][]byte, error)
+
+	// Encoding returns the encoding name used in the configuration.
+	Encoding() string
+}
+
+// tracesMarshallers returns the map of supported encodings for traces.
+func tracesMarshallers() map[string]tracesMarshaller {
+	otlpProto := &otlpTracesMarshaller{encoding: encodingOTLPProto, marshal: marshalProto}
+	otlpJSON := &otlpTracesMarshaller{encoding: encodingOTLPJSON, marshal: marshalJSON}
+	jaegerProto := &jaegerProtoMarshaller{}
+	zipkinJSON := &zipkinJSONMarshaller{serializer: zipkinreporter.JSONSerializer{}}
+	return map[string]tracesMarshaller{
+		otlpProto.Encoding():   otlpProto,
+		otlpJSON.Encoding():    otlpJSON,
+		jaegerProto.Encoding(): jaegerProto,
+		zipkinJSON.Encoding():  zipkinJSON,
+	}
+}
+
+// metricsMarshallers returns the map of supported encodings for metrics.
+func metricsMarshallers() map[string]metricsMarshaller {
+	otlpProto := &otlpMetricsMarshaller{encoding: encodingOTLPProto, marshal: marshalProto}
+	otlpJSON := &otlpMetricsMarshaller{encoding: encodingOTLPJSON, marshal: marshalJSON}
+	return map[string]metricsMarshaller{
+		otlpProto.Encoding(): otlpProto,
+		otlpJSON.Encoding():  otlpJSON,
+	}
+}
+
+// logsMarshallers returns the map of supported encodings for logs.
+func logsMarshallers() map[string]logsMarshaller {
+	otlpProto := &otlpLogsMarshaller{encoding: encodingOTLPProto, marshal: marshalProto}
+	otlpJSON := &otlpLogsMarshaller{encoding: encodingOTLPJSON, marshal: marshalJSON}
+	return map[string]logsMarshaller{
+		otlpProto.Encoding(): otlpProto,
+		otlpJSON.Encoding():  otlpJSON,
+	}
+}
+
+// marshalProto serializes the OTLP request into protobuf wire format.
+func marshalProto(request proto.Message) ([]byte, error) {
+	return proto.Marshal(request)
+}
+
+// marshalJSON serializes the OTLP request into JSON.
+func marshalJSON(request proto.Message) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := (&jsonpb.Marshaler{}).Marshal(&buf, request); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+type otlpTracesMarshaller struct {
+	encoding string
+	marshal  func(proto.Message) ([]byte, error)
+}
+
+var _ tracesMarshaller = (*otlpTracesMarshaller)(nil)
+
+func (m *otlpTracesMarshaller) Marshal(traces pdata.Traces) ([][]byte, error) {
+	request := &otlptrace.ExportTraceServiceRequest{
 		ResourceSpans: pdata.TracesToOtlp(traces),
 	}
-	return request.Marshal()
+	bts, err := m.marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	return [][]byte{bts}, nil
+}
+
+func (m *otlpTracesMarshaller) Encoding() string {
+	return m.encoding
+}
+
+type otlpMetricsMarshaller struct {
+	encoding string
+	marshal  func(proto.Message) ([]byte, error)
+}
+
+var _ metricsMarshaller = (*otlpMetricsMarshaller)(nil)
+
+func (m *otlpMetricsMarshaller) Marshal(metrics pdata.Metrics) ([][]byte, error) {
+	request := &otlpmetrics.ExportMetricsServiceRequest{
+		ResourceMetrics: data.MetricDataToOtlp(pdatautil.MetricsToInternalMetrics(metrics)),
+	}
+	bts, err := m.marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	return [][]byte{bts}, nil
+}
+
+func (m *otlpMetricsMarshaller) Encoding() string {
+	return m.encoding
+}
+
+type otlpLogsMarshaller struct {
+	encoding string
+	marshal  func(proto.Message) ([]byte, error)
+}
+
+var _ logsMarshaller = (*otlpLogsMarshaller)(nil)
+
+func (m *otlpLogsMarshaller) Marshal(logs pdata.Logs) ([][]byte, error) {
+	request := &otlplogs.ExportLogsServiceRequest{
+		ResourceLogs: pdata.LogsToOtlp(logs),
+	}
+	bts, err := m.marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	return [][]byte{bts}, nil
+}
+
+func (m *otlpLogsMarshaller) Encoding() string {
+	return m.encoding
+}
+
+// jaegerProtoMarshaller writes one Jaeger protobuf span per message, which is the format
+// read by the Jaeger ingester.
+type jaegerProtoMarshaller struct {
+}
+
+var _ tracesMarshaller = (*jaegerProtoMarshaller)(nil)
+
+func (m *jaegerProtoMarshaller) Marshal(traces pdata.Traces) ([][]byte, error) {
+	batches, err := jaeger.InternalTracesToJaegerProto(traces)
+	if err != nil {
+		return nil, err
+	}
+	var payloads [][]byte
+	for _, batch := range batches {
+		for _, span := range batch.Spans {
+			span.Process = batch.Process
+			bts, err := span.Marshal()
+			if err != nil {
+				return nil, err
+			}
+			payloads = append(payloads, bts)
+		}
+	}
+	return payloads, nil
+}
+
+func (m *jaegerProtoMarshaller) Encoding() string {
+	return encodingJaegerProto
+}
+
+// zipkinJSONMarshaller writes a JSON array of Zipkin v2 spans per message.
+type zipkinJSONMarshaller struct {
+	serializer zipkinreporter.SpanSerializer
+}
+
+var _ tracesMarshaller = (*zipkinJSONMarshaller)(nil)
+
+func (m *zipkinJSONMarshaller) Marshal(traces pdata.Traces) ([][]byte, error) {
+	spans := make([]*zipkinmodel.SpanModel, 0, traces.SpanCount())
+	for _, octd := range internaldata.TraceDataToOC(traces) {
+		for _, span := range octd.Spans {
+			zs, err := zipkin.OCSpanProtoToZipkin(octd.Node, octd.Resource, span, zipkinDefaultServiceName)
+			if err != nil {
+				return nil, err
+			}
+			spans = append(spans, zs)
+		}
+	}
+	bts, err := m.serializer.Serialize(spans)
+	if err != nil {
+		return nil, err
+	}
+	return [][]byte{bts}, nil
+}
+
+func (m *zipkinJSONMarshaller) Encoding() string {
+	return encodingZipkinJSON
 }
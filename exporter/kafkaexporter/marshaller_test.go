@@ -15,22 +15,50 @@
 package kafkaexporter
 
 import (
+	"encoding/json"
 	"testing"
 
+	"github.com/gogo/protobuf/jsonpb"
+	jaegerproto "github.com/jaegertracing/jaeger/model"
+	zipkinmodel "github.com/openzipkin/zipkin-go/model"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/consumer/pdatautil"
+	"go.opentelemetry.io/collector/internal/data"
+	otlplogs "go.opentelemetry.io/collector/internal/data/opentelemetry-proto-gen/collector/logs/v1"
+	otlpmetrics "go.opentelemetry.io/collector/internal/data/opentelemetry-proto-gen/collector/metrics/v1"
 	otlptrace "go.opentelemetry.io/collector/internal/data/opentelemetry-proto-gen/collector/trace/v1"
+	"go.opentelemetry.io/collector/internal/data/testdata"
 )
 
+func TestDefaultMarshallers(t *testing.T) {
+	assert.Len(t, tracesMarshallers(), 4)
+	for _, e := range []string{encodingOTLPProto, encodingOTLPJSON, encodingJaegerProto, encodingZipkinJSON} {
+		m, ok := tracesMarshallers()[e]
+		require.True(t, ok, e)
+		assert.Equal(t, e, m.Encoding())
+	}
+	assert.Len(t, metricsMarshallers(), 2)
+	assert.Len(t, logsMarshallers(), 2)
+	for _, e := range []string{encodingOTLPProto, encodingOTLPJSON} {
+		mm, ok := metricsMarshallers()[e]
+		require.True(t, ok, e)
+		assert.Equal(t, e, mm.Encoding())
+		lm, ok := logsMarshallers()[e]
+		require.True(t, ok, e)
+		assert.Equal(t, e, lm.Encoding())
+	}
+}
+
 func TestMarshall(t *testing.T) {
 	td := pdata.NewTraces()
 	td.ResourceSpans().Resize(1)
 	td.ResourceSpans().At(0).Resource().InitEmpty()
 	td.ResourceSpans().At(0).Resource().Attributes().InsertString("foo", "bar")
-	m := protoMarshaller{}
-	bts, err := m.Marshal(td)
+	m := tracesMarshallers()[encodingOTLPProto]
+	payloads, err := m.Marshal(td)
 	require.NoError(t, err)
 
 	request := &otlptrace.ExportTraceServiceRequest{
@@ -38,12 +66,98 @@ func TestMarshall(t *testing.T) {
 	}
 	expected, err := request.Marshal()
 	require.NoError(t, err)
-	assert.Equal(t, expected, bts)
+	assert.Equal(t, [][]byte{expected}, payloads)
 }
 
 func TestMarshall_empty(t *testing.T) {
-	m := protoMarshaller{}
-	bts, err := m.Marshal(pdata.NewTraces())
+	m := tracesMarshallers()[encodingOTLPProto]
+	payloads, err := m.Marshal(pdata.NewTraces())
+	require.NoError(t, err)
+	assert.Len(t, payloads, 1)
+	assert.NotNil(t, payloads[0])
+}
+
+func TestMarshallTracesJSON(t *testing.T) {
+	td := testdata.GenerateTraceDataTwoSpansSameResource()
+	payloads, err := tracesMarshallers()[encodingOTLPJSON].Marshal(td)
+	require.NoError(t, err)
+	require.Len(t, payloads, 1)
+
+	request := &otlptrace.ExportTraceServiceRequest{}
+	require.NoError(t, jsonpb.UnmarshalString(string(payloads[0]), request))
+	assert.Equal(t, td, pdata.TracesFromOtlp(request.ResourceSpans))
+}
+
+func TestMarshallJaegerProto(t *testing.T) {
+	td := generateTracesWithIDs()
+	payloads, err := tracesMarshallers()[encodingJaegerProto].Marshal(td)
+	require.NoError(t, err)
+	// One message per span.
+	require.Len(t, payloads, 2)
+	for i, payload := range payloads {
+		span := &jaegerproto.Span{}
+		require.NoError(t, span.Unmarshal(payload))
+		assert.Equal(t, td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(i).Name(), span.OperationName)
+		assert.NotNil(t, span.Process)
+	}
+}
+
+func TestMarshallZipkinJSON(t *testing.T) {
+	td := generateTracesWithIDs()
+	payloads, err := tracesMarshallers()[encodingZipkinJSON].Marshal(td)
 	require.NoError(t, err)
-	assert.NotNil(t, bts)
+	require.Len(t, payloads, 1)
+
+	var spans []zipkinmodel.SpanModel
+	require.NoError(t, json.Unmarshal(payloads[0], &spans))
+	require.Len(t, spans, 2)
+	assert.Equal(t, td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0).Name(), spans[0].Name)
+}
+
+func TestMarshallMetrics(t *testing.T) {
+	md := pdatautil.MetricsFromInternalMetrics(testdata.GenerateMetricDataTwoMetrics())
+
+	payloads, err := metricsMarshallers()[encodingOTLPProto].Marshal(md)
+	require.NoError(t, err)
+	require.Len(t, payloads, 1)
+	request := &otlpmetrics.ExportMetricsServiceRequest{}
+	require.NoError(t, request.Unmarshal(payloads[0]))
+	assert.Equal(t, pdatautil.MetricsToInternalMetrics(md), data.MetricDataFromOtlp(request.ResourceMetrics))
+
+	payloads, err = metricsMarshallers()[encodingOTLPJSON].Marshal(md)
+	require.NoError(t, err)
+	require.Len(t, payloads, 1)
+	request = &otlpmetrics.ExportMetricsServiceRequest{}
+	require.NoError(t, jsonpb.UnmarshalString(string(payloads[0]), request))
+	assert.Equal(t, pdatautil.MetricsToInternalMetrics(md), data.MetricDataFromOtlp(request.ResourceMetrics))
+}
+
+func TestMarshallLogs(t *testing.T) {
+	ld := testdata.GenerateLogDataTwoLogsSameResource()
+
+	payloads, err := logsMarshallers()[encodingOTLPProto].Marshal(ld)
+	require.NoError(t, err)
+	require.Len(t, payloads, 1)
+	request := &otlplogs.ExportLogsServiceRequest{}
+	require.NoError(t, request.Unmarshal(payloads[0]))
+	assert.Equal(t, ld, pdata.LogsFromOtlp(request.ResourceLogs))
+
+	payloads, err = logsMarshallers()[encodingOTLPJSON].Marshal(ld)
+	require.NoError(t, err)
+	require.Len(t, payloads, 1)
+	request = &otlplogs.ExportLogsServiceRequest{}
+	require.NoError(t, jsonpb.UnmarshalString(string(payloads[0]), request))
+	assert.Equal(t, ld, pdata.LogsFromOtlp(request.ResourceLogs))
+}
+
+// generateTracesWithIDs generates two spans with trace and span IDs, which are required by
+// the Jaeger and Zipkin translators.
+func generateTracesWithIDs() pdata.Traces {
+	td := testdata.GenerateTraceDataTwoSpansSameResource()
+	spans := td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans()
+	for i := 0; i < spans.Len(); i++ {
+		spans.At(i).SetTraceID(pdata.NewTraceID([]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}))
+		spans.At(i).SetSpanID(pdata.NewSpanID([]byte{1, 2, 3, 4, 5, 6, 7, byte(i + 1)}))
+	}
+	return td
 }
@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/exporter/otlpexporter"
+)
+
+// Config defines configuration for the load balancing exporter.
+type Config struct {
+	configmodels.ExporterSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+
+	// Protocol used to send the data to the backends.
+	Protocol Protocol `mapstructure:"protocol"`
+	// Resolver discovers the backends, exactly one resolver must be configured.
+	Resolver ResolverSettings `mapstructure:"resolver"`
+}
+
+// Protocol holds the individual protocol-specific settings. Only OTLP is supported at the moment.
+type Protocol struct {
+	// OTLP is the configuration of the OTLP exporter created for each backend, its endpoint
+	// is replaced by the backend address.
+	OTLP otlpexporter.Config `mapstructure:"otlp"`
+}
+
+// ResolverSettings defines the configurations for the backend resolver.
+type ResolverSettings struct {
+	// Static resolves to a fixed list of backends.
+	Static *StaticResolver `mapstructure:"static"`
+	// DNS resolves the backends from the IP addresses of a hostname.
+	DNS *DNSResolver `mapstructure:"dns"`
+}
+
+// StaticResolver defines the configuration for the resolver providing a fixed list of backends.
+type StaticResolver struct {
+	// Hostnames of the backends, e.g. "backend-1:55680".
+	Hostnames []string `mapstructure:"hostnames"`
+}
+
+// DNSResolver defines the configuration for the DNS resolver.
+type DNSResolver struct {
+	// Hostname is resolved to the IP addresses of the backends.
+	Hostname string `mapstructure:"hostname"`
+	// Port of the backends, defaults to the OTLP port 55680.
+	Port string `mapstructure:"port"`
+	// Interval between DNS resolutions, defaults to 5s.
+	Interval time.Duration `mapstructure:"interval"`
+	// Timeout of each DNS resolution, defaults to 1s.
+	Timeout time.Duration `mapstructure:"timeout"`
+}
@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.ExampleComponents()
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Exporters[typeStr] = factory
+	cfg, err := configtest.LoadConfigFile(t, path.Join(".", "testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	static := cfg.Exporters["loadbalancing"].(*Config)
+	assert.True(t, static.Protocol.OTLP.TLSSetting.Insecure)
+	require.NotNil(t, static.Resolver.Static)
+	assert.Equal(t, []string{"endpoint-1:55680", "endpoint-2:55680"}, static.Resolver.Static.Hostnames)
+	assert.Nil(t, static.Resolver.DNS)
+
+	dns := cfg.Exporters["loadbalancing/dns"].(*Config)
+	assert.Equal(t, 2*time.Second, dns.Protocol.OTLP.Timeout)
+	// Other OTLP settings keep their defaults.
+	assert.Equal(t, 512*1024, dns.Protocol.OTLP.WriteBufferSize)
+	assert.Nil(t, dns.Resolver.Static)
+	assert.Equal(t, &DNSResolver{
+		Hostname: "otelcol-backends.observability.svc.cluster.local",
+		Port:     "55690",
+		Interval: 10 * time.Second,
+		Timeout:  2 * time.Second,
+	}, dns.Resolver.DNS)
+}
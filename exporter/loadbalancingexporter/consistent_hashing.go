@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"fmt"
+	"hash/crc32"
+	"sort"
+)
+
+// numPoints is the number of positions each endpoint takes on the ring. More positions spread
+// the traces more evenly, at the cost of a bigger ring.
+const numPoints = 100
+
+// ringItem is a position on the ring, owned by an endpoint.
+type ringItem struct {
+	position uint32
+	endpoint string
+}
+
+// hashRing is a consistent hashing ring: each trace ID belongs to the endpoint owning the next
+// position on the ring. Adding or removing an endpoint only moves the trace IDs of the positions
+// of that endpoint, the other trace IDs keep going to the same endpoint.
+type hashRing struct {
+	// endpoints are sorted, so two rings with the same endpoints are equal.
+	endpoints []string
+	items     []ringItem
+}
+
+// newHashRing builds the ring for the given endpoints, duplicated endpoints are ignored.
+func newHashRing(endpoints []string) *hashRing {
+	sorted := make([]string, 0, len(endpoints))
+	seen := make(map[string]struct{}, len(endpoints))
+	for _, endpoint := range endpoints {
+		if _, ok := seen[endpoint]; ok {
+			continue
+		}
+		seen[endpoint] = struct{}{}
+		sorted = append(sorted, endpoint)
+	}
+	sort.Strings(sorted)
+
+	items := make([]ringItem, 0, len(sorted)*numPoints)
+	for _, endpoint := range sorted {
+		for i := 0; i < numPoints; i++ {
+			items = append(items, ringItem{
+				position: crc32.ChecksumIEEE([]byte(fmt.Sprintf("%s-%d", endpoint, i))),
+				endpoint: endpoint,
+			})
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].position == items[j].position {
+			// Position collisions are resolved deterministically.
+			return items[i].endpoint < items[j].endpoint
+		}
+		return items[i].position < items[j].position
+	})
+	return &hashRing{endpoints: sorted, items: items}
+}
+
+// endpointFor returns the endpoint owning the given identifier, or an empty string if the
+// ring has no endpoints.
+func (h *hashRing) endpointFor(identifier []byte) string {
+	if len(h.items) == 0 {
+		return ""
+	}
+	position := crc32.ChecksumIEEE(identifier)
+	i := sort.Search(len(h.items), func(i int) bool { return h.items[i].position >= position })
+	if i == len(h.items) {
+		// Wrap around the ring.
+		i = 0
+	}
+	return h.items[i].endpoint
+}
+
+// equal returns whether both rings have the same endpoints.
+func (h *hashRing) equal(other *hashRing) bool {
+	return other != nil && equalStrings(h.endpoints, other.endpoints)
+}
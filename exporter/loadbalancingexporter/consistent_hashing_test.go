@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	tracetranslator "go.opentelemetry.io/collector/translator/trace"
+)
+
+func traceIDs(n int) [][]byte {
+	ids := make([][]byte, n)
+	for i := range ids {
+		ids[i] = tracetranslator.UInt64ToByteTraceID(uint64(i), uint64(i)*7919)
+	}
+	return ids
+}
+
+func TestHashRing_Empty(t *testing.T) {
+	ring := newHashRing(nil)
+	assert.Equal(t, "", ring.endpointFor([]byte{1, 2, 3}))
+}
+
+func TestHashRing_SameEndpointForTraceID(t *testing.T) {
+	ring := newHashRing([]string{"endpoint-1", "endpoint-2", "endpoint-3"})
+	// The order of the endpoints doesn't matter.
+	other := newHashRing([]string{"endpoint-3", "endpoint-1", "endpoint-2", "endpoint-1"})
+	assert.True(t, ring.equal(other))
+	for _, id := range traceIDs(1000) {
+		assert.Equal(t, ring.endpointFor(id), other.endpointFor(id))
+	}
+}
+
+func TestHashRing_Distribution(t *testing.T) {
+	endpoints := []string{"endpoint-1", "endpoint-2", "endpoint-3", "endpoint-4"}
+	ring := newHashRing(endpoints)
+	counts := map[string]int{}
+	const n = 10000
+	for _, id := range traceIDs(n) {
+		counts[ring.endpointFor(id)]++
+	}
+	assert.Len(t, counts, len(endpoints))
+	for endpoint, count := range counts {
+		// Each endpoint should get roughly a quarter of the traces.
+		assert.InDelta(t, n/len(endpoints), count, n/10, fmt.Sprintf("endpoint %s", endpoint))
+	}
+}
+
+func TestHashRing_MinimalMovesOnChange(t *testing.T) {
+	before := newHashRing([]string{"endpoint-1", "endpoint-2", "endpoint-3"})
+	after := newHashRing([]string{"endpoint-1", "endpoint-2", "endpoint-3", "endpoint-4"})
+	assert.False(t, before.equal(after))
+
+	for _, id := range traceIDs(1000) {
+		if moved := after.endpointFor(id); moved != before.endpointFor(id) {
+			// Traces only move to the new endpoint.
+			assert.Equal(t, "endpoint-4", moved)
+		}
+	}
+}
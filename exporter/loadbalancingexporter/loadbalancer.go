@@ -0,0 +1,261 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/batchpertrace"
+)
+
+const (
+	// defaultRetryInterval is the interval between attempts to create the exporters of the
+	// backends that failed to be created.
+	defaultRetryInterval = 5 * time.Second
+)
+
+var (
+	errNoResolver                = errors.New("no resolvers specified for the exporter")
+	errMultipleResolversProvided = errors.New("only one resolver should be specified")
+	errNoBackends                = errors.New("no backends available to export the data")
+)
+
+// loadBalancerImp sends each trace to one of the backends, chosen by consistent hashing of
+// the trace ID, so all the spans of a trace reach the same backend.
+type loadBalancerImp struct {
+	logger  *zap.Logger
+	cfg     *Config
+	factory component.ExporterFactory
+	res     resolver
+	host    component.Host
+
+	// ring and exporters are replaced when the backends change, they are read under updateMu
+	// and only written while holding both changeMu and updateMu.
+	ring      *hashRing
+	exporters map[string]component.TraceExporter
+	updateMu  sync.RWMutex
+
+	// changeMu serializes the updates of the backends. The exporters are created holding
+	// only changeMu, so exporting the data is not blocked while the exporters are started.
+	changeMu sync.Mutex
+	// resolved is the latest list of backends from the resolver.
+	resolved []string
+	// retryTimer retries the creation of the exporters that failed, every retryInterval.
+	retryTimer    *time.Timer
+	retryInterval time.Duration
+	stopped       bool
+}
+
+func newLoadBalancer(params component.ExporterCreateParams, cfg *Config, factory component.ExporterFactory) (*loadBalancerImp, error) {
+	var res resolver
+	switch {
+	case cfg.Resolver.Static != nil && cfg.Resolver.DNS != nil:
+		return nil, errMultipleResolversProvided
+	case cfg.Resolver.Static != nil:
+		var err error
+		if res, err = newStaticResolver(cfg.Resolver.Static.Hostnames); err != nil {
+			return nil, err
+		}
+	case cfg.Resolver.DNS != nil:
+		var err error
+		if res, err = newDNSResolver(params.Logger, cfg.Resolver.DNS); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, errNoResolver
+	}
+
+	return &loadBalancerImp{
+		logger:        params.Logger,
+		cfg:           cfg,
+		factory:       factory,
+		res:           res,
+		ring:          newHashRing(nil),
+		exporters:     map[string]component.TraceExporter{},
+		retryInterval: defaultRetryInterval,
+	}, nil
+}
+
+// Start starts the resolver, the exporters are created as the backends are resolved.
+func (lb *loadBalancerImp) Start(ctx context.Context, host component.Host) error {
+	lb.host = host
+	lb.res.onChange(lb.onBackendChanges)
+	return lb.res.start(ctx)
+}
+
+// Shutdown stops the resolver and the exporters of all the backends.
+func (lb *loadBalancerImp) Shutdown(ctx context.Context) error {
+	var errs []error
+	if err := lb.res.shutdown(ctx); err != nil {
+		errs = append(errs, err)
+	}
+	lb.changeMu.Lock()
+	lb.stopped = true
+	if lb.retryTimer != nil {
+		lb.retryTimer.Stop()
+	}
+	lb.updateMu.Lock()
+	exporters := lb.exporters
+	lb.exporters = map[string]component.TraceExporter{}
+	lb.ring = newHashRing(nil)
+	lb.updateMu.Unlock()
+	lb.changeMu.Unlock()
+	for _, exp := range exporters {
+		if err := exp.Shutdown(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return componenterror.CombineErrors(errs)
+}
+
+// onBackendChanges updates the ring and the exporters for the new list of backends. Exporters
+// of the backends still present are kept, so only the traces of the changed backends move.
+func (lb *loadBalancerImp) onBackendChanges(resolved []string) {
+	lb.changeMu.Lock()
+	defer lb.changeMu.Unlock()
+	if lb.stopped {
+		return
+	}
+	lb.resolved = resolved
+	lb.updateBackends()
+}
+
+// retryBackends retries the creation of the exporters of the backends that failed.
+func (lb *loadBalancerImp) retryBackends() {
+	lb.changeMu.Lock()
+	defer lb.changeMu.Unlock()
+	if lb.stopped {
+		return
+	}
+	lb.updateBackends()
+}
+
+// updateBackends creates the exporters of the resolved backends and replaces the ring. Backends
+// whose exporter cannot be created are left out of the ring and retried after retryInterval,
+// since the resolvers only report changes of the backends. It must be called with changeMu held.
+func (lb *loadBalancerImp) updateBackends() {
+	if lb.retryTimer != nil {
+		lb.retryTimer.Stop()
+		lb.retryTimer = nil
+	}
+
+	newRing := newHashRing(lb.resolved)
+	// The ring is only written with changeMu held, so it can be read without updateMu.
+	if newRing.equal(lb.ring) {
+		return
+	}
+
+	ctx := context.Background()
+	newExporters := make(map[string]component.TraceExporter, len(newRing.endpoints))
+	available := make([]string, 0, len(newRing.endpoints))
+	for _, endpoint := range newRing.endpoints {
+		exp, ok := lb.exporters[endpoint]
+		if !ok {
+			var err error
+			if exp, err = lb.createExporter(ctx, endpoint); err != nil {
+				lb.logger.Error("Failed to create the exporter for a backend, will retry",
+					zap.String("endpoint", endpoint), zap.Error(err))
+				continue
+			}
+		}
+		newExporters[endpoint] = exp
+		available = append(available, endpoint)
+	}
+	if len(available) < len(newRing.endpoints) {
+		lb.retryTimer = time.AfterFunc(lb.retryInterval, lb.retryBackends)
+	}
+
+	newRing = newHashRing(available)
+	var removed []component.TraceExporter
+	for endpoint, exp := range lb.exporters {
+		if _, ok := newExporters[endpoint]; !ok {
+			removed = append(removed, exp)
+		}
+	}
+	changed := !newRing.equal(lb.ring)
+	lb.updateMu.Lock()
+	lb.exporters = newExporters
+	lb.ring = newRing
+	lb.updateMu.Unlock()
+
+	if changed {
+		lb.logger.Info("Backends changed", zap.Strings("endpoints", newRing.endpoints))
+	}
+	for _, exp := range removed {
+		if err := exp.Shutdown(ctx); err != nil {
+			lb.logger.Warn("Failed to shutdown the exporter of a removed backend", zap.Error(err))
+		}
+	}
+}
+
+func (lb *loadBalancerImp) createExporter(ctx context.Context, endpoint string) (component.TraceExporter, error) {
+	oCfg := lb.cfg.Protocol.OTLP
+	oCfg.Endpoint = endpoint
+	oCfg.NameVal = fmt.Sprintf("%s/%s", lb.cfg.Name(), endpoint)
+	params := component.ExporterCreateParams{Logger: lb.logger.With(zap.String("endpoint", endpoint))}
+	exp, err := lb.factory.CreateTraceExporter(ctx, params, &oCfg)
+	if err != nil {
+		return nil, err
+	}
+	if err = exp.Start(ctx, lb.host); err != nil {
+		return nil, err
+	}
+	return exp, nil
+}
+
+func (lb *loadBalancerImp) pushTraceData(ctx context.Context, td pdata.Traces) (int, error) {
+	lb.updateMu.RLock()
+	defer lb.updateMu.RUnlock()
+
+	// Merge the traces going to the same backend, so each backend gets a single request.
+	byEndpoint := make(map[string]pdata.Traces)
+	for _, batch := range batchpertrace.Split(td) {
+		endpoint := lb.ring.endpointFor(batch.TraceID.Bytes())
+		if endpoint == "" {
+			return td.SpanCount(), errNoBackends
+		}
+		dest, ok := byEndpoint[endpoint]
+		if !ok {
+			dest = pdata.NewTraces()
+			byEndpoint[endpoint] = dest
+		}
+		batch.Traces.ResourceSpans().MoveAndAppendTo(dest.ResourceSpans())
+	}
+
+	var errs []error
+	droppedSpans := 0
+	for endpoint, traces := range byEndpoint {
+		exp, ok := lb.exporters[endpoint]
+		if !ok {
+			droppedSpans += traces.SpanCount()
+			errs = append(errs, fmt.Errorf("no exporter for the backend %q", endpoint))
+			continue
+		}
+		if err := exp.ConsumeTraces(ctx, traces); err != nil {
+			droppedSpans += traces.SpanCount()
+			errs = append(errs, err)
+		}
+	}
+	return droppedSpans, componenterror.CombineErrors(errs)
+}
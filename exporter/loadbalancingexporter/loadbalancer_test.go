@@ -0,0 +1,215 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/exporter/otlpexporter"
+)
+
+// sinkFactory creates a sink exporter for each backend, recording them by endpoint.
+type sinkFactory struct {
+	mu    sync.Mutex
+	sinks map[string]*exportertest.SinkTraceExporter
+	err   error
+}
+
+func newSinkFactory() (*sinkFactory, component.ExporterFactory) {
+	sf := &sinkFactory{sinks: map[string]*exportertest.SinkTraceExporter{}}
+	factory := exporterhelper.NewFactory(
+		"otlp",
+		otlpexporter.NewFactory().CreateDefaultConfig,
+		exporterhelper.WithTraces(func(
+			_ context.Context,
+			_ component.ExporterCreateParams,
+			cfg configmodels.Exporter,
+		) (component.TraceExporter, error) {
+			sf.mu.Lock()
+			defer sf.mu.Unlock()
+			if sf.err != nil {
+				return nil, sf.err
+			}
+			sink := &exportertest.SinkTraceExporter{}
+			sf.sinks[cfg.(*otlpexporter.Config).Endpoint] = sink
+			return sink, nil
+		}))
+	return sf, factory
+}
+
+func newTestLoadBalancer(t *testing.T, endpoints ...string) (*loadBalancerImp, *sinkFactory) {
+	sf, factory := newSinkFactory()
+	cfg := createDefaultConfig().(*Config)
+	cfg.Resolver.Static = &StaticResolver{Hostnames: endpoints}
+	lb, err := newLoadBalancer(component.ExporterCreateParams{Logger: zap.NewNop()}, cfg, factory)
+	require.NoError(t, err)
+	return lb, sf
+}
+
+// generateTraces returns a batch with one span for each of the given trace IDs.
+func generateTraces(ids [][]byte) pdata.Traces {
+	td := pdata.NewTraces()
+	td.ResourceSpans().Resize(1)
+	rs := td.ResourceSpans().At(0)
+	rs.Resource().InitEmpty()
+	rs.Resource().Attributes().InsertString("service.name", "test")
+	rs.InstrumentationLibrarySpans().Resize(1)
+	spans := rs.InstrumentationLibrarySpans().At(0).Spans()
+	spans.Resize(len(ids))
+	for i, id := range ids {
+		spans.At(i).SetTraceID(pdata.NewTraceID(id))
+	}
+	return td
+}
+
+func TestNewLoadBalancer_Resolvers(t *testing.T) {
+	params := component.ExporterCreateParams{Logger: zap.NewNop()}
+	_, factory := newSinkFactory()
+
+	cfg := createDefaultConfig().(*Config)
+	_, err := newLoadBalancer(params, cfg, factory)
+	assert.Equal(t, errNoResolver, err)
+
+	cfg.Resolver.Static = &StaticResolver{Hostnames: []string{"endpoint-1"}}
+	cfg.Resolver.DNS = &DNSResolver{Hostname: "service-1"}
+	_, err = newLoadBalancer(params, cfg, factory)
+	assert.Equal(t, errMultipleResolversProvided, err)
+
+	cfg.Resolver.Static = nil
+	lb, err := newLoadBalancer(params, cfg, factory)
+	require.NoError(t, err)
+	assert.IsType(t, &dnsResolver{}, lb.res)
+
+	cfg.Resolver.DNS = &DNSResolver{}
+	_, err = newLoadBalancer(params, cfg, factory)
+	assert.Equal(t, errNoHostname, err)
+}
+
+func TestLoadBalancer_SameTraceSameBackend(t *testing.T) {
+	lb, sf := newTestLoadBalancer(t, "endpoint-1", "endpoint-2", "endpoint-3")
+	require.NoError(t, lb.Start(context.Background(), componenttest.NewNopHost()))
+	require.Len(t, sf.sinks, 3)
+
+	ids := traceIDs(100)
+	// Send every trace twice, in different batches.
+	for i := 0; i < 2; i++ {
+		dropped, err := lb.pushTraceData(context.Background(), generateTraces(ids))
+		require.NoError(t, err)
+		assert.Equal(t, 0, dropped)
+	}
+
+	total := 0
+	for endpoint, sink := range sf.sinks {
+		// One request per backend and batch.
+		assert.Len(t, sink.AllTraces(), 2)
+		for _, td := range sink.AllTraces() {
+			total += td.SpanCount()
+			rss := td.ResourceSpans()
+			for i := 0; i < rss.Len(); i++ {
+				v, ok := rss.At(i).Resource().Attributes().Get("service.name")
+				require.True(t, ok)
+				assert.Equal(t, "test", v.StringVal())
+				spans := rss.At(i).InstrumentationLibrarySpans().At(0).Spans()
+				for j := 0; j < spans.Len(); j++ {
+					assert.Equal(t, endpoint, lb.ring.endpointFor(spans.At(j).TraceID().Bytes()))
+				}
+			}
+		}
+	}
+	assert.Equal(t, 200, total)
+	assert.NoError(t, lb.Shutdown(context.Background()))
+}
+
+func TestLoadBalancer_BackendChanges(t *testing.T) {
+	lb, sf := newTestLoadBalancer(t, "endpoint-1", "endpoint-2")
+	require.NoError(t, lb.Start(context.Background(), componenttest.NewNopHost()))
+	first := sf.sinks["endpoint-1"]
+
+	lb.onBackendChanges([]string{"endpoint-1", "endpoint-3"})
+	assert.Equal(t, []string{"endpoint-1", "endpoint-3"}, lb.ring.endpoints)
+	assert.Len(t, lb.exporters, 2)
+	// The exporter of the remaining backend is kept.
+	assert.Same(t, first, lb.exporters["endpoint-1"])
+	assert.Contains(t, lb.exporters, "endpoint-3")
+	assert.NotContains(t, lb.exporters, "endpoint-2")
+
+	// Unchanged backends don't recreate the exporters.
+	lb.onBackendChanges([]string{"endpoint-3", "endpoint-1"})
+	assert.Same(t, first, lb.exporters["endpoint-1"])
+}
+
+func TestLoadBalancer_ExporterCreationFails(t *testing.T) {
+	lb, sf := newTestLoadBalancer(t, "endpoint-1")
+	sf.err = errors.New("failed to create")
+	require.NoError(t, lb.Start(context.Background(), componenttest.NewNopHost()))
+	assert.Len(t, lb.ring.endpoints, 0)
+
+	td := generateTraces(traceIDs(2))
+	dropped, err := lb.pushTraceData(context.Background(), td)
+	assert.Equal(t, errNoBackends, err)
+	assert.Equal(t, 2, dropped)
+	assert.NoError(t, lb.Shutdown(context.Background()))
+}
+
+func TestLoadBalancer_ExporterCreationRetried(t *testing.T) {
+	lb, sf := newTestLoadBalancer(t, "endpoint-1", "endpoint-2")
+	lb.retryInterval = 10 * time.Millisecond
+	sf.mu.Lock()
+	sf.err = errors.New("failed to create")
+	sf.mu.Unlock()
+	require.NoError(t, lb.Start(context.Background(), componenttest.NewNopHost()))
+	lb.updateMu.RLock()
+	assert.Len(t, lb.ring.endpoints, 0)
+	lb.updateMu.RUnlock()
+
+	// The static resolver never reports changes, the failed backends are retried.
+	sf.mu.Lock()
+	sf.err = nil
+	sf.mu.Unlock()
+	assert.Eventually(t, func() bool {
+		lb.updateMu.RLock()
+		defer lb.updateMu.RUnlock()
+		return len(lb.ring.endpoints) == 2
+	}, time.Second, 10*time.Millisecond)
+
+	dropped, err := lb.pushTraceData(context.Background(), generateTraces(traceIDs(2)))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, dropped)
+	assert.NoError(t, lb.Shutdown(context.Background()))
+}
+
+func TestLoadBalancer_ExportFails(t *testing.T) {
+	lb, sf := newTestLoadBalancer(t, "endpoint-1")
+	require.NoError(t, lb.Start(context.Background(), componenttest.NewNopHost()))
+	sf.sinks["endpoint-1"].SetConsumeTraceError(errors.New("export failed"))
+
+	dropped, err := lb.pushTraceData(context.Background(), generateTraces(traceIDs(3)))
+	assert.EqualError(t, err, "export failed")
+	assert.Equal(t, 3, dropped)
+}
@@ -0,0 +1,35 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"context"
+	"errors"
+)
+
+var errNoEndpoints = errors.New("the resolver did not return any endpoints")
+
+// resolver discovers the backends to balance the load between.
+type resolver interface {
+	// start resolves the endpoints for the first time, and keeps them updated if the
+	// resolver supports it.
+	start(ctx context.Context) error
+	// shutdown stops refreshing the endpoints.
+	shutdown(ctx context.Context) error
+	// resolve returns the current list of endpoints, notifying the callbacks if it changed.
+	resolve(ctx context.Context) ([]string, error)
+	// onChange registers a callback called with the new list of endpoints when it changes.
+	onChange(func([]string))
+}
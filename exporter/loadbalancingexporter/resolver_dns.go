@@ -0,0 +1,170 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	defaultPort        = "55680"
+	defaultDNSInterval = 5 * time.Second
+	defaultDNSTimeout  = time.Second
+)
+
+var errNoHostname = errors.New("no hostname was given to the DNS resolver")
+
+// netResolver is the subset of net.Resolver used by the DNS resolver, replaced in tests.
+type netResolver interface {
+	LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error)
+}
+
+// dnsResolver resolves the endpoints from the IP addresses of a hostname, refreshed periodically.
+type dnsResolver struct {
+	logger   *zap.Logger
+	hostname string
+	port     string
+	resolver netResolver
+	interval time.Duration
+	timeout  time.Duration
+
+	endpoints         []string
+	onChangeCallbacks []func([]string)
+	mu                sync.Mutex
+
+	stopCh   chan struct{}
+	stopOnce sync.Once
+	doneCh   chan struct{}
+}
+
+var _ resolver = (*dnsResolver)(nil)
+
+func newDNSResolver(logger *zap.Logger, cfg *DNSResolver) (*dnsResolver, error) {
+	if cfg.Hostname == "" {
+		return nil, errNoHostname
+	}
+	r := &dnsResolver{
+		logger:   logger,
+		hostname: cfg.Hostname,
+		port:     cfg.Port,
+		resolver: net.DefaultResolver,
+		interval: cfg.Interval,
+		timeout:  cfg.Timeout,
+		stopCh:   make(chan struct{}),
+		doneCh:   make(chan struct{}),
+	}
+	if r.port == "" {
+		r.port = defaultPort
+	}
+	if r.interval <= 0 {
+		r.interval = defaultDNSInterval
+	}
+	if r.timeout <= 0 {
+		r.timeout = defaultDNSTimeout
+	}
+	return r, nil
+}
+
+func (r *dnsResolver) start(ctx context.Context) error {
+	if _, err := r.resolve(ctx); err != nil {
+		// The backends may not be registered yet, the next resolutions will find them.
+		r.logger.Warn("Failed to resolve the backends, will retry", zap.String("hostname", r.hostname), zap.Error(err))
+	}
+	go r.periodicallyResolve()
+	return nil
+}
+
+func (r *dnsResolver) shutdown(ctx context.Context) error {
+	r.stopOnce.Do(func() { close(r.stopCh) })
+	select {
+	case <-r.doneCh:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (r *dnsResolver) periodicallyResolve() {
+	defer close(r.doneCh)
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := r.resolve(context.Background()); err != nil {
+				r.logger.Warn("Failed to resolve the backends", zap.String("hostname", r.hostname), zap.Error(err))
+			}
+		case <-r.stopCh:
+			return
+		}
+	}
+}
+
+func (r *dnsResolver) resolve(ctx context.Context) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	addrs, err := r.resolver.LookupIPAddr(ctx, r.hostname)
+	if err != nil {
+		return nil, err
+	}
+	if len(addrs) == 0 {
+		return nil, errNoEndpoints
+	}
+
+	endpoints := make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		endpoints = append(endpoints, net.JoinHostPort(addr.IP.String(), r.port))
+	}
+	sort.Strings(endpoints)
+
+	r.mu.Lock()
+	if equalStrings(r.endpoints, endpoints) {
+		r.mu.Unlock()
+		return endpoints, nil
+	}
+	r.endpoints = endpoints
+	callbacks := r.onChangeCallbacks
+	r.mu.Unlock()
+
+	for _, callback := range callbacks {
+		callback(endpoints)
+	}
+	return endpoints, nil
+}
+
+func (r *dnsResolver) onChange(f func([]string)) {
+	r.mu.Lock()
+	r.onChangeCallbacks = append(r.onChangeCallbacks, f)
+	r.mu.Unlock()
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
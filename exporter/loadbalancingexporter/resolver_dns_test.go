@@ -0,0 +1,133 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type mockNetResolver struct {
+	mu    sync.Mutex
+	addrs []net.IPAddr
+	err   error
+	calls int
+}
+
+func (m *mockNetResolver) LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls++
+	return m.addrs, m.err
+}
+
+func (m *mockNetResolver) set(err error, ips ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.err = err
+	m.addrs = nil
+	for _, ip := range ips {
+		m.addrs = append(m.addrs, net.IPAddr{IP: net.ParseIP(ip)})
+	}
+}
+
+func newTestDNSResolver(t *testing.T, interval time.Duration) (*dnsResolver, *mockNetResolver) {
+	res, err := newDNSResolver(zap.NewNop(), &DNSResolver{Hostname: "service-1", Interval: interval})
+	require.NoError(t, err)
+	netRes := &mockNetResolver{}
+	res.resolver = netRes
+	return res, netRes
+}
+
+func TestDNSResolver_Defaults(t *testing.T) {
+	res, err := newDNSResolver(zap.NewNop(), &DNSResolver{Hostname: "service-1"})
+	require.NoError(t, err)
+	assert.Equal(t, defaultPort, res.port)
+	assert.Equal(t, defaultDNSInterval, res.interval)
+	assert.Equal(t, defaultDNSTimeout, res.timeout)
+
+	_, err = newDNSResolver(zap.NewNop(), &DNSResolver{})
+	assert.Equal(t, errNoHostname, err)
+}
+
+func TestDNSResolver_OnChange(t *testing.T) {
+	res, netRes := newTestDNSResolver(t, time.Hour)
+	netRes.set(nil, "192.168.0.2", "192.168.0.1")
+
+	var calls int
+	var resolved []string
+	res.onChange(func(endpoints []string) {
+		calls++
+		resolved = endpoints
+	})
+
+	endpoints, err := res.resolve(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"192.168.0.1:55680", "192.168.0.2:55680"}, endpoints)
+	assert.Equal(t, endpoints, resolved)
+	assert.Equal(t, 1, calls)
+
+	// Same addresses, in a different order, don't trigger the callbacks.
+	netRes.set(nil, "192.168.0.1", "192.168.0.2")
+	_, err = res.resolve(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, calls)
+
+	netRes.set(nil, "192.168.0.1", "192.168.0.3")
+	_, err = res.resolve(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, []string{"192.168.0.1:55680", "192.168.0.3:55680"}, resolved)
+
+	// Failed resolutions keep the previous endpoints.
+	netRes.set(errors.New("no such host"))
+	_, err = res.resolve(context.Background())
+	assert.Error(t, err)
+	netRes.set(nil)
+	_, err = res.resolve(context.Background())
+	assert.Equal(t, errNoEndpoints, err)
+	assert.Equal(t, 2, calls)
+}
+
+func TestDNSResolver_Periodic(t *testing.T) {
+	res, netRes := newTestDNSResolver(t, 10*time.Millisecond)
+	netRes.set(errors.New("no such host"))
+
+	resolvedCh := make(chan []string, 1)
+	res.onChange(func(endpoints []string) {
+		resolvedCh <- endpoints
+	})
+	// A failed first resolution doesn't prevent the exporter from starting.
+	require.NoError(t, res.start(context.Background()))
+
+	netRes.set(nil, "192.168.0.1")
+	select {
+	case endpoints := <-resolvedCh:
+		assert.Equal(t, []string{"192.168.0.1:55680"}, endpoints)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the endpoints were not resolved periodically")
+	}
+	assert.NoError(t, res.shutdown(context.Background()))
+	// Shutting down again doesn't panic.
+	assert.NoError(t, res.shutdown(context.Background()))
+}
@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+)
+
+var errNoStaticEndpoints = errors.New("no endpoints were given to the static resolver")
+
+// staticResolver always resolves to the same endpoints.
+type staticResolver struct {
+	endpoints []string
+
+	onChangeCallbacks []func([]string)
+	mu                sync.Mutex
+}
+
+var _ resolver = (*staticResolver)(nil)
+
+func newStaticResolver(endpoints []string) (*staticResolver, error) {
+	if len(endpoints) == 0 {
+		return nil, errNoStaticEndpoints
+	}
+	sorted := append([]string(nil), endpoints...)
+	sort.Strings(sorted)
+	return &staticResolver{endpoints: sorted}, nil
+}
+
+func (r *staticResolver) start(ctx context.Context) error {
+	_, err := r.resolve(ctx)
+	return err
+}
+
+func (r *staticResolver) shutdown(ctx context.Context) error {
+	return nil
+}
+
+func (r *staticResolver) resolve(_ context.Context) ([]string, error) {
+	r.mu.Lock()
+	callbacks := r.onChangeCallbacks
+	r.mu.Unlock()
+	// The endpoints never change, but the callbacks are notified so they get the first list.
+	for _, callback := range callbacks {
+		callback(r.endpoints)
+	}
+	return r.endpoints, nil
+}
+
+func (r *staticResolver) onChange(f func([]string)) {
+	r.mu.Lock()
+	r.onChangeCallbacks = append(r.onChangeCallbacks, f)
+	r.mu.Unlock()
+}
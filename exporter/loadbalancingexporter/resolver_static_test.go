@@ -0,0 +1,41 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStaticResolver(t *testing.T) {
+	res, err := newStaticResolver([]string{"endpoint-2", "endpoint-1"})
+	require.NoError(t, err)
+
+	var resolved []string
+	res.onChange(func(endpoints []string) {
+		resolved = endpoints
+	})
+	require.NoError(t, res.start(context.Background()))
+	assert.Equal(t, []string{"endpoint-1", "endpoint-2"}, resolved)
+	assert.NoError(t, res.shutdown(context.Background()))
+}
+
+func TestStaticResolver_NoEndpoints(t *testing.T) {
+	_, err := newStaticResolver(nil)
+	assert.Equal(t, errNoStaticEndpoints, err)
+}
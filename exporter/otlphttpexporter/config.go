@@ -0,0 +1,47 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlphttpexporter
+
+import (
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+// Config defines configuration for OTLP/HTTP exporter.
+type Config struct {
+	configmodels.ExporterSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+	confighttp.HTTPClientSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+	exporterhelper.QueueSettings  `mapstructure:"sending_queue"`
+	exporterhelper.RetrySettings  `mapstructure:"retry_on_failure"`
+
+	// The URL to send traces to. If omitted the Endpoint + "/v1/trace" will be used.
+	TracesEndpoint string `mapstructure:"traces_endpoint"`
+
+	// The URL to send metrics to. If omitted the Endpoint + "/v1/metrics" will be used.
+	MetricsEndpoint string `mapstructure:"metrics_endpoint"`
+
+	// The URL to send logs to. If omitted the Endpoint + "/v1/logs" will be used.
+	LogsEndpoint string `mapstructure:"logs_endpoint"`
+
+	// Headers are added to every request.
+	Headers map[string]string `mapstructure:"headers"`
+
+	// Encoding of the request bodies, "proto" or "json" (default "proto").
+	Encoding string `mapstructure:"encoding"`
+
+	// Compression of the request bodies, "gzip" or "none" (default "none").
+	Compression string `mapstructure:"compression"`
+}
@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlphttpexporter
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+const (
+	// The value of "type" key in configuration.
+	typeStr = "otlphttp"
+)
+
+// NewFactory creates a factory for OTLP/HTTP exporter.
+func NewFactory() component.ExporterFactory {
+	return exporterhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		exporterhelper.WithTraces(createTraceExporter),
+		exporterhelper.WithMetrics(createMetricsExporter),
+		exporterhelper.WithLogs(createLogsExporter))
+}
+
+func createDefaultConfig() configmodels.Exporter {
+	return &Config{
+		ExporterSettings: configmodels.ExporterSettings{
+			TypeVal: typeStr,
+			NameVal: typeStr,
+		},
+		HTTPClientSettings: confighttp.HTTPClientSettings{
+			Timeout: 5 * time.Second,
+		},
+		RetrySettings: exporterhelper.CreateDefaultRetrySettings(),
+		QueueSettings: exporterhelper.CreateDefaultQueueSettings(),
+		Headers:       map[string]string{},
+		Encoding:      encodingProto,
+		Compression:   compressionNone,
+	}
+}
+
+// exporterOptions returns the exporterhelper options common to all the data types.
+func exporterOptions(oCfg *Config, oce *exporterImp) []exporterhelper.ExporterOption {
+	return []exporterhelper.ExporterOption{
+		// The timeout of each attempt is enforced by the HTTP client.
+		exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{}),
+		exporterhelper.WithRetry(oCfg.RetrySettings),
+		exporterhelper.WithQueue(oCfg.QueueSettings),
+		exporterhelper.WithShutdown(oce.shutdown),
+	}
+}
+
+func createTraceExporter(
+	_ context.Context,
+	_ component.ExporterCreateParams,
+	cfg configmodels.Exporter,
+) (component.TraceExporter, error) {
+	oCfg := cfg.(*Config)
+	url, err := composeSignalURL(oCfg, oCfg.TracesEndpoint, "trace")
+	if err != nil {
+		return nil, err
+	}
+	oce, err := newExporter(oCfg, url)
+	if err != nil {
+		return nil, err
+	}
+	return exporterhelper.NewTraceExporter(cfg, oce.pushTraceData, exporterOptions(oCfg, oce)...)
+}
+
+func createMetricsExporter(
+	_ context.Context,
+	_ component.ExporterCreateParams,
+	cfg configmodels.Exporter,
+) (component.MetricsExporter, error) {
+	oCfg := cfg.(*Config)
+	url, err := composeSignalURL(oCfg, oCfg.MetricsEndpoint, "metrics")
+	if err != nil {
+		return nil, err
+	}
+	oce, err := newExporter(oCfg, url)
+	if err != nil {
+		return nil, err
+	}
+	return exporterhelper.NewMetricsExporter(cfg, oce.pushMetricsData, exporterOptions(oCfg, oce)...)
+}
+
+func createLogsExporter(
+	_ context.Context,
+	_ component.ExporterCreateParams,
+	cfg configmodels.Exporter,
+) (component.LogsExporter, error) {
+	oCfg := cfg.(*Config)
+	url, err := composeSignalURL(oCfg, oCfg.LogsEndpoint, "logs")
+	if err != nil {
+		return nil, err
+	}
+	oce, err := newExporter(oCfg, url)
+	if err != nil {
+		return nil, err
+	}
+	return exporterhelper.NewLogsExporter(cfg, oce.pushLogData, exporterOptions(oCfg, oce)...)
+}
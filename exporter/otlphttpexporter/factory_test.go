@@ -0,0 +1,164 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlphttpexporter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configcheck"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configtls"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	assert.NotNil(t, cfg, "failed to create default config")
+	assert.NoError(t, configcheck.ValidateConfig(cfg))
+}
+
+func TestCreateMetricsExporter(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.Endpoint = "http://localhost:55681"
+
+	creationParams := component.ExporterCreateParams{Logger: zap.NewNop()}
+	oexp, err := factory.CreateMetricsExporter(context.Background(), creationParams, cfg)
+	require.Nil(t, err)
+	require.NotNil(t, oexp)
+}
+
+func TestCreateLogsExporter(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.Endpoint = "http://localhost:55681"
+
+	creationParams := component.ExporterCreateParams{Logger: zap.NewNop()}
+	oexp, err := factory.(component.LogsExporterFactory).CreateLogsExporter(context.Background(), creationParams, cfg)
+	require.Nil(t, err)
+	require.NotNil(t, oexp)
+}
+
+func TestCreateTraceExporter(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   Config
+		mustFail bool
+	}{
+		{
+			name:     "NoEndpoint",
+			config:   Config{},
+			mustFail: true,
+		},
+		{
+			name: "InvalidEndpoint",
+			config: Config{
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: "localhost:55681",
+				},
+			},
+			mustFail: true,
+		},
+		{
+			name: "InvalidTracesEndpoint",
+			config: Config{
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: "http://localhost:55681",
+				},
+				TracesEndpoint: "http://",
+			},
+			mustFail: true,
+		},
+		{
+			name: "TracesEndpoint",
+			config: Config{
+				TracesEndpoint: "http://localhost:55681/api/traces",
+			},
+		},
+		{
+			name: "Encoding",
+			config: Config{
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: "http://localhost:55681",
+				},
+				Encoding: "json",
+			},
+		},
+		{
+			name: "UnknownEncoding",
+			config: Config{
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: "http://localhost:55681",
+				},
+				Encoding: "xml",
+			},
+			mustFail: true,
+		},
+		{
+			name: "Compression",
+			config: Config{
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: "http://localhost:55681",
+				},
+				Compression: "gzip",
+			},
+		},
+		{
+			name: "UnknownCompression",
+			config: Config{
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: "http://localhost:55681",
+				},
+				Compression: "zstd",
+			},
+			mustFail: true,
+		},
+		{
+			name: "CertPemFileError",
+			config: Config{
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: "https://localhost:55681",
+					TLSSetting: configtls.TLSClientSetting{
+						TLSSetting: configtls.TLSSetting{
+							CAFile: "nosuchfile",
+						},
+					},
+				},
+			},
+			mustFail: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			factory := NewFactory()
+			creationParams := component.ExporterCreateParams{Logger: zap.NewNop()}
+			consumer, err := factory.CreateTraceExporter(context.Background(), creationParams, &tt.config)
+
+			if tt.mustFail {
+				assert.NotNil(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, consumer)
+			}
+		})
+	}
+}
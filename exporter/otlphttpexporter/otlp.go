@@ -0,0 +1,272 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlphttpexporter
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/gogo/protobuf/proto"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/consumer/pdatautil"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/internal/data"
+	otlplogs "go.opentelemetry.io/collector/internal/data/opentelemetry-proto-gen/collector/logs/v1"
+	otlpmetrics "go.opentelemetry.io/collector/internal/data/opentelemetry-proto-gen/collector/metrics/v1"
+	otlptrace "go.opentelemetry.io/collector/internal/data/opentelemetry-proto-gen/collector/trace/v1"
+)
+
+const (
+	encodingProto = "proto"
+	encodingJSON  = "json"
+
+	compressionNone = "none"
+	compressionGzip = "gzip"
+
+	// Maximum number of bytes of the response body included in the errors.
+	maxErrorBodySize = 1024
+)
+
+type exporterImp struct {
+	// Input configuration.
+	config      *Config
+	url         string
+	client      *http.Client
+	marshal     func(proto.Message) ([]byte, error)
+	contentType string
+}
+
+// composeSignalURL returns the URL of a data type, either the signal specific endpoint, or
+// the endpoint followed by the default path of the data type.
+func composeSignalURL(oCfg *Config, signalOverrideURL string, signalName string) (string, error) {
+	switch {
+	case signalOverrideURL != "":
+		if err := validateURL(signalOverrideURL); err != nil {
+			return "", fmt.Errorf("%s_endpoint must be a valid URL: %w", signalName, err)
+		}
+		return signalOverrideURL, nil
+	case oCfg.Endpoint == "":
+		return "", fmt.Errorf("OTLP/HTTP exporter config requires either an endpoint or %s_endpoint", signalName)
+	default:
+		if err := validateURL(oCfg.Endpoint); err != nil {
+			return "", fmt.Errorf("endpoint must be a valid URL: %w", err)
+		}
+		return strings.TrimSuffix(oCfg.Endpoint, "/") + "/v1/" + signalName, nil
+	}
+}
+
+// validateURL checks that the value is an absolute HTTP or HTTPS URL.
+func validateURL(value string) error {
+	u, err := url.Parse(value)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("%q must start with http:// or https://", value)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("%q has no host", value)
+	}
+	return nil
+}
+
+// Create new exporter sending the requests of one data type to the given URL.
+func newExporter(oCfg *Config, url string) (*exporterImp, error) {
+	e := &exporterImp{
+		config: oCfg,
+		url:    url,
+	}
+
+	switch oCfg.Encoding {
+	case encodingProto, "":
+		e.marshal = proto.Marshal
+		e.contentType = "application/x-protobuf"
+	case encodingJSON:
+		e.marshal = marshalJSON
+		e.contentType = "application/json"
+	default:
+		return nil, fmt.Errorf("unsupported encoding %q, must be %q or %q", oCfg.Encoding, encodingProto, encodingJSON)
+	}
+
+	switch oCfg.Compression {
+	case compressionNone, compressionGzip, "":
+	default:
+		return nil, fmt.Errorf("unsupported compression %q, must be %q or %q", oCfg.Compression, compressionNone, compressionGzip)
+	}
+
+	client, err := oCfg.HTTPClientSettings.ToClient()
+	if err != nil {
+		return nil, err
+	}
+	e.client = client
+	return e, nil
+}
+
+func (e *exporterImp) shutdown(context.Context) error {
+	e.client.CloseIdleConnections()
+	return nil
+}
+
+func (e *exporterImp) pushTraceData(ctx context.Context, td pdata.Traces) (int, error) {
+	request := &otlptrace.ExportTraceServiceRequest{
+		ResourceSpans: pdata.TracesToOtlp(td),
+	}
+	err := e.export(ctx, request)
+
+	if err != nil {
+		return td.SpanCount(), err
+	}
+	return 0, nil
+}
+
+func (e *exporterImp) pushMetricsData(ctx context.Context, md pdata.Metrics) (int, error) {
+	imd := pdatautil.MetricsToInternalMetrics(md)
+	request := &otlpmetrics.ExportMetricsServiceRequest{
+		ResourceMetrics: data.MetricDataToOtlp(imd),
+	}
+	err := e.export(ctx, request)
+
+	if err != nil {
+		return imd.MetricCount(), err
+	}
+	return 0, nil
+}
+
+func (e *exporterImp) pushLogData(ctx context.Context, logs pdata.Logs) (int, error) {
+	request := &otlplogs.ExportLogsServiceRequest{
+		ResourceLogs: pdata.LogsToOtlp(logs),
+	}
+	err := e.export(ctx, request)
+
+	if err != nil {
+		return logs.LogRecordCount(), err
+	}
+	return 0, nil
+}
+
+// export posts the request and converts the response into an error that tells the
+// exporterhelper whether and when to retry. The error is not wrapped further, as the
+// exporterhelper only recognizes permanent and throttling errors at the top level.
+func (e *exporterImp) export(ctx context.Context, request proto.Message) error {
+	body, err := e.marshal(request)
+	if err != nil {
+		return consumererror.Permanent(fmt.Errorf("failed to marshal the request: %w", err))
+	}
+
+	req, err := e.newRequest(ctx, body)
+	if err != nil {
+		return consumererror.Permanent(fmt.Errorf("failed to create the HTTP request: %w", err))
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make an HTTP request to %s: %w", e.url, err)
+	}
+	defer func() {
+		// Drain the body so the connection can be reused.
+		_, _ = io.Copy(ioutil.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode >= 200 && resp.StatusCode <= 299 {
+		return nil
+	}
+
+	respBody, _ := ioutil.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	err = fmt.Errorf("request to %s responded with HTTP status %d: %s", e.url, resp.StatusCode, bytes.TrimSpace(respBody))
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		// The server is overloaded, wait as requested by the server before retrying.
+		if delay := getRetryAfter(resp.Header.Get("Retry-After"), time.Now()); delay > 0 {
+			return exporterhelper.NewThrottleRetry(err, delay)
+		}
+		return err
+	case http.StatusBadGateway, http.StatusGatewayTimeout:
+		return err
+	default:
+		// Other errors mean the request is invalid, retrying would fail again.
+		return consumererror.Permanent(err)
+	}
+}
+
+func (e *exporterImp) newRequest(ctx context.Context, body []byte) (*http.Request, error) {
+	var reader io.Reader = bytes.NewReader(body)
+	if e.config.Compression == compressionGzip {
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		if _, err := zw.Write(body); err != nil {
+			return nil, err
+		}
+		if err := zw.Close(); err != nil {
+			return nil, err
+		}
+		reader = &buf
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, reader)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range e.config.Headers {
+		req.Header.Set(k, v)
+	}
+	req.Header.Set("Content-Type", e.contentType)
+	if e.config.Compression == compressionGzip {
+		req.Header.Set("Content-Encoding", "gzip")
+	}
+	return req, nil
+}
+
+// getRetryAfter parses the value of a Retry-After header, either a number of seconds or an
+// HTTP date, and returns 0 if it is missing or invalid.
+func getRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds <= 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if delay := date.Sub(now); delay > 0 {
+			return delay
+		}
+	}
+	return 0
+}
+
+// marshalJSON serializes the OTLP request into JSON.
+func marshalJSON(request proto.Message) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := (&jsonpb.Marshaler{}).Marshal(&buf, request); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,248 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlphttpexporter
+
+import (
+	"compress/gzip"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdatautil"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	otlptrace "go.opentelemetry.io/collector/internal/data/opentelemetry-proto-gen/collector/trace/v1"
+	"go.opentelemetry.io/collector/internal/data/testdata"
+	"go.opentelemetry.io/collector/receiver/otlpreceiver"
+	"go.opentelemetry.io/collector/testutil"
+)
+
+func startOTLPReceiver(t *testing.T, endpoint string) (*exportertest.SinkTraceExporter, *exportertest.SinkMetricsExporter, *exportertest.SinkLogsExporter) {
+	factory := otlpreceiver.NewFactory()
+	cfg := factory.CreateDefaultConfig().(*otlpreceiver.Config)
+	cfg.GRPC = nil
+	cfg.HTTP.Endpoint = endpoint
+	params := component.ReceiverCreateParams{Logger: zap.NewNop()}
+
+	tsink := new(exportertest.SinkTraceExporter)
+	tr, err := factory.CreateTraceReceiver(context.Background(), params, cfg, tsink)
+	require.NoError(t, err)
+	msink := new(exportertest.SinkMetricsExporter)
+	_, err = factory.CreateMetricsReceiver(context.Background(), params, cfg, msink)
+	require.NoError(t, err)
+	lsink := new(exportertest.SinkLogsExporter)
+	_, err = factory.(component.LogsReceiverFactory).CreateLogsReceiver(context.Background(), params, cfg, lsink)
+	require.NoError(t, err)
+
+	// The three receivers share the same server, starting one of them starts it.
+	require.NoError(t, tr.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, tr.Shutdown(context.Background()))
+	})
+	return tsink, msink, lsink
+}
+
+func TestExportToOTLPReceiver(t *testing.T) {
+	for _, encoding := range []string{encodingProto, encodingJSON} {
+		t.Run(encoding, func(t *testing.T) {
+			addr := testutil.GetAvailableLocalAddress(t)
+			tsink, msink, lsink := startOTLPReceiver(t, addr)
+
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig().(*Config)
+			cfg.Endpoint = "http://" + addr
+			cfg.Encoding = encoding
+			// Retry until the receiver is started.
+			cfg.RetrySettings.InitialInterval = 10 * time.Millisecond
+			params := component.ExporterCreateParams{Logger: zap.NewNop()}
+
+			te, err := factory.CreateTraceExporter(context.Background(), params, cfg)
+			require.NoError(t, err)
+			me, err := factory.CreateMetricsExporter(context.Background(), params, cfg)
+			require.NoError(t, err)
+			le, err := factory.(component.LogsExporterFactory).CreateLogsExporter(context.Background(), params, cfg)
+			require.NoError(t, err)
+			for _, exp := range []component.Exporter{te, me, le} {
+				require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+			}
+
+			require.NoError(t, te.ConsumeTraces(context.Background(), testdata.GenerateTraceDataOneSpan()))
+			md := pdatautil.MetricsFromInternalMetrics(testdata.GenerateMetricDataOneMetric())
+			require.NoError(t, me.ConsumeMetrics(context.Background(), md))
+			require.NoError(t, le.ConsumeLogs(context.Background(), testdata.GenerateLogDataOneLog()))
+
+			assert.Eventually(t, func() bool {
+				return tsink.SpansCount() == 1 && len(msink.AllMetrics()) == 1 && lsink.LogRecordsCount() == 1
+			}, 5*time.Second, 10*time.Millisecond)
+			assert.EqualValues(t, testdata.GenerateTraceDataOneSpan(), tsink.AllTraces()[0])
+			assert.EqualValues(t, testdata.GenerateLogDataOneLog(), lsink.AllLogs()[0])
+
+			for _, exp := range []component.Exporter{te, me, le} {
+				require.NoError(t, exp.Shutdown(context.Background()))
+			}
+		})
+	}
+}
+
+func TestExportRequest(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		request *otlptrace.ExportTraceServiceRequest
+		header  http.Header
+		path    string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		header = r.Header
+		path = r.URL.Path
+		body, err := gzip.NewReader(r.Body)
+		require.NoError(t, err)
+		bts, err := ioutil.ReadAll(body)
+		require.NoError(t, err)
+		request = &otlptrace.ExportTraceServiceRequest{}
+		require.NoError(t, proto.Unmarshal(bts, request))
+	}))
+	defer srv.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = srv.URL + "/"
+	cfg.Compression = compressionGzip
+	cfg.Headers = map[string]string{"X-Tenant": "acme"}
+	url, err := composeSignalURL(cfg, cfg.TracesEndpoint, "trace")
+	require.NoError(t, err)
+	exp, err := newExporter(cfg, url)
+	require.NoError(t, err)
+
+	dropped, err := exp.pushTraceData(context.Background(), testdata.GenerateTraceDataOneSpan())
+	require.NoError(t, err)
+	assert.Equal(t, 0, dropped)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, "/v1/trace", path)
+	assert.Equal(t, "application/x-protobuf", header.Get("Content-Type"))
+	assert.Equal(t, "gzip", header.Get("Content-Encoding"))
+	assert.Equal(t, "acme", header.Get("X-Tenant"))
+	require.NotNil(t, request)
+	assert.Len(t, request.ResourceSpans, 1)
+}
+
+func TestExportErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		retryAfter string
+		permanent  bool
+	}{
+		{name: "BadRequest", status: http.StatusBadRequest, permanent: true},
+		{name: "InternalServerError", status: http.StatusInternalServerError, permanent: true},
+		{name: "BadGateway", status: http.StatusBadGateway},
+		{name: "GatewayTimeout", status: http.StatusGatewayTimeout},
+		{name: "TooManyRequests", status: http.StatusTooManyRequests},
+		{name: "TooManyRequestsRetryAfter", status: http.StatusTooManyRequests, retryAfter: "3"},
+		{name: "UnavailableRetryAfter", status: http.StatusServiceUnavailable, retryAfter: "30"},
+		{name: "UnavailableInvalidRetryAfter", status: http.StatusServiceUnavailable, retryAfter: "soon"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.retryAfter != "" {
+					w.Header().Set("Retry-After", tt.retryAfter)
+				}
+				http.Error(w, "failed", tt.status)
+			}))
+			defer srv.Close()
+
+			cfg := createDefaultConfig().(*Config)
+			exp, err := newExporter(cfg, srv.URL+"/v1/trace")
+			require.NoError(t, err)
+
+			dropped, err := exp.pushTraceData(context.Background(), testdata.GenerateTraceDataOneSpan())
+			require.Error(t, err)
+			assert.Equal(t, 1, dropped)
+			assert.Contains(t, err.Error(), "failed")
+			assert.Equal(t, tt.permanent, consumererror.IsPermanent(err))
+		})
+	}
+}
+
+func TestExportRetryAfter(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		requests []time.Time
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		requests = append(requests, time.Now())
+		if len(requests) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.Endpoint = srv.URL
+	cfg.QueueSettings.Enabled = false
+	cfg.RetrySettings.InitialInterval = 10 * time.Millisecond
+	exp, err := factory.CreateTraceExporter(context.Background(), component.ExporterCreateParams{Logger: zap.NewNop()}, cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, exp.Shutdown(context.Background()))
+	}()
+
+	require.NoError(t, exp.ConsumeTraces(context.Background(), testdata.GenerateTraceDataOneSpan()))
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, requests, 2)
+	// The initial backoff is 10ms, but the retry waits for the delay requested by the server.
+	assert.True(t, requests[1].Sub(requests[0]) >= time.Second)
+}
+
+func TestExportNetworkError(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	exp, err := newExporter(cfg, "http://"+testutil.GetAvailableLocalAddress(t)+"/v1/trace")
+	require.NoError(t, err)
+
+	_, err = exp.pushTraceData(context.Background(), testdata.GenerateTraceDataOneSpan())
+	require.Error(t, err)
+	assert.False(t, consumererror.IsPermanent(err))
+}
+
+func TestGetRetryAfter(t *testing.T) {
+	now := time.Date(2020, 9, 1, 10, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Duration(0), getRetryAfter("", now))
+	assert.Equal(t, time.Duration(0), getRetryAfter("-1", now))
+	assert.Equal(t, time.Duration(0), getRetryAfter("abc", now))
+	assert.Equal(t, 120*time.Second, getRetryAfter("120", now))
+	assert.Equal(t, 90*time.Second, getRetryAfter("Tue, 01 Sep 2020 10:01:30 GMT", now))
+	assert.Equal(t, time.Duration(0), getRetryAfter("Tue, 01 Sep 2020 09:59:00 GMT", now))
+}
@@ -0,0 +1,188 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewriteexporter
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+
+	"github.com/golang/snappy"
+	"github.com/prometheus/prometheus/prompb"
+
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/consumer/pdatautil"
+)
+
+const (
+	// Version of the remote-write protocol sent in the requests.
+	remoteWriteVersion = "0.1.0"
+
+	// Maximum number of bytes of the response body included in the errors.
+	maxErrorBodySize = 1024
+)
+
+type prwExporter struct {
+	config    *Config
+	client    *http.Client
+	converter *converter
+}
+
+// newExporter creates an exporter sending the metrics to the remote-write
+// endpoint of the config.
+func newExporter(cfg *Config) (*prwExporter, error) {
+	if err := validateURL(cfg.Endpoint); err != nil {
+		return nil, fmt.Errorf("endpoint must be a valid URL: %w", err)
+	}
+
+	client, err := cfg.HTTPClientSettings.ToClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return &prwExporter{
+		config:    cfg,
+		client:    client,
+		converter: newConverter(cfg.Namespace, cfg.ExternalLabels),
+	}, nil
+}
+
+// validateURL checks that the value is an absolute HTTP or HTTPS URL.
+func validateURL(value string) error {
+	u, err := url.Parse(value)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("%q must start with http:// or https://", value)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("%q has no host", value)
+	}
+	return nil
+}
+
+func (prwe *prwExporter) shutdown(context.Context) error {
+	prwe.client.CloseIdleConnections()
+	return nil
+}
+
+// pushMetricsData converts the metrics into time series and sends them in a
+// single remote-write request. The metrics that cannot be converted are
+// dropped, and reported as a permanent error once the others are sent.
+func (prwe *prwExporter) pushMetricsData(ctx context.Context, md pdata.Metrics) (int, error) {
+	imd := pdatautil.MetricsToInternalMetrics(md)
+
+	set := timeSeriesSet{}
+	var errs []error
+	converted := 0
+	rms := imd.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		if rm.IsNil() {
+			continue
+		}
+		ilms := rm.InstrumentationLibraryMetrics()
+		for j := 0; j < ilms.Len(); j++ {
+			ilm := ilms.At(j)
+			if ilm.IsNil() {
+				continue
+			}
+			metrics := ilm.Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				if err := prwe.converter.addMetric(set, metrics.At(k)); err != nil {
+					errs = append(errs, err)
+					continue
+				}
+				converted++
+			}
+		}
+	}
+
+	if len(set) > 0 {
+		if err := prwe.export(ctx, &prompb.WriteRequest{Timeseries: set.timeSeries()}); err != nil {
+			if len(errs) == 0 {
+				return converted, err
+			}
+			// The metrics that failed the conversion are dropped as well, the export error
+			// tells the exporterhelper whether the request can be retried.
+			dropped := converted + len(errs)
+			combined := componenterror.CombineErrors(append(errs, err))
+			if consumererror.IsPermanent(err) {
+				combined = consumererror.Permanent(combined)
+			}
+			return dropped, combined
+		}
+	}
+
+	if len(errs) > 0 {
+		return len(errs), consumererror.Permanent(componenterror.CombineErrors(errs))
+	}
+	return 0, nil
+}
+
+// export posts the snappy compressed request and converts the response into
+// an error that tells the exporterhelper whether to retry. The error is not
+// wrapped further, as the exporterhelper only recognizes permanent errors at
+// the top level.
+func (prwe *prwExporter) export(ctx context.Context, request *prompb.WriteRequest) error {
+	data, err := request.Marshal()
+	if err != nil {
+		return consumererror.Permanent(fmt.Errorf("failed to marshal the request: %w", err))
+	}
+	body := snappy.Encode(nil, data)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, prwe.config.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return consumererror.Permanent(fmt.Errorf("failed to create the HTTP request: %w", err))
+	}
+	for k, v := range prwe.config.Headers {
+		req.Header.Set(k, v)
+	}
+	req.Header.Set("Content-Encoding", "snappy")
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("X-Prometheus-Remote-Write-Version", remoteWriteVersion)
+
+	resp, err := prwe.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make an HTTP request to %s: %w", prwe.config.Endpoint, err)
+	}
+	defer func() {
+		// Drain the body so the connection can be reused.
+		_, _ = io.Copy(ioutil.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode >= 200 && resp.StatusCode <= 299 {
+		return nil
+	}
+
+	respBody, _ := ioutil.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	err = fmt.Errorf("request to %s responded with HTTP status %d: %s", prwe.config.Endpoint, resp.StatusCode, bytes.TrimSpace(respBody))
+
+	// As the Prometheus remote-write client does, retry on server errors and
+	// when rate limited; other errors mean the request is invalid, retrying
+	// would fail again.
+	if resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests {
+		return err
+	}
+	return consumererror.Permanent(err)
+}
@@ -0,0 +1,242 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewriteexporter
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/golang/snappy"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/consumer/pdatautil"
+	"go.opentelemetry.io/collector/internal/data/testdata"
+	"go.opentelemetry.io/collector/testutil"
+)
+
+func TestNewExporter_InvalidEndpoint(t *testing.T) {
+	tests := []struct {
+		name        string
+		endpoint    string
+		expectedErr string
+	}{
+		{
+			name:        "Empty",
+			endpoint:    "",
+			expectedErr: `endpoint must be a valid URL: "" must start with http:// or https://`,
+		},
+		{
+			name:        "No Scheme",
+			endpoint:    "localhost:9009/api/v1/push",
+			expectedErr: `endpoint must be a valid URL: "localhost:9009/api/v1/push" must start with http:// or https://`,
+		},
+		{
+			name:        "No Host",
+			endpoint:    "http:///api/v1/push",
+			expectedErr: `endpoint must be a valid URL: "http:///api/v1/push" has no host`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Endpoint = test.endpoint
+			_, err := newExporter(cfg)
+			assert.EqualError(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestPushMetricsData_Request(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		request *prompb.WriteRequest
+		header  http.Header
+		path    string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		header = r.Header
+		path = r.URL.Path
+		compressed, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		bts, err := snappy.Decode(nil, compressed)
+		require.NoError(t, err)
+		request = &prompb.WriteRequest{}
+		require.NoError(t, request.Unmarshal(bts))
+	}))
+	defer srv.Close()
+
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.Endpoint = srv.URL + "/api/v1/push"
+	cfg.QueueSettings.Enabled = false
+	cfg.Namespace = "otel"
+	cfg.ExternalLabels = map[string]string{"cluster": "prod"}
+	cfg.Headers = map[string]string{"X-Scope-OrgID": "tenant1"}
+	exp, err := factory.CreateMetricsExporter(context.Background(), component.ExporterCreateParams{Logger: zap.NewNop()}, cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, exp.Shutdown(context.Background()))
+	}()
+
+	md := pdatautil.MetricsFromInternalMetrics(testdata.GenerateMetricDataWithCountersHistogramAndSummary())
+	require.NoError(t, exp.ConsumeMetrics(context.Background(), md))
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, "/api/v1/push", path)
+	assert.Equal(t, "application/x-protobuf", header.Get("Content-Type"))
+	assert.Equal(t, "snappy", header.Get("Content-Encoding"))
+	assert.Equal(t, "0.1.0", header.Get("X-Prometheus-Remote-Write-Version"))
+	assert.Equal(t, "tenant1", header.Get("X-Scope-OrgID"))
+	require.NotNil(t, request)
+	require.NotEmpty(t, request.Timeseries)
+	for _, ts := range request.Timeseries {
+		labels := map[string]string{}
+		for _, l := range ts.Labels {
+			labels[l.Name] = l.Value
+		}
+		assert.Equal(t, "prod", labels["cluster"])
+		assert.Regexp(t, "^otel_", labels["__name__"])
+		assert.NotEmpty(t, ts.Samples)
+	}
+}
+
+func TestPushMetricsData_InvalidMetric(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		requests int
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		requests++
+	}))
+	defer srv.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = srv.URL
+	exp, err := newExporter(cfg)
+	require.NoError(t, err)
+
+	imd := testdata.GenerateMetricDataOneMetric()
+	metrics := imd.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+	invalid := newTestMetric("invalid", pdata.MetricTypeInvalid)
+	metrics.Append(&invalid)
+
+	// the valid metric is sent, the invalid one is dropped
+	dropped, err := exp.pushMetricsData(context.Background(), pdatautil.MetricsFromInternalMetrics(imd))
+	require.Error(t, err)
+	assert.True(t, consumererror.IsPermanent(err))
+	assert.Equal(t, 1, dropped)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, 1, requests)
+}
+
+func TestPushMetricsData_Errors(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		permanent bool
+	}{
+		{name: "BadRequest", status: http.StatusBadRequest, permanent: true},
+		{name: "Unauthorized", status: http.StatusUnauthorized, permanent: true},
+		{name: "TooManyRequests", status: http.StatusTooManyRequests},
+		{name: "InternalServerError", status: http.StatusInternalServerError},
+		{name: "ServiceUnavailable", status: http.StatusServiceUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, "failed", tt.status)
+			}))
+			defer srv.Close()
+
+			cfg := createDefaultConfig().(*Config)
+			cfg.Endpoint = srv.URL
+			exp, err := newExporter(cfg)
+			require.NoError(t, err)
+
+			dropped, err := exp.pushMetricsData(context.Background(), pdatautil.MetricsFromInternalMetrics(testdata.GenerateMetricDataOneMetric()))
+			require.Error(t, err)
+			assert.Equal(t, 1, dropped)
+			assert.Contains(t, err.Error(), "failed")
+			assert.Equal(t, tt.permanent, consumererror.IsPermanent(err))
+		})
+	}
+}
+
+func TestPushMetricsData_InvalidMetricAndErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		permanent bool
+	}{
+		{name: "BadRequest", status: http.StatusBadRequest, permanent: true},
+		{name: "ServiceUnavailable", status: http.StatusServiceUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, "failed", tt.status)
+			}))
+			defer srv.Close()
+
+			cfg := createDefaultConfig().(*Config)
+			cfg.Endpoint = srv.URL
+			exp, err := newExporter(cfg)
+			require.NoError(t, err)
+
+			imd := testdata.GenerateMetricDataOneMetric()
+			metrics := imd.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+			invalid := newTestMetric("invalid", pdata.MetricTypeInvalid)
+			metrics.Append(&invalid)
+
+			// Both the metric sent in the failed request and the invalid metric are dropped.
+			dropped, err := exp.pushMetricsData(context.Background(), pdatautil.MetricsFromInternalMetrics(imd))
+			require.Error(t, err)
+			assert.Equal(t, 2, dropped)
+			assert.Contains(t, err.Error(), "failed")
+			assert.Contains(t, err.Error(), "invalid")
+			assert.Equal(t, tt.permanent, consumererror.IsPermanent(err))
+		})
+	}
+}
+
+func TestPushMetricsData_NetworkError(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = "http://" + testutil.GetAvailableLocalAddress(t) + "/api/v1/push"
+	exp, err := newExporter(cfg)
+	require.NoError(t, err)
+
+	_, err = exp.pushMetricsData(context.Background(), pdatautil.MetricsFromInternalMetrics(testdata.GenerateMetricDataOneMetric()))
+	require.Error(t, err)
+	assert.False(t, consumererror.IsPermanent(err))
+}
@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewriteexporter
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+const (
+	// The value of "type" key in configuration.
+	typeStr = "prometheusremotewrite"
+)
+
+// NewFactory creates a factory for Prometheus remote-write exporter.
+func NewFactory() component.ExporterFactory {
+	return exporterhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		exporterhelper.WithMetrics(createMetricsExporter))
+}
+
+func createDefaultConfig() configmodels.Exporter {
+	return &Config{
+		ExporterSettings: configmodels.ExporterSettings{
+			TypeVal: typeStr,
+			NameVal: typeStr,
+		},
+		HTTPClientSettings: confighttp.HTTPClientSettings{
+			Timeout: 5 * time.Second,
+		},
+		RetrySettings:  exporterhelper.CreateDefaultRetrySettings(),
+		QueueSettings:  exporterhelper.CreateDefaultQueueSettings(),
+		ExternalLabels: map[string]string{},
+		Headers:        map[string]string{},
+	}
+}
+
+func createMetricsExporter(
+	_ context.Context,
+	_ component.ExporterCreateParams,
+	cfg configmodels.Exporter,
+) (component.MetricsExporter, error) {
+	prwCfg := cfg.(*Config)
+	prwe, err := newExporter(prwCfg)
+	if err != nil {
+		return nil, err
+	}
+	return exporterhelper.NewMetricsExporter(
+		cfg,
+		prwe.pushMetricsData,
+		// The timeout of each attempt is enforced by the HTTP client.
+		exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{}),
+		exporterhelper.WithRetry(prwCfg.RetrySettings),
+		exporterhelper.WithQueue(prwCfg.QueueSettings),
+		exporterhelper.WithShutdown(prwe.shutdown))
+}
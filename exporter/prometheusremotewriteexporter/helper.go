@@ -0,0 +1,275 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewriteexporter
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/prompb"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+const (
+	sumSuffix     = "_sum"
+	countSuffix   = "_count"
+	bucketSuffix  = "_bucket"
+	leLabel       = "le"
+	quantileLabel = "quantile"
+	pInfStr       = "+Inf"
+
+	// Separates the label names and values in the signature of a time series.
+	signatureSep = "\xff"
+)
+
+// timeSeriesSet accumulates the samples converted from a batch of metrics,
+// keyed by the signature of the labels of their time series, so that the
+// data points of a time series spread over several metrics are sent as a
+// single time series.
+type timeSeriesSet map[string]*prompb.TimeSeries
+
+// addSample adds the sample to the time series with the given labels.
+func (s timeSeriesSet) addSample(labels []prompb.Label, sample prompb.Sample) {
+	sort.Slice(labels, func(i, j int) bool { return labels[i].Name < labels[j].Name })
+
+	var b strings.Builder
+	for _, l := range labels {
+		b.WriteString(l.Name)
+		b.WriteString(signatureSep)
+		b.WriteString(l.Value)
+		b.WriteString(signatureSep)
+	}
+	signature := b.String()
+
+	ts, ok := s[signature]
+	if !ok {
+		ts = &prompb.TimeSeries{Labels: labels}
+		s[signature] = ts
+	}
+	ts.Samples = append(ts.Samples, sample)
+}
+
+// timeSeries returns the time series of the set, ordered by signature, with
+// their samples ordered by timestamp as required by remote-write.
+func (s timeSeriesSet) timeSeries() []prompb.TimeSeries {
+	signatures := make([]string, 0, len(s))
+	for signature := range s {
+		signatures = append(signatures, signature)
+	}
+	sort.Strings(signatures)
+
+	tss := make([]prompb.TimeSeries, 0, len(s))
+	for _, signature := range signatures {
+		ts := s[signature]
+		sort.SliceStable(ts.Samples, func(i, j int) bool { return ts.Samples[i].Timestamp < ts.Samples[j].Timestamp })
+		tss = append(tss, *ts)
+	}
+	return tss
+}
+
+// converter converts pdata metrics into Prometheus time series.
+type converter struct {
+	namespace      string
+	externalLabels map[string]string
+}
+
+func newConverter(namespace string, externalLabels map[string]string) *converter {
+	c := &converter{
+		namespace:      sanitize(namespace),
+		externalLabels: make(map[string]string, len(externalLabels)),
+	}
+	for name, value := range externalLabels {
+		c.externalLabels[sanitize(name)] = value
+	}
+	return c
+}
+
+// addMetric converts the data points of the metric into samples added to
+// the set. It returns an error if the metric cannot be converted.
+func (c *converter) addMetric(set timeSeriesSet, metric pdata.Metric) error {
+	if metric.IsNil() || metric.MetricDescriptor().IsNil() {
+		return errors.New("invalid metric: missing metric descriptor")
+	}
+
+	descriptor := metric.MetricDescriptor()
+	if descriptor.Name() == "" {
+		return errors.New("invalid metric: missing metric name")
+	}
+	name := c.metricName(descriptor.Name())
+
+	switch descriptor.Type() {
+	case pdata.MetricTypeInt64, pdata.MetricTypeMonotonicInt64:
+		dps := metric.Int64DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			if dp.IsNil() {
+				continue
+			}
+			set.addSample(c.createLabels(name, dp.LabelsMap()), sample(float64(dp.Value()), dp.Timestamp()))
+		}
+	case pdata.MetricTypeDouble, pdata.MetricTypeMonotonicDouble:
+		dps := metric.DoubleDataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			if dp.IsNil() {
+				continue
+			}
+			set.addSample(c.createLabels(name, dp.LabelsMap()), sample(dp.Value(), dp.Timestamp()))
+		}
+	case pdata.MetricTypeHistogram:
+		dps := metric.HistogramDataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			if dp.IsNil() {
+				continue
+			}
+			if err := c.addHistogramDataPoint(set, name, dp); err != nil {
+				return fmt.Errorf("invalid metric %q: %w", descriptor.Name(), err)
+			}
+		}
+	case pdata.MetricTypeSummary:
+		dps := metric.SummaryDataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			if dp.IsNil() {
+				continue
+			}
+			c.addSummaryDataPoint(set, name, dp)
+		}
+	default:
+		return fmt.Errorf("invalid metric %q: unsupported metric type %v", descriptor.Name(), descriptor.Type())
+	}
+
+	return nil
+}
+
+// addHistogramDataPoint adds the cumulative count of each bucket, as the
+// _bucket time series with the upper bound of the bucket as "le" label, and
+// the _sum and _count time series.
+func (c *converter) addHistogramDataPoint(set timeSeriesSet, name string, dp pdata.HistogramDataPoint) error {
+	bounds := dp.ExplicitBounds()
+	buckets := dp.Buckets()
+	if buckets.Len() > 0 && buckets.Len() != len(bounds)+1 {
+		return fmt.Errorf("%d buckets do not match %d explicit bounds", buckets.Len(), len(bounds))
+	}
+
+	labels := dp.LabelsMap()
+	timestamp := dp.Timestamp()
+
+	var cumulativeCount uint64
+	for i := 0; i < buckets.Len()-1; i++ {
+		cumulativeCount += buckets.At(i).Count()
+		le := strconv.FormatFloat(bounds[i], 'g', -1, 64)
+		set.addSample(c.createLabels(name+bucketSuffix, labels, leLabel, le), sample(float64(cumulativeCount), timestamp))
+	}
+	// the +Inf bucket counts all the values
+	set.addSample(c.createLabels(name+bucketSuffix, labels, leLabel, pInfStr), sample(float64(dp.Count()), timestamp))
+
+	set.addSample(c.createLabels(name+sumSuffix, labels), sample(dp.Sum(), timestamp))
+	set.addSample(c.createLabels(name+countSuffix, labels), sample(float64(dp.Count()), timestamp))
+	return nil
+}
+
+// addSummaryDataPoint adds the value of each percentile, with the quantile in
+// [0, 1] as "quantile" label, and the _sum and _count time series.
+func (c *converter) addSummaryDataPoint(set timeSeriesSet, name string, dp pdata.SummaryDataPoint) {
+	labels := dp.LabelsMap()
+	timestamp := dp.Timestamp()
+
+	percentiles := dp.ValueAtPercentiles()
+	for i := 0; i < percentiles.Len(); i++ {
+		percentile := percentiles.At(i)
+		if percentile.IsNil() {
+			continue
+		}
+		quantile := strconv.FormatFloat(percentile.Percentile()/100, 'g', -1, 64)
+		set.addSample(c.createLabels(name, labels, quantileLabel, quantile), sample(percentile.Value(), timestamp))
+	}
+
+	set.addSample(c.createLabels(name+sumSuffix, labels), sample(dp.Sum(), timestamp))
+	set.addSample(c.createLabels(name+countSuffix, labels), sample(float64(dp.Count()), timestamp))
+}
+
+// createLabels returns the labels of a time series: the metric name, the
+// labels of the data point, the external labels that the data point does not
+// override, and the extra label name and value pairs.
+func (c *converter) createLabels(name string, labels pdata.StringMap, extras ...string) []prompb.Label {
+	l := make(map[string]string, labels.Len()+len(c.externalLabels)+len(extras)/2+1)
+
+	labels.ForEach(func(k string, v pdata.StringValue) {
+		l[sanitize(k)] = v.Value()
+	})
+
+	for name, value := range c.externalLabels {
+		if _, ok := l[name]; !ok {
+			l[name] = value
+		}
+	}
+
+	for i := 0; i+1 < len(extras); i += 2 {
+		l[extras[i]] = extras[i+1]
+	}
+
+	l[model.MetricNameLabel] = name
+
+	promLabels := make([]prompb.Label, 0, len(l))
+	for name, value := range l {
+		promLabels = append(promLabels, prompb.Label{Name: name, Value: value})
+	}
+	return promLabels
+}
+
+// metricName returns the sanitized metric name prefixed with the namespace.
+func (c *converter) metricName(name string) string {
+	if c.namespace != "" {
+		return c.namespace + "_" + sanitize(name)
+	}
+	return sanitize(name)
+}
+
+// sample returns a sample with the timestamp converted to milliseconds.
+func sample(value float64, timestamp pdata.TimestampUnixNano) prompb.Sample {
+	return prompb.Sample{
+		Value:     value,
+		Timestamp: int64(timestamp) / int64(1e6),
+	}
+}
+
+// sanitize replaces the characters that are not valid in Prometheus metric
+// and label names with underscores, and prefixes the names starting with a
+// digit with "key_".
+func sanitize(s string) string {
+	if s == "" {
+		return s
+	}
+
+	s = strings.Map(func(r rune) rune {
+		if r <= unicode.MaxASCII && (unicode.IsDigit(r) || unicode.IsLetter(r) || r == '_') {
+			return r
+		}
+		return '_'
+	}, s)
+
+	if unicode.IsDigit(rune(s[0])) {
+		s = "key_" + s
+	}
+	return s
+}
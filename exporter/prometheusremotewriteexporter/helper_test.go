@@ -0,0 +1,247 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewriteexporter
+
+import (
+	"testing"
+
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+const (
+	// 2020-01-01T00:00:00Z
+	testTimestamp   = pdata.TimestampUnixNano(1577836800000000000)
+	testTimestampMs = int64(1577836800000)
+)
+
+func newTestMetric(name string, metricType pdata.MetricType) pdata.Metric {
+	metric := pdata.NewMetric()
+	metric.InitEmpty()
+	metric.MetricDescriptor().InitEmpty()
+	metric.MetricDescriptor().SetName(name)
+	metric.MetricDescriptor().SetType(metricType)
+	return metric
+}
+
+func appendInt64DataPoint(metric pdata.Metric, value int64, labels map[string]string) {
+	dp := pdata.NewInt64DataPoint()
+	dp.InitEmpty()
+	dp.LabelsMap().InitFromMap(labels)
+	dp.SetTimestamp(testTimestamp)
+	dp.SetValue(value)
+	metric.Int64DataPoints().Append(&dp)
+}
+
+func appendDoubleDataPoint(metric pdata.Metric, value float64, labels map[string]string) {
+	dp := pdata.NewDoubleDataPoint()
+	dp.InitEmpty()
+	dp.LabelsMap().InitFromMap(labels)
+	dp.SetTimestamp(testTimestamp)
+	dp.SetValue(value)
+	metric.DoubleDataPoints().Append(&dp)
+}
+
+func appendHistogramDataPoint(metric pdata.Metric, bounds []float64, counts []uint64, sum float64) {
+	dp := pdata.NewHistogramDataPoint()
+	dp.InitEmpty()
+	dp.SetTimestamp(testTimestamp)
+	dp.SetExplicitBounds(bounds)
+	var count uint64
+	dp.Buckets().Resize(len(counts))
+	for i, c := range counts {
+		dp.Buckets().At(i).SetCount(c)
+		count += c
+	}
+	dp.SetCount(count)
+	dp.SetSum(sum)
+	metric.HistogramDataPoints().Append(&dp)
+}
+
+func appendSummaryDataPoint(metric pdata.Metric, percentiles map[float64]float64, count uint64, sum float64) {
+	dp := pdata.NewSummaryDataPoint()
+	dp.InitEmpty()
+	dp.SetTimestamp(testTimestamp)
+	for p, v := range percentiles {
+		percentile := pdata.NewSummaryValueAtPercentile()
+		percentile.InitEmpty()
+		percentile.SetPercentile(p)
+		percentile.SetValue(v)
+		dp.ValueAtPercentiles().Append(&percentile)
+	}
+	dp.SetCount(count)
+	dp.SetSum(sum)
+	metric.SummaryDataPoints().Append(&dp)
+}
+
+// series returns a time series with a single sample at the test timestamp,
+// with the labels given as name and value pairs, sorted by name.
+func series(value float64, labels ...string) prompb.TimeSeries {
+	ts := prompb.TimeSeries{Samples: []prompb.Sample{{Value: value, Timestamp: testTimestampMs}}}
+	for i := 0; i+1 < len(labels); i += 2 {
+		ts.Labels = append(ts.Labels, prompb.Label{Name: labels[i], Value: labels[i+1]})
+	}
+	return ts
+}
+
+func TestConverter_AddMetric(t *testing.T) {
+	tests := []struct {
+		name     string
+		metric   func() pdata.Metric
+		expected []prompb.TimeSeries
+	}{
+		{
+			name: "Int64",
+			metric: func() pdata.Metric {
+				metric := newTestMetric("system.processes.count", pdata.MetricTypeInt64)
+				appendInt64DataPoint(metric, 3, map[string]string{"status": "running"})
+				appendInt64DataPoint(metric, 5, map[string]string{"status": "sleeping"})
+				return metric
+			},
+			expected: []prompb.TimeSeries{
+				series(3, "__name__", "system_processes_count", "status", "running"),
+				series(5, "__name__", "system_processes_count", "status", "sleeping"),
+			},
+		},
+		{
+			name: "Monotonic Double",
+			metric: func() pdata.Metric {
+				metric := newTestMetric("system.cpu.time", pdata.MetricTypeMonotonicDouble)
+				appendDoubleDataPoint(metric, 1.5, map[string]string{"cpu.state": "idle"})
+				return metric
+			},
+			expected: []prompb.TimeSeries{
+				series(1.5, "__name__", "system_cpu_time", "cpu_state", "idle"),
+			},
+		},
+		{
+			name: "Histogram",
+			metric: func() pdata.Metric {
+				metric := newTestMetric("latency", pdata.MetricTypeHistogram)
+				appendHistogramDataPoint(metric, []float64{0.5, 1}, []uint64{1, 2, 3}, 7.5)
+				return metric
+			},
+			expected: []prompb.TimeSeries{
+				series(6, "__name__", "latency_bucket", "le", "+Inf"),
+				series(1, "__name__", "latency_bucket", "le", "0.5"),
+				series(3, "__name__", "latency_bucket", "le", "1"),
+				series(6, "__name__", "latency_count"),
+				series(7.5, "__name__", "latency_sum"),
+			},
+		},
+		{
+			name: "Summary",
+			metric: func() pdata.Metric {
+				metric := newTestMetric("latency", pdata.MetricTypeSummary)
+				appendSummaryDataPoint(metric, map[float64]float64{50: 0.2, 99: 0.9}, 10, 3)
+				return metric
+			},
+			expected: []prompb.TimeSeries{
+				series(10, "__name__", "latency_count"),
+				series(3, "__name__", "latency_sum"),
+				series(0.2, "__name__", "latency", "quantile", "0.5"),
+				series(0.9, "__name__", "latency", "quantile", "0.99"),
+			},
+		},
+	}
+
+	// the expected time series are ordered by the signature of their labels
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			set := timeSeriesSet{}
+			require.NoError(t, newConverter("", nil).addMetric(set, test.metric()))
+			assert.Equal(t, test.expected, set.timeSeries())
+		})
+	}
+}
+
+func TestConverter_AddMetric_Errors(t *testing.T) {
+	histogram := newTestMetric("latency", pdata.MetricTypeHistogram)
+	appendHistogramDataPoint(histogram, []float64{1}, []uint64{1, 2, 3}, 1)
+
+	tests := []struct {
+		name        string
+		metric      pdata.Metric
+		expectedErr string
+	}{
+		{
+			name:        "Nil Metric",
+			metric:      pdata.NewMetric(),
+			expectedErr: "invalid metric: missing metric descriptor",
+		},
+		{
+			name:        "Missing Name",
+			metric:      newTestMetric("", pdata.MetricTypeInt64),
+			expectedErr: "invalid metric: missing metric name",
+		},
+		{
+			name:        "Invalid Type",
+			metric:      newTestMetric("invalid", pdata.MetricTypeInvalid),
+			expectedErr: `invalid metric "invalid": unsupported metric type INVALID_TYPE`,
+		},
+		{
+			name:        "Histogram Buckets Mismatch",
+			metric:      histogram,
+			expectedErr: `invalid metric "latency": 3 buckets do not match 1 explicit bounds`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.EqualError(t, newConverter("", nil).addMetric(timeSeriesSet{}, test.metric), test.expectedErr)
+		})
+	}
+}
+
+func TestConverter_NamespaceAndExternalLabels(t *testing.T) {
+	metric := newTestMetric("http.requests", pdata.MetricTypeMonotonicInt64)
+	appendInt64DataPoint(metric, 1, map[string]string{"region": "us-east-1"})
+	appendInt64DataPoint(metric, 2, map[string]string{"http.method": "GET"})
+
+	c := newConverter("my-app", map[string]string{"region": "eu-west-1", "cluster.name": "prod"})
+	set := timeSeriesSet{}
+	require.NoError(t, c.addMetric(set, metric))
+
+	// the labels of the data points take precedence over the external labels
+	assert.Equal(t, []prompb.TimeSeries{
+		series(2, "__name__", "my_app_http_requests", "cluster_name", "prod", "http_method", "GET", "region", "eu-west-1"),
+		series(1, "__name__", "my_app_http_requests", "cluster_name", "prod", "region", "us-east-1"),
+	}, set.timeSeries())
+}
+
+func TestTimeSeriesSet_MergesSamples(t *testing.T) {
+	set := timeSeriesSet{}
+	labels := func() []prompb.Label {
+		return []prompb.Label{{Name: "b", Value: "2"}, {Name: "__name__", Value: "m"}, {Name: "a", Value: "1"}}
+	}
+	set.addSample(labels(), prompb.Sample{Value: 2, Timestamp: 2})
+	set.addSample(labels(), prompb.Sample{Value: 1, Timestamp: 1})
+
+	assert.Equal(t, []prompb.TimeSeries{{
+		Labels:  []prompb.Label{{Name: "__name__", Value: "m"}, {Name: "a", Value: "1"}, {Name: "b", Value: "2"}},
+		Samples: []prompb.Sample{{Value: 1, Timestamp: 1}, {Value: 2, Timestamp: 2}},
+	}}, set.timeSeries())
+}
+
+func TestSanitize(t *testing.T) {
+	assert.Equal(t, "", sanitize(""))
+	assert.Equal(t, "system_cpu_time", sanitize("system.cpu.time"))
+	assert.Equal(t, "key_99th_percentile", sanitize("99th-percentile"))
+	assert.Equal(t, "http_server_duration", sanitize("http/server:duration"))
+	assert.Equal(t, "caf_", sanitize("café"))
+}
@@ -26,4 +26,19 @@ type Config struct {
 	// Port is the port used to publish the health check status.
 	// The default value is 13133.
 	Port uint16 `mapstructure:"port"`
+
+	// LivenessPath is the path reporting whether all the components are healthy,
+	// with the status of each of them.
+	// The default value is "/health/live".
+	LivenessPath string `mapstructure:"liveness_path"`
+
+	// ReadinessPath is the path reporting whether the pipelines are ready and
+	// all the components are healthy, with the status of each of them.
+	// The default value is "/health/ready".
+	ReadinessPath string `mapstructure:"readiness_path"`
+
+	// ExporterFailureThreshold is the number of consecutive failed exports after
+	// which an exporter is reported unhealthy. Zero disables this check.
+	// The default value is 5.
+	ExporterFailureThreshold uint64 `mapstructure:"exporter_failure_threshold"`
 }
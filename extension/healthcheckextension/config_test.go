@@ -47,7 +47,10 @@ func TestLoadConfig(t *testing.T) {
 				TypeVal: "health_check",
 				NameVal: "health_check/1",
 			},
-			Port: 13,
+			Port:                     13,
+			LivenessPath:             "/live",
+			ReadinessPath:            "/ready",
+			ExporterFailureThreshold: 10,
 		},
 		ext1)
 
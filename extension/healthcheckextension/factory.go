@@ -44,7 +44,10 @@ func (f *Factory) CreateDefaultConfig() configmodels.Extension {
 			TypeVal: typeStr,
 			NameVal: typeStr,
 		},
-		Port: 13133,
+		Port:                     13133,
+		LivenessPath:             "/health/live",
+		ReadinessPath:            "/health/ready",
+		ExporterFailureThreshold: 5,
 	}
 }
 
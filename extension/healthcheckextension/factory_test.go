@@ -42,7 +42,10 @@ func TestFactory_CreateDefaultConfig(t *testing.T) {
 			NameVal: typeStr,
 			TypeVal: typeStr,
 		},
-		Port: 13133,
+		Port:                     13133,
+		LivenessPath:             "/health/live",
+		ReadinessPath:            "/health/ready",
+		ExporterFailureThreshold: 5,
 	},
 		cfg)
 
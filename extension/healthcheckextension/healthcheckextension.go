@@ -16,14 +16,17 @@ package healthcheckextension
 
 import (
 	"context"
+	"encoding/json"
 	"net"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/jaegertracing/jaeger/pkg/healthcheck"
 	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/internal/componentstatus"
 )
 
 type healthCheckExtension struct {
@@ -47,8 +50,16 @@ func (hc *healthCheckExtension) Start(ctx context.Context, host component.Host)
 		return nil
 	}
 
-	// Mount HC handler
-	hc.server.Handler = hc.state.Handler()
+	// Mount HC handlers, the root path keeps reporting only the readiness of the pipelines.
+	mux := http.NewServeMux()
+	mux.Handle("/", hc.state.Handler())
+	if hc.config.LivenessPath != "" {
+		mux.HandleFunc(hc.config.LivenessPath, hc.handleLiveness)
+	}
+	if hc.config.ReadinessPath != "" {
+		mux.HandleFunc(hc.config.ReadinessPath, hc.handleReadiness)
+	}
+	hc.server.Handler = mux
 
 	go func() {
 		// The listener ownership goes to the server.
@@ -74,6 +85,74 @@ func (hc *healthCheckExtension) NotReady() error {
 	return nil
 }
 
+// componentStatus is the JSON representation of the status of a component.
+type componentStatus struct {
+	Kind                string     `json:"kind"`
+	Name                string     `json:"name"`
+	Healthy             bool       `json:"healthy"`
+	ConsecutiveFailures uint64     `json:"consecutive_failures,omitempty"`
+	LastError           string     `json:"last_error,omitempty"`
+	LastErrorTime       *time.Time `json:"last_error_time,omitempty"`
+}
+
+// healthResponse is the JSON body served on the liveness and readiness paths.
+type healthResponse struct {
+	Healthy    bool              `json:"healthy"`
+	Ready      bool              `json:"ready"`
+	Components []componentStatus `json:"components"`
+}
+
+// health returns the status of the collector and of each of its components.
+func (hc *healthCheckExtension) health() healthResponse {
+	resp := healthResponse{
+		Healthy:    true,
+		Ready:      hc.state.Get() == healthcheck.Ready,
+		Components: []componentStatus{},
+	}
+	for _, st := range componentstatus.Snapshot() {
+		cs := componentStatus{
+			Kind:                string(st.Kind),
+			Name:                st.Name,
+			Healthy:             !st.Failing && !hc.failureThresholdReached(st.ConsecutiveFailures),
+			ConsecutiveFailures: st.ConsecutiveFailures,
+		}
+		if st.LastError != nil {
+			cs.LastError = st.LastError.Error()
+			lastErrorTime := st.LastErrorTime
+			cs.LastErrorTime = &lastErrorTime
+		}
+		resp.Healthy = resp.Healthy && cs.Healthy
+		resp.Components = append(resp.Components, cs)
+	}
+	return resp
+}
+
+func (hc *healthCheckExtension) failureThresholdReached(consecutiveFailures uint64) bool {
+	return hc.config.ExporterFailureThreshold > 0 && consecutiveFailures >= hc.config.ExporterFailureThreshold
+}
+
+func (hc *healthCheckExtension) handleLiveness(w http.ResponseWriter, _ *http.Request) {
+	resp := hc.health()
+	hc.writeHealth(w, resp, resp.Healthy)
+}
+
+func (hc *healthCheckExtension) handleReadiness(w http.ResponseWriter, _ *http.Request) {
+	resp := hc.health()
+	hc.writeHealth(w, resp, resp.Healthy && resp.Ready)
+}
+
+func (hc *healthCheckExtension) writeHealth(w http.ResponseWriter, resp healthResponse, ok bool) {
+	w.Header().Set("Content-Type", "application/json")
+	if ok {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		hc.logger.Warn("Failed to write the health check response", zap.Error(err))
+	}
+}
+
 func newServer(config Config, logger *zap.Logger) (*healthCheckExtension, error) {
 	hc := &healthCheckExtension{
 		config: config,
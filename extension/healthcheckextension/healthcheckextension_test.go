@@ -16,6 +16,8 @@ package healthcheckextension
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"net"
 	"net/http"
 	"runtime"
@@ -23,10 +25,12 @@ import (
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/internal/componentstatus"
 	"go.opentelemetry.io/collector/testutil"
 )
 
@@ -66,6 +70,99 @@ func TestHealthCheckExtensionUsage(t *testing.T) {
 	require.Equal(t, http.StatusServiceUnavailable, resp2.StatusCode)
 }
 
+func TestHealthCheckExtensionComponentStatus(t *testing.T) {
+	componentstatus.Reset()
+	defer componentstatus.Reset()
+
+	config := Config{
+		Port:                     testutil.GetAvailablePort(t),
+		LivenessPath:             "/live",
+		ReadinessPath:            "/ready",
+		ExporterFailureThreshold: 2,
+	}
+
+	hcExt, err := newServer(config, zap.NewNop())
+	require.NoError(t, err)
+	require.NotNil(t, hcExt)
+
+	require.NoError(t, hcExt.Start(context.Background(), componenttest.NewNopHost()))
+	defer hcExt.Shutdown(context.Background())
+
+	// Give a chance for the server goroutine to run.
+	runtime.Gosched()
+
+	baseURL := "http://localhost:" + strconv.Itoa(int(config.Port))
+	get := func(path string) (int, healthResponse) {
+		resp, err := http.Get(baseURL + path)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+		var body healthResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		return resp.StatusCode, body
+	}
+
+	// Healthy but not ready yet.
+	code, body := get("/live")
+	assert.Equal(t, http.StatusOK, code)
+	assert.True(t, body.Healthy)
+	assert.False(t, body.Ready)
+	assert.Empty(t, body.Components)
+	code, _ = get("/ready")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+
+	hcExt.Ready()
+	code, _ = get("/ready")
+	assert.Equal(t, http.StatusOK, code)
+
+	// A single failed export is below the threshold.
+	exportErr := errors.New("connection refused")
+	componentstatus.RecordResult(componentstatus.KindExporter, "otlp", exportErr)
+	code, body = get("/live")
+	assert.Equal(t, http.StatusOK, code)
+	require.Len(t, body.Components, 1)
+	assert.True(t, body.Components[0].Healthy)
+	assert.Equal(t, "connection refused", body.Components[0].LastError)
+
+	componentstatus.RecordResult(componentstatus.KindExporter, "otlp", exportErr)
+	code, body = get("/live")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.False(t, body.Healthy)
+	require.Len(t, body.Components, 1)
+	assert.Equal(t, "exporter", body.Components[0].Kind)
+	assert.Equal(t, "otlp", body.Components[0].Name)
+	assert.False(t, body.Components[0].Healthy)
+	assert.Equal(t, uint64(2), body.Components[0].ConsecutiveFailures)
+	assert.NotNil(t, body.Components[0].LastErrorTime)
+	code, _ = get("/ready")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+
+	// A successful export makes the exporter healthy again.
+	componentstatus.RecordResult(componentstatus.KindExporter, "otlp", nil)
+	code, _ = get("/ready")
+	assert.Equal(t, http.StatusOK, code)
+
+	componentstatus.SetStatus(componentstatus.KindProcessor, "memory_limiter", errors.New("dropping"))
+	code, body = get("/live")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	require.Len(t, body.Components, 2)
+	assert.Equal(t, "memory_limiter", body.Components[1].Name)
+	assert.False(t, body.Components[1].Healthy)
+
+	componentstatus.SetStatus(componentstatus.KindProcessor, "memory_limiter", nil)
+	code, _ = get("/live")
+	assert.Equal(t, http.StatusOK, code)
+
+	// The root path only reports the readiness of the pipelines.
+	componentstatus.SetStatus(componentstatus.KindService, "service", errors.New("fatal"))
+	resp, err := http.Get(baseURL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	code, _ = get("/live")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+}
+
 func TestHealthCheckExtensionPortAlreadyInUse(t *testing.T) {
 	endpoint := testutil.GetAvailableLocalAddress(t)
 	_, portStr, err := net.SplitHostPort(endpoint)
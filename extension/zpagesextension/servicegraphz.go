@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zpagesextension
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"net/http"
+	"strconv"
+
+	"go.opentelemetry.io/collector/internal/servicegraph"
+)
+
+const servicegraphzPath = "/servicegraphz"
+
+var servicegraphzTemplate = template.Must(template.New("servicegraphz").Funcs(template.FuncMap{
+	"avg": func(sum float64, count uint64) string {
+		if count == 0 {
+			return "-"
+		}
+		return strconv.FormatFloat(sum/float64(count), 'f', 2, 64)
+	},
+}).Parse(`<!DOCTYPE html>
+<html lang="en"><head>
+    <meta charset="utf-8">
+    <title>Service Graph</title>
+</head>
+<body>
+<h2>Service Graph</h2>
+{{range .}}
+<h3>{{.Name}}</h3>
+<p>Unpaired spans expired: {{.Expired}}, dropped: {{.Dropped}}. <a href="?format=dot">DOT format</a></p>
+<table border="1" cellpadding="4" style="border-collapse: collapse">
+  <tr><th>Client</th><th>Server</th><th>Requests</th><th>Failures</th><th>Average latency (ms)</th></tr>
+  {{range .Edges}}
+  <tr><td>{{.Client}}</td><td>{{.Server}}</td><td>{{.Requests}}</td><td>{{.Failures}}</td><td>{{avg .LatencySumMs .Requests}}</td></tr>
+  {{else}}
+  <tr><td colspan="5">No requests between services yet.</td></tr>
+  {{end}}
+</table>
+{{else}}
+<p>No servicegraph processor is running.</p>
+{{end}}
+</body>
+</html>
+`))
+
+// handleServicegraphzRequest renders the service graphs built by the servicegraph processors,
+// as HTML tables or, with the "format=dot" query parameter, in the Graphviz DOT language.
+func handleServicegraphzRequest(w http.ResponseWriter, r *http.Request) {
+	graphs := servicegraph.Snapshots()
+	if r.URL.Query().Get("format") == "dot" {
+		w.Header().Set("Content-Type", "text/vnd.graphviz; charset=utf-8")
+		writeDOT(w, graphs)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := servicegraphzTemplate.Execute(w, graphs); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// writeDOT writes the graphs in the Graphviz DOT language, one subgraph per processor.
+func writeDOT(w io.Writer, graphs []servicegraph.NamedGraph) {
+	fmt.Fprintln(w, "digraph servicegraph {")
+	for _, g := range graphs {
+		fmt.Fprintf(w, "  subgraph %q {\n", "cluster_"+g.Name)
+		fmt.Fprintf(w, "    label=%q;\n", g.Name)
+		for _, e := range g.Edges {
+			// Nodes are prefixed with the processor name, as the same service can be in
+			// several graphs.
+			fmt.Fprintf(w, "    %q [label=%q];\n", g.Name+"/"+e.Client, e.Client)
+			fmt.Fprintf(w, "    %q [label=%q];\n", g.Name+"/"+e.Server, e.Server)
+			fmt.Fprintf(w, "    %q -> %q [label=%q];\n", g.Name+"/"+e.Client, g.Name+"/"+e.Server,
+				fmt.Sprintf("%d req, %d failed", e.Requests, e.Failures))
+		}
+		fmt.Fprintln(w, "  }")
+	}
+	fmt.Fprintln(w, "}")
+}
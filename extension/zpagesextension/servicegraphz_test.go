@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zpagesextension
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/internal/servicegraph"
+)
+
+func TestServicegraphzNoGraph(t *testing.T) {
+	rr := httptest.NewRecorder()
+	handleServicegraphzRequest(rr, httptest.NewRequest(http.MethodGet, "/debug/servicegraphz", nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), "No servicegraph processor is running.")
+}
+
+func TestServicegraphz(t *testing.T) {
+	unregister := servicegraph.Register("servicegraph", func() servicegraph.Graph {
+		return servicegraph.Graph{
+			Edges: []servicegraph.Edge{
+				{Client: "frontend", Server: "cart", Requests: 4, Failures: 1, LatencySumMs: 10},
+			},
+			Expired: 2,
+			Dropped: 3,
+		}
+	})
+	defer unregister()
+
+	rr := httptest.NewRecorder()
+	handleServicegraphzRequest(rr, httptest.NewRequest(http.MethodGet, "/debug/servicegraphz", nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	body := rr.Body.String()
+	assert.Contains(t, body, "<h3>servicegraph</h3>")
+	assert.Contains(t, body, "Unpaired spans expired: 2, dropped: 3.")
+	assert.Contains(t, body, "<tr><td>frontend</td><td>cart</td><td>4</td><td>1</td><td>2.50</td></tr>")
+
+	rr = httptest.NewRecorder()
+	handleServicegraphzRequest(rr, httptest.NewRequest(http.MethodGet, "/debug/servicegraphz?format=dot", nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, `digraph servicegraph {
+  subgraph "cluster_servicegraph" {
+    label="servicegraph";
+    "servicegraph/frontend" [label="frontend"];
+    "servicegraph/cart" [label="cart"];
+    "servicegraph/frontend" -> "servicegraph/cart" [label="4 req, 1 failed"];
+  }
+}
+`, rr.Body.String())
+}
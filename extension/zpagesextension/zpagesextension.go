@@ -18,6 +18,7 @@ import (
 	"context"
 	"net"
 	"net/http"
+	"path"
 
 	"go.opencensus.io/zpages"
 	"go.uber.org/zap"
@@ -34,6 +35,7 @@ type zpagesExtension struct {
 func (zpe *zpagesExtension) Start(ctx context.Context, host component.Host) error {
 	zPagesMux := http.NewServeMux()
 	zpages.Handle(zPagesMux, "/debug")
+	zPagesMux.HandleFunc(path.Join("/debug", servicegraphzPath), handleServicegraphzRequest)
 
 	hostZPages, ok := host.(interface {
 		RegisterZPages(mux *http.ServeMux, pathPrefix string)
@@ -0,0 +1,100 @@
+// Copyright 2020 The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package batchpertrace splits traces in batches that contain the spans of a single trace.
+package batchpertrace
+
+import (
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// Batch holds all the spans of a single trace.
+type Batch struct {
+	TraceID pdata.TraceID
+	Traces  pdata.Traces
+}
+
+// Split splits the traces into batches that contain the spans of a single trace,
+// keeping the resource and instrumentation library of every span. Batches are returned in
+// the order the traces first appear in td.
+func Split(td pdata.Traces) []Batch {
+	var batches []Batch
+	indexByID := make(map[string]int)
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		if rs.IsNil() {
+			continue
+		}
+		// Destination resource spans of this resource, by batch index.
+		destRss := make(map[int]pdata.ResourceSpans)
+		ilss := rs.InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			ils := ilss.At(j)
+			if ils.IsNil() {
+				continue
+			}
+			// Destination instrumentation library spans of this library, by batch index.
+			destIlss := make(map[int]pdata.InstrumentationLibrarySpans)
+			spans := ils.Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				if span.IsNil() {
+					continue
+				}
+				id := string(span.TraceID().Bytes())
+				idx, ok := indexByID[id]
+				if !ok {
+					idx = len(batches)
+					indexByID[id] = idx
+					batches = append(batches, Batch{TraceID: span.TraceID(), Traces: pdata.NewTraces()})
+				}
+				destIls, ok := destIlss[idx]
+				if !ok {
+					destRs, ok := destRss[idx]
+					if !ok {
+						destRs = appendResource(batches[idx].Traces, rs)
+						destRss[idx] = destRs
+					}
+					destIls = appendLibrary(destRs, ils)
+					destIlss[idx] = destIls
+				}
+				destSpan := pdata.NewSpan()
+				destSpan.InitEmpty()
+				span.CopyTo(destSpan)
+				destIls.Spans().Append(&destSpan)
+			}
+		}
+	}
+	return batches
+}
+
+// appendResource appends a copy of the resource of rs to dest and returns the resource spans to fill.
+func appendResource(dest pdata.Traces, rs pdata.ResourceSpans) pdata.ResourceSpans {
+	destRs := pdata.NewResourceSpans()
+	destRs.InitEmpty()
+	rs.Resource().CopyTo(destRs.Resource())
+	dest.ResourceSpans().Append(&destRs)
+	return destRs
+}
+
+// appendLibrary appends a copy of the instrumentation library of ils to dest and returns
+// the instrumentation library spans to fill.
+func appendLibrary(dest pdata.ResourceSpans, ils pdata.InstrumentationLibrarySpans) pdata.InstrumentationLibrarySpans {
+	destIls := pdata.NewInstrumentationLibrarySpans()
+	destIls.InitEmpty()
+	ils.InstrumentationLibrary().CopyTo(destIls.InstrumentationLibrary())
+	dest.InstrumentationLibrarySpans().Append(&destIls)
+	return destIls
+}
@@ -0,0 +1,57 @@
+// Copyright 2020 The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package batchpertrace
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/data/testdata"
+)
+
+func TestSplit(t *testing.T) {
+	traceID1 := pdata.NewTraceID([]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	traceID2 := pdata.NewTraceID([]byte{16, 15, 14, 13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1})
+
+	td := testdata.GenerateTraceDataTwoSpansSameResourceOneDifferent()
+	rss := td.ResourceSpans()
+	rss.At(0).InstrumentationLibrarySpans().At(0).Spans().At(0).SetTraceID(traceID1)
+	rss.At(0).InstrumentationLibrarySpans().At(0).Spans().At(1).SetTraceID(traceID2)
+	rss.At(1).InstrumentationLibrarySpans().At(0).Spans().At(0).SetTraceID(traceID1)
+
+	batches := Split(td)
+	require.Len(t, batches, 2)
+
+	assert.Equal(t, traceID1, batches[0].TraceID)
+	assert.Equal(t, 2, batches[0].Traces.SpanCount())
+	require.Equal(t, 2, batches[0].Traces.ResourceSpans().Len())
+	assert.Equal(t, rss.At(0).Resource(), batches[0].Traces.ResourceSpans().At(0).Resource())
+	assert.Equal(t, rss.At(1).Resource(), batches[0].Traces.ResourceSpans().At(1).Resource())
+	assert.Equal(t, rss.At(0).InstrumentationLibrarySpans().At(0).Spans().At(0),
+		batches[0].Traces.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0))
+
+	assert.Equal(t, traceID2, batches[1].TraceID)
+	assert.Equal(t, 1, batches[1].Traces.SpanCount())
+	assert.Equal(t, rss.At(0).InstrumentationLibrarySpans().At(0).Spans().At(1),
+		batches[1].Traces.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0))
+}
+
+func TestSplit_empty(t *testing.T) {
+	assert.Len(t, Split(pdata.NewTraces()), 0)
+	assert.Len(t, Split(testdata.GenerateTraceDataOneEmptyResourceSpans()), 0)
+}
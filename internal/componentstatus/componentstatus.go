@@ -0,0 +1,159 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package componentstatus holds the health of the components of the collector, as reported by
+// obsreport and by the components themselves, so it can be exposed by other components, e.g.
+// the health_check extension.
+package componentstatus
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Kind is the kind of a component reporting its status.
+type Kind string
+
+const (
+	// KindExporter is the kind of exporters, reported for each export operation.
+	KindExporter Kind = "exporter"
+	// KindProcessor is the kind of processors.
+	KindProcessor Kind = "processor"
+	// KindService is the kind used for errors reported through the host, e.g. with
+	// component.Host.ReportFatalError, which are not attributed to a component.
+	KindService Kind = "service"
+)
+
+// Status is the status of a component.
+type Status struct {
+	Kind Kind
+	Name string
+	// Failing is true while the component reports that it is not working, e.g. the memory
+	// limiter while it is dropping data.
+	Failing bool
+	// ConsecutiveFailures is the number of operations that failed since the last successful
+	// one, for the components reporting each operation, e.g. exporters.
+	ConsecutiveFailures uint64
+	// LastError is the last error reported by the component, nil if it never reported one.
+	LastError error
+	// LastErrorTime is the time at which LastError was reported.
+	LastErrorTime time.Time
+}
+
+type key struct {
+	kind Kind
+	name string
+}
+
+// entry holds the status of a component. The failure counters are updated atomically so that
+// recording the result of each export does not contend on a lock.
+type entry struct {
+	// consecutiveFailures is first to keep it 64-bit aligned for atomic operations.
+	consecutiveFailures uint64
+	failing             int32
+
+	mu            sync.Mutex
+	lastError     error
+	lastErrorTime time.Time
+}
+
+func (e *entry) setError(err error) {
+	ts := now()
+	e.mu.Lock()
+	e.lastError = err
+	e.lastErrorTime = ts
+	e.mu.Unlock()
+}
+
+var (
+	// statuses maps the key of a component to its *entry.
+	statuses sync.Map
+	now      = time.Now
+)
+
+func get(kind Kind, name string) *entry {
+	k := key{kind: kind, name: name}
+	if e, ok := statuses.Load(k); ok {
+		return e.(*entry)
+	}
+	e, _ := statuses.LoadOrStore(k, &entry{})
+	return e.(*entry)
+}
+
+// RecordResult records the outcome of one operation of a component, a nil err meaning that the
+// operation succeeded.
+func RecordResult(kind Kind, name string, err error) {
+	e := get(kind, name)
+	if err == nil {
+		atomic.StoreUint64(&e.consecutiveFailures, 0)
+		return
+	}
+	atomic.AddUint64(&e.consecutiveFailures, 1)
+	e.setError(err)
+}
+
+// SetStatus records whether a component is failing: a non-nil err marks it as failing with
+// that error, a nil err marks it as recovered.
+func SetStatus(kind Kind, name string, err error) {
+	e := get(kind, name)
+	if err == nil {
+		atomic.StoreInt32(&e.failing, 0)
+		return
+	}
+	atomic.StoreInt32(&e.failing, 1)
+	e.setError(err)
+}
+
+// Snapshot returns the status of all the components that reported one, sorted by kind and name.
+func Snapshot() []Status {
+	var result []Status
+	statuses.Range(func(k, v interface{}) bool {
+		e := v.(*entry)
+		st := Status{
+			Kind:                k.(key).kind,
+			Name:                k.(key).name,
+			Failing:             atomic.LoadInt32(&e.failing) != 0,
+			ConsecutiveFailures: atomic.LoadUint64(&e.consecutiveFailures),
+		}
+		e.mu.Lock()
+		st.LastError = e.lastError
+		st.LastErrorTime = e.lastErrorTime
+		e.mu.Unlock()
+		result = append(result, st)
+		return true
+	})
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Kind != result[j].Kind {
+			return result[i].Kind < result[j].Kind
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// Remove forgets the status of a component, e.g. when it is shut down or removed from the
+// configuration.
+func Remove(kind Kind, name string) {
+	statuses.Delete(key{kind: kind, name: name})
+}
+
+// Reset forgets the status of all the components.
+func Reset() {
+	statuses.Range(func(k, _ interface{}) bool {
+		statuses.Delete(k)
+		return true
+	})
+}
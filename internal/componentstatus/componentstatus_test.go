@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package componentstatus
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordResult(t *testing.T) {
+	Reset()
+	defer Reset()
+	ts := time.Unix(100, 0)
+	now = func() time.Time { return ts }
+	defer func() { now = time.Now }()
+
+	exportErr := errors.New("connection refused")
+	RecordResult(KindExporter, "otlp", nil)
+	RecordResult(KindExporter, "otlp", exportErr)
+	RecordResult(KindExporter, "otlp", exportErr)
+
+	assert.Equal(t, []Status{{
+		Kind:                KindExporter,
+		Name:                "otlp",
+		ConsecutiveFailures: 2,
+		LastError:           exportErr,
+		LastErrorTime:       ts,
+	}}, Snapshot())
+
+	// A success resets the failures but keeps the last error.
+	RecordResult(KindExporter, "otlp", nil)
+	snapshot := Snapshot()
+	require.Len(t, snapshot, 1)
+	assert.Zero(t, snapshot[0].ConsecutiveFailures)
+	assert.Equal(t, exportErr, snapshot[0].LastError)
+}
+
+func TestSetStatus(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	dropErr := errors.New("dropping")
+	SetStatus(KindProcessor, "memory_limiter", dropErr)
+	snapshot := Snapshot()
+	require.Len(t, snapshot, 1)
+	assert.True(t, snapshot[0].Failing)
+	assert.Equal(t, dropErr, snapshot[0].LastError)
+
+	SetStatus(KindProcessor, "memory_limiter", nil)
+	snapshot = Snapshot()
+	require.Len(t, snapshot, 1)
+	assert.False(t, snapshot[0].Failing)
+	assert.Equal(t, dropErr, snapshot[0].LastError)
+}
+
+func TestSnapshotSorted(t *testing.T) {
+	Reset()
+	defer Reset()
+
+	SetStatus(KindService, "service", nil)
+	RecordResult(KindExporter, "zipkin", nil)
+	SetStatus(KindProcessor, "memory_limiter", nil)
+	RecordResult(KindExporter, "jaeger", nil)
+
+	var names []string
+	for _, st := range Snapshot() {
+		names = append(names, string(st.Kind)+"/"+st.Name)
+	}
+	assert.Equal(t, []string{"exporter/jaeger", "exporter/zipkin", "processor/memory_limiter", "service/service"}, names)
+
+	Remove(KindExporter, "zipkin")
+	assert.Len(t, Snapshot(), 3)
+
+	Reset()
+	assert.Empty(t, Snapshot())
+}
@@ -236,6 +236,22 @@ func GenerateLogDataTwoLogsSameResourceOneDifferent() pdata.Logs {
 	return ld
 }
 
+func GenerateLogDataManyLogsSameResource(count int) pdata.Logs {
+	ld := GenerateLogDataOneEmptyLogs()
+	rs0 := ld.ResourceLogs().At(0)
+	rs0.InstrumentationLibraryLogs().Resize(1)
+	rs0.InstrumentationLibraryLogs().At(0).Logs().Resize(count)
+	for i := 0; i < count; i++ {
+		l := rs0.InstrumentationLibraryLogs().At(0).Logs().At(i)
+		if i%2 == 0 {
+			fillLogOne(l)
+		} else {
+			fillLogTwo(l)
+		}
+	}
+	return ld
+}
+
 func generateLogOtlpTwoLogsSameResourceOneDifferent() []*otlplogs.ResourceLogs {
 	return []*otlplogs.ResourceLogs{
 		{
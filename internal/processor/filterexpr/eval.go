@@ -0,0 +1,200 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filterexpr
+
+import (
+	"regexp"
+)
+
+// node is a node of the parsed expression. Evaluated values are either nil, string, int64,
+// float64 or bool.
+type node interface {
+	eval(e env) interface{}
+}
+
+type literalNode struct {
+	value interface{}
+}
+
+func (n *literalNode) eval(env) interface{} {
+	return n.value
+}
+
+type fieldNode struct {
+	name string
+}
+
+func (n *fieldNode) eval(e env) interface{} {
+	return e.field(n.name)
+}
+
+type attributeNode struct {
+	key string
+}
+
+func (n *attributeNode) eval(e env) interface{} {
+	return e.attribute(n.key)
+}
+
+type resourceNode struct {
+	key string
+}
+
+func (n *resourceNode) eval(e env) interface{} {
+	return e.resourceAttribute(n.key)
+}
+
+type notNode struct {
+	operand node
+}
+
+func (n *notNode) eval(e env) interface{} {
+	return !isTrue(n.operand.eval(e))
+}
+
+type andNode struct {
+	left, right node
+}
+
+func (n *andNode) eval(e env) interface{} {
+	return isTrue(n.left.eval(e)) && isTrue(n.right.eval(e))
+}
+
+type orNode struct {
+	left, right node
+}
+
+func (n *orNode) eval(e env) interface{} {
+	return isTrue(n.left.eval(e)) || isTrue(n.right.eval(e))
+}
+
+type compareNode struct {
+	op          string
+	left, right node
+}
+
+func (n *compareNode) eval(e env) interface{} {
+	left, right := n.left.eval(e), n.right.eval(e)
+	switch n.op {
+	case "==":
+		return equal(left, right)
+	case "!=":
+		return !equal(left, right)
+	}
+
+	c, ok := compare(left, right)
+	if !ok {
+		return false
+	}
+	switch n.op {
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	case ">":
+		return c > 0
+	default:
+		return c >= 0
+	}
+}
+
+type regexpNode struct {
+	operand node
+	re      *regexp.Regexp
+	negate  bool
+}
+
+// eval matches string values against the pattern, values of other types never match.
+func (n *regexpNode) eval(e env) interface{} {
+	s, ok := n.operand.eval(e).(string)
+	if !ok {
+		return false
+	}
+	return n.re.MatchString(s) != n.negate
+}
+
+// isTrue returns true only for the true boolean value.
+func isTrue(v interface{}) bool {
+	b, ok := v.(bool)
+	return ok && b
+}
+
+// equal compares numbers by value regardless of their type, all other values must have the
+// same type to be equal.
+func equal(left, right interface{}) bool {
+	if c, ok := compareNumbers(left, right); ok {
+		return c == 0
+	}
+	return left == right
+}
+
+// compare orders two numbers or two strings, ok is false if the values cannot be ordered.
+func compare(left, right interface{}) (int, bool) {
+	if c, ok := compareNumbers(left, right); ok {
+		return c, true
+	}
+	ls, lok := left.(string)
+	rs, rok := right.(string)
+	if !lok || !rok {
+		return 0, false
+	}
+	switch {
+	case ls < rs:
+		return -1, true
+	case ls > rs:
+		return 1, true
+	}
+	return 0, true
+}
+
+func compareNumbers(left, right interface{}) (int, bool) {
+	li, lint := left.(int64)
+	ri, rint := right.(int64)
+	if lint && rint {
+		switch {
+		case li < ri:
+			return -1, true
+		case li > ri:
+			return 1, true
+		}
+		return 0, true
+	}
+
+	lf, lok := toFloat(left)
+	rf, rok := toFloat(right)
+	if !lok || !rok {
+		return 0, false
+	}
+	switch {
+	case lf < rf:
+		return -1, true
+	case lf > rf:
+		return 1, true
+	case lf == rf:
+		return 0, true
+	}
+	// NaN cannot be ordered.
+	return 0, false
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int64:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
@@ -0,0 +1,245 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package filterexpr is a helper package for matching spans, log records and metric data
+// points against match expressions, e.g.
+//  attributes["http.status_code"] >= 500 && resource["service.name"] == "cart"
+//
+// Expressions support string, number and boolean literals, the "attributes" and "resource"
+// maps, the fields of the matched item (see the Field constants), the ==, !=, <, <=, >, >=
+// comparisons, the =~ and !~ regular expression matches, the &&, || and ! operators and
+// parentheses. Missing attributes and fields that do not apply to the matched item evaluate
+// to nil, which only compares equal to nil.
+package filterexpr
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	otlptrace "go.opentelemetry.io/collector/internal/data/opentelemetry-proto-gen/trace/v1"
+)
+
+const (
+	// attributesIdent gives access to the span or log record attributes, or to the data point labels.
+	attributesIdent = "attributes"
+	// resourceIdent gives access to the resource attributes.
+	resourceIdent = "resource"
+)
+
+const (
+	// FieldName is the span name, the log record name or the metric name.
+	FieldName = "name"
+	// FieldKind is the span kind, e.g. "SERVER" or "CLIENT".
+	FieldKind = "kind"
+	// FieldStatus is the span status code, e.g. "Ok" or "UnknownError". Spans without status
+	// have the "Ok" status code.
+	FieldStatus = "status"
+	// FieldSeverityText is the log record severity text.
+	FieldSeverityText = "severity_text"
+	// FieldSeverityNumber is the log record severity number, e.g. 13 for WARN.
+	FieldSeverityNumber = "severity_number"
+	// FieldBody is the log record body.
+	FieldBody = "body"
+	// FieldMetricType is the metric type, e.g. "INT64" or "HISTOGRAM".
+	FieldMetricType = "metric_type"
+	// FieldValue is the data point value. Histogram and summary data points use their sum.
+	FieldValue = "value"
+)
+
+var knownFields = map[string]bool{
+	FieldName:           true,
+	FieldKind:           true,
+	FieldStatus:         true,
+	FieldSeverityText:   true,
+	FieldSeverityNumber: true,
+	FieldBody:           true,
+	FieldMetricType:     true,
+	FieldValue:          true,
+}
+
+// Expression is a compiled match expression. It is safe for concurrent use.
+type Expression struct {
+	source string
+	root   node
+}
+
+// Compile parses a match expression so it can be evaluated many times.
+func Compile(source string) (*Expression, error) {
+	root, err := parse(source)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing expression %q: %v", source, err)
+	}
+	return &Expression{source: source, root: root}, nil
+}
+
+// String returns the source of the expression.
+func (e *Expression) String() string {
+	return e.source
+}
+
+func (e *Expression) match(en env) bool {
+	return isTrue(e.root.eval(en))
+}
+
+// MatchSpan evaluates the expression for the span and its resource.
+func (e *Expression) MatchSpan(span pdata.Span, resource pdata.Resource) bool {
+	return e.match(spanEnv{resourceEnv: resourceEnv(resource), span: span})
+}
+
+// MatchLogRecord evaluates the expression for the log record and its resource.
+func (e *Expression) MatchLogRecord(lr pdata.LogRecord, resource pdata.Resource) bool {
+	return e.match(logEnv{resourceEnv: resourceEnv(resource), lr: lr})
+}
+
+// MatchInt64DataPoint evaluates the expression for the data point, its metric and resource.
+func (e *Expression) MatchInt64DataPoint(dp pdata.Int64DataPoint, metric pdata.Metric, resource pdata.Resource) bool {
+	return e.match(dataPointEnv{resourceEnv: resourceEnv(resource), metric: metric, labels: dp.LabelsMap(), value: dp.Value()})
+}
+
+// MatchDoubleDataPoint evaluates the expression for the data point, its metric and resource.
+func (e *Expression) MatchDoubleDataPoint(dp pdata.DoubleDataPoint, metric pdata.Metric, resource pdata.Resource) bool {
+	return e.match(dataPointEnv{resourceEnv: resourceEnv(resource), metric: metric, labels: dp.LabelsMap(), value: dp.Value()})
+}
+
+// MatchHistogramDataPoint evaluates the expression for the data point, its metric and resource.
+func (e *Expression) MatchHistogramDataPoint(dp pdata.HistogramDataPoint, metric pdata.Metric, resource pdata.Resource) bool {
+	return e.match(dataPointEnv{resourceEnv: resourceEnv(resource), metric: metric, labels: dp.LabelsMap(), value: dp.Sum()})
+}
+
+// MatchSummaryDataPoint evaluates the expression for the data point, its metric and resource.
+func (e *Expression) MatchSummaryDataPoint(dp pdata.SummaryDataPoint, metric pdata.Metric, resource pdata.Resource) bool {
+	return e.match(dataPointEnv{resourceEnv: resourceEnv(resource), metric: metric, labels: dp.LabelsMap(), value: dp.Sum()})
+}
+
+// env provides the values of the matched item to the expression.
+type env interface {
+	field(name string) interface{}
+	attribute(key string) interface{}
+	resourceAttribute(key string) interface{}
+}
+
+type resourceEnv pdata.Resource
+
+func (r resourceEnv) resourceAttribute(key string) interface{} {
+	resource := pdata.Resource(r)
+	if resource.IsNil() {
+		return nil
+	}
+	if v, ok := resource.Attributes().Get(key); ok {
+		return attributeValue(v)
+	}
+	return nil
+}
+
+type spanEnv struct {
+	resourceEnv
+	span pdata.Span
+}
+
+func (s spanEnv) field(name string) interface{} {
+	switch name {
+	case FieldName:
+		return s.span.Name()
+	case FieldKind:
+		return s.span.Kind().String()
+	case FieldStatus:
+		if s.span.Status().IsNil() {
+			return otlptrace.Status_Ok.String()
+		}
+		return s.span.Status().Code().String()
+	}
+	return nil
+}
+
+func (s spanEnv) attribute(key string) interface{} {
+	if v, ok := s.span.Attributes().Get(key); ok {
+		return attributeValue(v)
+	}
+	return nil
+}
+
+type logEnv struct {
+	resourceEnv
+	lr pdata.LogRecord
+}
+
+func (l logEnv) field(name string) interface{} {
+	switch name {
+	case FieldName:
+		return l.lr.Name()
+	case FieldSeverityText:
+		return l.lr.SeverityText()
+	case FieldSeverityNumber:
+		return int64(l.lr.SeverityNumber())
+	case FieldBody:
+		return attributeValue(l.lr.Body())
+	}
+	return nil
+}
+
+func (l logEnv) attribute(key string) interface{} {
+	if v, ok := l.lr.Attributes().Get(key); ok {
+		return attributeValue(v)
+	}
+	return nil
+}
+
+type dataPointEnv struct {
+	resourceEnv
+	metric pdata.Metric
+	labels pdata.StringMap
+	// value is either an int64 or a float64.
+	value interface{}
+}
+
+func (d dataPointEnv) field(name string) interface{} {
+	switch name {
+	case FieldName:
+		if desc := d.metric.MetricDescriptor(); !desc.IsNil() {
+			return desc.Name()
+		}
+		return ""
+	case FieldMetricType:
+		if desc := d.metric.MetricDescriptor(); !desc.IsNil() {
+			return desc.Type().String()
+		}
+		return pdata.MetricTypeInvalid.String()
+	case FieldValue:
+		return d.value
+	}
+	return nil
+}
+
+func (d dataPointEnv) attribute(key string) interface{} {
+	if v, ok := d.labels.Get(key); ok {
+		return v.Value()
+	}
+	return nil
+}
+
+// attributeValue converts an attribute value to an expression value, values that are not
+// scalars evaluate to nil.
+func attributeValue(v pdata.AttributeValue) interface{} {
+	switch v.Type() {
+	case pdata.AttributeValueSTRING:
+		return v.StringVal()
+	case pdata.AttributeValueINT:
+		return v.IntVal()
+	case pdata.AttributeValueDOUBLE:
+		return v.DoubleVal()
+	case pdata.AttributeValueBOOL:
+		return v.BoolVal()
+	}
+	return nil
+}
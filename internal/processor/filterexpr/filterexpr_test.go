@@ -0,0 +1,158 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filterexpr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	otlplogs "go.opentelemetry.io/collector/internal/data/opentelemetry-proto-gen/logs/v1"
+	"go.opentelemetry.io/collector/translator/conventions"
+)
+
+func newResource() pdata.Resource {
+	resource := pdata.NewResource()
+	resource.InitEmpty()
+	resource.Attributes().InsertString(conventions.AttributeServiceName, "cart")
+	return resource
+}
+
+func TestExpression_MatchSpan(t *testing.T) {
+	testcases := []struct {
+		expr  string
+		match bool
+	}{
+		{expr: `attributes["http.status_code"] >= 500 && resource["service.name"] == "cart"`, match: true},
+		{expr: `attributes["http.status_code"] >= 500 && resource["service.name"] == "checkout"`, match: false},
+		{expr: `attributes["http.status_code"] == 503.0`, match: true},
+		{expr: `attributes["http.method"] =~ "^(GET|HEAD)$"`, match: true},
+		{expr: `attributes["error"] == true`, match: true},
+		{expr: `attributes["error"]`, match: true},
+		{expr: `attributes["ratio"] < 0.5`, match: true},
+		{expr: `attributes["missing"] == nil`, match: true},
+		{expr: `attributes["missing"] < 1`, match: false},
+		{expr: `name == "GET /cart"`, match: true},
+		{expr: `kind == "SERVER"`, match: true},
+		{expr: `status == "Ok"`, match: true},
+		{expr: `severity_text == nil && value == nil`, match: true},
+	}
+
+	span := pdata.NewSpan()
+	span.InitEmpty()
+	span.SetName("GET /cart")
+	span.SetKind(pdata.SpanKindSERVER)
+	span.Attributes().InitFromMap(map[string]pdata.AttributeValue{
+		"http.status_code": pdata.NewAttributeValueInt(503),
+		"http.method":      pdata.NewAttributeValueString("GET"),
+		"error":            pdata.NewAttributeValueBool(true),
+		"ratio":            pdata.NewAttributeValueDouble(0.25),
+	})
+
+	for _, tc := range testcases {
+		t.Run(tc.expr, func(t *testing.T) {
+			expr, err := Compile(tc.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tc.match, expr.MatchSpan(span, newResource()))
+		})
+	}
+}
+
+func TestExpression_MatchSpan_StatusAndNilResource(t *testing.T) {
+	span := pdata.NewSpan()
+	span.InitEmpty()
+	span.Status().InitEmpty()
+	span.Status().SetCode(pdata.StatusCode(2))
+
+	expr, err := Compile(`status == "UnknownError" && resource["service.name"] == nil`)
+	require.NoError(t, err)
+	assert.True(t, expr.MatchSpan(span, pdata.NewResource()))
+}
+
+func TestExpression_MatchLogRecord(t *testing.T) {
+	testcases := []struct {
+		expr  string
+		match bool
+	}{
+		{expr: `severity_number >= 13 && resource["service.name"] == "cart"`, match: true},
+		{expr: `severity_number >= 17`, match: false},
+		{expr: `severity_text == "WARN"`, match: true},
+		{expr: `name == "logA" && body =~ "timeout"`, match: true},
+		{expr: `attributes["retry"] > 2`, match: true},
+		{expr: `kind == nil`, match: true},
+	}
+
+	lr := pdata.NewLogRecord()
+	lr.InitEmpty()
+	lr.SetName("logA")
+	lr.SetSeverityNumber(otlplogs.SeverityNumber_WARN)
+	lr.SetSeverityText("WARN")
+	lr.Body().SetStringVal("request timeout")
+	lr.Attributes().InsertInt("retry", 3)
+
+	for _, tc := range testcases {
+		t.Run(tc.expr, func(t *testing.T) {
+			expr, err := Compile(tc.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tc.match, expr.MatchLogRecord(lr, newResource()))
+		})
+	}
+}
+
+func TestExpression_MatchDataPoints(t *testing.T) {
+	metric := pdata.NewMetric()
+	metric.InitEmpty()
+	metric.MetricDescriptor().InitEmpty()
+	metric.MetricDescriptor().SetName("http.requests")
+	metric.MetricDescriptor().SetType(pdata.MetricTypeMonotonicInt64)
+
+	idp := pdata.NewInt64DataPoint()
+	idp.InitEmpty()
+	idp.SetValue(10)
+	idp.LabelsMap().Insert("code", "500")
+
+	ddp := pdata.NewDoubleDataPoint()
+	ddp.InitEmpty()
+	ddp.SetValue(0.5)
+	ddp.LabelsMap().Insert("code", "200")
+
+	hdp := pdata.NewHistogramDataPoint()
+	hdp.InitEmpty()
+	hdp.SetSum(42)
+
+	sdp := pdata.NewSummaryDataPoint()
+	sdp.InitEmpty()
+	sdp.SetSum(7)
+
+	expr, err := Compile(`name == "http.requests" && metric_type == "MONOTONIC_INT64" && attributes["code"] =~ "^5"`)
+	require.NoError(t, err)
+	assert.True(t, expr.MatchInt64DataPoint(idp, metric, newResource()))
+	assert.False(t, expr.MatchDoubleDataPoint(ddp, metric, newResource()))
+
+	expr, err = Compile(`value > 5 && resource["service.name"] == "cart"`)
+	require.NoError(t, err)
+	assert.True(t, expr.MatchInt64DataPoint(idp, metric, newResource()))
+	assert.False(t, expr.MatchDoubleDataPoint(ddp, metric, newResource()))
+	assert.True(t, expr.MatchHistogramDataPoint(hdp, metric, newResource()))
+	assert.True(t, expr.MatchSummaryDataPoint(sdp, metric, newResource()))
+
+	expr, err = Compile(`name == "" && metric_type == "INVALID_TYPE"`)
+	require.NoError(t, err)
+	emptyMetric := pdata.NewMetric()
+	emptyMetric.InitEmpty()
+	assert.True(t, expr.MatchInt64DataPoint(idp, emptyMetric, newResource()))
+}
@@ -0,0 +1,327 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filterexpr
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenString
+	tokenNumber
+	tokenOperator
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func (t token) String() string {
+	if t.kind == tokenEOF {
+		return "end of expression"
+	}
+	return strconv.Quote(t.text)
+}
+
+// operators are sorted so that the longest operators are matched first.
+var operators = []string{"&&", "||", "==", "!=", "<=", ">=", "=~", "!~", "<", ">", "!", "(", ")", "[", "]", "-"}
+
+// tokenize splits the expression source into tokens.
+func tokenize(src string) ([]token, error) {
+	var tokens []token
+	for pos := 0; pos < len(src); {
+		c := src[pos]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			pos++
+		case isIdentStart(c):
+			start := pos
+			for pos < len(src) && isIdentPart(src[pos]) {
+				pos++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: src[start:pos], pos: start})
+		case c >= '0' && c <= '9':
+			start := pos
+			pos++
+			for pos < len(src) && isNumberPart(src[pos], src[pos-1]) {
+				pos++
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: src[start:pos], pos: start})
+		case c == '"':
+			end := pos + 1
+			for ; end < len(src) && src[end] != '"'; end++ {
+				if src[end] == '\\' {
+					end++
+				}
+			}
+			if end >= len(src) {
+				return nil, fmt.Errorf("unterminated string at position %d", pos)
+			}
+			s, err := strconv.Unquote(src[pos : end+1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid string at position %d: %v", pos, err)
+			}
+			tokens = append(tokens, token{kind: tokenString, text: s, pos: pos})
+			pos = end + 1
+		default:
+			op := ""
+			for _, o := range operators {
+				if strings.HasPrefix(src[pos:], o) {
+					op = o
+					break
+				}
+			}
+			if op == "" {
+				return nil, fmt.Errorf("unexpected character %q at position %d", c, pos)
+			}
+			tokens = append(tokens, token{kind: tokenOperator, text: op, pos: pos})
+			pos += len(op)
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, pos: len(src)}), nil
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdentPart(c byte) bool {
+	return isIdentStart(c) || (c >= '0' && c <= '9')
+}
+
+func isNumberPart(c, prev byte) bool {
+	return (c >= '0' && c <= '9') || c == '.' || c == 'e' || c == 'E' ||
+		((c == '+' || c == '-') && (prev == 'e' || prev == 'E'))
+}
+
+// parser is a recursive descent parser for the expression grammar:
+//
+//  or         = and { "||" and }
+//  and        = unary { "&&" unary }
+//  unary      = "!" unary | comparison
+//  comparison = operand [ ( "==" | "!=" | "<" | "<=" | ">" | ">=" ) operand | ( "=~" | "!~" ) string ]
+//  operand    = "(" or ")" | string | [ "-" ] number | "true" | "false" | "nil"
+//             | ( "attributes" | "resource" ) "[" string "]" | field
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func parse(src string) (node, error) {
+	tokens, err := tokenize(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	n, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, unexpected(tok)
+	}
+	return n, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+// accept consumes the next token if it is the given operator.
+func (p *parser) accept(op string) bool {
+	if tok := p.peek(); tok.kind == tokenOperator && tok.text == op {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(op string) error {
+	if !p.accept(op) {
+		return fmt.Errorf("expected %q, found %v at position %d", op, p.peek(), p.peek().pos)
+	}
+	return nil
+}
+
+func unexpected(tok token) error {
+	return fmt.Errorf("unexpected %v at position %d", tok, tok.pos)
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("||") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &orNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("&&") {
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &andNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if p.accept("!") {
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &notNode{operand: operand}, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (node, error) {
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	tok := p.peek()
+	if tok.kind != tokenOperator {
+		return left, nil
+	}
+	switch tok.text {
+	case "==", "!=", "<", "<=", ">", ">=":
+		p.next()
+		right, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		return &compareNode{op: tok.text, left: left, right: right}, nil
+	case "=~", "!~":
+		p.next()
+		pattern := p.next()
+		if pattern.kind != tokenString {
+			return nil, fmt.Errorf("expected a string pattern after %q, found %v at position %d", tok.text, pattern, pattern.pos)
+		}
+		re, err := regexp.Compile(pattern.text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern at position %d: %v", pattern.pos, err)
+		}
+		return &regexpNode{operand: left, re: re, negate: tok.text == "!~"}, nil
+	}
+	return left, nil
+}
+
+func (p *parser) parseOperand() (node, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokenString:
+		return &literalNode{value: tok.text}, nil
+	case tokenNumber:
+		return parseNumber(tok, false)
+	case tokenOperator:
+		switch tok.text {
+		case "(":
+			n, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			return n, nil
+		case "-":
+			if num := p.peek(); num.kind == tokenNumber {
+				p.next()
+				return parseNumber(num, true)
+			}
+		}
+	case tokenIdent:
+		switch tok.text {
+		case "true":
+			return &literalNode{value: true}, nil
+		case "false":
+			return &literalNode{value: false}, nil
+		case "nil":
+			return &literalNode{value: nil}, nil
+		case attributesIdent, resourceIdent:
+			return p.parseIndex(tok.text)
+		}
+		if !knownFields[tok.text] {
+			return nil, fmt.Errorf("unknown identifier %v at position %d", tok, tok.pos)
+		}
+		return &fieldNode{name: tok.text}, nil
+	}
+	return nil, unexpected(tok)
+}
+
+func (p *parser) parseIndex(ident string) (node, error) {
+	if err := p.expect("["); err != nil {
+		return nil, err
+	}
+	key := p.next()
+	if key.kind != tokenString {
+		return nil, fmt.Errorf("expected a string key for %q, found %v at position %d", ident, key, key.pos)
+	}
+	if err := p.expect("]"); err != nil {
+		return nil, err
+	}
+	if ident == resourceIdent {
+		return &resourceNode{key: key.text}, nil
+	}
+	return &attributeNode{key: key.text}, nil
+}
+
+// parseNumber parses integers as int64 and all other numbers as float64.
+func parseNumber(tok token, negative bool) (node, error) {
+	text := tok.text
+	if negative {
+		text = "-" + text
+	}
+	if i, err := strconv.ParseInt(text, 10, 64); err == nil {
+		return &literalNode{value: i}, nil
+	}
+	f, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid number %v at position %d", tok, tok.pos)
+	}
+	return &literalNode{value: f}, nil
+}
@@ -0,0 +1,124 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filterexpr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompile_Literals(t *testing.T) {
+	testcases := []struct {
+		expr  string
+		match bool
+	}{
+		{expr: `true`, match: true},
+		{expr: `false`, match: false},
+		{expr: `!false`, match: true},
+		{expr: `"a" == "a"`, match: true},
+		{expr: `"a\"b" == "a\"b"`, match: true},
+		{expr: `"a" < "b"`, match: true},
+		{expr: `1 == 1.0`, match: true},
+		{expr: `-1 < 0`, match: true},
+		{expr: `1.5e2 == 150`, match: true},
+		{expr: `2 >= 3`, match: false},
+		{expr: `"1" == 1`, match: false},
+		{expr: `"1" < 2`, match: false},
+		{expr: `nil == nil`, match: true},
+		{expr: `1 != nil`, match: true},
+		{expr: `"abc" =~ "^a.c$"`, match: true},
+		{expr: `"abc" !~ "^a.c$"`, match: false},
+		{expr: `1 =~ "1"`, match: false},
+		{expr: `true && (false || true)`, match: true},
+		{expr: `true && false || true`, match: true},
+		{expr: `!(1 == 1) || 1 != 1`, match: false},
+		{expr: `"string"`, match: false},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.expr, func(t *testing.T) {
+			expr, err := Compile(tc.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tc.match, expr.match(nil))
+			assert.Equal(t, tc.expr, expr.String())
+		})
+	}
+}
+
+func TestCompile_Errors(t *testing.T) {
+	testcases := []struct {
+		expr        string
+		errorString string
+	}{
+		{
+			expr:        ``,
+			errorString: `error parsing expression "": unexpected end of expression at position 0`,
+		},
+		{
+			expr:        `name == `,
+			errorString: `error parsing expression "name == ": unexpected end of expression at position 8`,
+		},
+		{
+			expr:        `foo == "bar"`,
+			errorString: `error parsing expression "foo == \"bar\"": unknown identifier "foo" at position 0`,
+		},
+		{
+			expr:        `(name == "a"`,
+			errorString: `error parsing expression "(name == \"a\"": expected ")", found end of expression at position 12`,
+		},
+		{
+			expr:        `attributes[1] == 1`,
+			errorString: `error parsing expression "attributes[1] == 1": expected a string key for "attributes", found "1" at position 11`,
+		},
+		{
+			expr:        `resource == "a"`,
+			errorString: `error parsing expression "resource == \"a\"": expected "[", found "==" at position 9`,
+		},
+		{
+			expr:        `name =~ kind`,
+			errorString: `error parsing expression "name =~ kind": expected a string pattern after "=~", found "kind" at position 8`,
+		},
+		{
+			expr:        `name =~ "["`,
+			errorString: "error parsing expression \"name =~ \\\"[\\\"\": invalid pattern at position 8: error parsing regexp: missing closing ]: `[`",
+		},
+		{
+			expr:        `name == "a`,
+			errorString: `error parsing expression "name == \"a": unterminated string at position 8`,
+		},
+		{
+			expr:        `name = "a"`,
+			errorString: `error parsing expression "name = \"a\"": unexpected character '=' at position 5`,
+		},
+		{
+			expr:        `name == "a" "b"`,
+			errorString: `error parsing expression "name == \"a\" \"b\"": unexpected "b" at position 12`,
+		},
+		{
+			expr:        `value > 1.2.3`,
+			errorString: `error parsing expression "value > 1.2.3": invalid number "1.2.3" at position 8`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.expr, func(t *testing.T) {
+			expr, err := Compile(tc.expr)
+			assert.Nil(t, expr)
+			assert.EqualError(t, err, tc.errorString)
+		})
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filterlog
+
+import (
+	"go.opentelemetry.io/collector/internal/processor/filterset"
+	"go.opentelemetry.io/collector/internal/processor/filterspan"
+)
+
+// MatchConfig has two optional MatchProperties, include to define which log records
+// are processed and exclude to define which log records are excluded from processing.
+type MatchConfig struct {
+	// Include specifies the set of log record properties that must be present in
+	// order for the processor to apply to it.
+	// Note: If `exclude` is specified, the log record is compared against those
+	// properties after the `include` properties.
+	// This is an optional field. If neither `include` and `exclude` are set, all
+	// log records are processed.
+	Include *MatchProperties `mapstructure:"include"`
+
+	// Exclude specifies when the processor will not be applied to the log records
+	// which match the specified properties.
+	// Note: The `exclude` properties are checked after the `include` properties,
+	// if they exist, are checked.
+	// This is an optional field. If neither `include` and `exclude` are set, all
+	// log records are processed.
+	Exclude *MatchProperties `mapstructure:"exclude"`
+}
+
+// MatchProperties specifies the set of properties in a log record to match against
+// and if the log record should be included or excluded from the processor.
+// At least one of log names, severity texts, min severity, bodies or attributes must
+// be specified, all specified properties must match for the inclusion/exclusion to
+// occur. Alternatively, Expr can be specified alone.
+type MatchProperties struct {
+	// Config configures the matching patterns used when matching log record properties.
+	filterset.Config `mapstructure:",squash"`
+
+	// Expr specifies a match expression, e.g.
+	// `severity_number >= 17 && attributes["component"] == "auth"`.
+	// A match occurs if the expression evaluates to true. Expr cannot be combined
+	// with the other properties. See the filterexpr package for the expression syntax.
+	// This is an optional field.
+	Expr string `mapstructure:"expr"`
+
+	// LogNames specify the list of items to match the log name against.
+	// A match occurs if the log name matches at least one item in this list.
+	// This is an optional field.
+	LogNames []string `mapstructure:"log_names"`
+
+	// SeverityTexts specify the list of items to match the log severity text against.
+	// A match occurs if the severity text matches at least one item in this list.
+	// This is an optional field.
+	SeverityTexts []string `mapstructure:"severity_texts"`
+
+	// MinSeverity specifies the minimum severity number of the log records to match,
+	// using the severity names, e.g. "INFO" or "WARN".
+	// This is an optional field.
+	MinSeverity string `mapstructure:"min_severity"`
+
+	// Bodies specify the list of items to match the log body against. Only string
+	// bodies can match.
+	// A match occurs if the body matches at least one item in this list.
+	// This is an optional field.
+	Bodies []string `mapstructure:"bodies"`
+
+	// Attributes specifies the list of attributes to match against.
+	// All of these attributes must match exactly for a match to occur.
+	// Only match_type=strict is allowed if "attributes" are specified.
+	// This is an optional field.
+	Attributes []filterspan.Attribute `mapstructure:"attributes"`
+}
+
+const (
+	// MinSeverityFieldName is the mapstructure field name for MatchProperties.MinSeverity field.
+	MinSeverityFieldName = "min_severity"
+)
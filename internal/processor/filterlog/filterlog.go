@@ -0,0 +1,165 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package filterlog is a helper package for matching log records against
+// include/exclude properties.
+package filterlog
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	otlplogs "go.opentelemetry.io/collector/internal/data/opentelemetry-proto-gen/logs/v1"
+	"go.opentelemetry.io/collector/internal/processor/filterexpr"
+	"go.opentelemetry.io/collector/internal/processor/filterset"
+	"go.opentelemetry.io/collector/internal/processor/filterspan"
+)
+
+var (
+	errAtLeastOneMatchFieldNeeded = errors.New(
+		`error creating processor. At least one ` +
+			`of "log_names", "severity_texts", "min_severity", "bodies", "attributes" or "expr" field must be specified"`)
+)
+
+// Matcher is an interface that allows matching a log record against a
+// configuration of a match.
+type Matcher interface {
+	MatchLogRecord(lr pdata.LogRecord, resource pdata.Resource) bool
+}
+
+// exprMatcher matches a log record against a match expression.
+type exprMatcher struct {
+	expr *filterexpr.Expression
+}
+
+func (em *exprMatcher) MatchLogRecord(lr pdata.LogRecord, resource pdata.Resource) bool {
+	return em.expr.MatchLogRecord(lr, resource)
+}
+
+// propertiesMatcher allows matching a log record against various log record properties.
+type propertiesMatcher struct {
+	// Log names to compare to.
+	nameFilters filterset.FilterSet
+
+	// Severity texts to compare to.
+	severityTextFilters filterset.FilterSet
+
+	// Minimum severity number, matching is disabled if it is undefined.
+	minSeverity otlplogs.SeverityNumber
+
+	// Log bodies to compare to.
+	bodyFilters filterset.FilterSet
+
+	// The attribute values are stored in the internal format.
+	attributes filterspan.AttributesMatcher
+}
+
+// NewMatcher creates a log record Matcher that matches based on the given MatchProperties.
+func NewMatcher(mp *MatchProperties) (Matcher, error) {
+	if mp == nil {
+		return nil, nil
+	}
+
+	hasProperties := len(mp.LogNames) > 0 || len(mp.SeverityTexts) > 0 || mp.MinSeverity != "" ||
+		len(mp.Bodies) > 0 || len(mp.Attributes) > 0
+	if mp.Expr != "" {
+		if hasProperties {
+			return nil, fmt.Errorf("%q cannot be combined with other match properties", filterspan.ExprFieldName)
+		}
+		expr, err := filterexpr.Compile(mp.Expr)
+		if err != nil {
+			return nil, err
+		}
+		return &exprMatcher{expr: expr}, nil
+	}
+
+	if !hasProperties {
+		return nil, errAtLeastOneMatchFieldNeeded
+	}
+
+	am, err := filterspan.NewAttributesMatcher(mp.Config, mp.Attributes)
+	if err != nil {
+		return nil, err
+	}
+
+	var nameFS filterset.FilterSet
+	if len(mp.LogNames) > 0 {
+		nameFS, err = filterset.CreateFilterSet(mp.LogNames, &mp.Config)
+		if err != nil {
+			return nil, fmt.Errorf("error creating log record name filters: %v", err)
+		}
+	}
+
+	var severityTextFS filterset.FilterSet
+	if len(mp.SeverityTexts) > 0 {
+		severityTextFS, err = filterset.CreateFilterSet(mp.SeverityTexts, &mp.Config)
+		if err != nil {
+			return nil, fmt.Errorf("error creating log record severity text filters: %v", err)
+		}
+	}
+
+	var minSeverity otlplogs.SeverityNumber
+	if mp.MinSeverity != "" {
+		number, ok := otlplogs.SeverityNumber_value[strings.ToUpper(mp.MinSeverity)]
+		if !ok || number == int32(otlplogs.SeverityNumber_UNDEFINED_SEVERITY_NUMBER) {
+			return nil, fmt.Errorf("unrecognized severity %q in %q", mp.MinSeverity, MinSeverityFieldName)
+		}
+		minSeverity = otlplogs.SeverityNumber(number)
+	}
+
+	var bodyFS filterset.FilterSet
+	if len(mp.Bodies) > 0 {
+		bodyFS, err = filterset.CreateFilterSet(mp.Bodies, &mp.Config)
+		if err != nil {
+			return nil, fmt.Errorf("error creating log record body filters: %v", err)
+		}
+	}
+
+	return &propertiesMatcher{
+		nameFilters:         nameFS,
+		severityTextFilters: severityTextFS,
+		minSeverity:         minSeverity,
+		bodyFilters:         bodyFS,
+		attributes:          am,
+	}, nil
+}
+
+// MatchLogRecord matches a log record to a set of properties.
+// The log name is checked first, then the severity and the body, if specified.
+// The attributes are checked last, if specified.
+// All specified properties must evaluate to true for a match to occur.
+func (mp *propertiesMatcher) MatchLogRecord(lr pdata.LogRecord, _ pdata.Resource) bool {
+	if mp.nameFilters != nil && !mp.nameFilters.Matches(lr.Name()) {
+		return false
+	}
+
+	if mp.severityTextFilters != nil && !mp.severityTextFilters.Matches(lr.SeverityText()) {
+		return false
+	}
+
+	if mp.minSeverity != otlplogs.SeverityNumber_UNDEFINED_SEVERITY_NUMBER && lr.SeverityNumber() < mp.minSeverity {
+		return false
+	}
+
+	if mp.bodyFilters != nil {
+		body := lr.Body()
+		if body.Type() != pdata.AttributeValueSTRING || !mp.bodyFilters.Matches(body.StringVal()) {
+			return false
+		}
+	}
+
+	return mp.attributes.Match(lr.Attributes())
+}
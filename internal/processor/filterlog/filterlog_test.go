@@ -0,0 +1,219 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filterlog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	otlplogs "go.opentelemetry.io/collector/internal/data/opentelemetry-proto-gen/logs/v1"
+	"go.opentelemetry.io/collector/internal/processor/filterset"
+	"go.opentelemetry.io/collector/internal/processor/filterspan"
+)
+
+func createConfig(matchType filterset.MatchType) *filterset.Config {
+	return &filterset.Config{
+		MatchType: matchType,
+	}
+}
+
+func TestLogRecord_validateMatchesConfiguration_InvalidConfig(t *testing.T) {
+	testcases := []struct {
+		name        string
+		property    MatchProperties
+		errorString string
+	}{
+		{
+			name:        "empty_property",
+			property:    MatchProperties{},
+			errorString: errAtLeastOneMatchFieldNeeded.Error(),
+		},
+		{
+			name: "regexp_match_type_for_attributes",
+			property: MatchProperties{
+				Config: *createConfig(filterset.Regexp),
+				Attributes: []filterspan.Attribute{
+					{Key: "key", Value: "value"},
+				},
+			},
+			errorString: `match_type=regexp is not supported for "attributes"`,
+		},
+		{
+			name: "invalid_regexp_pattern",
+			property: MatchProperties{
+				Config:   *createConfig(filterset.Regexp),
+				LogNames: []string{"["},
+			},
+			errorString: "error creating log record name filters: error parsing regexp: missing closing ]: `[`",
+		},
+		{
+			name: "invalid_min_severity",
+			property: MatchProperties{
+				Config:      *createConfig(filterset.Strict),
+				MinSeverity: "LOUD",
+			},
+			errorString: `unrecognized severity "LOUD" in "min_severity"`,
+		},
+		{
+			name: "expr_with_other_properties",
+			property: MatchProperties{
+				Config:   *createConfig(filterset.Strict),
+				LogNames: []string{"log"},
+				Expr:     `severity_number >= 13`,
+			},
+			errorString: `"expr" cannot be combined with other match properties`,
+		},
+		{
+			name: "invalid_expr",
+			property: MatchProperties{
+				Expr: `severity >= 13`,
+			},
+			errorString: `error parsing expression "severity >= 13": unknown identifier "severity" at position 0`,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			output, err := NewMatcher(&tc.property)
+			assert.Nil(t, output)
+			require.Error(t, err)
+			assert.Equal(t, tc.errorString, err.Error())
+		})
+	}
+}
+
+func TestLogRecord_Matching(t *testing.T) {
+	lr := pdata.NewLogRecord()
+	lr.InitEmpty()
+	lr.SetName("auth.login")
+	lr.SetSeverityText("Warning")
+	lr.SetSeverityNumber(otlplogs.SeverityNumber_WARN)
+	lr.Body().SetStringVal("user logged in")
+	lr.Attributes().InitFromMap(map[string]pdata.AttributeValue{
+		"env":  pdata.NewAttributeValueString("prod"),
+		"code": pdata.NewAttributeValueInt(200),
+	})
+
+	testcases := []struct {
+		name     string
+		property MatchProperties
+		match    bool
+	}{
+		{
+			name: "log_name_regexp",
+			property: MatchProperties{
+				Config:   *createConfig(filterset.Regexp),
+				LogNames: []string{"^auth\\..*"},
+			},
+			match: true,
+		},
+		{
+			name: "log_name_mismatch",
+			property: MatchProperties{
+				Config:   *createConfig(filterset.Strict),
+				LogNames: []string{"auth.logout"},
+			},
+			match: false,
+		},
+		{
+			name: "severity_text",
+			property: MatchProperties{
+				Config:        *createConfig(filterset.Strict),
+				SeverityTexts: []string{"Error", "Warning"},
+			},
+			match: true,
+		},
+		{
+			name: "min_severity",
+			property: MatchProperties{
+				Config:      *createConfig(filterset.Strict),
+				MinSeverity: "info",
+			},
+			match: true,
+		},
+		{
+			name: "min_severity_mismatch",
+			property: MatchProperties{
+				Config:      *createConfig(filterset.Strict),
+				MinSeverity: "ERROR",
+			},
+			match: false,
+		},
+		{
+			name: "body_regexp",
+			property: MatchProperties{
+				Config: *createConfig(filterset.Regexp),
+				Bodies: []string{"logged in$"},
+			},
+			match: true,
+		},
+		{
+			name: "attributes",
+			property: MatchProperties{
+				Config: *createConfig(filterset.Strict),
+				Attributes: []filterspan.Attribute{
+					{Key: "env", Value: "prod"},
+					{Key: "code"},
+				},
+			},
+			match: true,
+		},
+		{
+			name: "log_name_and_attribute_mismatch",
+			property: MatchProperties{
+				Config:   *createConfig(filterset.Strict),
+				LogNames: []string{"auth.login"},
+				Attributes: []filterspan.Attribute{
+					{Key: "env", Value: "dev"},
+				},
+			},
+			match: false,
+		},
+		{
+			name: "expr",
+			property: MatchProperties{
+				Expr: `severity_number >= 13 && attributes["code"] == 200 && resource["service.name"] == "auth"`,
+			},
+			match: true,
+		},
+		{
+			name: "expr_mismatch",
+			property: MatchProperties{
+				Expr: `body =~ "logged out"`,
+			},
+			match: false,
+		},
+	}
+
+	resource := pdata.NewResource()
+	resource.InitEmpty()
+	resource.Attributes().InsertString("service.name", "auth")
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			matcher, err := NewMatcher(&tc.property)
+			require.NoError(t, err)
+			require.NotNil(t, matcher)
+			assert.Equal(t, tc.match, matcher.MatchLogRecord(lr, resource))
+		})
+	}
+}
+
+func TestLogRecord_NilMatchProperties(t *testing.T) {
+	matcher, err := NewMatcher(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, matcher)
+}
@@ -16,8 +16,29 @@ package filtermetric
 
 import (
 	"go.opentelemetry.io/collector/internal/processor/filterset"
+	"go.opentelemetry.io/collector/internal/processor/filterspan"
 )
 
+// MatchConfig has two optional MatchProperties, include to define which metrics are
+// processed and exclude to define which metrics are excluded from processing.
+type MatchConfig struct {
+	// Include specifies the set of metric properties that must be present in order
+	// for the processor to apply to it.
+	// Note: If `exclude` is specified, the metric is compared against those
+	// properties after the `include` properties.
+	// This is an optional field. If neither `include` and `exclude` are set, all
+	// metrics are processed.
+	Include *MatchProperties `mapstructure:"include"`
+
+	// Exclude specifies when the processor will not be applied to the metrics
+	// which match the specified properties.
+	// Note: The `exclude` properties are checked after the `include` properties,
+	// if they exist, are checked.
+	// This is an optional field. If neither `include` and `exclude` are set, all
+	// metrics are processed.
+	Exclude *MatchProperties `mapstructure:"exclude"`
+}
+
 // MatchProperties specifies the set of properties in a metric to match against and the
 // type of string pattern matching to use.
 type MatchProperties struct {
@@ -27,4 +48,47 @@ type MatchProperties struct {
 	// MetricNames specifies the list of string patterns to match metric names against.
 	// A match occurs if the metric name matches at least one string pattern in this list.
 	MetricNames []string `mapstructure:"metric_names"`
+
+	// MetricTypes specifies the list of metric types to match against, e.g. "INT64",
+	// "MONOTONIC_DOUBLE" or "HISTOGRAM".
+	// A match occurs if the metric type is one of the items in this list.
+	MetricTypes []string `mapstructure:"metric_types"`
+
+	// ResourceAttributes specifies the list of resource attributes to match against.
+	// All of these attributes must match exactly for a match to occur, regardless of match_type.
+	ResourceAttributes []filterspan.Attribute `mapstructure:"resource_attributes"`
+
+	// Labels specifies the list of data point labels to match against.
+	// All of these labels must match for a data point to match. Label values are matched
+	// using match_type.
+	Labels []Label `mapstructure:"labels"`
+
+	// ValueRange specifies the range of values the data points must be in to match.
+	// Histogram and summary data points are compared by their sum.
+	ValueRange *ValueRange `mapstructure:"value_range"`
+
+	// Expr specifies a match expression evaluated for each data point, e.g.
+	// `name == "http_requests_total" && attributes["status"] =~ "^5"`. The data point
+	// labels are accessed through "attributes". Expr cannot be combined with the other
+	// properties. See the filterexpr package for the expression syntax.
+	Expr string `mapstructure:"expr"`
+}
+
+// Label specifies the data point label key and optional value to match against.
+type Label struct {
+	// Key specifies the label key.
+	Key string `mapstructure:"key"`
+
+	// Value specifies the string pattern to match the label value against.
+	// If it is not set, any value will match.
+	Value string `mapstructure:"value"`
+}
+
+// ValueRange specifies an inclusive range of data point values. Either bound is optional.
+type ValueRange struct {
+	// Min is the minimum value of the range.
+	Min *float64 `mapstructure:"min"`
+
+	// Max is the maximum value of the range.
+	Max *float64 `mapstructure:"max"`
 }
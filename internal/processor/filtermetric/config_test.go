@@ -24,6 +24,7 @@ import (
 	"go.opentelemetry.io/collector/config/configtest"
 	"go.opentelemetry.io/collector/internal/processor/filterset"
 	"go.opentelemetry.io/collector/internal/processor/filterset/regexp"
+	"go.opentelemetry.io/collector/internal/processor/filterspan"
 )
 
 var (
@@ -38,6 +39,10 @@ var (
 	strictNameMatches = []string{
 		"exact_string_match",
 	}
+
+	// minValue and maxValue match the value range specified in testdata/config.yaml
+	minValue = 0.0
+	maxValue = 1000.0
 )
 
 func createConfigWithRegexpOptions(filters []string, rCfg *regexp.Config) *MatchProperties {
@@ -75,6 +80,31 @@ func TestConfig(t *testing.T) {
 		}, {
 			name:   "config/emptyproperties",
 			expCfg: createConfig(nil, filterset.Regexp),
+		}, {
+			name: "config/datapointproperties",
+			expCfg: &MatchProperties{
+				Config: filterset.Config{
+					MatchType: filterset.Strict,
+				},
+				MetricNames: []string{"http_requests_total"},
+				MetricTypes: []string{"MONOTONIC_INT64"},
+				ResourceAttributes: []filterspan.Attribute{
+					{Key: "service.name", Value: "cart"},
+				},
+				Labels: []Label{
+					{Key: "status", Value: "200"},
+					{Key: "method"},
+				},
+				ValueRange: &ValueRange{
+					Min: &minValue,
+					Max: &maxValue,
+				},
+			},
+		}, {
+			name: "config/expr",
+			expCfg: &MatchProperties{
+				Expr: `name == "http_requests_total" && attributes["status"] =~ "^5"`,
+			},
 		},
 	}
 
@@ -15,21 +15,141 @@
 package filtermetric
 
 import (
-	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"errors"
+	"fmt"
+	"strings"
 
+	"go.opentelemetry.io/collector/consumer/pdata"
+	otlpmetrics "go.opentelemetry.io/collector/internal/data/opentelemetry-proto-gen/metrics/v1"
+	"go.opentelemetry.io/collector/internal/processor/filterexpr"
 	"go.opentelemetry.io/collector/internal/processor/filterset"
+	"go.opentelemetry.io/collector/internal/processor/filterspan"
 )
 
 // Matcher matches metrics by metric properties against prespecified values for each property.
 type Matcher struct {
-	nameFilters filterset.FilterSet
+	nameFilters        filterset.FilterSet
+	metricTypes        map[pdata.MetricType]bool
+	resourceAttributes filterspan.AttributesMatcher
+	labels             []labelMatcher
+	valueRange         *ValueRange
+	expr               *filterexpr.Expression
 }
 
-// MatchMetric matches a metric using the metric properties configured on the Matcher.
-// A metric only matches if every metric property configured on the Matcher is a match.
-func (m *Matcher) MatchMetric(metric *metricspb.Metric) bool {
-	name := metric.GetMetricDescriptor().GetName()
-	return m.nameFilters.Matches(name)
+// labelMatcher matches a data point label, if values is nil only the key existence is checked.
+type labelMatcher struct {
+	key    string
+	values filterset.FilterSet
+}
+
+// MatchMetric matches a metric using the metric level properties configured on the Matcher: metric name,
+// metric type and resource attributes.
+// A metric only matches if every metric level property configured on the Matcher is a match.
+// Expressions are only evaluated per data point, so all metrics match a Matcher configured with
+// an expression.
+func (m *Matcher) MatchMetric(metric pdata.Metric, resource pdata.Resource) bool {
+	if m.expr != nil {
+		return true
+	}
+
+	desc := metric.MetricDescriptor()
+	name := ""
+	metricType := pdata.MetricTypeInvalid
+	if !desc.IsNil() {
+		name = desc.Name()
+		metricType = desc.Type()
+	}
+
+	if m.nameFilters != nil && !m.nameFilters.Matches(name) {
+		return false
+	}
+
+	if m.metricTypes != nil && !m.metricTypes[metricType] {
+		return false
+	}
+
+	if len(m.resourceAttributes) > 0 {
+		if resource.IsNil() {
+			return false
+		}
+		return m.resourceAttributes.Match(resource.Attributes())
+	}
+
+	return true
+}
+
+// HasMetricProperties returns true if the Matcher has properties that are matched per metric: metric name,
+// metric type or resource attributes.
+func (m *Matcher) HasMetricProperties() bool {
+	return m.nameFilters != nil || m.metricTypes != nil || len(m.resourceAttributes) > 0
+}
+
+// HasDataPointProperties returns true if the Matcher has properties that are matched per data point:
+// labels, value range or expression.
+func (m *Matcher) HasDataPointProperties() bool {
+	return len(m.labels) > 0 || m.valueRange != nil || m.expr != nil
+}
+
+// MatchInt64DataPoint matches an int64 data point of the metric using the data point properties configured
+// on the Matcher.
+func (m *Matcher) MatchInt64DataPoint(dp pdata.Int64DataPoint, metric pdata.Metric, resource pdata.Resource) bool {
+	if m.expr != nil {
+		return m.expr.MatchInt64DataPoint(dp, metric, resource)
+	}
+	return m.matchLabels(dp.LabelsMap()) && m.matchValue(float64(dp.Value()))
+}
+
+// MatchDoubleDataPoint matches a double data point of the metric using the data point properties configured
+// on the Matcher.
+func (m *Matcher) MatchDoubleDataPoint(dp pdata.DoubleDataPoint, metric pdata.Metric, resource pdata.Resource) bool {
+	if m.expr != nil {
+		return m.expr.MatchDoubleDataPoint(dp, metric, resource)
+	}
+	return m.matchLabels(dp.LabelsMap()) && m.matchValue(dp.Value())
+}
+
+// MatchHistogramDataPoint matches a histogram data point of the metric using the data point properties
+// configured on the Matcher. The sum of the histogram is used to match the value range.
+func (m *Matcher) MatchHistogramDataPoint(dp pdata.HistogramDataPoint, metric pdata.Metric, resource pdata.Resource) bool {
+	if m.expr != nil {
+		return m.expr.MatchHistogramDataPoint(dp, metric, resource)
+	}
+	return m.matchLabels(dp.LabelsMap()) && m.matchValue(dp.Sum())
+}
+
+// MatchSummaryDataPoint matches a summary data point of the metric using the data point properties
+// configured on the Matcher. The sum of the summary is used to match the value range.
+func (m *Matcher) MatchSummaryDataPoint(dp pdata.SummaryDataPoint, metric pdata.Metric, resource pdata.Resource) bool {
+	if m.expr != nil {
+		return m.expr.MatchSummaryDataPoint(dp, metric, resource)
+	}
+	return m.matchLabels(dp.LabelsMap()) && m.matchValue(dp.Sum())
+}
+
+func (m *Matcher) matchLabels(labels pdata.StringMap) bool {
+	for _, lm := range m.labels {
+		v, ok := labels.Get(lm.key)
+		if !ok {
+			return false
+		}
+		if lm.values != nil && !lm.values.Matches(v.Value()) {
+			return false
+		}
+	}
+	return true
+}
+
+func (m *Matcher) matchValue(v float64) bool {
+	if m.valueRange == nil {
+		return true
+	}
+	if m.valueRange.Min != nil && v < *m.valueRange.Min {
+		return false
+	}
+	if m.valueRange.Max != nil && v > *m.valueRange.Max {
+		return false
+	}
+	return true
 }
 
 // NewMatcher constructs a metric Matcher that can be used to match metrics by metric properties.
@@ -39,13 +159,90 @@ func (m *Matcher) MatchMetric(metric *metricspb.Metric) bool {
 //
 // The metric Matcher supports matching by the following metric properties:
 // - Metric name
+// - Metric type
+// - Resource attributes
+// - Data point labels
+// - Data point value range
+//
+// Alternatively, the Matcher matches data points using an expression.
+// If no property is configured the Matcher does not match any metric.
 func NewMatcher(config *MatchProperties) (Matcher, error) {
-	nameFS, err := filterset.CreateFilterSet(config.MetricNames, &config.Config)
+	if config.Expr != "" {
+		if hasProperties(config) {
+			return Matcher{}, fmt.Errorf("%q cannot be combined with other match properties", filterspan.ExprFieldName)
+		}
+		expr, err := filterexpr.Compile(config.Expr)
+		if err != nil {
+			return Matcher{}, err
+		}
+		return Matcher{expr: expr}, nil
+	}
+
+	var nameFS filterset.FilterSet
+	var err error
+	if len(config.MetricNames) > 0 || !hasProperties(config) {
+		nameFS, err = filterset.CreateFilterSet(config.MetricNames, &config.Config)
+		if err != nil {
+			return Matcher{}, err
+		}
+	}
+
+	metricTypes, err := newMetricTypes(config.MetricTypes)
 	if err != nil {
 		return Matcher{}, err
 	}
 
+	// Resource attributes are always compared exactly, match_type only applies to names and label values.
+	resourceAttributes, err := filterspan.NewAttributesMatcher(filterset.Config{MatchType: filterset.Strict}, config.ResourceAttributes)
+	if err != nil {
+		return Matcher{}, err
+	}
+
+	labels := make([]labelMatcher, 0, len(config.Labels))
+	for _, label := range config.Labels {
+		if label.Key == "" {
+			return Matcher{}, errors.New("error creating processor. Can't have empty key in the list of labels")
+		}
+		lm := labelMatcher{key: label.Key}
+		if label.Value != "" {
+			lm.values, err = filterset.CreateFilterSet([]string{label.Value}, &config.Config)
+			if err != nil {
+				return Matcher{}, fmt.Errorf("error creating label value filters: %v", err)
+			}
+		}
+		labels = append(labels, lm)
+	}
+
+	if vr := config.ValueRange; vr != nil && vr.Min != nil && vr.Max != nil && *vr.Min > *vr.Max {
+		return Matcher{}, fmt.Errorf("invalid value_range, min %v is greater than max %v", *vr.Min, *vr.Max)
+	}
+
 	return Matcher{
-		nameFilters: nameFS,
+		nameFilters:        nameFS,
+		metricTypes:        metricTypes,
+		resourceAttributes: resourceAttributes,
+		labels:             labels,
+		valueRange:         config.ValueRange,
 	}, nil
 }
+
+func hasProperties(config *MatchProperties) bool {
+	return len(config.MetricNames) > 0 || len(config.MetricTypes) > 0 || len(config.ResourceAttributes) > 0 ||
+		len(config.Labels) > 0 || config.ValueRange != nil
+}
+
+// newMetricTypes parses the metric type names, the names are case insensitive.
+func newMetricTypes(names []string) (map[pdata.MetricType]bool, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	types := make(map[pdata.MetricType]bool, len(names))
+	for _, name := range names {
+		metricType, ok := otlpmetrics.MetricDescriptor_Type_value[strings.ToUpper(name)]
+		if !ok || metricType == int32(otlpmetrics.MetricDescriptor_INVALID_TYPE) {
+			return nil, fmt.Errorf("unrecognized metric type %q", name)
+		}
+		types[pdata.MetricType(metricType)] = true
+	}
+	return types, nil
+}
@@ -17,10 +17,12 @@ package filtermetric
 import (
 	"testing"
 
-	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/internal/processor/filterset"
+	"go.opentelemetry.io/collector/internal/processor/filterspan"
 )
 
 var (
@@ -42,19 +44,19 @@ var (
 	}
 )
 
-func createMetric(name string) *metricspb.Metric {
-	return &metricspb.Metric{
-		MetricDescriptor: &metricspb.MetricDescriptor{
-			Name: name,
-		},
-	}
+func createMetric(name string) pdata.Metric {
+	metric := pdata.NewMetric()
+	metric.InitEmpty()
+	metric.MetricDescriptor().InitEmpty()
+	metric.MetricDescriptor().SetName(name)
+	return metric
 }
 
 func TestMatcherMatches(t *testing.T) {
 	tests := []struct {
 		name        string
 		cfg         *MatchProperties
-		metric      *metricspb.Metric
+		metric      pdata.Metric
 		shouldMatch bool
 	}{
 		{
@@ -91,7 +93,239 @@ func TestMatcherMatches(t *testing.T) {
 			assert.NotNil(t, matcher)
 			assert.NoError(t, err)
 
-			assert.Equal(t, test.shouldMatch, matcher.MatchMetric(test.metric))
+			assert.Equal(t, test.shouldMatch, matcher.MatchMetric(test.metric, pdata.NewResource()))
+		})
+	}
+}
+
+func TestMatcherMatchesMetricProperties(t *testing.T) {
+	resource := pdata.NewResource()
+	resource.InitEmpty()
+	resource.Attributes().InsertString("service.name", "cart")
+
+	metric := createMetric("http_requests_total")
+	metric.MetricDescriptor().SetType(pdata.MetricTypeMonotonicInt64)
+
+	tests := []struct {
+		name        string
+		cfg         *MatchProperties
+		shouldMatch bool
+	}{
+		{
+			name: "metricTypeMatch",
+			cfg: &MatchProperties{
+				Config:      filterset.Config{MatchType: filterset.Strict},
+				MetricTypes: []string{"monotonic_int64", "INT64"},
+			},
+			shouldMatch: true,
+		}, {
+			name: "metricTypeMismatch",
+			cfg: &MatchProperties{
+				Config:      filterset.Config{MatchType: filterset.Strict},
+				MetricTypes: []string{"HISTOGRAM"},
+			},
+			shouldMatch: false,
+		}, {
+			name: "resourceAttributesWithRegexpNamesMatch",
+			cfg: &MatchProperties{
+				Config:      filterset.Config{MatchType: filterset.Regexp},
+				MetricNames: []string{"http_.*"},
+				ResourceAttributes: []filterspan.Attribute{
+					{Key: "service.name", Value: "cart"},
+				},
+			},
+			shouldMatch: true,
+		}, {
+			name: "resourceAttributesMismatch",
+			cfg: &MatchProperties{
+				Config: filterset.Config{MatchType: filterset.Strict},
+				ResourceAttributes: []filterspan.Attribute{
+					{Key: "service.name", Value: "checkout"},
+				},
+			},
+			shouldMatch: false,
+		}, {
+			name: "onlyDataPointProperties",
+			cfg: &MatchProperties{
+				Config: filterset.Config{MatchType: filterset.Strict},
+				Labels: []Label{{Key: "status"}},
+			},
+			shouldMatch: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			matcher, err := NewMatcher(test.cfg)
+			require.NoError(t, err)
+			assert.Equal(t, test.shouldMatch, matcher.MatchMetric(metric, resource))
+		})
+	}
+}
+
+func TestMatcherMatchesDataPoints(t *testing.T) {
+	min, max := 10.0, 100.0
+
+	intPoint := pdata.NewInt64DataPoint()
+	intPoint.InitEmpty()
+	intPoint.LabelsMap().InitFromMap(map[string]string{"status": "200", "method": "GET"})
+	intPoint.SetValue(50)
+
+	doublePoint := pdata.NewDoubleDataPoint()
+	doublePoint.InitEmpty()
+	doublePoint.LabelsMap().InitFromMap(map[string]string{"status": "500"})
+	doublePoint.SetValue(5)
+
+	histogramPoint := pdata.NewHistogramDataPoint()
+	histogramPoint.InitEmpty()
+	histogramPoint.LabelsMap().InitFromMap(map[string]string{"status": "204"})
+	histogramPoint.SetSum(20)
+
+	summaryPoint := pdata.NewSummaryDataPoint()
+	summaryPoint.InitEmpty()
+	summaryPoint.SetSum(200)
+
+	tests := []struct {
+		name      string
+		cfg       *MatchProperties
+		int64     bool
+		double    bool
+		histogram bool
+		summary   bool
+	}{
+		{
+			name: "labelKeyAndValueStrict",
+			cfg: &MatchProperties{
+				Config: filterset.Config{MatchType: filterset.Strict},
+				Labels: []Label{{Key: "status", Value: "200"}},
+			},
+			int64: true,
+		}, {
+			name: "labelValueRegexp",
+			cfg: &MatchProperties{
+				Config: filterset.Config{MatchType: filterset.Regexp},
+				Labels: []Label{{Key: "status", Value: "^2.."}},
+			},
+			int64:     true,
+			histogram: true,
+		}, {
+			name: "labelKeyExists",
+			cfg: &MatchProperties{
+				Config: filterset.Config{MatchType: filterset.Strict},
+				Labels: []Label{{Key: "status"}},
+			},
+			int64:     true,
+			double:    true,
+			histogram: true,
+		}, {
+			name: "valueRange",
+			cfg: &MatchProperties{
+				Config:     filterset.Config{MatchType: filterset.Strict},
+				ValueRange: &ValueRange{Min: &min, Max: &max},
+			},
+			int64:     true,
+			histogram: true,
+		}, {
+			name: "valueRangeMinOnly",
+			cfg: &MatchProperties{
+				Config:     filterset.Config{MatchType: filterset.Strict},
+				ValueRange: &ValueRange{Min: &max},
+			},
+			summary: true,
+		}, {
+			name: "noDataPointProperties",
+			cfg: &MatchProperties{
+				Config:      filterset.Config{MatchType: filterset.Strict},
+				MetricNames: []string{"metric"},
+			},
+			int64:     true,
+			double:    true,
+			histogram: true,
+			summary:   true,
+		}, {
+			name: "expr",
+			cfg: &MatchProperties{
+				Expr: `name == "metric" && (attributes["status"] =~ "^5" || value >= 100)`,
+			},
+			double:  true,
+			summary: true,
+		},
+	}
+
+	metric := pdata.NewMetric()
+	metric.InitEmpty()
+	metric.MetricDescriptor().InitEmpty()
+	metric.MetricDescriptor().SetName("metric")
+	resource := pdata.NewResource()
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			matcher, err := NewMatcher(test.cfg)
+			require.NoError(t, err)
+			assert.Equal(t, test.int64, matcher.MatchInt64DataPoint(intPoint, metric, resource))
+			assert.Equal(t, test.double, matcher.MatchDoubleDataPoint(doublePoint, metric, resource))
+			assert.Equal(t, test.histogram, matcher.MatchHistogramDataPoint(histogramPoint, metric, resource))
+			assert.Equal(t, test.summary, matcher.MatchSummaryDataPoint(summaryPoint, metric, resource))
+		})
+	}
+}
+
+func TestNewMatcherInvalidConfig(t *testing.T) {
+	min, max := 10.0, 1.0
+	tests := []struct {
+		name   string
+		cfg    *MatchProperties
+		errStr string
+	}{
+		{
+			name: "invalidMetricType",
+			cfg: &MatchProperties{
+				Config:      filterset.Config{MatchType: filterset.Strict},
+				MetricTypes: []string{"GAUGE"},
+			},
+			errStr: `unrecognized metric type "GAUGE"`,
+		}, {
+			name: "emptyLabelKey",
+			cfg: &MatchProperties{
+				Config: filterset.Config{MatchType: filterset.Strict},
+				Labels: []Label{{Value: "200"}},
+			},
+			errStr: "error creating processor. Can't have empty key in the list of labels",
+		}, {
+			name: "invalidLabelRegexp",
+			cfg: &MatchProperties{
+				Config: filterset.Config{MatchType: filterset.Regexp},
+				Labels: []Label{{Key: "status", Value: "["}},
+			},
+			errStr: "error creating label value filters: error parsing regexp: missing closing ]: `[`",
+		}, {
+			name: "invalidValueRange",
+			cfg: &MatchProperties{
+				Config:     filterset.Config{MatchType: filterset.Strict},
+				ValueRange: &ValueRange{Min: &min, Max: &max},
+			},
+			errStr: "invalid value_range, min 10 is greater than max 1",
+		}, {
+			name: "exprWithOtherProperties",
+			cfg: &MatchProperties{
+				Config:      filterset.Config{MatchType: filterset.Strict},
+				MetricNames: []string{"metric"},
+				Expr:        `value > 1`,
+			},
+			errStr: `"expr" cannot be combined with other match properties`,
+		}, {
+			name: "invalidExpr",
+			cfg: &MatchProperties{
+				Expr: `value >`,
+			},
+			errStr: `error parsing expression "value >": unexpected end of expression at position 7`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := NewMatcher(test.cfg)
+			require.Error(t, err)
+			assert.Equal(t, test.errStr, err.Error())
 		})
 	}
 }
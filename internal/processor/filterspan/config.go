@@ -68,8 +68,16 @@ type MatchProperties struct {
 	// Config configures the matching patterns used when matching span properties.
 	filterset.Config `mapstructure:",squash"`
 
-	// Note: one of Services, SpanNames or Attributes must be specified with a
-	// non-empty value for a valid configuration.
+	// Note: one of Services, SpanNames, SpanKinds, StatusCodes or Attributes must be
+	// specified with a non-empty value for a valid configuration. Alternatively, Expr
+	// can be specified alone.
+
+	// Expr specifies a match expression, e.g.
+	// `attributes["http.status_code"] >= 500 && resource["service.name"] == "cart"`.
+	// A match occurs if the expression evaluates to true. Expr cannot be combined
+	// with the other properties. See the filterexpr package for the expression syntax.
+	// This is an optional field.
+	Expr string `mapstructure:"expr"`
 
 	// Services specify the list of of items to match service name against.
 	// A match occurs if the span's service name matches at least one item in this list.
@@ -81,6 +89,17 @@ type MatchProperties struct {
 	// This is an optional field.
 	SpanNames []string `mapstructure:"span_names"`
 
+	// SpanKinds specify the list of span kinds to match against, e.g. "server" or "client".
+	// A match occurs if the span kind is one of the items in this list.
+	// This is an optional field.
+	SpanKinds []string `mapstructure:"span_kinds"`
+
+	// StatusCodes specify the list of span status codes to match against, e.g. "Ok" or
+	// "UnknownError". Spans without status are considered to have the "Ok" status code.
+	// A match occurs if the span status code is one of the items in this list.
+	// This is an optional field.
+	StatusCodes []string `mapstructure:"status_codes"`
+
 	// Attributes specifies the list of attributes to match against.
 	// All of these attributes must match exactly for a match to occur.
 	// Only match_type=strict is allowed if "attributes" are specified.
@@ -88,8 +107,16 @@ type MatchProperties struct {
 	Attributes []Attribute `mapstructure:"attributes"`
 }
 
-// MatchTypeFieldName is the mapstructure field name for MatchProperties.Attributes field.
-const AttributesFieldName = "attributes"
+const (
+	// ExprFieldName is the mapstructure field name for MatchProperties.Expr field.
+	ExprFieldName = "expr"
+	// AttributesFieldName is the mapstructure field name for MatchProperties.Attributes field.
+	AttributesFieldName = "attributes"
+	// SpanKindsFieldName is the mapstructure field name for MatchProperties.SpanKinds field.
+	SpanKindsFieldName = "span_kinds"
+	// StatusCodesFieldName is the mapstructure field name for MatchProperties.StatusCodes field.
+	StatusCodesFieldName = "status_codes"
+)
 
 // Attribute specifies the attribute key and optional value to match against.
 type Attribute struct {
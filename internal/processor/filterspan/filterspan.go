@@ -17,17 +17,21 @@ package filterspan
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"go.opentelemetry.io/collector/consumer/pdata"
+	otlptrace "go.opentelemetry.io/collector/internal/data/opentelemetry-proto-gen/trace/v1"
+	"go.opentelemetry.io/collector/internal/processor/filterexpr"
 	"go.opentelemetry.io/collector/internal/processor/filterhelper"
 	"go.opentelemetry.io/collector/internal/processor/filterset"
+	"go.opentelemetry.io/collector/processor"
 )
 
 var (
 	// TODO Add processor type invoking the NewMatcher in error text.
 	errAtLeastOneMatchFieldNeeded = errors.New(
 		`error creating processor. At least one ` +
-			`of "services", "span_names" or "attributes" field must be specified"`)
+			`of "services", "span_names", "span_kinds", "status_codes", "attributes" or "expr" field must be specified"`)
 )
 
 // TODO: Modify Matcher to invoke both the include and exclude properties so
@@ -35,7 +39,16 @@ var (
 // Matcher is an interface that allows matching a span against a configuration
 // of a match.
 type Matcher interface {
-	MatchSpan(span pdata.Span, serviceName string) bool
+	MatchSpan(span pdata.Span, resource pdata.Resource) bool
+}
+
+// exprMatcher matches a span against a match expression.
+type exprMatcher struct {
+	expr *filterexpr.Expression
+}
+
+func (em *exprMatcher) MatchSpan(span pdata.Span, resource pdata.Resource) bool {
+	return em.expr.MatchSpan(span, resource)
 }
 
 // propertiesMatcher allows matching a span against various span properties.
@@ -46,11 +59,18 @@ type propertiesMatcher struct {
 	// Span names to compare to.
 	nameFilters filterset.FilterSet
 
+	// Span kinds to compare to.
+	kinds map[pdata.SpanKind]bool
+
+	// Status codes to compare to.
+	statusCodes map[pdata.StatusCode]bool
+
 	// The attribute values are stored in the internal format.
-	Attributes attributesMatcher
+	Attributes AttributesMatcher
 }
 
-type attributesMatcher []attributeMatcher
+// AttributesMatcher matches an attribute map against a list of expected attributes.
+type AttributesMatcher []attributeMatcher
 
 // attributeMatcher is a attribute key/value pair to match to.
 type attributeMatcher struct {
@@ -65,18 +85,26 @@ func NewMatcher(mp *MatchProperties) (Matcher, error) {
 		return nil, nil
 	}
 
-	if len(mp.Services) == 0 && len(mp.SpanNames) == 0 && len(mp.Attributes) == 0 {
-		return nil, errAtLeastOneMatchFieldNeeded
-	}
-
-	var err error
-
-	var am attributesMatcher
-	if len(mp.Attributes) > 0 {
-		am, err = newAttributesMatcher(mp)
+	hasProperties := len(mp.Services) > 0 || len(mp.SpanNames) > 0 || len(mp.SpanKinds) > 0 ||
+		len(mp.StatusCodes) > 0 || len(mp.Attributes) > 0
+	if mp.Expr != "" {
+		if hasProperties {
+			return nil, fmt.Errorf("%q cannot be combined with other match properties", ExprFieldName)
+		}
+		expr, err := filterexpr.Compile(mp.Expr)
 		if err != nil {
 			return nil, err
 		}
+		return &exprMatcher{expr: expr}, nil
+	}
+
+	if !hasProperties {
+		return nil, errAtLeastOneMatchFieldNeeded
+	}
+
+	am, err := NewAttributesMatcher(mp.Config, mp.Attributes)
+	if err != nil {
+		return nil, err
 	}
 
 	var serviceFS filterset.FilterSet = nil
@@ -95,16 +123,72 @@ func NewMatcher(mp *MatchProperties) (Matcher, error) {
 		}
 	}
 
+	kinds, err := newSpanKinds(mp.SpanKinds)
+	if err != nil {
+		return nil, err
+	}
+
+	statusCodes, err := newStatusCodes(mp.StatusCodes)
+	if err != nil {
+		return nil, err
+	}
+
 	return &propertiesMatcher{
 		serviceFilters: serviceFS,
 		nameFilters:    nameFS,
+		kinds:          kinds,
+		statusCodes:    statusCodes,
 		Attributes:     am,
 	}, nil
 }
 
-func newAttributesMatcher(mp *MatchProperties) (attributesMatcher, error) {
+// newSpanKinds parses the span kind names, the names are case insensitive.
+func newSpanKinds(names []string) (map[pdata.SpanKind]bool, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	kinds := make(map[pdata.SpanKind]bool, len(names))
+	for _, name := range names {
+		kind, ok := otlptrace.Span_SpanKind_value[strings.ToUpper(name)]
+		if !ok {
+			return nil, fmt.Errorf("unrecognized span kind %q in %q", name, SpanKindsFieldName)
+		}
+		kinds[pdata.SpanKind(kind)] = true
+	}
+	return kinds, nil
+}
+
+// newStatusCodes parses the status code names, the names are case insensitive.
+func newStatusCodes(names []string) (map[pdata.StatusCode]bool, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	codes := make(map[pdata.StatusCode]bool, len(names))
+	for _, name := range names {
+		found := false
+		for code, codeName := range otlptrace.Status_StatusCode_name {
+			if strings.EqualFold(name, codeName) {
+				codes[pdata.StatusCode(code)] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unrecognized status code %q in %q", name, StatusCodesFieldName)
+		}
+	}
+	return codes, nil
+}
+
+// NewAttributesMatcher creates an AttributesMatcher for the given attributes. It returns a nil
+// AttributesMatcher, which matches everything, if no attributes are given.
+func NewAttributesMatcher(config filterset.Config, attributes []Attribute) (AttributesMatcher, error) {
+	if len(attributes) == 0 {
+		return nil, nil
+	}
+
 	// attribute matching is only supported with strict matching
-	if mp.Config.MatchType != filterset.Strict {
+	if config.MatchType != filterset.Strict {
 		return nil, fmt.Errorf(
 			"%s=%s is not supported for %q",
 			filterset.MatchTypeFieldName, filterset.Regexp, AttributesFieldName,
@@ -113,7 +197,7 @@ func newAttributesMatcher(mp *MatchProperties) (attributesMatcher, error) {
 
 	// Convert attribute values from mp representation to in-memory representation.
 	var rawAttributes []attributeMatcher
-	for _, attribute := range mp.Attributes {
+	for _, attribute := range attributes {
 
 		if attribute.Key == "" {
 			return nil, errors.New("error creating processor. Can't have empty key in the list of attributes")
@@ -135,16 +219,16 @@ func newAttributesMatcher(mp *MatchProperties) (attributesMatcher, error) {
 	return rawAttributes, nil
 }
 
-// MatchSpan matches a span and service to a set of properties.
-// There are 3 sets of properties to match against.
-// The service name is checked first, if specified. Then span names are matched, if specified.
-// The attributes are checked last, if specified.
-// At least one of services, span names or attributes must be specified. It is supported
+// MatchSpan matches a span and the service of its resource to a set of properties.
+// There are 5 sets of properties to match against.
+// The service name is checked first, if specified. Then span names, span kinds and status
+// codes are matched, if specified. The attributes are checked last, if specified.
+// At least one of these properties must be specified. It is supported
 // to have more than one of these specified, and all specified must evaluate
 // to true for a match to occur.
-func (mp *propertiesMatcher) MatchSpan(span pdata.Span, serviceName string) bool {
+func (mp *propertiesMatcher) MatchSpan(span pdata.Span, resource pdata.Resource) bool {
 	// If a set of properties was not in the mp, all spans are considered to match on that property
-	if mp.serviceFilters != nil && !mp.serviceFilters.Matches(serviceName) {
+	if mp.serviceFilters != nil && !mp.serviceFilters.Matches(processor.ServiceNameForResource(resource)) {
 		return false
 	}
 
@@ -152,20 +236,34 @@ func (mp *propertiesMatcher) MatchSpan(span pdata.Span, serviceName string) bool
 		return false
 	}
 
+	if mp.kinds != nil && !mp.kinds[span.Kind()] {
+		return false
+	}
+
+	// A span without status is considered to have the Ok status code.
+	if mp.statusCodes != nil {
+		code := pdata.StatusCode(otlptrace.Status_Ok)
+		if !span.Status().IsNil() {
+			code = span.Status().Code()
+		}
+		if !mp.statusCodes[code] {
+			return false
+		}
+	}
+
 	// Service name and span name matched. Now match attributes.
-	return mp.Attributes.match(span)
+	return mp.Attributes.Match(span.Attributes())
 }
 
-// match attributes specification against a span.
-func (ma attributesMatcher) match(span pdata.Span) bool {
-	// If there are no attributes to match against, the span matches.
+// Match matches the attributes specification against the given attributes.
+func (ma AttributesMatcher) Match(attrs pdata.AttributeMap) bool {
+	// If there are no attributes to match against, the attributes match.
 	if len(ma) == 0 {
 		return true
 	}
 
-	attrs := span.Attributes()
-	// At this point, it is expected of the span to have attributes because of
-	// len(ma) != 0. This means for spans with no attributes, it does not match.
+	// At this point, it is expected to have attributes because of
+	// len(ma) != 0. This means that empty attributes do not match.
 	if attrs.Len() == 0 {
 		return false
 	}
@@ -22,6 +22,7 @@ import (
 
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/internal/processor/filterset"
+	"go.opentelemetry.io/collector/translator/conventions"
 )
 
 func createConfig(matchType filterset.MatchType) *filterset.Config {
@@ -30,6 +31,13 @@ func createConfig(matchType filterset.MatchType) *filterset.Config {
 	}
 }
 
+func resource(service string) pdata.Resource {
+	r := pdata.NewResource()
+	r.InitEmpty()
+	r.Attributes().InsertString(conventions.AttributeServiceName, service)
+	return r
+}
+
 func TestSpan_validateMatchesConfiguration_InvalidConfig(t *testing.T) {
 	testcases := []struct {
 		name        string
@@ -48,6 +56,22 @@ func TestSpan_validateMatchesConfiguration_InvalidConfig(t *testing.T) {
 			},
 			errorString: errAtLeastOneMatchFieldNeeded.Error(),
 		},
+		{
+			name: "expr_with_other_properties",
+			property: MatchProperties{
+				Config:   *createConfig(filterset.Strict),
+				Services: []string{"abc"},
+				Expr:     `kind == "SERVER"`,
+			},
+			errorString: `"expr" cannot be combined with other match properties`,
+		},
+		{
+			name: "invalid_expr",
+			property: MatchProperties{
+				Expr: `kind = "SERVER"`,
+			},
+			errorString: `error parsing expression "kind = \"SERVER\"": unexpected character '=' at position 5`,
+		},
 		{
 			name: "invalid_match_type",
 			property: MatchProperties{
@@ -102,6 +126,22 @@ func TestSpan_validateMatchesConfiguration_InvalidConfig(t *testing.T) {
 			},
 			errorString: "error creating processor. Can't have empty key in the list of attributes",
 		},
+		{
+			name: "invalid_span_kind",
+			property: MatchProperties{
+				Config:    *createConfig(filterset.Strict),
+				SpanKinds: []string{"server", "backend"},
+			},
+			errorString: `unrecognized span kind "backend" in "span_kinds"`,
+		},
+		{
+			name: "invalid_status_code",
+			property: MatchProperties{
+				Config:      *createConfig(filterset.Strict),
+				StatusCodes: []string{"Failed"},
+			},
+			errorString: `unrecognized status code "Failed" in "status_codes"`,
+		},
 	}
 	for _, tc := range testcases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -209,7 +249,7 @@ func TestSpan_Matching_False(t *testing.T) {
 			assert.Nil(t, err)
 			assert.NotNil(t, matcher)
 
-			assert.False(t, matcher.MatchSpan(span, "wrongSvc"))
+			assert.False(t, matcher.MatchSpan(span, resource("wrongSvc")))
 		})
 	}
 }
@@ -232,7 +272,7 @@ func TestSpan_MatchingCornerCases(t *testing.T) {
 
 	emptySpan := pdata.NewSpan()
 	emptySpan.InitEmpty()
-	assert.False(t, mp.MatchSpan(emptySpan, "svcA"))
+	assert.False(t, mp.MatchSpan(emptySpan, resource("svcA")))
 }
 
 func TestSpan_MissingServiceName(t *testing.T) {
@@ -247,7 +287,7 @@ func TestSpan_MissingServiceName(t *testing.T) {
 
 	emptySpan := pdata.NewSpan()
 	emptySpan.InitEmpty()
-	assert.False(t, mp.MatchSpan(emptySpan, ""))
+	assert.False(t, mp.MatchSpan(emptySpan, pdata.NewResource()))
 }
 
 func TestSpan_Matching_True(t *testing.T) {
@@ -367,8 +407,86 @@ func TestSpan_Matching_True(t *testing.T) {
 			assert.NotNil(t, mp)
 
 			assert.NotNil(t, span)
-			// assert.True(t, mp.MatchSpan(span, "svcA"))
+			// assert.True(t, mp.MatchSpan(span, resource("svcA")))
+
+		})
+	}
+}
+
+func TestSpan_MatchingKindsAndStatusCodes(t *testing.T) {
+	testcases := []struct {
+		name       string
+		properties *MatchProperties
+		withStatus bool
+		match      bool
+	}{
+		{
+			name: "kind_match",
+			properties: &MatchProperties{
+				Config:    *createConfig(filterset.Strict),
+				SpanKinds: []string{"client", "SERVER"},
+			},
+			match: true,
+		},
+		{
+			name: "kind_mismatch",
+			properties: &MatchProperties{
+				Config:    *createConfig(filterset.Strict),
+				SpanKinds: []string{"client"},
+			},
+			match: false,
+		},
+		{
+			name: "status_code_match",
+			properties: &MatchProperties{
+				Config:      *createConfig(filterset.Strict),
+				StatusCodes: []string{"unknownerror"},
+			},
+			withStatus: true,
+			match:      true,
+		},
+		{
+			name: "status_code_mismatch",
+			properties: &MatchProperties{
+				Config:      *createConfig(filterset.Strict),
+				StatusCodes: []string{"Ok"},
+			},
+			withStatus: true,
+			match:      false,
+		},
+		{
+			name: "missing_status_is_ok",
+			properties: &MatchProperties{
+				Config:      *createConfig(filterset.Strict),
+				StatusCodes: []string{"Ok"},
+			},
+			match: true,
+		},
+		{
+			name: "kind_and_span_name_mismatch",
+			properties: &MatchProperties{
+				Config:    *createConfig(filterset.Strict),
+				SpanNames: []string{"otherSpan"},
+				SpanKinds: []string{"server"},
+			},
+			match: false,
+		},
+	}
 
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			span := pdata.NewSpan()
+			span.InitEmpty()
+			span.SetName("spanName")
+			span.SetKind(pdata.SpanKindSERVER)
+			if tc.withStatus {
+				span.Status().InitEmpty()
+				span.Status().SetCode(pdata.StatusCode(2))
+			}
+
+			mp, err := NewMatcher(tc.properties)
+			require.NoError(t, err)
+			assert.Equal(t, tc.match, mp.MatchSpan(span, resource("svcA")))
 		})
 	}
 }
@@ -446,3 +564,19 @@ func newAttributeValueInt(v int64) *pdata.AttributeValue {
 	attr := pdata.NewAttributeValueInt(v)
 	return &attr
 }
+
+func TestSpan_MatchingExpr(t *testing.T) {
+	span := pdata.NewSpan()
+	span.InitEmpty()
+	span.SetName("spanName")
+	span.SetKind(pdata.SpanKindSERVER)
+	span.Attributes().InsertInt("http.status_code", 503)
+
+	mp, err := NewMatcher(&MatchProperties{
+		Expr: `attributes["http.status_code"] >= 500 && resource["service.name"] == "svcA"`,
+	})
+	require.NoError(t, err)
+	assert.True(t, mp.MatchSpan(span, resource("svcA")))
+	assert.False(t, mp.MatchSpan(span, resource("svcB")))
+	assert.False(t, mp.MatchSpan(span, pdata.NewResource()))
+}
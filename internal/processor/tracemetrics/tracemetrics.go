@@ -0,0 +1,187 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tracemetrics contains the helpers shared by the processors deriving metrics from the
+// spans of a traces pipeline, e.g. the spanmetrics and servicegraph processors.
+package tracemetrics
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/converter"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// ErrUnsortedBuckets is returned by LatencyBounds when the buckets are not in increasing order.
+var ErrUnsortedBuckets = errors.New("the \"latency_histogram_buckets\" must be in increasing order")
+
+// DefaultLatencyHistogramBuckets are the upper bounds of the latency buckets used when none
+// are configured.
+var DefaultLatencyHistogramBuckets = []time.Duration{
+	2 * time.Millisecond,
+	4 * time.Millisecond,
+	6 * time.Millisecond,
+	8 * time.Millisecond,
+	10 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	200 * time.Millisecond,
+	400 * time.Millisecond,
+	800 * time.Millisecond,
+	1 * time.Second,
+	1400 * time.Millisecond,
+	2 * time.Second,
+	5 * time.Second,
+	10 * time.Second,
+	15 * time.Second,
+}
+
+// LatencyBounds returns the upper bounds of the latency buckets in milliseconds, using
+// DefaultLatencyHistogramBuckets if no buckets are given.
+func LatencyBounds(buckets []time.Duration) ([]float64, error) {
+	if len(buckets) == 0 {
+		buckets = DefaultLatencyHistogramBuckets
+	}
+	bounds := make([]float64, len(buckets))
+	for i, bucket := range buckets {
+		if i > 0 && bucket <= buckets[i-1] {
+			return nil, ErrUnsortedBuckets
+		}
+		bounds[i] = DurationToMillis(bucket)
+	}
+	return bounds, nil
+}
+
+// MetricsExporter returns the metrics exporter of the given name, which must be listed in a
+// metrics pipeline. It is only available once the pipelines are built, i.e. in Start.
+func MetricsExporter(host component.Host, processorCfg configmodels.Processor, exporterName string) (consumer.MetricsConsumer, error) {
+	var available []string
+	for cfg, exp := range host.GetExporters()[configmodels.MetricsDataType] {
+		if cfg.Name() != exporterName {
+			available = append(available, cfg.Name())
+			continue
+		}
+		switch mc := exp.(type) {
+		case consumer.MetricsConsumer:
+			return mc, nil
+		case consumer.MetricsConsumerOld:
+			return converter.NewInternalToOCMetricsConverter(mc), nil
+		default:
+			return nil, fmt.Errorf("%s processor %q: exporter %q does not consume metrics",
+				processorCfg.Type(), processorCfg.Name(), cfg.Name())
+		}
+	}
+	sort.Strings(available)
+	return nil, fmt.Errorf("%s processor %q: metrics exporter %q not found, it must be listed in a metrics pipeline, available exporters: %v",
+		processorCfg.Type(), processorCfg.Name(), exporterName, available)
+}
+
+// InitMetricDescriptor initializes the descriptor of the metric.
+func InitMetricDescriptor(metric pdata.Metric, name, description, unit string, metricType pdata.MetricType) {
+	descriptor := metric.MetricDescriptor()
+	descriptor.InitEmpty()
+	descriptor.SetName(name)
+	descriptor.SetDescription(description)
+	descriptor.SetUnit(unit)
+	descriptor.SetType(metricType)
+}
+
+// AppendInt64DataPoint appends a data point with the given labels and value to dps.
+func AppendInt64DataPoint(dps pdata.Int64DataPointSlice, labels map[string]string, start, timestamp pdata.TimestampUnixNano, value int64) {
+	dps.Resize(dps.Len() + 1)
+	dp := dps.At(dps.Len() - 1)
+	dp.LabelsMap().InitFromMap(labels)
+	dp.SetStartTime(start)
+	dp.SetTimestamp(timestamp)
+	dp.SetValue(value)
+}
+
+// AppendHistogramDataPoint appends a histogram data point with the given labels to hdps. The
+// bucketCounts has one more element than bounds, for the values above the last bound.
+func AppendHistogramDataPoint(hdps pdata.HistogramDataPointSlice, labels map[string]string, start, timestamp pdata.TimestampUnixNano,
+	count uint64, sum float64, bounds []float64, bucketCounts []uint64) {
+	hdps.Resize(hdps.Len() + 1)
+	hdp := hdps.At(hdps.Len() - 1)
+	hdp.LabelsMap().InitFromMap(labels)
+	hdp.SetStartTime(start)
+	hdp.SetTimestamp(timestamp)
+	hdp.SetCount(count)
+	hdp.SetSum(sum)
+	hdp.SetExplicitBounds(bounds)
+	buckets := hdp.Buckets()
+	buckets.Resize(len(bucketCounts))
+	for i, c := range bucketCounts {
+		buckets.At(i).SetCount(c)
+	}
+}
+
+// DurationToMillis returns the duration in milliseconds.
+func DurationToMillis(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// ToTimestamp converts the time to a pdata timestamp.
+func ToTimestamp(t time.Time) pdata.TimestampUnixNano {
+	return pdata.TimestampUnixNano(uint64(t.UnixNano()))
+}
+
+// Flusher calls a flush function at a fixed interval, until it is stopped.
+type Flusher struct {
+	interval time.Duration
+	flush    func()
+	stopCh   chan struct{}
+	done     sync.WaitGroup
+	stopOnce sync.Once
+}
+
+// NewFlusher returns a Flusher calling flush every interval once started.
+func NewFlusher(interval time.Duration, flush func()) *Flusher {
+	return &Flusher{
+		interval: interval,
+		flush:    flush,
+		stopCh:   make(chan struct{}),
+	}
+}
+
+// Start starts calling the flush function in a separate goroutine.
+func (f *Flusher) Start() {
+	f.done.Add(1)
+	go func() {
+		defer f.done.Done()
+		ticker := time.NewTicker(f.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				f.flush()
+			case <-f.stopCh:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops calling the flush function and waits for an ongoing call to return. It can be
+// called several times.
+func (f *Flusher) Stop() {
+	f.stopOnce.Do(func() { close(f.stopCh) })
+	f.done.Wait()
+}
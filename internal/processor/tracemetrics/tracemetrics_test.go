@@ -0,0 +1,112 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracemetrics
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+)
+
+type mockHost struct {
+	component.Host
+	exporters map[configmodels.Exporter]component.Exporter
+}
+
+func (m *mockHost) GetExporters() map[configmodels.DataType]map[configmodels.Exporter]component.Exporter {
+	return map[configmodels.DataType]map[configmodels.Exporter]component.Exporter{
+		configmodels.MetricsDataType: m.exporters,
+	}
+}
+
+func TestLatencyBounds(t *testing.T) {
+	bounds, err := LatencyBounds([]time.Duration{500 * time.Microsecond, 10 * time.Millisecond})
+	require.NoError(t, err)
+	assert.Equal(t, []float64{0.5, 10}, bounds)
+
+	bounds, err = LatencyBounds(nil)
+	require.NoError(t, err)
+	assert.Len(t, bounds, len(DefaultLatencyHistogramBuckets))
+
+	_, err = LatencyBounds([]time.Duration{10 * time.Millisecond, 10 * time.Millisecond})
+	assert.Equal(t, ErrUnsortedBuckets, err)
+}
+
+func TestMetricsExporter(t *testing.T) {
+	sink := new(exportertest.SinkMetricsExporter)
+	host := &mockHost{
+		Host: componenttest.NewNopHost(),
+		exporters: map[configmodels.Exporter]component.Exporter{
+			&configmodels.ExporterSettings{TypeVal: "prometheus", NameVal: "prometheus"}: sink,
+			&configmodels.ExporterSettings{TypeVal: "logging", NameVal: "logging"}:       new(exportertest.SinkMetricsExporterOld),
+			&configmodels.ExporterSettings{TypeVal: "otlp", NameVal: "otlp"}:             &struct{ component.Exporter }{},
+		},
+	}
+	processorCfg := &configmodels.ProcessorSettings{TypeVal: "spanmetrics", NameVal: "spanmetrics/1"}
+
+	exporter, err := MetricsExporter(host, processorCfg, "prometheus")
+	require.NoError(t, err)
+	assert.Equal(t, sink, exporter)
+
+	exporter, err = MetricsExporter(host, processorCfg, "logging")
+	require.NoError(t, err)
+	assert.NotNil(t, exporter)
+
+	_, err = MetricsExporter(host, processorCfg, "otlp")
+	assert.EqualError(t, err, `spanmetrics processor "spanmetrics/1": exporter "otlp" does not consume metrics`)
+
+	_, err = MetricsExporter(host, processorCfg, "jaeger")
+	assert.EqualError(t, err, `spanmetrics processor "spanmetrics/1": metrics exporter "jaeger" not found, it must be listed in a metrics pipeline, available exporters: [logging otlp prometheus]`)
+}
+
+func TestAppendHistogramDataPoint(t *testing.T) {
+	hdps := pdata.NewHistogramDataPointSlice()
+	AppendHistogramDataPoint(hdps, map[string]string{"service.name": "cart"}, 1, 2, 3, 42.5, []float64{10}, []uint64{1, 2})
+
+	require.Equal(t, 1, hdps.Len())
+	hdp := hdps.At(0)
+	assert.EqualValues(t, 1, hdp.StartTime())
+	assert.EqualValues(t, 2, hdp.Timestamp())
+	assert.EqualValues(t, 3, hdp.Count())
+	assert.Equal(t, 42.5, hdp.Sum())
+	assert.Equal(t, []float64{10}, hdp.ExplicitBounds())
+	require.Equal(t, 2, hdp.Buckets().Len())
+	assert.EqualValues(t, 2, hdp.Buckets().At(1).Count())
+	value, ok := hdp.LabelsMap().Get("service.name")
+	require.True(t, ok)
+	assert.Equal(t, "cart", value.Value())
+}
+
+func TestFlusher(t *testing.T) {
+	var flushes int64
+	f := NewFlusher(5*time.Millisecond, func() { atomic.AddInt64(&flushes, 1) })
+	f.Start()
+	assert.Eventually(t, func() bool { return atomic.LoadInt64(&flushes) >= 2 }, time.Second, time.Millisecond)
+
+	f.Stop()
+	f.Stop()
+	count := atomic.LoadInt64(&flushes)
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, count, atomic.LoadInt64(&flushes))
+}
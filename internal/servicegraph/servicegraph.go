@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package servicegraph holds the service graphs built by the servicegraph processors, so they
+// can be rendered by other components, e.g. the zPages extension.
+package servicegraph
+
+import (
+	"sort"
+	"sync"
+)
+
+// Edge is a caller to callee dependency between two services.
+type Edge struct {
+	// Client is the name of the calling service.
+	Client string
+	// Server is the name of the called service.
+	Server string
+	// Requests is the number of requests from the client to the server.
+	Requests uint64
+	// Failures is the number of requests that failed.
+	Failures uint64
+	// LatencySumMs is the sum of the latency of the requests, as seen by the client, in
+	// milliseconds.
+	LatencySumMs float64
+}
+
+// Graph is a snapshot of a service graph.
+type Graph struct {
+	// Edges contains the edges of the graph, sorted by client and server.
+	Edges []Edge
+	// Expired is the number of spans that expired before their peer span was received.
+	Expired uint64
+	// Dropped is the number of spans dropped because the store of unpaired spans was full.
+	Dropped uint64
+}
+
+// Source returns the current snapshot of a service graph.
+type Source func() Graph
+
+var (
+	mu      sync.Mutex
+	sources = make(map[string]Source)
+)
+
+// Register makes the graph of the given source available under the given name, typically the
+// name of the processor building it. It returns a function that unregisters the source.
+func Register(name string, source Source) (unregister func()) {
+	mu.Lock()
+	defer mu.Unlock()
+	sources[name] = source
+	return func() {
+		mu.Lock()
+		defer mu.Unlock()
+		delete(sources, name)
+	}
+}
+
+// NamedGraph is a service graph with the name of its source.
+type NamedGraph struct {
+	Name string
+	Graph
+}
+
+// Snapshots returns the current graphs of all the registered sources, sorted by name.
+func Snapshots() []NamedGraph {
+	// The sources are called without holding the lock, as they take their own locks.
+	mu.Lock()
+	names := make([]string, 0, len(sources))
+	current := make(map[string]Source, len(sources))
+	for name, source := range sources {
+		names = append(names, name)
+		current[name] = source
+	}
+	mu.Unlock()
+
+	sort.Strings(names)
+	graphs := make([]NamedGraph, 0, len(names))
+	for _, name := range names {
+		graphs = append(graphs, NamedGraph{Name: name, Graph: current[name]()})
+	}
+	return graphs
+}
+
+// SortEdges sorts the edges by client and server.
+func SortEdges(edges []Edge) {
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].Client != edges[j].Client {
+			return edges[i].Client < edges[j].Client
+		}
+		return edges[i].Server < edges[j].Server
+	})
+}
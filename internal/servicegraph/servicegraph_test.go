@@ -0,0 +1,53 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicegraph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegister(t *testing.T) {
+	graph := Graph{
+		Edges:   []Edge{{Client: "frontend", Server: "cart", Requests: 2, Failures: 1, LatencySumMs: 30}},
+		Expired: 3,
+	}
+	unregisterB := Register("servicegraph/b", func() Graph { return Graph{} })
+	unregisterA := Register("servicegraph/a", func() Graph { return graph })
+
+	assert.Equal(t, []NamedGraph{
+		{Name: "servicegraph/a", Graph: graph},
+		{Name: "servicegraph/b"},
+	}, Snapshots())
+
+	unregisterA()
+	unregisterB()
+	assert.Len(t, Snapshots(), 0)
+}
+
+func TestSortEdges(t *testing.T) {
+	edges := []Edge{
+		{Client: "frontend", Server: "checkout"},
+		{Client: "checkout", Server: "payment"},
+		{Client: "frontend", Server: "cart"},
+	}
+	SortEdges(edges)
+	assert.Equal(t, []Edge{
+		{Client: "checkout", Server: "payment"},
+		{Client: "frontend", Server: "cart"},
+		{Client: "frontend", Server: "checkout"},
+	}, edges)
+}
@@ -148,6 +148,22 @@ func AllViews() (views []*view.View) {
 	tagKeys = []tag.Key{tagKeyProcessor}
 	views = append(views, genViews(measures, tagKeys, view.Sum())...)
 
+	// Scraper views.
+	measures = []*stats.Int64Measure{
+		mScraperScrapedMetrics,
+		mScraperErroredMetrics,
+		mScraperScrapeErrors,
+	}
+	tagKeys = []tag.Key{tagKeyReceiver, tagKeyScraper}
+	views = append(views, genViews(measures, tagKeys, view.Sum())...)
+	views = append(views, &view.View{
+		Name:        mScraperScrapeDuration.Name(),
+		Description: mScraperScrapeDuration.Description(),
+		TagKeys:     tagKeys,
+		Measure:     mScraperScrapeDuration,
+		Aggregation: scrapeDurationDistribution,
+	})
+
 	return views
 }
 
@@ -22,6 +22,7 @@ import (
 	"go.opencensus.io/trace"
 
 	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/internal/componentstatus"
 )
 
 const (
@@ -226,6 +227,10 @@ func endExportOp(
 		numFailedToSend = numExportedItems
 	}
 
+	if exporterName, ok := tag.FromContext(exporterCtx).Value(tagKeyExporter); ok {
+		componentstatus.RecordResult(componentstatus.KindExporter, exporterName, err)
+	}
+
 	if useNew {
 		var sentMeasure, failedToSendMeasure *stats.Int64Measure
 		switch dataType {
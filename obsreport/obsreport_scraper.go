@@ -0,0 +1,142 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package obsreport
+
+import (
+	"context"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"go.opencensus.io/trace"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
+)
+
+const (
+	// Key used to identify scrapers in metrics and traces.
+	ScraperKey = "scraper"
+
+	// Key used to identify metrics scraped by the Collector.
+	ScrapedMetricsKey = "scraped_metrics"
+	// Key used to identify metrics that failed to be scraped by the Collector.
+	ErroredMetricsKey = "errored_metrics"
+	// Key used to identify scrapes that returned an error.
+	ScrapeErrorsKey = "scrape_errors"
+	// Key used to identify the duration of scrapes.
+	ScrapeDurationKey = "scrape_duration"
+)
+
+var (
+	tagKeyScraper, _ = tag.NewKey(ScraperKey)
+
+	scraperPrefix                 = ScraperKey + nameSep
+	scraperMetricsOperationSuffix = nameSep + "MetricsScraped"
+
+	mScraperScrapedMetrics = stats.Int64(
+		scraperPrefix+ScrapedMetricsKey,
+		"Number of metrics successfully scraped.",
+		stats.UnitDimensionless)
+	mScraperErroredMetrics = stats.Int64(
+		scraperPrefix+ErroredMetricsKey,
+		"Number of metrics that were unable to be scraped.",
+		stats.UnitDimensionless)
+	mScraperScrapeErrors = stats.Int64(
+		scraperPrefix+ScrapeErrorsKey,
+		"Number of scrapes that returned an error.",
+		stats.UnitDimensionless)
+	mScraperScrapeDuration = stats.Float64(
+		scraperPrefix+ScrapeDurationKey,
+		"Duration of scrapes.",
+		stats.UnitMilliseconds)
+
+	scrapeDurationDistribution = view.Distribution(
+		1, 2, 5, 10, 25, 50, 75, 100, 250, 500, 750, 1000, 2500, 5000, 10000, 30000, 60000)
+)
+
+type scrapeStartTimeKey struct{}
+
+// ScraperContext adds the keys used when recording observability metrics to
+// the given context returning the newly created context. This context should
+// be used in related calls to the obsreport functions so metrics are properly
+// recorded.
+func ScraperContext(
+	ctx context.Context,
+	receiver string,
+	scraper string,
+) context.Context {
+	ctx, _ = tag.New(ctx,
+		tag.Upsert(tagKeyReceiver, receiver, tag.WithTTL(tag.TTLNoPropagation)),
+		tag.Upsert(tagKeyScraper, scraper, tag.WithTTL(tag.TTLNoPropagation)))
+
+	return ctx
+}
+
+// StartMetricsScrapeOp is called when a scrape operation is started. The
+// returned context should be used in other calls to the obsreport functions
+// dealing with the same scrape operation.
+func StartMetricsScrapeOp(
+	scraperCtx context.Context,
+	receiver string,
+	scraper string,
+) context.Context {
+	spanName := scraperPrefix + receiver + nameSep + scraper + scraperMetricsOperationSuffix
+	ctx, _ := trace.StartSpan(scraperCtx, spanName)
+	return context.WithValue(ctx, scrapeStartTimeKey{}, time.Now())
+}
+
+// EndMetricsScrapeOp completes the scrape operation that was started with
+// StartMetricsScrapeOp. If err is a consumererror.PartialScrapeError, its
+// failed count is recorded as the number of errored metrics.
+func EndMetricsScrapeOp(
+	scraperCtx context.Context,
+	numScrapedMetrics int,
+	err error,
+) {
+	numErroredMetrics := 0
+	numScrapeErrors := 0
+	if err != nil {
+		numScrapeErrors = 1
+		if partialErr, isPartial := err.(consumererror.PartialScrapeError); isPartial {
+			numErroredMetrics = partialErr.Failed
+		}
+	}
+
+	span := trace.FromContext(scraperCtx)
+
+	if useNew {
+		measurements := []stats.Measurement{
+			mScraperScrapedMetrics.M(int64(numScrapedMetrics)),
+			mScraperErroredMetrics.M(int64(numErroredMetrics)),
+			mScraperScrapeErrors.M(int64(numScrapeErrors)),
+		}
+		if startTime, ok := scraperCtx.Value(scrapeStartTimeKey{}).(time.Time); ok {
+			duration := float64(time.Since(startTime)) / float64(time.Millisecond)
+			measurements = append(measurements, mScraperScrapeDuration.M(duration))
+		}
+		stats.Record(scraperCtx, measurements...)
+	}
+
+	// end span according to errors
+	if span.IsRecordingEvents() {
+		span.AddAttributes(
+			trace.Int64Attribute(ScrapedMetricsKey, int64(numScrapedMetrics)),
+			trace.Int64Attribute(ErroredMetricsKey, int64(numErroredMetrics)),
+		)
+		span.SetStatus(errToStatus(err))
+	}
+	span.End()
+}
@@ -29,6 +29,8 @@ import (
 	"go.opencensus.io/tag"
 	"go.opencensus.io/trace"
 
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/internal/componentstatus"
 	"go.opentelemetry.io/collector/obsreport"
 	"go.opentelemetry.io/collector/obsreport/obsreporttest"
 )
@@ -37,6 +39,7 @@ const (
 	exporter   = "fakeExporter"
 	processor  = "fakeProcessor"
 	receiver   = "fakeReicever"
+	scraper    = "fakeScraper"
 	transport  = "fakeTransport"
 	format     = "fakeFormat"
 	legacyName = "fakeLegacyName"
@@ -235,6 +238,60 @@ func TestReceiveMetricsOp(t *testing.T) {
 	obsreporttest.CheckReceiverMetricsViews(t, receiver, transport, int64(acceptedMetricPoints), int64(refusedMetricPoints))
 }
 
+func TestScrapeMetricsOp(t *testing.T) {
+	doneFn, err := obsreporttest.SetupRecordedMetricsTest()
+	require.NoError(t, err)
+	defer doneFn()
+
+	ss := &spanStore{}
+	trace.RegisterExporter(ss)
+	defer trace.UnregisterExporter(ss)
+
+	parentCtx, parentSpan := trace.StartSpan(context.Background(),
+		t.Name(), trace.WithSampler(trace.AlwaysSample()))
+	defer parentSpan.End()
+
+	errs := []error{
+		nil,
+		consumererror.NewPartialScrapeError(errFake, 3),
+		errFake,
+	}
+	scrapedMetrics := []int{13, 7, 0}
+	scraperCtx := obsreport.ScraperContext(parentCtx, receiver, scraper)
+	for i, err := range errs {
+		ctx := obsreport.StartMetricsScrapeOp(scraperCtx, receiver, scraper)
+		assert.NotNil(t, ctx)
+
+		obsreport.EndMetricsScrapeOp(ctx, scrapedMetrics[i], err)
+	}
+
+	spans := ss.PullAllSpans()
+	require.Equal(t, len(errs), len(spans))
+
+	for i, span := range spans {
+		assert.Equal(t, "scraper/"+receiver+"/"+scraper+"/MetricsScraped", span.Name)
+		assert.Equal(t, int64(scrapedMetrics[i]), span.Attributes[obsreport.ScrapedMetricsKey])
+		switch errs[i] {
+		case nil:
+			assert.Equal(t, int64(0), span.Attributes[obsreport.ErroredMetricsKey])
+			assert.Equal(t, trace.Status{Code: trace.StatusCodeOK}, span.Status)
+		case errFake:
+			assert.Equal(t, int64(0), span.Attributes[obsreport.ErroredMetricsKey])
+			assert.Equal(t, errFake.Error(), span.Status.Message)
+		default:
+			assert.Equal(t, int64(3), span.Attributes[obsreport.ErroredMetricsKey])
+			assert.Equal(t, errFake.Error(), span.Status.Message)
+		}
+	}
+
+	obsreporttest.CheckScraperMetricsViews(t, receiver, scraper, 20, 3, 2)
+
+	rows, err := view.RetrieveData("scraper/scrape_duration")
+	require.NoError(t, err)
+	require.Equal(t, 1, len(rows))
+	assert.Equal(t, int64(len(errs)), rows[0].Data.(*view.DistributionData).Count)
+}
+
 func TestExportTraceDataOp(t *testing.T) {
 	doneFn, err := obsreporttest.SetupRecordedMetricsTest()
 	require.NoError(t, err)
@@ -294,6 +351,30 @@ func TestExportTraceDataOp(t *testing.T) {
 	obsreporttest.CheckExporterTracesViews(t, exporter, int64(sentSpans), int64(failedToSendSpans))
 }
 
+func TestExportOpComponentStatus(t *testing.T) {
+	componentstatus.Reset()
+	defer componentstatus.Reset()
+
+	exporterCtx := obsreport.ExporterContext(context.Background(), exporter)
+	for _, err := range []error{nil, errFake, errFake} {
+		ctx := obsreport.StartTraceDataExportOp(exporterCtx, exporter)
+		obsreport.EndTraceDataExportOp(ctx, 1, 0, err)
+	}
+
+	snapshot := componentstatus.Snapshot()
+	require.Len(t, snapshot, 1)
+	assert.Equal(t, componentstatus.KindExporter, snapshot[0].Kind)
+	assert.Equal(t, exporter, snapshot[0].Name)
+	assert.Equal(t, uint64(2), snapshot[0].ConsecutiveFailures)
+	assert.Equal(t, errFake, snapshot[0].LastError)
+
+	ctx := obsreport.StartMetricsExportOp(exporterCtx, exporter)
+	obsreport.EndMetricsExportOp(ctx, 1, 1, 0, nil)
+	snapshot = componentstatus.Snapshot()
+	require.Len(t, snapshot, 1)
+	assert.Zero(t, snapshot[0].ConsecutiveFailures)
+}
+
 func TestExportMetricsOp(t *testing.T) {
 	doneFn, err := obsreporttest.SetupRecordedMetricsTest()
 	require.NoError(t, err)
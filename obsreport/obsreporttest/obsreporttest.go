@@ -37,6 +37,7 @@ var (
 	transportTag, _ = tag.NewKey("transport")
 	exporterTag, _  = tag.NewKey("exporter")
 	processorTag, _ = tag.NewKey("processor")
+	scraperTag, _   = tag.NewKey("scraper")
 )
 
 // SetupRecordedMetricsTest does setup the testing environment to check the metrics recorded by receivers, producers or exporters.
@@ -120,6 +121,15 @@ func CheckReceiverMetricsViews(t *testing.T, receiver, protocol string, accepted
 	CheckValueForView(t, receiverTags, droppedMetricPoints, "receiver/refused_metric_points")
 }
 
+// CheckScraperMetricsViews checks that for the current exported values for metrics scraper views match given values.
+// When this function is called it is required to also call SetupRecordedMetricsTest as first thing.
+func CheckScraperMetricsViews(t *testing.T, receiver, scraper string, scrapedMetrics, erroredMetrics, scrapeErrors int64) {
+	scraperTags := tagsForScraperView(receiver, scraper)
+	CheckValueForView(t, scraperTags, scrapedMetrics, "scraper/scraped_metrics")
+	CheckValueForView(t, scraperTags, erroredMetrics, "scraper/errored_metrics")
+	CheckValueForView(t, scraperTags, scrapeErrors, "scraper/scrape_errors")
+}
+
 // CheckValueForView checks that for the current exported value in the view with the given name
 // for {LegacyTagKeyReceiver: receiverName} is equal to "value".
 func CheckValueForView(t *testing.T, wantTags []tag.Tag, value int64, vName string) {
@@ -150,6 +160,14 @@ func tagsForReceiverView(receiver, transport string) []tag.Tag {
 	}
 }
 
+// tagsForScraperView returns the tags that are needed for the scraper views.
+func tagsForScraperView(receiver, scraper string) []tag.Tag {
+	return []tag.Tag{
+		{Key: receiverTag, Value: receiver},
+		{Key: scraperTag, Value: scraper},
+	}
+}
+
 // tagsForProcessorView returns the tags that are needed for the processor views.
 func tagsForProcessorView(processor string) []tag.Tag {
 	return []tag.Tag{
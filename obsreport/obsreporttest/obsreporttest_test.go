@@ -16,11 +16,13 @@ package obsreporttest_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/obsreport"
 	"go.opentelemetry.io/collector/obsreport/obsreporttest"
 )
@@ -28,6 +30,7 @@ import (
 const (
 	exporter  = "fakeExporter"
 	receiver  = "fakeReicever"
+	scraper   = "fakeScraper"
 	transport = "fakeTransport"
 	format    = "fakeFormat"
 )
@@ -67,6 +70,22 @@ func TestCheckReceiverMetricsViews(t *testing.T) {
 	obsreporttest.CheckReceiverMetricsViews(t, receiver, transport, 7, 0)
 }
 
+func TestCheckScraperMetricsViews(t *testing.T) {
+	doneFn, err := obsreporttest.SetupRecordedMetricsTest()
+	require.NoError(t, err)
+	defer doneFn()
+
+	scraperCtx := obsreport.ScraperContext(context.Background(), receiver, scraper)
+	ctx := obsreport.StartMetricsScrapeOp(scraperCtx, receiver, scraper)
+	assert.NotNil(t, ctx)
+	obsreport.EndMetricsScrapeOp(ctx, 7, nil)
+
+	ctx = obsreport.StartMetricsScrapeOp(scraperCtx, receiver, scraper)
+	obsreport.EndMetricsScrapeOp(ctx, 5, consumererror.NewPartialScrapeError(errors.New("err1"), 2))
+
+	obsreporttest.CheckScraperMetricsViews(t, receiver, scraper, 12, 2, 1)
+}
+
 func TestCheckExporterTracesViews(t *testing.T) {
 	doneFn, err := obsreporttest.SetupRecordedMetricsTest()
 	require.NoError(t, err)
@@ -20,7 +20,6 @@ import (
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/internal/processor/attraction"
 	"go.opentelemetry.io/collector/internal/processor/filterspan"
-	"go.opentelemetry.io/collector/processor"
 )
 
 type attributesProcessor struct {
@@ -48,7 +47,6 @@ func (a *attributesProcessor) ProcessTraces(_ context.Context, td pdata.Traces)
 		if rs.IsNil() {
 			continue
 		}
-		serviceName := processor.ServiceNameForResource(rs.Resource())
 		ilss := rss.At(i).InstrumentationLibrarySpans()
 		for j := 0; j < ilss.Len(); j++ {
 			ils := ilss.At(j)
@@ -63,7 +61,7 @@ func (a *attributesProcessor) ProcessTraces(_ context.Context, td pdata.Traces)
 					continue
 				}
 
-				if a.skipSpan(span, serviceName) {
+				if a.skipSpan(span, rs.Resource()) {
 					continue
 				}
 
@@ -80,17 +78,17 @@ func (a *attributesProcessor) ProcessTraces(_ context.Context, td pdata.Traces)
 // The logic determining if a span should be processed is set
 // in the attribute configuration with the include and exclude settings.
 // Include properties are checked before exclude settings are checked.
-func (a *attributesProcessor) skipSpan(span pdata.Span, serviceName string) bool {
+func (a *attributesProcessor) skipSpan(span pdata.Span, resource pdata.Resource) bool {
 	if a.include != nil {
 		// A false returned in this case means the span should not be processed.
-		if include := a.include.MatchSpan(span, serviceName); !include {
+		if include := a.include.MatchSpan(span, resource); !include {
 			return true
 		}
 	}
 
 	if a.exclude != nil {
 		// A true returned in this case means the span should not be processed.
-		if exclude := a.exclude.MatchSpan(span, serviceName); exclude {
+		if exclude := a.exclude.MatchSpan(span, resource); exclude {
 			return true
 		}
 	}
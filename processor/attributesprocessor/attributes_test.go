@@ -220,6 +220,59 @@ func TestAttributes_FilterSpans(t *testing.T) {
 	}
 }
 
+func TestAttributes_FilterSpansByExpr(t *testing.T) {
+	testCases := []testCase{
+		{
+			name:        "expr match",
+			serviceName: "svcA",
+			inputAttributes: map[string]pdata.AttributeValue{
+				"http.status_code": pdata.NewAttributeValueInt(503),
+			},
+			expectedAttributes: map[string]pdata.AttributeValue{
+				"http.status_code": pdata.NewAttributeValueInt(503),
+				"attribute1":       pdata.NewAttributeValueInt(123),
+			},
+		},
+		{
+			name:        "expr mismatch for status code",
+			serviceName: "svcA",
+			inputAttributes: map[string]pdata.AttributeValue{
+				"http.status_code": pdata.NewAttributeValueInt(200),
+			},
+			expectedAttributes: map[string]pdata.AttributeValue{
+				"http.status_code": pdata.NewAttributeValueInt(200),
+			},
+		},
+		{
+			name:        "expr mismatch for service",
+			serviceName: "svcB",
+			inputAttributes: map[string]pdata.AttributeValue{
+				"http.status_code": pdata.NewAttributeValueInt(503),
+			},
+			expectedAttributes: map[string]pdata.AttributeValue{
+				"http.status_code": pdata.NewAttributeValueInt(503),
+			},
+		},
+	}
+
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	oCfg := cfg.(*Config)
+	oCfg.Actions = []attraction.ActionKeyValue{
+		{Key: "attribute1", Action: attraction.INSERT, Value: 123},
+	}
+	oCfg.Include = &filterspan.MatchProperties{
+		Expr: `attributes["http.status_code"] >= 500 && resource["service.name"] == "svcA"`,
+	}
+	tp, err := factory.CreateTraceProcessor(context.Background(), component.ProcessorCreateParams{}, exportertest.NewNopTraceExporter(), cfg)
+	require.Nil(t, err)
+	require.NotNil(t, tp)
+
+	for _, tt := range testCases {
+		runIndividualTestCase(t, tt, tp)
+	}
+}
+
 func TestAttributes_FilterSpansByNameStrict(t *testing.T) {
 	testCases := []testCase{
 		{
@@ -17,21 +17,34 @@ package attributesprocessor
 import (
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/internal/processor/attraction"
+	"go.opentelemetry.io/collector/internal/processor/filterlog"
+	"go.opentelemetry.io/collector/internal/processor/filtermetric"
 	"go.opentelemetry.io/collector/internal/processor/filterspan"
 )
 
 // Config specifies the set of attributes to be inserted, updated, upserted and
-// deleted and the properties to include/exclude a span from being processed.
-// This processor handles all forms of modifications to attributes within a span.
-// Prior to any actions being applied, each span is compared against
+// deleted and the properties to include/exclude a span, metric or log record from
+// being processed. The top level include/exclude properties only apply to spans,
+// metrics and log records are matched with the "metrics" and "logs" properties.
+// This processor handles all forms of modifications to attributes within a span,
+// the labels of metric data points and the attributes of log records.
+// Prior to any actions being applied, each item is compared against
 // the include properties and then the exclude properties if they are specified.
-// This determines if a span is to be processed or not.
+// This determines if an item is to be processed or not.
 // The list of actions is applied in order specified in the configuration.
 type Config struct {
 	configmodels.ProcessorSettings `mapstructure:",squash"`
 
 	filterspan.MatchConfig `mapstructure:",squash"`
 
+	// Metrics specifies the properties to include/exclude metrics from being processed.
+	// This is an optional field, all metrics are processed if it is not set.
+	Metrics filtermetric.MatchConfig `mapstructure:"metrics"`
+
+	// Logs specifies the properties to include/exclude log records from being processed.
+	// This is an optional field, all log records are processed if it is not set.
+	Logs filterlog.MatchConfig `mapstructure:"logs"`
+
 	// Specifies the list of attributes to act on.
 	// The set of actions are {INSERT, UPDATE, UPSERT, DELETE, HASH, EXTRACT}.
 	// This is a required field.
@@ -25,6 +25,8 @@ import (
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/config/configtest"
 	"go.opentelemetry.io/collector/internal/processor/attraction"
+	"go.opentelemetry.io/collector/internal/processor/filterlog"
+	"go.opentelemetry.io/collector/internal/processor/filtermetric"
 	"go.opentelemetry.io/collector/internal/processor/filterset"
 	"go.opentelemetry.io/collector/internal/processor/filterspan"
 )
@@ -234,4 +236,64 @@ func TestLoadingConifg(t *testing.T) {
 		},
 	})
 
+	p11 := cfg.Processors["attributes/metrics"]
+	assert.Equal(t, p11, &Config{
+		ProcessorSettings: configmodels.ProcessorSettings{
+			NameVal: "attributes/metrics",
+			TypeVal: typeStr,
+		},
+		Metrics: filtermetric.MatchConfig{
+			Include: &filtermetric.MatchProperties{
+				Config:      *createConfig(filterset.Regexp),
+				MetricNames: []string{"http.*"},
+			},
+			Exclude: &filtermetric.MatchProperties{
+				Config:      *createConfig(filterset.Strict),
+				MetricNames: []string{"http.server.active"},
+			},
+		},
+		Settings: attraction.Settings{
+			Actions: []attraction.ActionKeyValue{
+				{Key: "http.url", Action: attraction.DELETE},
+			},
+		},
+	})
+
+	p12 := cfg.Processors["attributes/logs"]
+	assert.Equal(t, p12, &Config{
+		ProcessorSettings: configmodels.ProcessorSettings{
+			NameVal: "attributes/logs",
+			TypeVal: typeStr,
+		},
+		Logs: filterlog.MatchConfig{
+			Include: &filterlog.MatchProperties{
+				Config:   *createConfig(filterset.Regexp),
+				LogNames: []string{"auth.*"},
+			},
+		},
+		Settings: attraction.Settings{
+			Actions: []attraction.ActionKeyValue{
+				{Key: "user.email", Action: attraction.HASH},
+				{Key: "password", Action: attraction.DELETE},
+			},
+		},
+	})
+
+	p13 := cfg.Processors["attributes/expr"]
+	assert.Equal(t, p13, &Config{
+		ProcessorSettings: configmodels.ProcessorSettings{
+			NameVal: "attributes/expr",
+			TypeVal: typeStr,
+		},
+		MatchConfig: filterspan.MatchConfig{
+			Include: &filterspan.MatchProperties{
+				Expr: `attributes["http.status_code"] >= 500 && resource["service.name"] == "cart"`,
+			},
+		},
+		Settings: attraction.Settings{
+			Actions: []attraction.ActionKeyValue{
+				{Key: "error.reviewed", Value: false, Action: attraction.INSERT},
+			},
+		},
+	})
 }
@@ -22,6 +22,7 @@ import (
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/internal/processor/attraction"
+	"go.opentelemetry.io/collector/internal/processor/filterlog"
 	"go.opentelemetry.io/collector/internal/processor/filterspan"
 	"go.opentelemetry.io/collector/processor/processorhelper"
 )
@@ -38,7 +39,9 @@ func NewFactory() component.ProcessorFactory {
 	return processorhelper.NewFactory(
 		typeStr,
 		createDefaultConfig,
-		processorhelper.WithTraces(createTraceProcessor))
+		processorhelper.WithTraces(createTraceProcessor),
+		processorhelper.WithMetrics(createMetricsProcessor),
+		processorhelper.WithLogs(createLogsProcessor))
 }
 
 // Note: This isn't a valid configuration because the processor would do no work.
@@ -58,12 +61,9 @@ func createTraceProcessor(
 	nextConsumer consumer.TraceConsumer,
 ) (component.TraceProcessor, error) {
 	oCfg := cfg.(*Config)
-	if len(oCfg.Actions) == 0 {
-		return nil, fmt.Errorf("error creating \"attributes\" processor due to missing required field \"actions\" of processor %q", cfg.Name())
-	}
-	attrProc, err := attraction.NewAttrProc(&oCfg.Settings)
+	attrProc, err := newAttrProc(oCfg)
 	if err != nil {
-		return nil, fmt.Errorf("error creating \"attributes\" processor: %w of processor %q", err, cfg.Name())
+		return nil, err
 	}
 	include, err := filterspan.NewMatcher(oCfg.Include)
 	if err != nil {
@@ -80,3 +80,68 @@ func createTraceProcessor(
 		newAttributesProcessor(attrProc, include, exclude),
 		processorhelper.WithCapabilities(processorCapabilities))
 }
+
+func createMetricsProcessor(
+	_ context.Context,
+	_ component.ProcessorCreateParams,
+	cfg configmodels.Processor,
+	nextConsumer consumer.MetricsConsumer,
+) (component.MetricsProcessor, error) {
+	oCfg := cfg.(*Config)
+	attrProc, err := newAttrProc(oCfg)
+	if err != nil {
+		return nil, err
+	}
+	include, err := newMetricMatcher(oCfg.Metrics.Include)
+	if err != nil {
+		return nil, err
+	}
+	exclude, err := newMetricMatcher(oCfg.Metrics.Exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	return processorhelper.NewMetricsProcessor(
+		cfg,
+		nextConsumer,
+		newMetricAttributesProcessor(attrProc, include, exclude),
+		processorhelper.WithCapabilities(processorCapabilities))
+}
+
+func createLogsProcessor(
+	_ context.Context,
+	_ component.ProcessorCreateParams,
+	cfg configmodels.Processor,
+	nextConsumer consumer.LogsConsumer,
+) (component.LogsProcessor, error) {
+	oCfg := cfg.(*Config)
+	attrProc, err := newAttrProc(oCfg)
+	if err != nil {
+		return nil, err
+	}
+	include, err := filterlog.NewMatcher(oCfg.Logs.Include)
+	if err != nil {
+		return nil, err
+	}
+	exclude, err := filterlog.NewMatcher(oCfg.Logs.Exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	return processorhelper.NewLogsProcessor(
+		cfg,
+		nextConsumer,
+		newLogAttributesProcessor(attrProc, include, exclude),
+		processorhelper.WithCapabilities(processorCapabilities))
+}
+
+func newAttrProc(cfg *Config) (*attraction.AttrProc, error) {
+	if len(cfg.Actions) == 0 {
+		return nil, fmt.Errorf("error creating \"attributes\" processor due to missing required field \"actions\" of processor %q", cfg.Name())
+	}
+	attrProc, err := attraction.NewAttrProc(&cfg.Settings)
+	if err != nil {
+		return nil, fmt.Errorf("error creating \"attributes\" processor: %w of processor %q", err, cfg.Name())
+	}
+	return attrProc, nil
+}
@@ -19,14 +19,15 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
-	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configcheck"
-	"go.opentelemetry.io/collector/config/configerror"
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/exporter/exportertest"
 	"go.opentelemetry.io/collector/internal/processor/attraction"
+	"go.opentelemetry.io/collector/internal/processor/filterlog"
+	"go.opentelemetry.io/collector/internal/processor/filtermetric"
+	"go.opentelemetry.io/collector/internal/processor/filterset"
 )
 
 func TestFactory_Type(t *testing.T) {
@@ -97,9 +98,55 @@ func TestFactoryCreateTraceProcessor(t *testing.T) {
 func TestFactory_CreateMetricsProcessor(t *testing.T) {
 	factory := NewFactory()
 	cfg := factory.CreateDefaultConfig()
+	oCfg := cfg.(*Config)
 
 	mp, err := factory.CreateMetricsProcessor(
-		context.Background(), component.ProcessorCreateParams{}, nil, cfg)
-	require.Nil(t, mp)
-	assert.Equal(t, err, configerror.ErrDataTypeIsNotSupported)
+		context.Background(), component.ProcessorCreateParams{}, exportertest.NewNopMetricsExporter(), cfg)
+	assert.Nil(t, mp)
+	assert.Error(t, err)
+
+	oCfg.Actions = []attraction.ActionKeyValue{
+		{Key: "a key", Action: attraction.DELETE},
+	}
+	mp, err = factory.CreateMetricsProcessor(
+		context.Background(), component.ProcessorCreateParams{}, exportertest.NewNopMetricsExporter(), cfg)
+	assert.NotNil(t, mp)
+	assert.NoError(t, err)
+
+	oCfg.Metrics.Include = &filtermetric.MatchProperties{
+		Config:      *createConfig(filterset.Strict),
+		MetricTypes: []string{"unknown"},
+	}
+	mp, err = factory.CreateMetricsProcessor(
+		context.Background(), component.ProcessorCreateParams{}, exportertest.NewNopMetricsExporter(), cfg)
+	assert.Nil(t, mp)
+	assert.Error(t, err)
+}
+
+func TestFactory_CreateLogsProcessor(t *testing.T) {
+	factory := NewFactory().(component.LogsProcessorFactory)
+	cfg := factory.CreateDefaultConfig()
+	oCfg := cfg.(*Config)
+
+	lp, err := factory.CreateLogsProcessor(
+		context.Background(), component.ProcessorCreateParams{}, cfg, exportertest.NewNopLogsExporter())
+	assert.Nil(t, lp)
+	assert.Error(t, err)
+
+	oCfg.Actions = []attraction.ActionKeyValue{
+		{Key: "a key", Action: attraction.DELETE},
+	}
+	lp, err = factory.CreateLogsProcessor(
+		context.Background(), component.ProcessorCreateParams{}, cfg, exportertest.NewNopLogsExporter())
+	assert.NotNil(t, lp)
+	assert.NoError(t, err)
+
+	oCfg.Logs.Include = &filterlog.MatchProperties{
+		Config:      *createConfig(filterset.Strict),
+		MinSeverity: "unknown",
+	}
+	lp, err = factory.CreateLogsProcessor(
+		context.Background(), component.ProcessorCreateParams{}, cfg, exportertest.NewNopLogsExporter())
+	assert.Nil(t, lp)
+	assert.Error(t, err)
 }
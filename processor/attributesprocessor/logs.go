@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attributesprocessor
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/processor/attraction"
+	"go.opentelemetry.io/collector/internal/processor/filterlog"
+)
+
+type logAttributesProcessor struct {
+	attrProc *attraction.AttrProc
+	include  filterlog.Matcher
+	exclude  filterlog.Matcher
+}
+
+// newLogAttributesProcessor returns a processor that modifies attributes of a log record.
+// To construct the attributes processors, the use of the factory methods are required
+// in order to validate the inputs.
+func newLogAttributesProcessor(attrProc *attraction.AttrProc, include, exclude filterlog.Matcher) *logAttributesProcessor {
+	return &logAttributesProcessor{
+		attrProc: attrProc,
+		include:  include,
+		exclude:  exclude,
+	}
+}
+
+// ProcessLogs implements the LProcessor
+func (a *logAttributesProcessor) ProcessLogs(_ context.Context, ld pdata.Logs) (pdata.Logs, error) {
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		if rl.IsNil() {
+			continue
+		}
+		ills := rl.InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			ill := ills.At(j)
+			if ill.IsNil() {
+				continue
+			}
+			logs := ill.Logs()
+			for k := 0; k < logs.Len(); k++ {
+				lr := logs.At(k)
+				if lr.IsNil() {
+					// Do not create empty log records just to add attributes
+					continue
+				}
+
+				if a.skipLogRecord(lr, rl.Resource()) {
+					continue
+				}
+
+				a.attrProc.Process(lr.Attributes())
+			}
+		}
+	}
+	return ld, nil
+}
+
+// skipLogRecord determines if a log record should be processed.
+// True is returned when a log record should be skipped.
+// Include properties are checked before exclude settings are checked.
+func (a *logAttributesProcessor) skipLogRecord(lr pdata.LogRecord, resource pdata.Resource) bool {
+	if a.include != nil && !a.include.MatchLogRecord(lr, resource) {
+		return true
+	}
+
+	if a.exclude != nil && a.exclude.MatchLogRecord(lr, resource) {
+		return true
+	}
+
+	return false
+}
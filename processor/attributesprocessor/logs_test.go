@@ -0,0 +1,247 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attributesprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/internal/data/testdata"
+	"go.opentelemetry.io/collector/internal/processor/attraction"
+	"go.opentelemetry.io/collector/internal/processor/filterlog"
+	"go.opentelemetry.io/collector/internal/processor/filterset"
+	"go.opentelemetry.io/collector/internal/processor/filterspan"
+)
+
+type logTestCase struct {
+	name               string
+	logName            string
+	inputAttributes    map[string]pdata.AttributeValue
+	expectedAttributes map[string]pdata.AttributeValue
+}
+
+// runIndividualLogTestCase is the common logic of passing log data through a configured attributes processor.
+func runIndividualLogTestCase(t *testing.T, tt logTestCase, lp component.LogsProcessor) {
+	t.Run(tt.name, func(t *testing.T) {
+		ld := generateLogData(tt.logName, tt.inputAttributes)
+		assert.NoError(t, lp.ConsumeLogs(context.Background(), ld))
+		// Ensure that the modified `ld` has the attributes sorted:
+		sortLogAttributes(ld)
+		require.Equal(t, generateLogData(tt.logName, tt.expectedAttributes), ld)
+	})
+}
+
+func generateLogData(logName string, attrs map[string]pdata.AttributeValue) pdata.Logs {
+	ld := pdata.NewLogs()
+	ld.ResourceLogs().Resize(1)
+	rl := ld.ResourceLogs().At(0)
+	rl.InstrumentationLibraryLogs().Resize(1)
+	logs := rl.InstrumentationLibraryLogs().At(0).Logs()
+	logs.Resize(1)
+	logs.At(0).SetName(logName)
+	logs.At(0).Attributes().InitFromMap(attrs).Sort()
+	return ld
+}
+
+func sortLogAttributes(ld pdata.Logs) {
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		ills := rls.At(i).InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			logs := ills.At(j).Logs()
+			for k := 0; k < logs.Len(); k++ {
+				logs.At(k).Attributes().Sort()
+			}
+		}
+	}
+}
+
+func newTestLogsProcessor(t *testing.T, oCfg *Config) component.LogsProcessor {
+	lp, err := NewFactory().(component.LogsProcessorFactory).CreateLogsProcessor(
+		context.Background(), component.ProcessorCreateParams{}, oCfg, exportertest.NewNopLogsExporter())
+	require.NoError(t, err)
+	require.NotNil(t, lp)
+	return lp
+}
+
+func TestLogAttributes_NilEmptyData(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input func() pdata.Logs
+	}{
+		{
+			name:  "empty",
+			input: testdata.GenerateLogDataEmpty,
+		},
+		{
+			name:  "one-empty-one-nil-resource-logs",
+			input: testdata.GenerateLogDataOneEmptyOneNilResourceLogs,
+		},
+		{
+			name:  "one-empty-logs",
+			input: testdata.GenerateLogDataOneEmptyLogs,
+		},
+		{
+			name:  "one-empty-one-nil-log-record",
+			input: testdata.GenerateLogDataOneEmptyOneNilLogRecord,
+		},
+	}
+	oCfg := NewFactory().CreateDefaultConfig().(*Config)
+	// Update is a no-op for log records without the key, the input must be left untouched.
+	oCfg.Actions = []attraction.ActionKeyValue{
+		{Key: "attribute1", Action: attraction.UPDATE, Value: 123},
+	}
+	lp := newTestLogsProcessor(t, oCfg)
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			ld := tt.input()
+			assert.NoError(t, lp.ConsumeLogs(context.Background(), ld))
+			assert.EqualValues(t, tt.input(), ld)
+		})
+	}
+}
+
+func TestLogAttributes_Actions(t *testing.T) {
+	testCases := []logTestCase{
+		{
+			name: "redact_and_insert",
+			inputAttributes: map[string]pdata.AttributeValue{
+				"user.email": pdata.NewAttributeValueString("john.doe@example.com"),
+				"password":   pdata.NewAttributeValueString("secret"),
+			},
+			expectedAttributes: map[string]pdata.AttributeValue{
+				"user.email": pdata.NewAttributeValueString("73ec53c4ba1747d485ae2a0d7bfafa6cda80a5a9"),
+				"attribute1": pdata.NewAttributeValueInt(123),
+			},
+		},
+		{
+			name:            "no_attributes",
+			inputAttributes: map[string]pdata.AttributeValue{},
+			expectedAttributes: map[string]pdata.AttributeValue{
+				"attribute1": pdata.NewAttributeValueInt(123),
+			},
+		},
+	}
+
+	oCfg := NewFactory().CreateDefaultConfig().(*Config)
+	oCfg.Actions = []attraction.ActionKeyValue{
+		{Key: "attribute1", Action: attraction.INSERT, Value: 123},
+		{Key: "password", Action: attraction.DELETE},
+		{Key: "user.email", Action: attraction.HASH},
+	}
+	lp := newTestLogsProcessor(t, oCfg)
+	for _, tt := range testCases {
+		runIndividualLogTestCase(t, tt, lp)
+	}
+}
+
+func TestLogAttributes_FilterLogs(t *testing.T) {
+	testCases := []logTestCase{
+		{
+			name:    "included_and_not_excluded",
+			logName: "auth.login",
+			inputAttributes: map[string]pdata.AttributeValue{
+				"env": pdata.NewAttributeValueString("prod"),
+			},
+			expectedAttributes: map[string]pdata.AttributeValue{
+				"env":        pdata.NewAttributeValueString("prod"),
+				"attribute1": pdata.NewAttributeValueInt(123),
+			},
+		},
+		{
+			name:    "included_and_excluded",
+			logName: "auth.login",
+			inputAttributes: map[string]pdata.AttributeValue{
+				"env": pdata.NewAttributeValueString("dev"),
+			},
+			expectedAttributes: map[string]pdata.AttributeValue{
+				"env": pdata.NewAttributeValueString("dev"),
+			},
+		},
+		{
+			name:    "not_included",
+			logName: "http.access",
+			inputAttributes: map[string]pdata.AttributeValue{
+				"env": pdata.NewAttributeValueString("prod"),
+			},
+			expectedAttributes: map[string]pdata.AttributeValue{
+				"env": pdata.NewAttributeValueString("prod"),
+			},
+		},
+	}
+
+	oCfg := NewFactory().CreateDefaultConfig().(*Config)
+	oCfg.Actions = []attraction.ActionKeyValue{
+		{Key: "attribute1", Action: attraction.INSERT, Value: 123},
+	}
+	oCfg.Logs.Include = &filterlog.MatchProperties{
+		Config:   *createConfig(filterset.Regexp),
+		LogNames: []string{"^auth\\..*"},
+	}
+	oCfg.Logs.Exclude = &filterlog.MatchProperties{
+		Config: *createConfig(filterset.Strict),
+		Attributes: []filterspan.Attribute{
+			{Key: "env", Value: "dev"},
+		},
+	}
+	lp := newTestLogsProcessor(t, oCfg)
+	for _, tt := range testCases {
+		runIndividualLogTestCase(t, tt, lp)
+	}
+}
+
+func TestLogAttributes_FilterLogsByExpr(t *testing.T) {
+	testCases := []logTestCase{
+		{
+			name:    "not_excluded",
+			logName: "auth.login",
+			inputAttributes: map[string]pdata.AttributeValue{
+				"env": pdata.NewAttributeValueString("prod"),
+			},
+			expectedAttributes: map[string]pdata.AttributeValue{
+				"env":        pdata.NewAttributeValueString("prod"),
+				"attribute1": pdata.NewAttributeValueInt(123),
+			},
+		},
+		{
+			name:    "excluded",
+			logName: "auth.login",
+			inputAttributes: map[string]pdata.AttributeValue{
+				"env": pdata.NewAttributeValueString("dev"),
+			},
+			expectedAttributes: map[string]pdata.AttributeValue{
+				"env": pdata.NewAttributeValueString("dev"),
+			},
+		},
+	}
+
+	oCfg := NewFactory().CreateDefaultConfig().(*Config)
+	oCfg.Actions = []attraction.ActionKeyValue{
+		{Key: "attribute1", Action: attraction.INSERT, Value: 123},
+	}
+	oCfg.Logs.Exclude = &filterlog.MatchProperties{
+		Expr: `name =~ "^auth" && attributes["env"] != "prod"`,
+	}
+	lp := newTestLogsProcessor(t, oCfg)
+	for _, tt := range testCases {
+		runIndividualLogTestCase(t, tt, lp)
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attributesprocessor
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/consumer/pdatautil"
+	"go.opentelemetry.io/collector/internal/processor/attraction"
+	"go.opentelemetry.io/collector/internal/processor/filtermetric"
+)
+
+type metricAttributesProcessor struct {
+	attrProc *attraction.AttrProc
+	include  *filtermetric.Matcher
+	exclude  *filtermetric.Matcher
+}
+
+// newMetricAttributesProcessor returns a processor that modifies the labels of metric data points.
+// To construct the attributes processors, the use of the factory methods are required
+// in order to validate the inputs.
+func newMetricAttributesProcessor(attrProc *attraction.AttrProc, include, exclude *filtermetric.Matcher) *metricAttributesProcessor {
+	return &metricAttributesProcessor{
+		attrProc: attrProc,
+		include:  include,
+		exclude:  exclude,
+	}
+}
+
+// newMetricMatcher creates a metric Matcher based on the given MatchProperties.
+func newMetricMatcher(mp *filtermetric.MatchProperties) (*filtermetric.Matcher, error) {
+	if mp == nil {
+		return nil, nil
+	}
+
+	matcher, err := filtermetric.NewMatcher(mp)
+	if err != nil {
+		return nil, fmt.Errorf("error creating metric filters: %v", err)
+	}
+	return &matcher, nil
+}
+
+// ProcessMetrics implements the MProcessor
+func (a *metricAttributesProcessor) ProcessMetrics(_ context.Context, md pdata.Metrics) (pdata.Metrics, error) {
+	imd := pdatautil.MetricsToInternalMetrics(md)
+	rms := imd.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		if rm.IsNil() {
+			continue
+		}
+		ilms := rm.InstrumentationLibraryMetrics()
+		for j := 0; j < ilms.Len(); j++ {
+			ilm := ilms.At(j)
+			if ilm.IsNil() {
+				continue
+			}
+			metrics := ilm.Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				metric := metrics.At(k)
+				if metric.IsNil() || metric.MetricDescriptor().IsNil() {
+					continue
+				}
+
+				excludeMetric, skip := a.skipMetric(metric, rm.Resource())
+				if skip {
+					continue
+				}
+
+				a.processMetric(metric, rm.Resource(), excludeMetric)
+			}
+		}
+	}
+	return pdatautil.MetricsFromInternalMetrics(imd), nil
+}
+
+func (a *metricAttributesProcessor) processMetric(metric pdata.Metric, resource pdata.Resource, excludeMetric bool) {
+	idps := metric.Int64DataPoints()
+	for i := 0; i < idps.Len(); i++ {
+		dp := idps.At(i)
+		if !dp.IsNil() && !a.skipDataPoint(excludeMetric, func(m *filtermetric.Matcher) bool { return m.MatchInt64DataPoint(dp, metric, resource) }) {
+			a.processLabels(dp.LabelsMap())
+		}
+	}
+	ddps := metric.DoubleDataPoints()
+	for i := 0; i < ddps.Len(); i++ {
+		dp := ddps.At(i)
+		if !dp.IsNil() && !a.skipDataPoint(excludeMetric, func(m *filtermetric.Matcher) bool { return m.MatchDoubleDataPoint(dp, metric, resource) }) {
+			a.processLabels(dp.LabelsMap())
+		}
+	}
+	hdps := metric.HistogramDataPoints()
+	for i := 0; i < hdps.Len(); i++ {
+		dp := hdps.At(i)
+		if !dp.IsNil() && !a.skipDataPoint(excludeMetric, func(m *filtermetric.Matcher) bool { return m.MatchHistogramDataPoint(dp, metric, resource) }) {
+			a.processLabels(dp.LabelsMap())
+		}
+	}
+	sdps := metric.SummaryDataPoints()
+	for i := 0; i < sdps.Len(); i++ {
+		dp := sdps.At(i)
+		if !dp.IsNil() && !a.skipDataPoint(excludeMetric, func(m *filtermetric.Matcher) bool { return m.MatchSummaryDataPoint(dp, metric, resource) }) {
+			a.processLabels(dp.LabelsMap())
+		}
+	}
+}
+
+// processLabels applies the actions to the labels of a data point. Labels only support
+// string values, so values of other types set by the actions are converted to strings.
+func (a *metricAttributesProcessor) processLabels(labels pdata.StringMap) {
+	attrs := pdata.NewAttributeMap()
+	attrs.InitEmptyWithCapacity(labels.Len())
+	labels.ForEach(func(k string, v pdata.StringValue) {
+		attrs.InsertString(k, v.Value())
+	})
+
+	a.attrProc.Process(attrs)
+
+	labels.InitEmptyWithCapacity(attrs.Len())
+	attrs.ForEach(func(k string, v pdata.AttributeValue) {
+		labels.Insert(k, labelValue(v))
+	})
+}
+
+// skipMetric determines if a metric should be processed, based on its metric level properties.
+// True is returned when a metric should be skipped. Metrics matched by an exclude Matcher with
+// data point properties are not skipped, the data points are matched by skipDataPoint. The returned
+// excludeMetric reports whether the metric matched the exclude metric level properties.
+// Include properties are checked before exclude settings are checked.
+func (a *metricAttributesProcessor) skipMetric(metric pdata.Metric, resource pdata.Resource) (excludeMetric bool, skip bool) {
+	if a.include != nil && !a.include.MatchMetric(metric, resource) {
+		return false, true
+	}
+
+	excludeMetric = a.exclude != nil && a.exclude.MatchMetric(metric, resource)
+	return excludeMetric, excludeMetric && !a.exclude.HasDataPointProperties()
+}
+
+// skipDataPoint determines if a data point of a metric that was not skipped should be processed.
+// True is returned when the data point should be skipped.
+func (a *metricAttributesProcessor) skipDataPoint(excludeMetric bool, match func(m *filtermetric.Matcher) bool) bool {
+	if a.include != nil && a.include.HasDataPointProperties() && !match(a.include) {
+		return true
+	}
+
+	if excludeMetric && match(a.exclude) {
+		return true
+	}
+
+	return false
+}
+
+func labelValue(v pdata.AttributeValue) string {
+	switch v.Type() {
+	case pdata.AttributeValueSTRING:
+		return v.StringVal()
+	case pdata.AttributeValueINT:
+		return strconv.FormatInt(v.IntVal(), 10)
+	case pdata.AttributeValueDOUBLE:
+		return strconv.FormatFloat(v.DoubleVal(), 'f', -1, 64)
+	case pdata.AttributeValueBOOL:
+		return strconv.FormatBool(v.BoolVal())
+	default:
+		return ""
+	}
+}
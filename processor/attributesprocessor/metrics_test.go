@@ -0,0 +1,351 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attributesprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/pdatautil"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/internal/data"
+	"go.opentelemetry.io/collector/internal/data/testdata"
+	"go.opentelemetry.io/collector/internal/processor/attraction"
+	"go.opentelemetry.io/collector/internal/processor/filtermetric"
+	"go.opentelemetry.io/collector/internal/processor/filterset"
+)
+
+type metricTestCase struct {
+	name           string
+	metricName     string
+	inputLabels    map[string]string
+	expectedLabels map[string]string
+}
+
+// runIndividualMetricTestCase is the common logic of passing metric data through a configured attributes processor.
+func runIndividualMetricTestCase(t *testing.T, tt metricTestCase, oCfg *Config) {
+	t.Run(tt.name, func(t *testing.T) {
+		sink := &exportertest.SinkMetricsExporter{}
+		mp, err := NewFactory().CreateMetricsProcessor(context.Background(), component.ProcessorCreateParams{}, sink, oCfg)
+		require.NoError(t, err)
+		require.NotNil(t, mp)
+
+		md := generateMetricData(tt.metricName, tt.inputLabels)
+		assert.NoError(t, mp.ConsumeMetrics(context.Background(), pdatautil.MetricsFromInternalMetrics(md)))
+		require.Len(t, sink.AllMetrics(), 1)
+		got := pdatautil.MetricsToInternalMetrics(sink.AllMetrics()[0])
+		// Ensure that the modified metrics have the labels sorted:
+		sortLabels(got)
+		assert.Equal(t, generateMetricData(tt.metricName, tt.expectedLabels), got)
+	})
+}
+
+// generateMetricData returns a metric with one data point of each type, all of them having the given labels.
+func generateMetricData(metricName string, labels map[string]string) data.MetricData {
+	md := data.NewMetricData()
+	md.ResourceMetrics().Resize(1)
+	rm := md.ResourceMetrics().At(0)
+	rm.InstrumentationLibraryMetrics().Resize(1)
+	ilm := rm.InstrumentationLibraryMetrics().At(0)
+	ilm.Metrics().Resize(1)
+	metric := ilm.Metrics().At(0)
+	metric.MetricDescriptor().InitEmpty()
+	metric.MetricDescriptor().SetName(metricName)
+	metric.Int64DataPoints().Resize(1)
+	metric.Int64DataPoints().At(0).LabelsMap().InitFromMap(labels).Sort()
+	metric.DoubleDataPoints().Resize(1)
+	metric.DoubleDataPoints().At(0).LabelsMap().InitFromMap(labels).Sort()
+	metric.HistogramDataPoints().Resize(1)
+	metric.HistogramDataPoints().At(0).LabelsMap().InitFromMap(labels).Sort()
+	metric.SummaryDataPoints().Resize(1)
+	metric.SummaryDataPoints().At(0).LabelsMap().InitFromMap(labels).Sort()
+	return md
+}
+
+func sortLabels(md data.MetricData) {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		ilms := rms.At(i).InstrumentationLibraryMetrics()
+		for j := 0; j < ilms.Len(); j++ {
+			metrics := ilms.At(j).Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				metric := metrics.At(k)
+				for l := 0; l < metric.Int64DataPoints().Len(); l++ {
+					metric.Int64DataPoints().At(l).LabelsMap().Sort()
+				}
+				for l := 0; l < metric.DoubleDataPoints().Len(); l++ {
+					metric.DoubleDataPoints().At(l).LabelsMap().Sort()
+				}
+				for l := 0; l < metric.HistogramDataPoints().Len(); l++ {
+					metric.HistogramDataPoints().At(l).LabelsMap().Sort()
+				}
+				for l := 0; l < metric.SummaryDataPoints().Len(); l++ {
+					metric.SummaryDataPoints().At(l).LabelsMap().Sort()
+				}
+			}
+		}
+	}
+}
+
+func TestMetricAttributes_NilEmptyData(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input func() data.MetricData
+	}{
+		{
+			name:  "empty",
+			input: testdata.GenerateMetricDataEmpty,
+		},
+		{
+			name:  "one-empty-one-nil-resource-metrics",
+			input: testdata.GenerateMetricDataOneEmptyOneNilResourceMetrics,
+		},
+		{
+			name:  "one-empty-one-nil-instrumentation-library",
+			input: testdata.GenerateMetricDataOneEmptyOneNilInstrumentationLibrary,
+		},
+		{
+			name:  "nil-metric-descriptor",
+			input: testdata.GenerateMetricDataNilMetricDescriptor,
+		},
+		{
+			name:  "all-types-nil-data-point",
+			input: testdata.GenerateMetricDataAllTypesNilDataPoint,
+		},
+	}
+	oCfg := NewFactory().CreateDefaultConfig().(*Config)
+	oCfg.Actions = []attraction.ActionKeyValue{
+		{Key: "attribute1", Action: attraction.INSERT, Value: 123},
+		{Key: "attribute1", Action: attraction.DELETE},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := &exportertest.SinkMetricsExporter{}
+			mp, err := NewFactory().CreateMetricsProcessor(context.Background(), component.ProcessorCreateParams{}, sink, oCfg)
+			require.NoError(t, err)
+			assert.NoError(t, mp.ConsumeMetrics(context.Background(), pdatautil.MetricsFromInternalMetrics(tt.input())))
+			require.Len(t, sink.AllMetrics(), 1)
+			assert.EqualValues(t, tt.input(), pdatautil.MetricsToInternalMetrics(sink.AllMetrics()[0]))
+		})
+	}
+}
+
+func TestMetricAttributes_Actions(t *testing.T) {
+	testCases := []metricTestCase{
+		{
+			name:        "insert_int_value",
+			inputLabels: map[string]string{"label": "value"},
+			expectedLabels: map[string]string{
+				"label":      "value",
+				"attribute1": "123",
+			},
+		},
+		{
+			name:        "delete_label",
+			inputLabels: map[string]string{"host.id": "h1", "label": "value"},
+			expectedLabels: map[string]string{
+				"label":      "value",
+				"attribute1": "123",
+			},
+		},
+		{
+			name: "hash_and_existing_attribute1",
+			inputLabels: map[string]string{
+				"user.email": "john.doe@example.com",
+				"attribute1": "existing",
+			},
+			expectedLabels: map[string]string{
+				"user.email": "73ec53c4ba1747d485ae2a0d7bfafa6cda80a5a9",
+				"attribute1": "existing",
+			},
+		},
+		{
+			name:        "no_labels",
+			inputLabels: map[string]string{},
+			expectedLabels: map[string]string{
+				"attribute1": "123",
+			},
+		},
+	}
+
+	oCfg := NewFactory().CreateDefaultConfig().(*Config)
+	oCfg.Actions = []attraction.ActionKeyValue{
+		{Key: "attribute1", Action: attraction.INSERT, Value: 123},
+		{Key: "host.id", Action: attraction.DELETE},
+		{Key: "user.email", Action: attraction.HASH},
+	}
+	for _, tt := range testCases {
+		runIndividualMetricTestCase(t, tt, oCfg)
+	}
+}
+
+func TestMetricAttributes_FilterMetricsByName(t *testing.T) {
+	testCases := []metricTestCase{
+		{
+			name:           "include_and_not_excluded",
+			metricName:     "http.server.duration",
+			inputLabels:    map[string]string{"label": "value"},
+			expectedLabels: map[string]string{"label": "value", "attribute1": "123"},
+		},
+		{
+			name:           "include_and_excluded",
+			metricName:     "http.server.requests",
+			inputLabels:    map[string]string{"label": "value"},
+			expectedLabels: map[string]string{"label": "value"},
+		},
+		{
+			name:           "not_included",
+			metricName:     "rpc.server.duration",
+			inputLabels:    map[string]string{"label": "value"},
+			expectedLabels: map[string]string{"label": "value"},
+		},
+	}
+
+	oCfg := NewFactory().CreateDefaultConfig().(*Config)
+	oCfg.Actions = []attraction.ActionKeyValue{
+		{Key: "attribute1", Action: attraction.INSERT, Value: 123},
+	}
+	oCfg.Metrics.Include = &filtermetric.MatchProperties{
+		Config:      *createConfig(filterset.Regexp),
+		MetricNames: []string{"^http\\..*"},
+	}
+	oCfg.Metrics.Exclude = &filtermetric.MatchProperties{
+		Config:      *createConfig(filterset.Strict),
+		MetricNames: []string{"http.server.requests"},
+	}
+	for _, tt := range testCases {
+		runIndividualMetricTestCase(t, tt, oCfg)
+	}
+}
+
+func TestMetricAttributes_FilterMetricsByExpr(t *testing.T) {
+	testCases := []metricTestCase{
+		{
+			name:           "included",
+			metricName:     "http.server.duration",
+			inputLabels:    map[string]string{"status": "500"},
+			expectedLabels: map[string]string{"status": "500", "attribute1": "123"},
+		},
+		{
+			name:           "included_and_excluded",
+			metricName:     "http.server.duration",
+			inputLabels:    map[string]string{"status": "500", "internal": "true"},
+			expectedLabels: map[string]string{"status": "500", "internal": "true"},
+		},
+		{
+			name:           "not_included",
+			metricName:     "http.server.duration",
+			inputLabels:    map[string]string{"status": "200"},
+			expectedLabels: map[string]string{"status": "200"},
+		},
+	}
+
+	oCfg := NewFactory().CreateDefaultConfig().(*Config)
+	oCfg.Actions = []attraction.ActionKeyValue{
+		{Key: "attribute1", Action: attraction.INSERT, Value: 123},
+	}
+	oCfg.Metrics.Include = &filtermetric.MatchProperties{
+		Expr: `name =~ "^http\\." && attributes["status"] =~ "^5"`,
+	}
+	oCfg.Metrics.Exclude = &filtermetric.MatchProperties{
+		Expr: `attributes["internal"] == "true"`,
+	}
+	for _, tt := range testCases {
+		runIndividualMetricTestCase(t, tt, oCfg)
+	}
+}
+
+func TestMetricAttributes_FilterDataPointsByLabels(t *testing.T) {
+	testCases := []metricTestCase{
+		{
+			name:           "not_excluded",
+			metricName:     "http.server.duration",
+			inputLabels:    map[string]string{"internal": "false"},
+			expectedLabels: map[string]string{"internal": "false", "attribute1": "123"},
+		},
+		{
+			name:           "excluded",
+			metricName:     "http.server.duration",
+			inputLabels:    map[string]string{"internal": "true"},
+			expectedLabels: map[string]string{"internal": "true"},
+		},
+		{
+			name:           "other_metric_not_excluded",
+			metricName:     "http.server.requests",
+			inputLabels:    map[string]string{"internal": "true"},
+			expectedLabels: map[string]string{"internal": "true", "attribute1": "123"},
+		},
+	}
+
+	oCfg := NewFactory().CreateDefaultConfig().(*Config)
+	oCfg.Actions = []attraction.ActionKeyValue{
+		{Key: "attribute1", Action: attraction.INSERT, Value: 123},
+	}
+	oCfg.Metrics.Exclude = &filtermetric.MatchProperties{
+		Config:      *createConfig(filterset.Strict),
+		MetricNames: []string{"http.server.duration"},
+		Labels:      []filtermetric.Label{{Key: "internal", Value: "true"}},
+	}
+	for _, tt := range testCases {
+		runIndividualMetricTestCase(t, tt, oCfg)
+	}
+}
+
+func TestMetricAttributes_InvalidMatchProperties(t *testing.T) {
+	testCases := []struct {
+		name     string
+		property filtermetric.MatchProperties
+	}{
+		{
+			name: "unknown_metric_type",
+			property: filtermetric.MatchProperties{
+				Config:      *createConfig(filterset.Strict),
+				MetricTypes: []string{"unknown"},
+			},
+		},
+		{
+			name: "invalid_regexp",
+			property: filtermetric.MatchProperties{
+				Config:      *createConfig(filterset.Regexp),
+				MetricNames: []string{"["},
+			},
+		},
+		{
+			name: "expr_with_metric_names",
+			property: filtermetric.MatchProperties{
+				Config:      *createConfig(filterset.Strict),
+				MetricNames: []string{"metric"},
+				Expr:        `value > 1`,
+			},
+		},
+		{
+			name: "invalid_expr",
+			property: filtermetric.MatchProperties{
+				Expr: `value >`,
+			},
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			matcher, err := newMetricMatcher(&tt.property)
+			assert.Nil(t, matcher)
+			assert.Error(t, err)
+		})
+	}
+}
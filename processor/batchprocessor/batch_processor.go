@@ -32,10 +32,10 @@ import (
 	"go.opentelemetry.io/collector/processor"
 )
 
-// batch_processor is a component that accepts spans and metrics, places them
+// batch_processor is a component that accepts spans, metrics and logs, places them
 // into batches and sends downstream.
 //
-// batch_processor implements consumer.TraceConsumer and consumer.MetricsConsumer
+// batch_processor implements consumer.TraceConsumer, consumer.MetricsConsumer and consumer.LogsConsumer
 //
 // Batches are sent out with any of the following conditions:
 // - batch size reaches cfg.SendBatchSize
@@ -74,6 +74,7 @@ type batch interface {
 
 var _ consumer.TraceConsumer = (*batchProcessor)(nil)
 var _ consumer.MetricsConsumer = (*batchProcessor)(nil)
+var _ consumer.LogsConsumer = (*batchProcessor)(nil)
 
 func newBatchProcessor(params component.ProcessorCreateParams, cfg *Config, batch batch, telemetryLevel telemetry.Level) *batchProcessor {
 	return &batchProcessor{
@@ -117,19 +118,40 @@ func (bp *batchProcessor) startProcessingCycle() {
 				if bp.batch.itemCount() > 0 {
 					// TODO: Set a timeout on sendTraces or
 					// make it cancellable using the context that Shutdown gets as a parameter
-					bp.sendItems(statTimeoutTriggerSend)
+					bp.sendItems(statTimeoutTriggerSend, triggerTimeout)
 				}
 				close(bp.done)
 				return
 			}
 			if bp.sendBatchMaxSize > 0 {
-				if td, ok := item.(pdata.Traces); ok {
-					itemCount := bp.batch.itemCount()
-					if itemCount+uint32(td.SpanCount()) > bp.sendBatchMaxSize {
-						tdRemainSize := splitTrace(int(bp.sendBatchSize-itemCount), td)
+				itemCount := bp.batch.itemCount()
+				switch it := item.(type) {
+				case pdata.Traces:
+					if itemCount+uint32(it.SpanCount()) > bp.sendBatchMaxSize {
+						tdRemainSize := splitTrace(int(bp.sendBatchSize-itemCount), it)
 						item = tdRemainSize
 						go func() {
-							bp.newItem <- td
+							bp.newItem <- it
+						}()
+					}
+				case pdata.Metrics:
+					md := pdatautil.MetricsToInternalMetrics(it)
+					_, dataPointCount := md.MetricAndDataPointCount()
+					if itemCount+uint32(dataPointCount) > bp.sendBatchMaxSize {
+						mdRemainSize := splitMetrics(int(bp.sendBatchSize-itemCount), md)
+						item = pdatautil.MetricsFromInternalMetrics(mdRemainSize)
+						// The split removed the sent data points from md, which is a copy when it is
+						// backed by OC data, so re-queue md instead of the original item.
+						go func() {
+							bp.newItem <- pdatautil.MetricsFromInternalMetrics(md)
+						}()
+					}
+				case pdata.Logs:
+					if itemCount+uint32(it.LogRecordCount()) > bp.sendBatchMaxSize {
+						ldRemainSize := splitLogs(int(bp.sendBatchSize-itemCount), it)
+						item = ldRemainSize
+						go func() {
+							bp.newItem <- it
 						}()
 					}
 				}
@@ -138,12 +160,12 @@ func (bp *batchProcessor) startProcessingCycle() {
 			bp.batch.add(item)
 			if bp.batch.itemCount() >= bp.sendBatchSize {
 				bp.timer.Stop()
-				bp.sendItems(statBatchSizeTriggerSend)
+				bp.sendItems(statBatchSizeTriggerSend, triggerBatchSize)
 				bp.resetTimer()
 			}
 		case <-bp.timer.C:
 			if bp.batch.itemCount() > 0 {
-				bp.sendItems(statTimeoutTriggerSend)
+				bp.sendItems(statTimeoutTriggerSend, triggerTimeout)
 			}
 			bp.resetTimer()
 		}
@@ -154,10 +176,12 @@ func (bp *batchProcessor) resetTimer() {
 	bp.timer.Reset(bp.timeout)
 }
 
-func (bp *batchProcessor) sendItems(measure *stats.Int64Measure) {
+func (bp *batchProcessor) sendItems(measure *stats.Int64Measure, trigger string) {
 	// Add that it came form the trace pipeline?
 	statsTags := []tag.Mutator{tag.Insert(processor.TagProcessorNameKey, bp.name)}
-	_ = stats.RecordWithTags(context.Background(), statsTags, measure.M(1), statBatchSendSize.M(int64(bp.batch.itemCount())))
+	_ = stats.RecordWithTags(context.Background(), statsTags, measure.M(1))
+	sizeTags := append(statsTags, tag.Insert(tagTriggerKey, trigger))
+	_ = stats.RecordWithTags(context.Background(), sizeTags, statBatchSendSize.M(int64(bp.batch.itemCount())))
 
 	if bp.telemetryLevel == telemetry.Detailed {
 		_ = stats.RecordWithTags(context.Background(), statsTags, statBatchSendSizeBytes.M(int64(bp.batch.size())))
@@ -182,6 +206,12 @@ func (bp *batchProcessor) ConsumeMetrics(_ context.Context, md pdata.Metrics) er
 	return nil
 }
 
+// ConsumeLogs implements LogsProcessor
+func (bp *batchProcessor) ConsumeLogs(_ context.Context, ld pdata.Logs) error {
+	bp.newItem <- ld
+	return nil
+}
+
 // newBatchTracesProcessor creates a new batch processor that batches traces by size or with timeout
 func newBatchTracesProcessor(params component.ProcessorCreateParams, trace consumer.TraceConsumer, cfg *Config, telemetryLevel telemetry.Level) *batchProcessor {
 	return newBatchProcessor(params, cfg, newBatchTraces(trace), telemetryLevel)
@@ -192,6 +222,11 @@ func newBatchMetricsProcessor(params component.ProcessorCreateParams, metrics co
 	return newBatchProcessor(params, cfg, newBatchMetrics(metrics), telemetryLevel)
 }
 
+// newBatchLogsProcessor creates a new batch processor that batches logs by size or with timeout
+func newBatchLogsProcessor(params component.ProcessorCreateParams, logs consumer.LogsConsumer, cfg *Config, telemetryLevel telemetry.Level) *batchProcessor {
+	return newBatchProcessor(params, cfg, newBatchLogs(logs), telemetryLevel)
+}
+
 type batchTraces struct {
 	nextConsumer consumer.TraceConsumer
 	traceData    pdata.Traces
@@ -235,9 +270,9 @@ func (bt *batchTraces) reset() {
 }
 
 type batchMetrics struct {
-	nextConsumer consumer.MetricsConsumer
-	metricData   data.MetricData
-	metricCount  uint32
+	nextConsumer   consumer.MetricsConsumer
+	metricData     data.MetricData
+	dataPointCount uint32
 }
 
 func newBatchMetrics(nextConsumer consumer.MetricsConsumer) *batchMetrics {
@@ -251,7 +286,7 @@ func (bm *batchMetrics) export(ctx context.Context) error {
 }
 
 func (bm *batchMetrics) itemCount() uint32 {
-	return bm.metricCount
+	return bm.dataPointCount
 }
 
 func (bm *batchMetrics) size() int {
@@ -261,16 +296,57 @@ func (bm *batchMetrics) size() int {
 // resets the current batchMetrics structure with zero/empty values.
 func (bm *batchMetrics) reset() {
 	bm.metricData = data.NewMetricData()
-	bm.metricCount = 0
+	bm.dataPointCount = 0
 }
 
 func (bm *batchMetrics) add(item interface{}) {
 	md := pdatautil.MetricsToInternalMetrics(item.(pdata.Metrics))
 
-	newMetricsCount := md.MetricCount()
-	if newMetricsCount == 0 {
+	_, newDataPointCount := md.MetricAndDataPointCount()
+	if newDataPointCount == 0 {
 		return
 	}
-	bm.metricCount += uint32(newMetricsCount)
+	bm.dataPointCount += uint32(newDataPointCount)
 	md.ResourceMetrics().MoveAndAppendTo(bm.metricData.ResourceMetrics())
 }
+
+type batchLogs struct {
+	nextConsumer consumer.LogsConsumer
+	logData      pdata.Logs
+	logCount     uint32
+}
+
+func newBatchLogs(nextConsumer consumer.LogsConsumer) *batchLogs {
+	b := &batchLogs{nextConsumer: nextConsumer}
+	b.reset()
+	return b
+}
+
+func (bl *batchLogs) export(ctx context.Context) error {
+	return bl.nextConsumer.ConsumeLogs(ctx, bl.logData)
+}
+
+func (bl *batchLogs) itemCount() uint32 {
+	return bl.logCount
+}
+
+func (bl *batchLogs) size() int {
+	return bl.logData.Size()
+}
+
+// resets the current batchLogs structure with zero/empty values.
+func (bl *batchLogs) reset() {
+	bl.logData = pdata.NewLogs()
+	bl.logCount = 0
+}
+
+func (bl *batchLogs) add(item interface{}) {
+	ld := item.(pdata.Logs)
+
+	newLogsCount := ld.LogRecordCount()
+	if newLogsCount == 0 {
+		return
+	}
+	bl.logCount += uint32(newLogsCount)
+	ld.ResourceLogs().MoveAndAppendTo(bl.logData.ResourceLogs())
+}
@@ -178,6 +178,46 @@ func TestBatchProcessorSentBySize(t *testing.T) {
 	assert.Equal(t, sizeSum, int(distData.Sum()))
 }
 
+func TestBatchProcessorSendSizeByTrigger(t *testing.T) {
+	views := MetricViews(telemetry.Detailed)
+	view.Register(views...)
+	defer view.Unregister(views...)
+
+	sink := &exportertest.SinkTraceExporter{}
+	cfg := createDefaultConfig().(*Config)
+	cfg.SendBatchSize = 20
+	cfg.Timeout = 10 * time.Second
+	creationParams := component.ProcessorCreateParams{Logger: zap.NewNop()}
+	batcher := newBatchTracesProcessor(creationParams, sink, cfg, telemetry.Detailed)
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+
+	for requestNum := 0; requestNum < 5; requestNum++ {
+		td := testdata.GenerateTraceDataManySpansSameResource(5)
+		assert.NoError(t, batcher.ConsumeTraces(context.Background(), td))
+	}
+	// The remaining spans are sent at shutdown, which counts as a timeout trigger.
+	require.NoError(t, batcher.Shutdown(context.Background()))
+	require.Equal(t, 25, sink.SpansCount())
+
+	viewData, err := view.RetrieveData(statBatchSendSize.Name())
+	require.NoError(t, err)
+	require.Equal(t, 2, len(viewData))
+	sizeByTrigger := map[string]*view.DistributionData{}
+	for _, row := range viewData {
+		for _, tag := range row.Tags {
+			if tag.Key == tagTriggerKey {
+				sizeByTrigger[tag.Value] = row.Data.(*view.DistributionData)
+			}
+		}
+	}
+	require.Contains(t, sizeByTrigger, triggerBatchSize)
+	assert.Equal(t, int64(1), sizeByTrigger[triggerBatchSize].Count)
+	assert.Equal(t, 20, int(sizeByTrigger[triggerBatchSize].Sum()))
+	require.Contains(t, sizeByTrigger, triggerTimeout)
+	assert.Equal(t, int64(1), sizeByTrigger[triggerTimeout].Count)
+	assert.Equal(t, 5, int(sizeByTrigger[triggerTimeout].Sum()))
+}
+
 func TestBatchProcessorSentByTimeout(t *testing.T) {
 	sink := &exportertest.SinkTraceExporter{}
 	cfg := createDefaultConfig().(*Config)
@@ -313,6 +353,8 @@ func TestBatchMetricProcessor_BatchSize(t *testing.T) {
 
 	requestCount := 100
 	metricsPerRequest := 5
+	dataPointsPerMetric := 2 // Since the int counter uses two datapoints.
+	dataPointsPerRequest := metricsPerRequest * dataPointsPerMetric
 	sink := &exportertest.SinkMetricsExporter{}
 
 	createParams := component.ProcessorCreateParams{Logger: zap.NewNop()}
@@ -332,8 +374,8 @@ func TestBatchMetricProcessor_BatchSize(t *testing.T) {
 	elapsed := time.Since(start)
 	require.LessOrEqual(t, elapsed.Nanoseconds(), cfg.Timeout.Nanoseconds())
 
-	expectedBatchesNum := requestCount * metricsPerRequest / int(cfg.SendBatchSize)
-	expectedBatchingFactor := int(cfg.SendBatchSize) / metricsPerRequest
+	expectedBatchesNum := requestCount * dataPointsPerRequest / int(cfg.SendBatchSize)
+	expectedBatchingFactor := int(cfg.SendBatchSize) / dataPointsPerRequest
 
 	require.Equal(t, requestCount*metricsPerRequest, sink.MetricsCount())
 	receivedMds := sink.AllMetrics()
@@ -351,7 +393,7 @@ func TestBatchMetricProcessor_BatchSize(t *testing.T) {
 	assert.Equal(t, 1, len(viewData))
 	distData := viewData[0].Data.(*view.DistributionData)
 	assert.Equal(t, int64(expectedBatchesNum), distData.Count)
-	assert.Equal(t, sink.MetricsCount(), int(distData.Sum()))
+	assert.Equal(t, requestCount*dataPointsPerRequest, int(distData.Sum()))
 	assert.Equal(t, cfg.SendBatchSize, uint32(distData.Min))
 	assert.Equal(t, cfg.SendBatchSize, uint32(distData.Max))
 
@@ -363,10 +405,96 @@ func TestBatchMetricProcessor_BatchSize(t *testing.T) {
 	assert.Equal(t, size, int(distData.Sum()))
 }
 
+func TestBatchMetricProcessor_EnforceBatchSize(t *testing.T) {
+	sink := &exportertest.SinkMetricsExporter{}
+	cfg := createDefaultConfig().(*Config)
+	cfg.SendBatchSize = 128
+	cfg.SendBatchMaxSize = 128
+	creationParams := component.ProcessorCreateParams{Logger: zap.NewNop()}
+	batcher := newBatchMetricsProcessor(creationParams, sink, cfg, telemetry.Basic)
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+
+	requestCount := 1000
+	metricsPerRequest := 75
+	dataPointsPerRequest := metricsPerRequest * 2 // Since the int counter uses two datapoints.
+	for requestNum := 0; requestNum < requestCount; requestNum++ {
+		md := testdata.GenerateMetricDataManyMetricsSameResource(metricsPerRequest)
+		assert.NoError(t, batcher.ConsumeMetrics(context.Background(), pdatautil.MetricsFromInternalMetrics(md)))
+	}
+
+	// wait for all data points to be reported
+	dataPointsCount := func() int {
+		count := 0
+		for _, md := range sink.AllMetrics() {
+			_, dataPoints := pdatautil.MetricAndDataPointCount(md)
+			count += dataPoints
+		}
+		return count
+	}
+	for {
+		if dataPointsCount() == requestCount*dataPointsPerRequest {
+			break
+		}
+		<-time.After(cfg.Timeout)
+	}
+
+	require.NoError(t, batcher.Shutdown(context.Background()))
+
+	require.Equal(t, requestCount*dataPointsPerRequest, dataPointsCount())
+	receivedMds := sink.AllMetrics()
+	for i := 0; i < len(receivedMds)-1; i++ {
+		_, dataPoints := pdatautil.MetricAndDataPointCount(receivedMds[i])
+		assert.Equal(t, cfg.SendBatchSize, uint32(dataPoints))
+	}
+	// the last batch has the remaining size
+	_, dataPoints := pdatautil.MetricAndDataPointCount(receivedMds[len(receivedMds)-1])
+	assert.Equal(t, (requestCount*dataPointsPerRequest)%int(cfg.SendBatchSize), dataPoints)
+}
+
+func TestBatchMetricProcessor_EnforceBatchSizeMetricsData(t *testing.T) {
+	sink := &exportertest.SinkMetricsExporter{}
+	cfg := createDefaultConfig().(*Config)
+	cfg.SendBatchSize = 128
+	cfg.SendBatchMaxSize = 128
+	creationParams := component.ProcessorCreateParams{Logger: zap.NewNop()}
+	batcher := newBatchMetricsProcessor(creationParams, sink, cfg, telemetry.Basic)
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+
+	requestCount := 100
+	metricsPerRequest := 75
+	dataPointsPerRequest := metricsPerRequest * 2 // Since the int counter uses two datapoints.
+	for requestNum := 0; requestNum < requestCount; requestNum++ {
+		// Metrics backed by OC data, as sent by the Prometheus receiver.
+		ocmds := pdatautil.MetricsToMetricsData(pdatautil.MetricsFromInternalMetrics(testdata.GenerateMetricDataManyMetricsSameResource(metricsPerRequest)))
+		assert.NoError(t, batcher.ConsumeMetrics(context.Background(), pdatautil.MetricsFromMetricsData(ocmds)))
+	}
+
+	dataPointsCount := func() int {
+		count := 0
+		for _, md := range sink.AllMetrics() {
+			_, dataPoints := pdatautil.MetricAndDataPointCount(md)
+			count += dataPoints
+		}
+		return count
+	}
+	assert.Eventually(t, func() bool {
+		return dataPointsCount() >= requestCount*dataPointsPerRequest
+	}, 10*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, batcher.Shutdown(context.Background()))
+
+	// No data point is sent twice.
+	assert.Equal(t, requestCount*dataPointsPerRequest, dataPointsCount())
+	for _, md := range sink.AllMetrics() {
+		_, dataPoints := pdatautil.MetricAndDataPointCount(md)
+		assert.LessOrEqual(t, uint32(dataPoints), cfg.SendBatchMaxSize)
+	}
+}
+
 func TestBatchMetricsProcessor_Timeout(t *testing.T) {
 	cfg := Config{
 		Timeout:       100 * time.Millisecond,
-		SendBatchSize: 100,
+		SendBatchSize: 101,
 	}
 	requestCount := 5
 	metricsPerRequest := 10
@@ -437,6 +565,255 @@ func TestBatchMetricProcessor_Shutdown(t *testing.T) {
 	require.Equal(t, 1, len(sink.AllMetrics()))
 }
 
+func TestBatchLogProcessor_ReceivingData(t *testing.T) {
+	// Instantiate the batch processor with low config values to test data
+	// gets sent through the processor.
+	cfg := Config{
+		Timeout:       200 * time.Millisecond,
+		SendBatchSize: 50,
+	}
+
+	requestCount := 100
+	logsPerRequest := 5
+	sink := &exportertest.SinkLogsExporter{}
+
+	createParams := component.ProcessorCreateParams{Logger: zap.NewNop()}
+	batcher := newBatchLogsProcessor(createParams, sink, &cfg, telemetry.Detailed)
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+
+	logDataSlice := make([]pdata.Logs, 0, requestCount)
+
+	for requestNum := 0; requestNum < requestCount; requestNum++ {
+		ld := testdata.GenerateLogDataManyLogsSameResource(logsPerRequest)
+		logs := ld.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs()
+		for logIndex := 0; logIndex < logsPerRequest; logIndex++ {
+			logs.At(logIndex).SetName(getTestLogName(requestNum, logIndex))
+		}
+		logDataSlice = append(logDataSlice, ld.Clone())
+		assert.NoError(t, batcher.ConsumeLogs(context.Background(), ld))
+	}
+
+	// Added to test case with empty resources sent.
+	ld := testdata.GenerateLogDataEmpty()
+	assert.NoError(t, batcher.ConsumeLogs(context.Background(), ld))
+
+	require.NoError(t, batcher.Shutdown(context.Background()))
+
+	require.Equal(t, requestCount*logsPerRequest, sink.LogRecordsCount())
+	receivedMds := sink.AllLogs()
+	logsReceivedByName := logsReceivedByName(receivedMds)
+	for requestNum := 0; requestNum < requestCount; requestNum++ {
+		logs := logDataSlice[requestNum].ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs()
+		for logIndex := 0; logIndex < logsPerRequest; logIndex++ {
+			require.EqualValues(t,
+				logs.At(logIndex),
+				logsReceivedByName[getTestLogName(requestNum, logIndex)])
+		}
+	}
+}
+
+func TestBatchLogProcessor_BatchSize(t *testing.T) {
+	views := MetricViews(telemetry.Detailed)
+	view.Register(views...)
+	defer view.Unregister(views...)
+
+	// Instantiate the batch processor with low config values to test data
+	// gets sent through the processor.
+	cfg := Config{
+		Timeout:       100 * time.Millisecond,
+		SendBatchSize: 50,
+	}
+
+	requestCount := 100
+	logsPerRequest := 5
+	sink := &exportertest.SinkLogsExporter{}
+
+	createParams := component.ProcessorCreateParams{Logger: zap.NewNop()}
+	batcher := newBatchLogsProcessor(createParams, sink, &cfg, telemetry.Detailed)
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+
+	start := time.Now()
+	size := 0
+	for requestNum := 0; requestNum < requestCount; requestNum++ {
+		ld := testdata.GenerateLogDataManyLogsSameResource(logsPerRequest)
+		size += ld.Size()
+		assert.NoError(t, batcher.ConsumeLogs(context.Background(), ld))
+	}
+	require.NoError(t, batcher.Shutdown(context.Background()))
+
+	elapsed := time.Since(start)
+	require.LessOrEqual(t, elapsed.Nanoseconds(), cfg.Timeout.Nanoseconds())
+
+	expectedBatchesNum := requestCount * logsPerRequest / int(cfg.SendBatchSize)
+	expectedBatchingFactor := int(cfg.SendBatchSize) / logsPerRequest
+
+	require.Equal(t, requestCount*logsPerRequest, sink.LogRecordsCount())
+	receivedMds := sink.AllLogs()
+	require.Equal(t, expectedBatchesNum, len(receivedMds))
+	for _, ld := range receivedMds {
+		require.Equal(t, expectedBatchingFactor, ld.ResourceLogs().Len())
+		for i := 0; i < expectedBatchingFactor; i++ {
+			require.Equal(t, logsPerRequest, ld.ResourceLogs().At(i).InstrumentationLibraryLogs().At(0).Logs().Len())
+		}
+	}
+
+	viewData, err := view.RetrieveData(statBatchSendSize.Name())
+	require.NoError(t, err)
+	assert.Equal(t, 1, len(viewData))
+	distData := viewData[0].Data.(*view.DistributionData)
+	assert.Equal(t, int64(expectedBatchesNum), distData.Count)
+	assert.Equal(t, sink.LogRecordsCount(), int(distData.Sum()))
+	assert.Equal(t, cfg.SendBatchSize, uint32(distData.Min))
+	assert.Equal(t, cfg.SendBatchSize, uint32(distData.Max))
+
+	viewData, err = view.RetrieveData(statBatchSendSizeBytes.Name())
+	require.NoError(t, err)
+	assert.Equal(t, 1, len(viewData))
+	distData = viewData[0].Data.(*view.DistributionData)
+	assert.Equal(t, int64(expectedBatchesNum), distData.Count)
+	assert.Equal(t, size, int(distData.Sum()))
+}
+
+func TestBatchLogProcessor_EnforceBatchSize(t *testing.T) {
+	sink := &exportertest.SinkLogsExporter{}
+	cfg := createDefaultConfig().(*Config)
+	cfg.SendBatchSize = 128
+	cfg.SendBatchMaxSize = 128
+	creationParams := component.ProcessorCreateParams{Logger: zap.NewNop()}
+	batcher := newBatchLogsProcessor(creationParams, sink, cfg, telemetry.Basic)
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+
+	requestCount := 1000
+	logsPerRequest := 150
+	for requestNum := 0; requestNum < requestCount; requestNum++ {
+		ld := testdata.GenerateLogDataManyLogsSameResource(logsPerRequest)
+		logs := ld.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs()
+		for logIndex := 0; logIndex < logsPerRequest; logIndex++ {
+			logs.At(logIndex).SetName(getTestLogName(requestNum, logIndex))
+		}
+		assert.NoError(t, batcher.ConsumeLogs(context.Background(), ld))
+	}
+
+	// wait for all logs to be reported
+	for {
+		if sink.LogRecordsCount() == requestCount*logsPerRequest {
+			break
+		}
+		<-time.After(cfg.Timeout)
+	}
+
+	require.NoError(t, batcher.Shutdown(context.Background()))
+
+	require.Equal(t, requestCount*logsPerRequest, sink.LogRecordsCount())
+	for i := 0; i < len(sink.AllLogs())-1; i++ {
+		assert.Equal(t, cfg.SendBatchSize, uint32(sink.AllLogs()[i].LogRecordCount()))
+	}
+	// the last batch has the remaining size
+	assert.Equal(t, (requestCount*logsPerRequest)%int(cfg.SendBatchSize), sink.AllLogs()[len(sink.AllLogs())-1].LogRecordCount())
+}
+
+func TestBatchLogsProcessor_Timeout(t *testing.T) {
+	cfg := Config{
+		Timeout:       100 * time.Millisecond,
+		SendBatchSize: 100,
+	}
+	requestCount := 5
+	logsPerRequest := 10
+	sink := &exportertest.SinkLogsExporter{}
+
+	createParams := component.ProcessorCreateParams{Logger: zap.NewNop()}
+	batcher := newBatchLogsProcessor(createParams, sink, &cfg, telemetry.Detailed)
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+
+	start := time.Now()
+	for requestNum := 0; requestNum < requestCount; requestNum++ {
+		ld := testdata.GenerateLogDataManyLogsSameResource(logsPerRequest)
+		assert.NoError(t, batcher.ConsumeLogs(context.Background(), ld))
+	}
+
+	// Wait for at least one batch to be sent.
+	for {
+		if sink.LogRecordsCount() != 0 {
+			break
+		}
+		<-time.After(cfg.Timeout)
+	}
+
+	elapsed := time.Since(start)
+	require.LessOrEqual(t, cfg.Timeout.Nanoseconds(), elapsed.Nanoseconds())
+
+	// This should not change the results in the sink, verified by the expectedBatchesNum
+	require.NoError(t, batcher.Shutdown(context.Background()))
+
+	expectedBatchesNum := 1
+	expectedBatchingFactor := 5
+
+	require.Equal(t, requestCount*logsPerRequest, sink.LogRecordsCount())
+	receivedMds := sink.AllLogs()
+	require.Equal(t, expectedBatchesNum, len(receivedMds))
+	for _, ld := range receivedMds {
+		require.Equal(t, expectedBatchingFactor, ld.ResourceLogs().Len())
+		for i := 0; i < expectedBatchingFactor; i++ {
+			require.Equal(t, logsPerRequest, ld.ResourceLogs().At(i).InstrumentationLibraryLogs().At(0).Logs().Len())
+		}
+	}
+}
+
+func TestBatchLogsProcessor_Shutdown(t *testing.T) {
+	cfg := Config{
+		Timeout:       3 * time.Second,
+		SendBatchSize: 1000,
+	}
+	requestCount := 5
+	logsPerRequest := 10
+	sink := &exportertest.SinkLogsExporter{}
+
+	createParams := component.ProcessorCreateParams{Logger: zap.NewNop()}
+	batcher := newBatchLogsProcessor(createParams, sink, &cfg, telemetry.Detailed)
+	require.NoError(t, batcher.Start(context.Background(), componenttest.NewNopHost()))
+
+	for requestNum := 0; requestNum < requestCount; requestNum++ {
+		ld := testdata.GenerateLogDataManyLogsSameResource(logsPerRequest)
+		assert.NoError(t, batcher.ConsumeLogs(context.Background(), ld))
+	}
+
+	require.NoError(t, batcher.Shutdown(context.Background()))
+
+	require.Equal(t, requestCount*logsPerRequest, sink.LogRecordsCount())
+	require.Equal(t, 1, len(sink.AllLogs()))
+}
+
+func getTestLogName(requestNum, index int) string {
+	return fmt.Sprintf("test-log-int-%d-%d", requestNum, index)
+}
+
+func logsReceivedByName(lds []pdata.Logs) map[string]pdata.LogRecord {
+	logsReceivedByName := map[string]pdata.LogRecord{}
+	for i := range lds {
+		ld := lds[i]
+		rms := ld.ResourceLogs()
+		for i := 0; i < rms.Len(); i++ {
+			rm := rms.At(i)
+			if rm.IsNil() {
+				continue
+			}
+			ilms := rm.InstrumentationLibraryLogs()
+			for j := 0; j < ilms.Len(); j++ {
+				ilm := ilms.At(j)
+				if ilm.IsNil() {
+					continue
+				}
+				logs := ilm.Logs()
+				for k := 0; k < logs.Len(); k++ {
+					log := logs.At(k)
+					logsReceivedByName[log.Name()] = log
+				}
+			}
+		}
+	}
+	return logsReceivedByName
+}
+
 func getTestSpanName(requestNum, index int) string {
 	return fmt.Sprintf("test-span-%d-%d", requestNum, index)
 }
@@ -45,4 +45,9 @@ func TestCreateProcessor(t *testing.T) {
 	mp, err := factory.CreateMetricsProcessor(context.Background(), creationParams, nil, cfg)
 	assert.NotNil(t, mp)
 	assert.NoError(t, err, "cannot create metric processor")
+
+	lfactory := factory.(component.LogsProcessorFactory)
+	lp, err := lfactory.CreateLogsProcessor(context.Background(), creationParams, cfg, nil)
+	assert.NotNil(t, lp)
+	assert.NoError(t, err, "cannot create logs processor")
 }
@@ -24,7 +24,16 @@ import (
 	"go.opentelemetry.io/collector/processor"
 )
 
+const (
+	// triggerBatchSize is the trigger tag value of batches sent because they reached the batch size.
+	triggerBatchSize = "batch_size"
+	// triggerTimeout is the trigger tag value of batches sent because of the timeout or at shutdown.
+	triggerTimeout = "timeout"
+)
+
 var (
+	tagTriggerKey, _ = tag.NewKey("trigger")
+
 	statBatchSizeTriggerSend = stats.Int64("batch_size_trigger_send", "Number of times the batch was sent due to a size trigger", stats.UnitDimensionless)
 	statTimeoutTriggerSend   = stats.Int64("timeout_trigger_send", "Number of times the batch was sent due to a timeout trigger", stats.UnitDimensionless)
 	statBatchSendSize        = stats.Int64("batch_send_size", "Number of units in the batch", stats.UnitDimensionless)
@@ -59,7 +68,7 @@ func MetricViews(level telemetry.Level) []*view.View {
 		Name:        statBatchSendSize.Name(),
 		Measure:     statBatchSendSize,
 		Description: statBatchSendSize.Description(),
-		TagKeys:     processorTagKeys,
+		TagKeys:     []tag.Key{processor.TagProcessorNameKey, tagTriggerKey},
 		Aggregation: view.Distribution(10, 25, 50, 75, 100, 250, 500, 750, 1000, 2000, 3000, 4000, 5000, 6000, 7000, 8000, 9000, 10000, 20000, 30000, 50000, 100000),
 	}
 
@@ -0,0 +1,142 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package batchprocessor
+
+import (
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/data"
+)
+
+// splitMetrics removes data points from the input data and returns a new data with the specified
+// number of data points. Metrics which data points are split are copied with the same descriptor,
+// so the resource and instrumentation library grouping is kept in both data.
+func splitMetrics(size int, toSplit data.MetricData) data.MetricData {
+	if _, dataPointCount := toSplit.MetricAndDataPointCount(); dataPointCount <= size {
+		return toSplit
+	}
+	copiedDataPoints := 0
+	result := data.NewMetricData()
+	rms := toSplit.ResourceMetrics()
+	for i := rms.Len() - 1; i >= 0; i-- {
+		rm := rms.At(i)
+		destRm := pdata.NewResourceMetrics()
+		destRm.InitEmpty()
+		rm.Resource().CopyTo(destRm.Resource())
+		result.ResourceMetrics().Append(&destRm)
+
+		for j := rm.InstrumentationLibraryMetrics().Len() - 1; j >= 0; j-- {
+			instMetrics := rm.InstrumentationLibraryMetrics().At(j)
+			destInstMetrics := pdata.NewInstrumentationLibraryMetrics()
+			destInstMetrics.InitEmpty()
+			destRm.InstrumentationLibraryMetrics().Append(&destInstMetrics)
+			instMetrics.InstrumentationLibrary().CopyTo(destInstMetrics.InstrumentationLibrary())
+
+			for k := instMetrics.Metrics().Len() - 1; k >= 0 && copiedDataPoints < size; k-- {
+				metric := instMetrics.Metrics().At(k)
+				if metric.IsNil() {
+					instMetrics.Metrics().Resize(instMetrics.Metrics().Len() - 1)
+					continue
+				}
+				destMetric := pdata.NewMetric()
+				destMetric.InitEmpty()
+				metric.MetricDescriptor().CopyTo(destMetric.MetricDescriptor())
+				destInstMetrics.Metrics().Append(&destMetric)
+
+				copiedDataPoints += splitDataPoints(size-copiedDataPoints, metric, destMetric)
+				if metricDataPointCount(metric) == 0 {
+					// remove metric
+					instMetrics.Metrics().Resize(instMetrics.Metrics().Len() - 1)
+				}
+			}
+			if instMetrics.Metrics().Len() == 0 {
+				rm.InstrumentationLibraryMetrics().Resize(rm.InstrumentationLibraryMetrics().Len() - 1)
+			}
+			if copiedDataPoints == size {
+				return result
+			}
+		}
+		if rm.InstrumentationLibraryMetrics().Len() == 0 {
+			rms.Resize(rms.Len() - 1)
+		}
+	}
+	return result
+}
+
+// splitDataPoints moves up to size data points from the end of the data points of src to dest,
+// and returns the number of moved data points.
+func splitDataPoints(size int, src, dest pdata.Metric) int {
+	moved := splitInt64DataPoints(size, src.Int64DataPoints(), dest.Int64DataPoints())
+	moved += splitDoubleDataPoints(size-moved, src.DoubleDataPoints(), dest.DoubleDataPoints())
+	moved += splitHistogramDataPoints(size-moved, src.HistogramDataPoints(), dest.HistogramDataPoints())
+	moved += splitSummaryDataPoints(size-moved, src.SummaryDataPoints(), dest.SummaryDataPoints())
+	return moved
+}
+
+func splitInt64DataPoints(size int, src, dest pdata.Int64DataPointSlice) int {
+	count := minInt(size, src.Len())
+	start := src.Len() - count
+	dest.Resize(count)
+	for i := 0; i < count; i++ {
+		src.At(start + i).CopyTo(dest.At(i))
+	}
+	src.Resize(start)
+	return count
+}
+
+func splitDoubleDataPoints(size int, src, dest pdata.DoubleDataPointSlice) int {
+	count := minInt(size, src.Len())
+	start := src.Len() - count
+	dest.Resize(count)
+	for i := 0; i < count; i++ {
+		src.At(start + i).CopyTo(dest.At(i))
+	}
+	src.Resize(start)
+	return count
+}
+
+func splitHistogramDataPoints(size int, src, dest pdata.HistogramDataPointSlice) int {
+	count := minInt(size, src.Len())
+	start := src.Len() - count
+	dest.Resize(count)
+	for i := 0; i < count; i++ {
+		src.At(start + i).CopyTo(dest.At(i))
+	}
+	src.Resize(start)
+	return count
+}
+
+func splitSummaryDataPoints(size int, src, dest pdata.SummaryDataPointSlice) int {
+	count := minInt(size, src.Len())
+	start := src.Len() - count
+	dest.Resize(count)
+	for i := 0; i < count; i++ {
+		src.At(start + i).CopyTo(dest.At(i))
+	}
+	src.Resize(start)
+	return count
+}
+
+// metricDataPointCount returns the number of data points of the metric.
+func metricDataPointCount(metric pdata.Metric) int {
+	return metric.Int64DataPoints().Len() + metric.DoubleDataPoints().Len() +
+		metric.HistogramDataPoints().Len() + metric.SummaryDataPoints().Len()
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
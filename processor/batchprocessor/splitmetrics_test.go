@@ -0,0 +1,130 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package batchprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/data"
+	"go.opentelemetry.io/collector/internal/data/testdata"
+)
+
+func TestSplitMetrics_noop(t *testing.T) {
+	md := testdata.GenerateMetricDataManyMetricsSameResource(20)
+	splitSize := 40
+	split := splitMetrics(splitSize, md)
+	assert.Equal(t, md, split)
+
+	md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().Resize(5)
+	assert.EqualValues(t, md, split)
+}
+
+func TestSplitMetrics(t *testing.T) {
+	md := testdata.GenerateMetricDataManyMetricsSameResource(20)
+	metrics := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+	for i := 0; i < metrics.Len(); i++ {
+		metrics.At(i).MetricDescriptor().SetName(getTestMetricName(0, i))
+	}
+	cp := data.NewMetricData()
+	cp.ResourceMetrics().Resize(1)
+	cp.ResourceMetrics().At(0).InstrumentationLibraryMetrics().Resize(1)
+	cp.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().Resize(5)
+	cpMetrics := cp.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+	md.ResourceMetrics().At(0).Resource().CopyTo(
+		cp.ResourceMetrics().At(0).Resource())
+	md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).InstrumentationLibrary().CopyTo(
+		cp.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).InstrumentationLibrary())
+	metrics.At(19).CopyTo(cpMetrics.At(0))
+	metrics.At(18).CopyTo(cpMetrics.At(1))
+	metrics.At(17).CopyTo(cpMetrics.At(2))
+	metrics.At(16).CopyTo(cpMetrics.At(3))
+	metrics.At(15).CopyTo(cpMetrics.At(4))
+
+	// Every metric has two data points.
+	splitSize := 10
+	split := splitMetrics(splitSize, md)
+	assert.Equal(t, 5, split.MetricCount())
+	assert.Equal(t, cp, split)
+	assert.Equal(t, 15, md.MetricCount())
+	assert.Equal(t, "test-metric-int-0-19", split.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0).MetricDescriptor().Name())
+	assert.Equal(t, "test-metric-int-0-15", split.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(4).MetricDescriptor().Name())
+}
+
+func TestSplitMetrics_splitDataPoints(t *testing.T) {
+	md := testdata.GenerateMetricDataManyMetricsSameResource(2)
+	metrics := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+	for i := 0; i < metrics.Len(); i++ {
+		metrics.At(i).MetricDescriptor().SetName(getTestMetricName(0, i))
+	}
+	lastDataPoint := pdata.NewInt64DataPoint()
+	lastDataPoint.InitEmpty()
+	metrics.At(1).Int64DataPoints().At(1).CopyTo(lastDataPoint)
+
+	// The last metric is split, one data point stays in the input data.
+	splitSize := 3
+	split := splitMetrics(splitSize, md)
+	_, splitDataPoints := split.MetricAndDataPointCount()
+	assert.Equal(t, splitSize, splitDataPoints)
+	metricCount, dataPoints := md.MetricAndDataPointCount()
+	assert.Equal(t, 1, metricCount)
+	assert.Equal(t, 1, dataPoints)
+	assert.Equal(t, "test-metric-int-0-0", metrics.At(0).MetricDescriptor().Name())
+	assert.Equal(t, 1, metrics.At(0).Int64DataPoints().Len())
+
+	// Both halves keep the resource, instrumentation library and metric descriptor.
+	require.Equal(t, 1, split.ResourceMetrics().Len())
+	assert.Equal(t, md.ResourceMetrics().At(0).Resource(), split.ResourceMetrics().At(0).Resource())
+	splitMetrics := split.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+	require.Equal(t, 2, splitMetrics.Len())
+	assert.Equal(t, "test-metric-int-0-1", splitMetrics.At(0).MetricDescriptor().Name())
+	assert.Equal(t, 2, splitMetrics.At(0).Int64DataPoints().Len())
+	assert.Equal(t, lastDataPoint, splitMetrics.At(0).Int64DataPoints().At(1))
+	assert.Equal(t, "test-metric-int-0-0", splitMetrics.At(1).MetricDescriptor().Name())
+	assert.Equal(t, 1, splitMetrics.At(1).Int64DataPoints().Len())
+}
+
+func TestSplitMetricsMultipleResourceMetrics(t *testing.T) {
+	md := testdata.GenerateMetricDataManyMetricsSameResource(20)
+	metrics := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+	for i := 0; i < metrics.Len(); i++ {
+		metrics.At(i).MetricDescriptor().SetName(getTestMetricName(0, i))
+	}
+	md.ResourceMetrics().Resize(2)
+	// add second index to resource metrics
+	testdata.GenerateMetricDataManyMetricsSameResource(20).
+		ResourceMetrics().At(0).CopyTo(md.ResourceMetrics().At(1))
+	metrics = md.ResourceMetrics().At(1).InstrumentationLibraryMetrics().At(0).Metrics()
+	for i := 0; i < metrics.Len(); i++ {
+		metrics.At(i).MetricDescriptor().SetName(getTestMetricName(1, i))
+	}
+
+	splitSize := 50
+	split := splitMetrics(splitSize, md)
+	_, splitDataPoints := split.MetricAndDataPointCount()
+	assert.Equal(t, splitSize, splitDataPoints)
+	_, dataPoints := md.MetricAndDataPointCount()
+	assert.Equal(t, 80-splitSize, dataPoints)
+	assert.Equal(t, 1, md.ResourceMetrics().Len())
+	assert.Equal(t, 20, split.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().Len())
+	assert.Equal(t, "test-metric-int-1-19", split.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0).MetricDescriptor().Name())
+	assert.Equal(t, "test-metric-int-1-0", split.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(19).MetricDescriptor().Name())
+	assert.Equal(t, 5, split.ResourceMetrics().At(1).InstrumentationLibraryMetrics().At(0).Metrics().Len())
+	assert.Equal(t, "test-metric-int-0-19", split.ResourceMetrics().At(1).InstrumentationLibraryMetrics().At(0).Metrics().At(0).MetricDescriptor().Name())
+	assert.Equal(t, "test-metric-int-0-15", split.ResourceMetrics().At(1).InstrumentationLibraryMetrics().At(0).Metrics().At(4).MetricDescriptor().Name())
+}
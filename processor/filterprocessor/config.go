@@ -16,13 +16,17 @@ package filterprocessor
 
 import (
 	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/internal/processor/filterlog"
 	"go.opentelemetry.io/collector/internal/processor/filtermetric"
+	"go.opentelemetry.io/collector/internal/processor/filterspan"
 )
 
 // Config defines configuration for Resource processor.
 type Config struct {
 	configmodels.ProcessorSettings `mapstructure:",squash"`
 	Metrics                        MetricFilters `mapstructure:"metrics"`
+	Spans                          SpanFilters   `mapstructure:"spans"`
+	Logs                           LogFilters    `mapstructure:"logs"`
 }
 
 // MetricFilter filters by Metric properties.
@@ -37,3 +41,29 @@ type MetricFilters struct {
 	// If both Include and Exclude are specified, Include filtering occurs first.
 	Exclude *filtermetric.MatchProperties `mapstructure:"exclude"`
 }
+
+// SpanFilters filters by Span properties.
+type SpanFilters struct {
+	// Include match properties describe spans that should be included in the Collector Service pipeline,
+	// all other spans should be dropped from further processing.
+	// If both Include and Exclude are specified, Include filtering occurs first.
+	Include *filterspan.MatchProperties `mapstructure:"include"`
+
+	// Exclude match properties describe spans that should be excluded from the Collector Service pipeline,
+	// all other spans should be included.
+	// If both Include and Exclude are specified, Include filtering occurs first.
+	Exclude *filterspan.MatchProperties `mapstructure:"exclude"`
+}
+
+// LogFilters filters by LogRecord properties.
+type LogFilters struct {
+	// Include match properties describe log records that should be included in the Collector Service pipeline,
+	// all other log records should be dropped from further processing.
+	// If both Include and Exclude are specified, Include filtering occurs first.
+	Include *filterlog.MatchProperties `mapstructure:"include"`
+
+	// Exclude match properties describe log records that should be excluded from the Collector Service pipeline,
+	// all other log records should be included.
+	// If both Include and Exclude are specified, Include filtering occurs first.
+	Exclude *filterlog.MatchProperties `mapstructure:"exclude"`
+}
@@ -24,9 +24,11 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/config/configtest"
+	"go.opentelemetry.io/collector/internal/processor/filterlog"
 	"go.opentelemetry.io/collector/internal/processor/filtermetric"
 	"go.opentelemetry.io/collector/internal/processor/filterset"
 	fsregexp "go.opentelemetry.io/collector/internal/processor/filterset/regexp"
+	"go.opentelemetry.io/collector/internal/processor/filterspan"
 )
 
 // TestLoadingConfigRegexp tests loading testdata/config_strict.yaml
@@ -229,3 +231,62 @@ func TestLoadingConfigRegexp(t *testing.T) {
 		})
 	}
 }
+
+// TestLoadingConfigSpansLogs tests loading testdata/config_spans_logs.yaml
+func TestLoadingConfigSpansLogs(t *testing.T) {
+	factories, err := componenttest.ExampleComponents()
+	assert.Nil(t, err)
+
+	factory := NewFactory()
+	factories.Processors[typeStr] = factory
+	config, err := configtest.LoadConfigFile(t, path.Join(".", "testdata", "config_spans_logs.yaml"), factories)
+
+	assert.Nil(t, err)
+	require.NotNil(t, config)
+
+	assert.Equal(t, &Config{
+		ProcessorSettings: configmodels.ProcessorSettings{
+			NameVal: "filter/spans",
+			TypeVal: typeStr,
+		},
+		Spans: SpanFilters{
+			Include: &filterspan.MatchProperties{
+				Config: filterset.Config{
+					MatchType: filterset.Strict,
+				},
+				Services:  []string{"checkout"},
+				SpanKinds: []string{"server", "client"},
+			},
+			Exclude: &filterspan.MatchProperties{
+				Config: filterset.Config{
+					MatchType: filterset.Regexp,
+				},
+				SpanNames:   []string{"^/health.*"},
+				StatusCodes: []string{"Ok"},
+			},
+		},
+	}, config.Processors["filter/spans"])
+
+	assert.Equal(t, &Config{
+		ProcessorSettings: configmodels.ProcessorSettings{
+			NameVal: "filter/logs",
+			TypeVal: typeStr,
+		},
+		Logs: LogFilters{
+			Include: &filterlog.MatchProperties{
+				Config: filterset.Config{
+					MatchType: filterset.Strict,
+				},
+				MinSeverity: "WARN",
+			},
+			Exclude: &filterlog.MatchProperties{
+				Config: filterset.Config{
+					MatchType: filterset.Strict,
+				},
+				Attributes: []filterspan.Attribute{
+					{Key: "component", Value: "healthcheck"},
+				},
+			},
+		},
+	}, config.Processors["filter/logs"])
+}
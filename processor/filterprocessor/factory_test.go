@@ -52,18 +52,30 @@ func TestCreateDefaultConfig(t *testing.T) {
 
 func TestCreateProcessors(t *testing.T) {
 	tests := []struct {
-		configName string
-		succeed    bool
+		configName   string
+		succeed      bool
+		spansSucceed bool
 	}{
 		{
-			configName: "config_regexp.yaml",
-			succeed:    true,
+			configName:   "config_regexp.yaml",
+			succeed:      true,
+			spansSucceed: true,
 		}, {
-			configName: "config_strict.yaml",
-			succeed:    true,
+			configName:   "config_strict.yaml",
+			succeed:      true,
+			spansSucceed: true,
 		}, {
-			configName: "config_invalid.yaml",
-			succeed:    false,
+			configName:   "config_invalid.yaml",
+			succeed:      false,
+			spansSucceed: true,
+		}, {
+			configName:   "config_spans_logs.yaml",
+			succeed:      true,
+			spansSucceed: true,
+		}, {
+			configName:   "config_spans_logs_invalid.yaml",
+			succeed:      true,
+			spansSucceed: false,
 		},
 	}
 
@@ -85,9 +97,16 @@ func TestCreateProcessors(t *testing.T) {
 					component.ProcessorCreateParams{Logger: zap.NewNop()},
 					exportertest.NewNopTraceExporter(),
 					cfg)
-				// Not implemented error
-				assert.NotNil(t, tErr)
-				assert.Nil(t, tp)
+				assert.Equal(t, test.spansSucceed, tp != nil)
+				assert.Equal(t, test.spansSucceed, tErr == nil)
+
+				lp, lErr := factory.(component.LogsProcessorFactory).CreateLogsProcessor(
+					context.Background(),
+					component.ProcessorCreateParams{Logger: zap.NewNop()},
+					cfg,
+					exportertest.NewNopLogsExporter())
+				assert.NotNil(t, lp)
+				assert.Nil(t, lErr)
 
 				mp, mErr := factory.CreateMetricsProcessor(
 					context.Background(),
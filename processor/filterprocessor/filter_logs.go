@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filterprocessor
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/processor/filterlog"
+)
+
+type filterLogProcessor struct {
+	include filterlog.Matcher
+	exclude filterlog.Matcher
+}
+
+func newFilterLogProcessor(cfg *Config) (*filterLogProcessor, error) {
+	inc, err := filterlog.NewMatcher(cfg.Logs.Include)
+	if err != nil {
+		return nil, err
+	}
+
+	exc, err := filterlog.NewMatcher(cfg.Logs.Exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	return &filterLogProcessor{
+		include: inc,
+		exclude: exc,
+	}, nil
+}
+
+// ProcessLogs filters the given log records based off the filterLogProcessor's filters.
+func (flp *filterLogProcessor) ProcessLogs(_ context.Context, ld pdata.Logs) (pdata.Logs, error) {
+	if flp.include == nil && flp.exclude == nil {
+		return ld, nil
+	}
+
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		if rl.IsNil() {
+			continue
+		}
+		ills := rl.InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			ill := ills.At(j)
+			if ill.IsNil() {
+				continue
+			}
+			logs := ill.Logs()
+			keep := pdata.NewLogSlice()
+			for k := 0; k < logs.Len(); k++ {
+				lr := logs.At(k)
+				if lr.IsNil() {
+					continue
+				}
+				if flp.shouldKeepLogRecord(lr, rl.Resource()) {
+					keep.Append(&lr)
+				}
+			}
+			logs.Resize(0)
+			keep.MoveAndAppendTo(logs)
+		}
+	}
+	return ld, nil
+}
+
+// shouldKeepLogRecord determines whether a log record should be kept based off the filterLogProcessor's filters.
+func (flp *filterLogProcessor) shouldKeepLogRecord(lr pdata.LogRecord, resource pdata.Resource) bool {
+	if flp.include != nil && !flp.include.MatchLogRecord(lr, resource) {
+		return false
+	}
+
+	if flp.exclude != nil && flp.exclude.MatchLogRecord(lr, resource) {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,183 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filterprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	etest "go.opentelemetry.io/collector/exporter/exportertest"
+	otlplogs "go.opentelemetry.io/collector/internal/data/opentelemetry-proto-gen/logs/v1"
+	"go.opentelemetry.io/collector/internal/data/testdata"
+	"go.opentelemetry.io/collector/internal/processor/filterlog"
+	"go.opentelemetry.io/collector/internal/processor/filterset"
+	"go.opentelemetry.io/collector/internal/processor/filterspan"
+)
+
+type testLogRecord struct {
+	name      string
+	severity  otlplogs.SeverityNumber
+	body      string
+	component string
+}
+
+func logsWithRecords(records []testLogRecord) pdata.Logs {
+	ld := pdata.NewLogs()
+	ld.ResourceLogs().Resize(1)
+	rl := ld.ResourceLogs().At(0)
+	rl.InstrumentationLibraryLogs().Resize(1)
+	logs := rl.InstrumentationLibraryLogs().At(0).Logs()
+	logs.Resize(len(records))
+	for i, r := range records {
+		lr := logs.At(i)
+		lr.SetName(r.name)
+		lr.SetSeverityNumber(r.severity)
+		lr.Body().SetStringVal(r.body)
+		lr.Attributes().InsertString("component", r.component)
+	}
+	return ld
+}
+
+func logNames(ld pdata.Logs) []string {
+	var names []string
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		ills := rls.At(i).InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			logs := ills.At(j).Logs()
+			for k := 0; k < logs.Len(); k++ {
+				names = append(names, logs.At(k).Name())
+			}
+		}
+	}
+	return names
+}
+
+func TestFilterLogProcessor(t *testing.T) {
+	inRecords := []testLogRecord{
+		{name: "login", severity: otlplogs.SeverityNumber_INFO, body: "user logged in", component: "auth"},
+		{name: "probe", severity: otlplogs.SeverityNumber_WARN, body: "slow probe", component: "healthcheck"},
+		{name: "payment", severity: otlplogs.SeverityNumber_ERROR, body: "payment failed", component: "billing"},
+	}
+
+	tests := []struct {
+		name     string
+		inc      *filterlog.MatchProperties
+		exc      *filterlog.MatchProperties
+		outNames []string
+	}{
+		{
+			name:     "noFilters",
+			outNames: []string{"login", "probe", "payment"},
+		},
+		{
+			name: "includeMinSeverity",
+			inc: &filterlog.MatchProperties{
+				Config:      filterset.Config{MatchType: filterset.Strict},
+				MinSeverity: "WARN",
+			},
+			outNames: []string{"probe", "payment"},
+		},
+		{
+			name: "includeMinSeverityExcludeAttribute",
+			inc: &filterlog.MatchProperties{
+				Config:      filterset.Config{MatchType: filterset.Strict},
+				MinSeverity: "WARN",
+			},
+			exc: &filterlog.MatchProperties{
+				Config: filterset.Config{MatchType: filterset.Strict},
+				Attributes: []filterspan.Attribute{
+					{Key: "component", Value: "healthcheck"},
+				},
+			},
+			outNames: []string{"payment"},
+		},
+		{
+			name: "excludeBody",
+			exc: &filterlog.MatchProperties{
+				Config: filterset.Config{MatchType: filterset.Regexp},
+				Bodies: []string{"^user "},
+			},
+			outNames: []string{"probe", "payment"},
+		},
+		{
+			name: "excludeExpr",
+			exc: &filterlog.MatchProperties{
+				Expr: `severity_number < 17 && attributes["component"] != "auth"`,
+			},
+			outNames: []string{"login", "payment"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			next := &etest.SinkLogsExporter{}
+			cfg := &Config{
+				ProcessorSettings: configmodels.ProcessorSettings{
+					TypeVal: typeStr,
+					NameVal: typeStr,
+				},
+				Logs: LogFilters{
+					Include: test.inc,
+					Exclude: test.exc,
+				},
+			}
+			fp, err := NewFactory().(component.LogsProcessorFactory).CreateLogsProcessor(
+				context.Background(), component.ProcessorCreateParams{}, cfg, next)
+			require.NoError(t, err)
+			require.NotNil(t, fp)
+			assert.True(t, fp.GetCapabilities().MutatesConsumedData)
+
+			require.NoError(t, fp.ConsumeLogs(context.Background(), logsWithRecords(inRecords)))
+			got := next.AllLogs()
+			require.Len(t, got, 1)
+			assert.Equal(t, test.outNames, logNames(got[0]))
+		})
+	}
+}
+
+func TestFilterLogProcessor_NilEmptyData(t *testing.T) {
+	cfg := &Config{
+		ProcessorSettings: configmodels.ProcessorSettings{
+			TypeVal: typeStr,
+			NameVal: typeStr,
+		},
+		Logs: LogFilters{
+			Include: &filterlog.MatchProperties{
+				Config:   filterset.Config{MatchType: filterset.Strict},
+				LogNames: []string{"logA"},
+			},
+		},
+	}
+	next := &etest.SinkLogsExporter{}
+	fp, err := NewFactory().(component.LogsProcessorFactory).CreateLogsProcessor(
+		context.Background(), component.ProcessorCreateParams{}, cfg, next)
+	require.NoError(t, err)
+
+	for _, ld := range []pdata.Logs{
+		testdata.GenerateLogDataEmpty(),
+		testdata.GenerateLogDataOneEmptyOneNilResourceLogs(),
+		testdata.GenerateLogDataOneEmptyOneNilLogRecord(),
+	} {
+		assert.NoError(t, fp.ConsumeLogs(context.Background(), ld))
+	}
+	assert.Equal(t, 0, next.LogRecordsCount())
+}
@@ -17,8 +17,6 @@ package filterprocessor
 import (
 	"context"
 
-	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
-
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/consumer/pdatautil"
 	"go.opentelemetry.io/collector/internal/processor/filtermetric"
@@ -62,37 +60,129 @@ func createMatcher(mp *filtermetric.MatchProperties) (*filtermetric.Matcher, err
 	return &matcher, nil
 }
 
-// ProcessMetrics filters the given spans based off the filterMetricProcessor's filters.
+// ProcessMetrics filters the given metrics based off the filterMetricProcessor's filters.
+// Metrics are dropped based on their metric level properties, data points are dropped based on the
+// data point properties. Metrics left without data points are dropped.
 func (fmp *filterMetricProcessor) ProcessMetrics(_ context.Context, md pdata.Metrics) (pdata.Metrics, error) {
-	mds := pdatautil.MetricsToMetricsData(md)
-	for i := range mds {
-		if len(mds[i].Metrics) == 0 {
+	if fmp.include == nil && fmp.exclude == nil {
+		return md, nil
+	}
+
+	imd := pdatautil.MetricsToInternalMetrics(md)
+	rms := imd.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		if rm.IsNil() {
 			continue
 		}
-		keep := make([]*metricspb.Metric, 0, len(mds[i].Metrics))
-		for _, m := range mds[i].Metrics {
-			if fmp.shouldKeepMetric(m) {
-				keep = append(keep, m)
+		ilms := rm.InstrumentationLibraryMetrics()
+		for j := 0; j < ilms.Len(); j++ {
+			ilm := ilms.At(j)
+			if ilm.IsNil() {
+				continue
 			}
+			metrics := ilm.Metrics()
+			keep := pdata.NewMetricSlice()
+			for k := 0; k < metrics.Len(); k++ {
+				metric := metrics.At(k)
+				if metric.IsNil() {
+					continue
+				}
+				if fmp.filterMetric(metric, rm.Resource()) {
+					keep.Append(&metric)
+				}
+			}
+			metrics.Resize(0)
+			keep.MoveAndAppendTo(metrics)
 		}
-		mds[i].Metrics = keep
 	}
-	return pdatautil.MetricsFromMetricsData(mds), nil
+	return pdatautil.MetricsFromInternalMetrics(imd), nil
 }
 
-// shouldKeepMetric determines whether a metric should be kept based off the filterMetricProcessor's filters.
-func (fmp *filterMetricProcessor) shouldKeepMetric(metric *metricspb.Metric) bool {
-	if fmp.include != nil {
-		if !fmp.include.MatchMetric(metric) {
-			return false
+// filterMetric removes the data points of the metric that should be dropped, and returns whether the
+// metric should be kept.
+func (fmp *filterMetricProcessor) filterMetric(metric pdata.Metric, resource pdata.Resource) bool {
+	includeMetric := fmp.include == nil || fmp.include.MatchMetric(metric, resource)
+	excludeMetric := fmp.exclude != nil && fmp.exclude.MatchMetric(metric, resource)
+	if !includeMetric || (excludeMetric && !fmp.exclude.HasDataPointProperties()) {
+		return false
+	}
+
+	// Metrics without data points are dropped if they match the configured metric level properties of the
+	// exclude filter, even if it has data point properties. An exclude filter configured only with data
+	// point properties matches all metrics, so it does not drop them.
+	if dataPointCount(metric) == 0 || !fmp.hasDataPointProperties() {
+		return !excludeMetric || !fmp.exclude.HasMetricProperties()
+	}
+
+	idps := metric.Int64DataPoints()
+	keepInt64 := pdata.NewInt64DataPointSlice()
+	for i := 0; i < idps.Len(); i++ {
+		dp := idps.At(i)
+		if !dp.IsNil() && fmp.shouldKeepDataPoint(excludeMetric, func(m *filtermetric.Matcher) bool { return m.MatchInt64DataPoint(dp, metric, resource) }) {
+			keepInt64.Append(&dp)
+		}
+	}
+	idps.Resize(0)
+	keepInt64.MoveAndAppendTo(idps)
+
+	ddps := metric.DoubleDataPoints()
+	keepDouble := pdata.NewDoubleDataPointSlice()
+	for i := 0; i < ddps.Len(); i++ {
+		dp := ddps.At(i)
+		if !dp.IsNil() && fmp.shouldKeepDataPoint(excludeMetric, func(m *filtermetric.Matcher) bool { return m.MatchDoubleDataPoint(dp, metric, resource) }) {
+			keepDouble.Append(&dp)
+		}
+	}
+	ddps.Resize(0)
+	keepDouble.MoveAndAppendTo(ddps)
+
+	hdps := metric.HistogramDataPoints()
+	keepHistogram := pdata.NewHistogramDataPointSlice()
+	for i := 0; i < hdps.Len(); i++ {
+		dp := hdps.At(i)
+		if !dp.IsNil() && fmp.shouldKeepDataPoint(excludeMetric, func(m *filtermetric.Matcher) bool { return m.MatchHistogramDataPoint(dp, metric, resource) }) {
+			keepHistogram.Append(&dp)
 		}
 	}
+	hdps.Resize(0)
+	keepHistogram.MoveAndAppendTo(hdps)
 
-	if fmp.exclude != nil {
-		if fmp.exclude.MatchMetric(metric) {
-			return false
+	sdps := metric.SummaryDataPoints()
+	keepSummary := pdata.NewSummaryDataPointSlice()
+	for i := 0; i < sdps.Len(); i++ {
+		dp := sdps.At(i)
+		if !dp.IsNil() && fmp.shouldKeepDataPoint(excludeMetric, func(m *filtermetric.Matcher) bool { return m.MatchSummaryDataPoint(dp, metric, resource) }) {
+			keepSummary.Append(&dp)
 		}
 	}
+	sdps.Resize(0)
+	keepSummary.MoveAndAppendTo(sdps)
+
+	return dataPointCount(metric) > 0
+}
+
+// shouldKeepDataPoint determines whether a data point should be kept based off the filterMetricProcessor's
+// filters. The metric level properties are already matched, excludeMetric reports whether the metric matched
+// the exclude filter.
+func (fmp *filterMetricProcessor) shouldKeepDataPoint(excludeMetric bool, match func(m *filtermetric.Matcher) bool) bool {
+	if fmp.include != nil && !match(fmp.include) {
+		return false
+	}
+
+	if excludeMetric && match(fmp.exclude) {
+		return false
+	}
 
 	return true
 }
+
+func (fmp *filterMetricProcessor) hasDataPointProperties() bool {
+	return (fmp.include != nil && fmp.include.HasDataPointProperties()) ||
+		(fmp.exclude != nil && fmp.exclude.HasDataPointProperties())
+}
+
+func dataPointCount(metric pdata.Metric) int {
+	return metric.Int64DataPoints().Len() + metric.DoubleDataPoints().Len() +
+		metric.HistogramDataPoints().Len() + metric.SummaryDataPoints().Len()
+}
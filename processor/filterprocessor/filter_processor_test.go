@@ -25,10 +25,14 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/consumer/consumerdata"
+	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/consumer/pdatautil"
 	etest "go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/internal/data"
 	"go.opentelemetry.io/collector/internal/processor/filtermetric"
 	"go.opentelemetry.io/collector/internal/processor/filterset"
+	"go.opentelemetry.io/collector/internal/processor/filterspan"
+	"go.opentelemetry.io/collector/translator/conventions"
 )
 
 type metricNameTest struct {
@@ -144,8 +148,8 @@ var (
 				},
 			},
 			inMN: [][]*metricspb.Metric{nil, metricsWithName(inMetricNames), {}},
+			// Batches without metrics are dropped when converted to the internal metrics representation.
 			outMN: [][]string{
-				{},
 				{
 					"full_name_match",
 					"prefix/test/match",
@@ -159,7 +163,6 @@ var (
 					"full/name/match",
 					"full_name_match",
 				},
-				{},
 			},
 		},
 		{
@@ -206,7 +209,7 @@ func TestFilterMetricProcessor(t *testing.T) {
 			assert.Nil(t, err)
 
 			caps := fmp.GetCapabilities()
-			assert.Equal(t, false, caps.MutatesConsumedData)
+			assert.Equal(t, true, caps.MutatesConsumedData)
 			ctx := context.Background()
 			assert.NoError(t, fmp.Start(ctx, nil))
 
@@ -236,6 +239,231 @@ func TestFilterMetricProcessor(t *testing.T) {
 	}
 }
 
+// requestsMetrics returns one "http_requests_total" metric per service, with one data point per status.
+func requestsMetrics(services []string, statuses []string) data.MetricData {
+	md := data.NewMetricData()
+	md.ResourceMetrics().Resize(len(services))
+	for i, service := range services {
+		rm := md.ResourceMetrics().At(i)
+		rm.Resource().InitEmpty()
+		rm.Resource().Attributes().InsertString(conventions.AttributeServiceName, service)
+		rm.InstrumentationLibraryMetrics().Resize(1)
+		metrics := rm.InstrumentationLibraryMetrics().At(0).Metrics()
+		metrics.Resize(1)
+		metrics.At(0).MetricDescriptor().InitEmpty()
+		metrics.At(0).MetricDescriptor().SetName("http_requests_total")
+		metrics.At(0).MetricDescriptor().SetType(pdata.MetricTypeMonotonicInt64)
+		dps := metrics.At(0).Int64DataPoints()
+		dps.Resize(len(statuses))
+		for j, status := range statuses {
+			dps.At(j).LabelsMap().InitFromMap(map[string]string{"status": status})
+			dps.At(j).SetValue(int64(j))
+		}
+	}
+	return md
+}
+
+// seriesByService returns the status label of the data points for each service.
+func seriesByService(md data.MetricData) map[string][]string {
+	series := map[string][]string{}
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		service, _ := rms.At(i).Resource().Attributes().Get(conventions.AttributeServiceName)
+		ilms := rms.At(i).InstrumentationLibraryMetrics()
+		for j := 0; j < ilms.Len(); j++ {
+			metrics := ilms.At(j).Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				dps := metrics.At(k).Int64DataPoints()
+				for l := 0; l < dps.Len(); l++ {
+					status, _ := dps.At(l).LabelsMap().Get("status")
+					series[service.StringVal()] = append(series[service.StringVal()], status.Value())
+				}
+			}
+		}
+	}
+	return series
+}
+
+func TestFilterMetricProcessor_DataPoints(t *testing.T) {
+	min := 1.0
+	tests := []struct {
+		name string
+		inc  *filtermetric.MatchProperties
+		exc  *filtermetric.MatchProperties
+		out  map[string][]string
+	}{
+		{
+			name: "excludeStatusFromOneService",
+			exc: &filtermetric.MatchProperties{
+				Config:      filterset.Config{MatchType: filterset.Strict},
+				MetricNames: []string{"http_requests_total"},
+				ResourceAttributes: []filterspan.Attribute{
+					{Key: conventions.AttributeServiceName, Value: "noisy"},
+				},
+				Labels: []filtermetric.Label{{Key: "status", Value: "200"}},
+			},
+			out: map[string][]string{
+				"cart":  {"200", "404", "500"},
+				"noisy": {"404", "500"},
+			},
+		},
+		{
+			name: "includeLabelRegexp",
+			inc: &filtermetric.MatchProperties{
+				Config: filterset.Config{MatchType: filterset.Regexp},
+				Labels: []filtermetric.Label{{Key: "status", Value: "^5.."}},
+			},
+			out: map[string][]string{
+				"cart":  {"500"},
+				"noisy": {"500"},
+			},
+		},
+		{
+			name: "includeValueRangeExcludeService",
+			inc: &filtermetric.MatchProperties{
+				Config:     filterset.Config{MatchType: filterset.Strict},
+				ValueRange: &filtermetric.ValueRange{Min: &min},
+			},
+			exc: &filtermetric.MatchProperties{
+				Config: filterset.Config{MatchType: filterset.Strict},
+				ResourceAttributes: []filterspan.Attribute{
+					{Key: conventions.AttributeServiceName, Value: "noisy"},
+				},
+			},
+			out: map[string][]string{
+				"cart": {"404", "500"},
+			},
+		},
+		{
+			name: "excludeMetricType",
+			exc: &filtermetric.MatchProperties{
+				Config:      filterset.Config{MatchType: filterset.Strict},
+				MetricTypes: []string{"MONOTONIC_INT64"},
+			},
+			out: map[string][]string{},
+		},
+		{
+			name: "excludeExpr",
+			exc: &filtermetric.MatchProperties{
+				Expr: `resource["service.name"] == "noisy" && attributes["status"] != "500"`,
+			},
+			out: map[string][]string{
+				"cart":  {"200", "404", "500"},
+				"noisy": {"500"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			next := &etest.SinkMetricsExporter{}
+			cfg := &Config{
+				ProcessorSettings: configmodels.ProcessorSettings{
+					TypeVal: typeStr,
+					NameVal: typeStr,
+				},
+				Metrics: MetricFilters{
+					Include: test.inc,
+					Exclude: test.exc,
+				},
+			}
+			fmp, err := NewFactory().CreateMetricsProcessor(context.Background(), component.ProcessorCreateParams{}, next, cfg)
+			require.NoError(t, err)
+
+			md := requestsMetrics([]string{"cart", "noisy"}, []string{"200", "404", "500"})
+			require.NoError(t, fmp.ConsumeMetrics(context.Background(), pdatautil.MetricsFromInternalMetrics(md)))
+			got := next.AllMetrics()
+			require.Len(t, got, 1)
+			assert.Equal(t, test.out, seriesByService(pdatautil.MetricsToInternalMetrics(got[0])))
+		})
+	}
+}
+
+func TestFilterMetricProcessor_MetricsWithoutDataPoints(t *testing.T) {
+	next := &etest.SinkMetricsExporter{}
+	cfg := &Config{
+		ProcessorSettings: configmodels.ProcessorSettings{
+			TypeVal: typeStr,
+			NameVal: typeStr,
+		},
+		Metrics: MetricFilters{
+			Exclude: &filtermetric.MatchProperties{
+				Config:      filterset.Config{MatchType: filterset.Strict},
+				MetricNames: []string{"http_requests_total"},
+				ResourceAttributes: []filterspan.Attribute{
+					{Key: conventions.AttributeServiceName, Value: "noisy"},
+				},
+				Labels: []filtermetric.Label{{Key: "status", Value: "200"}},
+			},
+		},
+	}
+	fmp, err := NewFactory().CreateMetricsProcessor(context.Background(), component.ProcessorCreateParams{}, next, cfg)
+	require.NoError(t, err)
+
+	md := requestsMetrics([]string{"cart", "noisy"}, nil)
+	require.NoError(t, fmp.ConsumeMetrics(context.Background(), pdatautil.MetricsFromInternalMetrics(md)))
+	got := next.AllMetrics()
+	require.Len(t, got, 1)
+	// A metric without data points is dropped if it matches the metric level properties of the
+	// exclude filter, the data point properties cannot be matched.
+	rms := pdatautil.MetricsToInternalMetrics(got[0]).ResourceMetrics()
+	require.Equal(t, 2, rms.Len())
+	assert.Equal(t, 1, rms.At(0).InstrumentationLibraryMetrics().At(0).Metrics().Len())
+	assert.Equal(t, 0, rms.At(1).InstrumentationLibraryMetrics().At(0).Metrics().Len())
+}
+
+func TestFilterMetricProcessor_MetricsWithoutDataPointsDataPointExclude(t *testing.T) {
+	min := 100.0
+	tests := []struct {
+		name    string
+		exclude *filtermetric.MatchProperties
+	}{
+		{
+			name:    "expr",
+			exclude: &filtermetric.MatchProperties{Expr: `resource["service.name"] == "noisy" && attributes["status"] == "200"`},
+		},
+		{
+			name: "labels",
+			exclude: &filtermetric.MatchProperties{
+				Config: filterset.Config{MatchType: filterset.Strict},
+				Labels: []filtermetric.Label{{Key: "status", Value: "200"}},
+			},
+		},
+		{
+			name: "valueRange",
+			exclude: &filtermetric.MatchProperties{
+				Config:     filterset.Config{MatchType: filterset.Strict},
+				ValueRange: &filtermetric.ValueRange{Min: &min},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			next := &etest.SinkMetricsExporter{}
+			cfg := &Config{
+				ProcessorSettings: configmodels.ProcessorSettings{
+					TypeVal: typeStr,
+					NameVal: typeStr,
+				},
+				Metrics: MetricFilters{Exclude: test.exclude},
+			}
+			fmp, err := NewFactory().CreateMetricsProcessor(context.Background(), component.ProcessorCreateParams{}, next, cfg)
+			require.NoError(t, err)
+
+			md := requestsMetrics([]string{"cart", "noisy"}, nil)
+			require.NoError(t, fmp.ConsumeMetrics(context.Background(), pdatautil.MetricsFromInternalMetrics(md)))
+			got := next.AllMetrics()
+			require.Len(t, got, 1)
+			// The exclude filter has no metric level properties, so metrics without data points are kept.
+			rms := pdatautil.MetricsToInternalMetrics(got[0]).ResourceMetrics()
+			require.Equal(t, 2, rms.Len())
+			assert.Equal(t, 1, rms.At(0).InstrumentationLibraryMetrics().At(0).Metrics().Len())
+			assert.Equal(t, 1, rms.At(1).InstrumentationLibraryMetrics().At(0).Metrics().Len())
+		})
+	}
+}
+
 func BenchmarkFilter_MetricNames(b *testing.B) {
 	// runs 1000 metrics through a filterprocessor with both include and exclude filters.
 	stressTest := metricNameTest{
@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filterprocessor
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/processor/filterspan"
+)
+
+type filterSpanProcessor struct {
+	include filterspan.Matcher
+	exclude filterspan.Matcher
+}
+
+func newFilterSpanProcessor(cfg *Config) (*filterSpanProcessor, error) {
+	inc, err := filterspan.NewMatcher(cfg.Spans.Include)
+	if err != nil {
+		return nil, err
+	}
+
+	exc, err := filterspan.NewMatcher(cfg.Spans.Exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	return &filterSpanProcessor{
+		include: inc,
+		exclude: exc,
+	}, nil
+}
+
+// ProcessTraces filters the given spans based off the filterSpanProcessor's filters.
+func (fsp *filterSpanProcessor) ProcessTraces(_ context.Context, td pdata.Traces) (pdata.Traces, error) {
+	if fsp.include == nil && fsp.exclude == nil {
+		return td, nil
+	}
+
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		if rs.IsNil() {
+			continue
+		}
+		ilss := rs.InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			ils := ilss.At(j)
+			if ils.IsNil() {
+				continue
+			}
+			spans := ils.Spans()
+			keep := pdata.NewSpanSlice()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				if span.IsNil() {
+					continue
+				}
+				if fsp.shouldKeepSpan(span, rs.Resource()) {
+					keep.Append(&span)
+				}
+			}
+			spans.Resize(0)
+			keep.MoveAndAppendTo(spans)
+		}
+	}
+	return td, nil
+}
+
+// shouldKeepSpan determines whether a span should be kept based off the filterSpanProcessor's filters.
+func (fsp *filterSpanProcessor) shouldKeepSpan(span pdata.Span, resource pdata.Resource) bool {
+	if fsp.include != nil && !fsp.include.MatchSpan(span, resource) {
+		return false
+	}
+
+	if fsp.exclude != nil && fsp.exclude.MatchSpan(span, resource) {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,184 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filterprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	etest "go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/internal/data/testdata"
+	"go.opentelemetry.io/collector/internal/processor/filterset"
+	"go.opentelemetry.io/collector/internal/processor/filterspan"
+	"go.opentelemetry.io/collector/translator/conventions"
+)
+
+type testSpan struct {
+	service string
+	name    string
+	kind    pdata.SpanKind
+}
+
+func tracesWithSpans(spans []testSpan) pdata.Traces {
+	td := pdata.NewTraces()
+	td.ResourceSpans().Resize(len(spans))
+	for i, s := range spans {
+		rs := td.ResourceSpans().At(i)
+		rs.Resource().InitEmpty()
+		rs.Resource().Attributes().InsertString(conventions.AttributeServiceName, s.service)
+		rs.InstrumentationLibrarySpans().Resize(1)
+		ss := rs.InstrumentationLibrarySpans().At(0).Spans()
+		ss.Resize(1)
+		ss.At(0).SetName(s.name)
+		ss.At(0).SetKind(s.kind)
+	}
+	return td
+}
+
+func spanNames(td pdata.Traces) []string {
+	var names []string
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		ilss := rss.At(i).InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				names = append(names, spans.At(k).Name())
+			}
+		}
+	}
+	return names
+}
+
+func TestFilterSpanProcessor(t *testing.T) {
+	inSpans := []testSpan{
+		{service: "checkout", name: "/cart", kind: pdata.SpanKindSERVER},
+		{service: "checkout", name: "/health", kind: pdata.SpanKindSERVER},
+		{service: "checkout", name: "db.query", kind: pdata.SpanKindCLIENT},
+		{service: "frontend", name: "/cart", kind: pdata.SpanKindSERVER},
+	}
+
+	tests := []struct {
+		name     string
+		inc      *filterspan.MatchProperties
+		exc      *filterspan.MatchProperties
+		outNames []string
+	}{
+		{
+			name:     "noFilters",
+			outNames: []string{"/cart", "/health", "db.query", "/cart"},
+		},
+		{
+			name: "includeService",
+			inc: &filterspan.MatchProperties{
+				Config:   filterset.Config{MatchType: filterset.Strict},
+				Services: []string{"checkout"},
+			},
+			outNames: []string{"/cart", "/health", "db.query"},
+		},
+		{
+			name: "includeServiceExcludeName",
+			inc: &filterspan.MatchProperties{
+				Config:   filterset.Config{MatchType: filterset.Strict},
+				Services: []string{"checkout"},
+			},
+			exc: &filterspan.MatchProperties{
+				Config:    filterset.Config{MatchType: filterset.Regexp},
+				SpanNames: []string{"^/health"},
+			},
+			outNames: []string{"/cart", "db.query"},
+		},
+		{
+			name: "excludeKind",
+			exc: &filterspan.MatchProperties{
+				Config:    filterset.Config{MatchType: filterset.Strict},
+				SpanKinds: []string{"client"},
+			},
+			outNames: []string{"/cart", "/health", "/cart"},
+		},
+		{
+			name: "excludeAll",
+			exc: &filterspan.MatchProperties{
+				Config:      filterset.Config{MatchType: filterset.Strict},
+				StatusCodes: []string{"Ok"},
+			},
+		},
+		{
+			name: "includeExpr",
+			inc: &filterspan.MatchProperties{
+				Expr: `resource["service.name"] == "checkout" && kind == "SERVER" && name != "/health"`,
+			},
+			outNames: []string{"/cart"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			next := &etest.SinkTraceExporter{}
+			cfg := &Config{
+				ProcessorSettings: configmodels.ProcessorSettings{
+					TypeVal: typeStr,
+					NameVal: typeStr,
+				},
+				Spans: SpanFilters{
+					Include: test.inc,
+					Exclude: test.exc,
+				},
+			}
+			fp, err := NewFactory().CreateTraceProcessor(context.Background(), component.ProcessorCreateParams{}, next, cfg)
+			require.NoError(t, err)
+			require.NotNil(t, fp)
+			assert.True(t, fp.GetCapabilities().MutatesConsumedData)
+
+			require.NoError(t, fp.ConsumeTraces(context.Background(), tracesWithSpans(inSpans)))
+			got := next.AllTraces()
+			require.Len(t, got, 1)
+			assert.Equal(t, test.outNames, spanNames(got[0]))
+		})
+	}
+}
+
+func TestFilterSpanProcessor_NilEmptyData(t *testing.T) {
+	cfg := &Config{
+		ProcessorSettings: configmodels.ProcessorSettings{
+			TypeVal: typeStr,
+			NameVal: typeStr,
+		},
+		Spans: SpanFilters{
+			Include: &filterspan.MatchProperties{
+				Config:    filterset.Config{MatchType: filterset.Strict},
+				SpanNames: []string{"operationA"},
+			},
+		},
+	}
+	next := &etest.SinkTraceExporter{}
+	fp, err := NewFactory().CreateTraceProcessor(context.Background(), component.ProcessorCreateParams{}, next, cfg)
+	require.NoError(t, err)
+
+	for _, td := range []pdata.Traces{
+		testdata.GenerateTraceDataEmpty(),
+		testdata.GenerateTraceDataOneEmptyOneNilResourceSpans(),
+		testdata.GenerateTraceDataOneEmptyOneNilInstrumentationLibrary(),
+	} {
+		assert.NoError(t, fp.ConsumeTraces(context.Background(), td))
+	}
+	assert.Equal(t, 0, next.SpansCount())
+}
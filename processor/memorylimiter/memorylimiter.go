@@ -26,6 +26,7 @@ import (
 
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/consumer/pdatautil"
+	"go.opentelemetry.io/collector/internal/componentstatus"
 	"go.opentelemetry.io/collector/obsreport"
 	"go.opentelemetry.io/collector/processor"
 )
@@ -214,9 +215,13 @@ func (ml *memoryLimiter) shouldForceDrop(ms *runtime.MemStats) bool {
 
 func (ml *memoryLimiter) memLimiting(ms *runtime.MemStats) {
 	if !ml.shouldForceDrop(ms) {
-		atomic.StoreInt64(&ml.forceDrop, 0)
+		if atomic.SwapInt64(&ml.forceDrop, 0) != 0 {
+			componentstatus.SetStatus(componentstatus.KindProcessor, ml.procName, nil)
+		}
 	} else {
-		atomic.StoreInt64(&ml.forceDrop, 1)
+		if atomic.SwapInt64(&ml.forceDrop, 1) == 0 {
+			componentstatus.SetStatus(componentstatus.KindProcessor, ml.procName, errForcedDrop)
+		}
 		// Force a GC at this point and see if this is enough to get to
 		// the desired level.
 		runtime.GC()
@@ -29,6 +29,7 @@ import (
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/consumer/pdatautil"
 	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/internal/componentstatus"
 	"go.opentelemetry.io/collector/internal/data"
 	"go.opentelemetry.io/collector/processor/processorhelper"
 )
@@ -297,3 +298,36 @@ func TestLogMemoryPressureResponse(t *testing.T) {
 	ml.memCheck()
 	assert.Equal(t, errForcedDrop, lp.ConsumeLogs(ctx, ld))
 }
+
+func TestMemoryPressureComponentStatus(t *testing.T) {
+	componentstatus.Reset()
+	defer componentstatus.Reset()
+
+	var currentMemAlloc uint64
+	ml := &memoryLimiter{
+		memAllocLimit: 1024,
+		procName:      typeStr,
+		readMemStatsFn: func(ms *runtime.MemStats) {
+			ms.Alloc = currentMemAlloc
+		},
+	}
+
+	currentMemAlloc = 800
+	ml.memCheck()
+	assert.Empty(t, componentstatus.Snapshot())
+
+	currentMemAlloc = 1800
+	ml.memCheck()
+	snapshot := componentstatus.Snapshot()
+	require.Len(t, snapshot, 1)
+	assert.Equal(t, componentstatus.KindProcessor, snapshot[0].Kind)
+	assert.Equal(t, typeStr, snapshot[0].Name)
+	assert.True(t, snapshot[0].Failing)
+	assert.Equal(t, errForcedDrop, snapshot[0].LastError)
+
+	currentMemAlloc = 800
+	ml.memCheck()
+	snapshot = componentstatus.Snapshot()
+	require.Len(t, snapshot, 1)
+	assert.False(t, snapshot[0].Failing)
+}
@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routingprocessor
+
+import (
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+// AttributeSource indicates where the attribute used for routing is read from.
+type AttributeSource string
+
+const (
+	// ContextAttributeSource reads the attribute from the metadata of the incoming request,
+	// e.g. a gRPC or HTTP header.
+	ContextAttributeSource AttributeSource = "context"
+	// ResourceAttributeSource reads the attribute from the resource of the data.
+	ResourceAttributeSource AttributeSource = "resource"
+)
+
+// Config defines configuration for the Routing processor.
+type Config struct {
+	configmodels.ProcessorSettings `mapstructure:",squash"`
+
+	// DefaultExporters contains the list of exporters to use when no route matches the value
+	// of the attribute. Data not matching any route is dropped if this list is empty.
+	// Optional.
+	DefaultExporters []string `mapstructure:"default_exporters"`
+
+	// AttributeSource defines where the attribute is read from, either "context" (the default)
+	// or "resource".
+	// Optional.
+	AttributeSource AttributeSource `mapstructure:"attribute_source"`
+
+	// FromAttribute contains the attribute name to look up the route value, e.g. "X-Tenant".
+	// Header names are case insensitive when AttributeSource is "context".
+	// Required.
+	FromAttribute string `mapstructure:"from_attribute"`
+
+	// Table contains the routes, the exporters of the route whose value is equal to the value
+	// of the attribute are used.
+	// Required.
+	Table []RoutingTableItem `mapstructure:"table"`
+}
+
+// RoutingTableItem specifies how data should be routed to the different exporters.
+type RoutingTableItem struct {
+	// Value represents a possible value for the attribute, which should route data to the
+	// given exporters.
+	Value string `mapstructure:"value"`
+
+	// Exporters contains the list of exporters the data is routed to when the attribute
+	// has the value of this route.
+	Exporters []string `mapstructure:"exporters"`
+}
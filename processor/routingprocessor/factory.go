@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routingprocessor
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+const (
+	// The value of "type" key in configuration.
+	typeStr = "routing"
+)
+
+// NewFactory returns a new factory for the Routing processor.
+func NewFactory() component.ProcessorFactory {
+	return processorhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		processorhelper.WithTraces(createTraceProcessor),
+		processorhelper.WithMetrics(createMetricsProcessor),
+		processorhelper.WithLogs(createLogsProcessor))
+}
+
+func createDefaultConfig() configmodels.Processor {
+	return &Config{
+		ProcessorSettings: configmodels.ProcessorSettings{
+			TypeVal: typeStr,
+			NameVal: typeStr,
+		},
+		AttributeSource: ContextAttributeSource,
+	}
+}
+
+// The next consumers of the routing processor are not used, the data is sent to the exporters
+// of the matching route instead.
+
+func createTraceProcessor(
+	_ context.Context,
+	params component.ProcessorCreateParams,
+	cfg configmodels.Processor,
+	_ consumer.TraceConsumer,
+) (component.TraceProcessor, error) {
+	r, err := newRouter(params.Logger, cfg.(*Config))
+	if err != nil {
+		return nil, err
+	}
+	return &tracesProcessor{router: r}, nil
+}
+
+func createMetricsProcessor(
+	_ context.Context,
+	params component.ProcessorCreateParams,
+	cfg configmodels.Processor,
+	_ consumer.MetricsConsumer,
+) (component.MetricsProcessor, error) {
+	r, err := newRouter(params.Logger, cfg.(*Config))
+	if err != nil {
+		return nil, err
+	}
+	return &metricsProcessor{router: r}, nil
+}
+
+func createLogsProcessor(
+	_ context.Context,
+	params component.ProcessorCreateParams,
+	cfg configmodels.Processor,
+	_ consumer.LogsConsumer,
+) (component.LogsProcessor, error) {
+	r, err := newRouter(params.Logger, cfg.(*Config))
+	if err != nil {
+		return nil, err
+	}
+	return &logsProcessor{router: r}, nil
+}
@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routingprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configcheck"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+
+	cfg := factory.CreateDefaultConfig()
+	assert.NotNil(t, cfg, "failed to create default config")
+	assert.NoError(t, configcheck.ValidateConfig(cfg))
+}
+
+func TestCreateProcessor(t *testing.T) {
+	factory := NewFactory()
+
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.FromAttribute = "X-Tenant"
+	cfg.Table = []RoutingTableItem{{Value: "acme", Exporters: []string{"otlp"}}}
+
+	creationParams := component.ProcessorCreateParams{Logger: zap.NewNop()}
+	tp, err := factory.CreateTraceProcessor(context.Background(), creationParams, exportertest.NewNopTraceExporter(), cfg)
+	assert.NoError(t, err)
+	assert.NotNil(t, tp)
+
+	mp, err := factory.CreateMetricsProcessor(context.Background(), creationParams, exportertest.NewNopMetricsExporter(), cfg)
+	assert.NoError(t, err)
+	assert.NotNil(t, mp)
+
+	lp, err := factory.(component.LogsProcessorFactory).CreateLogsProcessor(context.Background(), creationParams, cfg, exportertest.NewNopLogsExporter())
+	assert.NoError(t, err)
+	assert.NotNil(t, lp)
+}
+
+func TestCreateProcessorInvalidConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(cfg *Config)
+		errMsg string
+	}{
+		{
+			name:   "no from_attribute",
+			modify: func(cfg *Config) { cfg.FromAttribute = "" },
+			errMsg: errNoFromAttribute.Error(),
+		},
+		{
+			name:   "unknown attribute_source",
+			modify: func(cfg *Config) { cfg.AttributeSource = "span" },
+			errMsg: `unknown attribute_source "span", must be "context" or "resource"`,
+		},
+		{
+			name:   "no table",
+			modify: func(cfg *Config) { cfg.Table = nil },
+			errMsg: errNoRoutingTable.Error(),
+		},
+		{
+			name:   "route without value",
+			modify: func(cfg *Config) { cfg.Table[0].Value = "" },
+			errMsg: errRouteWithoutValue.Error(),
+		},
+		{
+			name:   "route without exporters",
+			modify: func(cfg *Config) { cfg.Table[0].Exporters = nil },
+			errMsg: errRouteWithoutExporters.Error(),
+		},
+		{
+			name: "duplicate route",
+			modify: func(cfg *Config) {
+				cfg.Table = append(cfg.Table, RoutingTableItem{Value: "acme", Exporters: []string{"otlp/2"}})
+			},
+			errMsg: `duplicate route for the value "acme"`,
+		},
+	}
+
+	factory := NewFactory()
+	creationParams := component.ProcessorCreateParams{Logger: zap.NewNop()}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := factory.CreateDefaultConfig().(*Config)
+			cfg.FromAttribute = "X-Tenant"
+			cfg.Table = []RoutingTableItem{{Value: "acme", Exporters: []string{"otlp"}}}
+			tt.modify(cfg)
+
+			tp, err := factory.CreateTraceProcessor(context.Background(), creationParams, exportertest.NewNopTraceExporter(), cfg)
+			require.EqualError(t, err, tt.errMsg)
+			assert.Nil(t, tp)
+		})
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routingprocessor
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/processor"
+)
+
+type logsProcessor struct {
+	*router
+
+	// routes maps the values of the routing table to the index of their consumer.
+	routes map[string]int
+	// consumers contains the fan out of the exporters of every route, the first one being
+	// the default route.
+	consumers []consumer.LogsConsumer
+}
+
+var _ component.LogsProcessor = (*logsProcessor)(nil)
+
+// Start resolves the exporters of the routes, which are only known once the pipelines are built.
+func (lp *logsProcessor) Start(_ context.Context, host component.Host) error {
+	routes, err := lp.resolveRoutes(host, configmodels.LogsDataType,
+		func(names []string, exporters []component.Exporter) error {
+			lcs := make([]consumer.LogsConsumer, 0, len(exporters))
+			for i, exp := range exporters {
+				lc, ok := exp.(consumer.LogsConsumer)
+				if !ok {
+					return lp.unsupportedExporterError(names[i], configmodels.LogsDataType)
+				}
+				lcs = append(lcs, lc)
+			}
+			lp.consumers = append(lp.consumers, processor.NewLogFanOutConnector(lcs))
+			return nil
+		})
+	lp.routes = routes
+	return err
+}
+
+// Shutdown is invoked during service shutdown.
+func (lp *logsProcessor) Shutdown(context.Context) error {
+	return nil
+}
+
+// GetCapabilities returns the Capabilities assigned to this processor.
+func (lp *logsProcessor) GetCapabilities() component.ProcessorCapabilities {
+	return component.ProcessorCapabilities{MutatesConsumedData: false}
+}
+
+// ConsumeLogs sends the logs to the exporters of the route matching the value of the
+// routing attribute.
+func (lp *logsProcessor) ConsumeLogs(ctx context.Context, ld pdata.Logs) error {
+	if !lp.fromResource() {
+		return lp.consumers[lp.routes[lp.valueFromContext(ctx)]].ConsumeLogs(ctx, ld)
+	}
+
+	groups := make(map[int]pdata.Logs)
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		if rl.IsNil() {
+			continue
+		}
+		route := lp.routes[lp.valueFromResource(rl.Resource())]
+		group, ok := groups[route]
+		if !ok {
+			group = pdata.NewLogs()
+			groups[route] = group
+		}
+		group.ResourceLogs().Append(&rl)
+	}
+
+	var errs []error
+	for route, group := range groups {
+		if err := lp.consumers[route].ConsumeLogs(ctx, group); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return componenterror.CombineErrors(errs)
+}
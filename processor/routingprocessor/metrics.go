@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routingprocessor
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/consumer/pdatautil"
+	"go.opentelemetry.io/collector/internal/data"
+	"go.opentelemetry.io/collector/processor"
+)
+
+type metricsProcessor struct {
+	*router
+
+	// routes maps the values of the routing table to the index of their consumer.
+	routes map[string]int
+	// consumers contains the fan out of the exporters of every route, the first one being
+	// the default route.
+	consumers []consumer.MetricsConsumer
+}
+
+var _ component.MetricsProcessor = (*metricsProcessor)(nil)
+
+// Start resolves the exporters of the routes, which are only known once the pipelines are built.
+func (mp *metricsProcessor) Start(_ context.Context, host component.Host) error {
+	routes, err := mp.resolveRoutes(host, configmodels.MetricsDataType,
+		func(names []string, exporters []component.Exporter) error {
+			mcs := make([]consumer.MetricsConsumer, 0, len(exporters))
+			for i, exp := range exporters {
+				mc, ok := exp.(consumer.MetricsConsumer)
+				if !ok {
+					return mp.unsupportedExporterError(names[i], configmodels.MetricsDataType)
+				}
+				mcs = append(mcs, mc)
+			}
+			mp.consumers = append(mp.consumers, processor.NewMetricsFanOutConnector(mcs))
+			return nil
+		})
+	mp.routes = routes
+	return err
+}
+
+// Shutdown is invoked during service shutdown.
+func (mp *metricsProcessor) Shutdown(context.Context) error {
+	return nil
+}
+
+// GetCapabilities returns the Capabilities assigned to this processor.
+func (mp *metricsProcessor) GetCapabilities() component.ProcessorCapabilities {
+	return component.ProcessorCapabilities{MutatesConsumedData: false}
+}
+
+// ConsumeMetrics sends the metrics to the exporters of the route matching the value of the
+// routing attribute.
+func (mp *metricsProcessor) ConsumeMetrics(ctx context.Context, md pdata.Metrics) error {
+	if !mp.fromResource() {
+		return mp.consumers[mp.routes[mp.valueFromContext(ctx)]].ConsumeMetrics(ctx, md)
+	}
+
+	groups := make(map[int]data.MetricData)
+	rms := pdatautil.MetricsToInternalMetrics(md).ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		if rm.IsNil() {
+			continue
+		}
+		route := mp.routes[mp.valueFromResource(rm.Resource())]
+		group, ok := groups[route]
+		if !ok {
+			group = data.NewMetricData()
+			groups[route] = group
+		}
+		group.ResourceMetrics().Append(&rm)
+	}
+
+	var errs []error
+	for route, group := range groups {
+		if err := mp.consumers[route].ConsumeMetrics(ctx, pdatautil.MetricsFromInternalMetrics(group)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return componenterror.CombineErrors(errs)
+}
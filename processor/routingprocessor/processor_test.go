@@ -0,0 +1,288 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routingprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/metadata"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/consumer/pdatautil"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/internal/data"
+)
+
+// mockHost is a host exposing a fixed set of exporters.
+type mockHost struct {
+	component.Host
+	exporters map[configmodels.DataType]map[configmodels.Exporter]component.Exporter
+}
+
+func newMockHost(dataType configmodels.DataType, exporters map[string]component.Exporter) component.Host {
+	exps := make(map[configmodels.Exporter]component.Exporter, len(exporters))
+	for name, exp := range exporters {
+		exps[&configmodels.ExporterSettings{TypeVal: "otlp", NameVal: name}] = exp
+	}
+	return &mockHost{
+		Host:      componenttest.NewNopHost(),
+		exporters: map[configmodels.DataType]map[configmodels.Exporter]component.Exporter{dataType: exps},
+	}
+}
+
+func (m *mockHost) GetExporters() map[configmodels.DataType]map[configmodels.Exporter]component.Exporter {
+	return m.exporters
+}
+
+func newTestConfig(source AttributeSource, defaultExporters ...string) *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.AttributeSource = source
+	cfg.FromAttribute = "X-Tenant"
+	cfg.DefaultExporters = defaultExporters
+	cfg.Table = []RoutingTableItem{
+		{Value: "acme", Exporters: []string{"otlp/acme"}},
+		{Value: "globex", Exporters: []string{"otlp/acme", "otlp/globex"}},
+	}
+	return cfg
+}
+
+func contextWithTenant(tenant string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs("X-Tenant", tenant))
+}
+
+func newTracesWithTenants(tenants ...string) pdata.Traces {
+	td := pdata.NewTraces()
+	rss := td.ResourceSpans()
+	rss.Resize(len(tenants))
+	for i, tenant := range tenants {
+		rss.At(i).Resource().InitEmpty()
+		if tenant != "" {
+			rss.At(i).Resource().Attributes().InsertString("X-Tenant", tenant)
+		}
+	}
+	return td
+}
+
+func newTestTracesProcessor(t *testing.T, cfg *Config, exporters map[string]component.Exporter) component.TraceProcessor {
+	tp, err := NewFactory().CreateTraceProcessor(context.Background(),
+		component.ProcessorCreateParams{Logger: zap.NewNop()}, exportertest.NewNopTraceExporter(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, tp.Start(context.Background(), newMockHost(configmodels.TracesDataType, exporters)))
+	return tp
+}
+
+func spanResourceCount(sink *exportertest.SinkTraceExporter) int {
+	count := 0
+	for _, td := range sink.AllTraces() {
+		count += td.ResourceSpans().Len()
+	}
+	return count
+}
+
+func TestTracesRoutingFromContext(t *testing.T) {
+	defaultSink := new(exportertest.SinkTraceExporter)
+	acmeSink := new(exportertest.SinkTraceExporter)
+	globexSink := new(exportertest.SinkTraceExporter)
+	tp := newTestTracesProcessor(t, newTestConfig(ContextAttributeSource, "otlp"), map[string]component.Exporter{
+		"otlp":        defaultSink,
+		"otlp/acme":   acmeSink,
+		"otlp/globex": globexSink,
+	})
+
+	require.NoError(t, tp.ConsumeTraces(contextWithTenant("acme"), newTracesWithTenants("")))
+	assert.Len(t, defaultSink.AllTraces(), 0)
+	assert.Len(t, acmeSink.AllTraces(), 1)
+	assert.Len(t, globexSink.AllTraces(), 0)
+
+	require.NoError(t, tp.ConsumeTraces(contextWithTenant("globex"), newTracesWithTenants("")))
+	assert.Len(t, defaultSink.AllTraces(), 0)
+	assert.Len(t, acmeSink.AllTraces(), 2)
+	assert.Len(t, globexSink.AllTraces(), 1)
+
+	require.NoError(t, tp.ConsumeTraces(contextWithTenant("initech"), newTracesWithTenants("")))
+	require.NoError(t, tp.ConsumeTraces(context.Background(), newTracesWithTenants("")))
+	assert.Len(t, defaultSink.AllTraces(), 2)
+	assert.Len(t, acmeSink.AllTraces(), 2)
+	assert.Len(t, globexSink.AllTraces(), 1)
+}
+
+func TestTracesRoutingFromResource(t *testing.T) {
+	defaultSink := new(exportertest.SinkTraceExporter)
+	acmeSink := new(exportertest.SinkTraceExporter)
+	globexSink := new(exportertest.SinkTraceExporter)
+	tp := newTestTracesProcessor(t, newTestConfig(ResourceAttributeSource, "otlp"), map[string]component.Exporter{
+		"otlp":        defaultSink,
+		"otlp/acme":   acmeSink,
+		"otlp/globex": globexSink,
+	})
+
+	// The context is ignored when routing on the resource.
+	td := newTracesWithTenants("acme", "globex", "", "acme", "initech")
+	require.NoError(t, tp.ConsumeTraces(contextWithTenant("globex"), td))
+
+	assert.Equal(t, 2, spanResourceCount(defaultSink))
+	assert.Equal(t, 3, spanResourceCount(acmeSink))
+	assert.Equal(t, 1, spanResourceCount(globexSink))
+	// The exporters of a route receive the resources of every matching value in a single batch.
+	assert.Len(t, acmeSink.AllTraces(), 2)
+}
+
+func TestTracesRoutingWithoutDefault(t *testing.T) {
+	acmeSink := new(exportertest.SinkTraceExporter)
+	globexSink := new(exportertest.SinkTraceExporter)
+	tp := newTestTracesProcessor(t, newTestConfig(ResourceAttributeSource), map[string]component.Exporter{
+		"otlp/acme":   acmeSink,
+		"otlp/globex": globexSink,
+	})
+
+	require.NoError(t, tp.ConsumeTraces(context.Background(), newTracesWithTenants("initech", "")))
+	assert.Len(t, acmeSink.AllTraces(), 0)
+	assert.Len(t, globexSink.AllTraces(), 0)
+}
+
+func TestTracesRoutingExporterError(t *testing.T) {
+	acmeSink := new(exportertest.SinkTraceExporter)
+	acmeSink.SetConsumeTraceError(assert.AnError)
+	tp := newTestTracesProcessor(t, newTestConfig(ContextAttributeSource), map[string]component.Exporter{
+		"otlp/acme":   acmeSink,
+		"otlp/globex": new(exportertest.SinkTraceExporter),
+	})
+
+	assert.Equal(t, assert.AnError, tp.ConsumeTraces(contextWithTenant("acme"), newTracesWithTenants("")))
+}
+
+func TestStartExporterNotFound(t *testing.T) {
+	tp, err := NewFactory().CreateTraceProcessor(context.Background(),
+		component.ProcessorCreateParams{Logger: zap.NewNop()}, exportertest.NewNopTraceExporter(),
+		newTestConfig(ContextAttributeSource, "otlp"))
+	require.NoError(t, err)
+
+	host := newMockHost(configmodels.TracesDataType, map[string]component.Exporter{
+		"otlp/acme":   new(exportertest.SinkTraceExporter),
+		"otlp/globex": new(exportertest.SinkTraceExporter),
+	})
+	assert.EqualError(t, tp.Start(context.Background(), host),
+		`routing processor "routing": exporter "otlp" not found, it must also be listed in a traces pipeline`)
+}
+
+func TestStartUnsupportedExporter(t *testing.T) {
+	tp, err := NewFactory().CreateTraceProcessor(context.Background(),
+		component.ProcessorCreateParams{Logger: zap.NewNop()}, exportertest.NewNopTraceExporter(),
+		newTestConfig(ContextAttributeSource))
+	require.NoError(t, err)
+
+	host := newMockHost(configmodels.TracesDataType, map[string]component.Exporter{
+		"otlp/acme":   new(exportertest.SinkTraceExporterOld),
+		"otlp/globex": new(exportertest.SinkTraceExporter),
+	})
+	assert.EqualError(t, tp.Start(context.Background(), host),
+		`routing processor "routing": exporter "otlp/acme" does not support traces in the pdata format`)
+}
+
+func TestMetricsRoutingFromResource(t *testing.T) {
+	defaultSink := new(exportertest.SinkMetricsExporter)
+	acmeSink := new(exportertest.SinkMetricsExporter)
+	globexSink := new(exportertest.SinkMetricsExporter)
+
+	mp, err := NewFactory().CreateMetricsProcessor(context.Background(),
+		component.ProcessorCreateParams{Logger: zap.NewNop()}, exportertest.NewNopMetricsExporter(),
+		newTestConfig(ResourceAttributeSource, "otlp"))
+	require.NoError(t, err)
+	require.NoError(t, mp.Start(context.Background(), newMockHost(configmodels.MetricsDataType, map[string]component.Exporter{
+		"otlp":        defaultSink,
+		"otlp/acme":   acmeSink,
+		"otlp/globex": globexSink,
+	})))
+
+	md := data.NewMetricData()
+	rms := md.ResourceMetrics()
+	rms.Resize(3)
+	for i, tenant := range []string{"acme", "globex", "initech"} {
+		rms.At(i).Resource().InitEmpty()
+		rms.At(i).Resource().Attributes().InsertString("X-Tenant", tenant)
+	}
+	require.NoError(t, mp.ConsumeMetrics(context.Background(), pdatautil.MetricsFromInternalMetrics(md)))
+
+	resourceCount := func(sink *exportertest.SinkMetricsExporter) int {
+		count := 0
+		for _, md := range sink.AllMetrics() {
+			count += pdatautil.MetricsToInternalMetrics(md).ResourceMetrics().Len()
+		}
+		return count
+	}
+	assert.Equal(t, 1, resourceCount(defaultSink))
+	assert.Equal(t, 2, resourceCount(acmeSink))
+	assert.Equal(t, 1, resourceCount(globexSink))
+}
+
+func TestMetricsRoutingFromContext(t *testing.T) {
+	acmeSink := new(exportertest.SinkMetricsExporter)
+	globexSink := new(exportertest.SinkMetricsExporter)
+
+	mp, err := NewFactory().CreateMetricsProcessor(context.Background(),
+		component.ProcessorCreateParams{Logger: zap.NewNop()}, exportertest.NewNopMetricsExporter(),
+		newTestConfig(ContextAttributeSource))
+	require.NoError(t, err)
+	require.NoError(t, mp.Start(context.Background(), newMockHost(configmodels.MetricsDataType, map[string]component.Exporter{
+		"otlp/acme":   acmeSink,
+		"otlp/globex": globexSink,
+	})))
+
+	md := pdatautil.MetricsFromInternalMetrics(data.NewMetricData())
+	require.NoError(t, mp.ConsumeMetrics(contextWithTenant("globex"), md))
+	assert.Len(t, acmeSink.AllMetrics(), 1)
+	assert.Len(t, globexSink.AllMetrics(), 1)
+}
+
+func TestLogsRouting(t *testing.T) {
+	for _, source := range []AttributeSource{ContextAttributeSource, ResourceAttributeSource} {
+		t.Run(string(source), func(t *testing.T) {
+			defaultSink := new(exportertest.SinkLogsExporter)
+			acmeSink := new(exportertest.SinkLogsExporter)
+			globexSink := new(exportertest.SinkLogsExporter)
+			exporters := map[string]component.Exporter{
+				"otlp":        defaultSink,
+				"otlp/acme":   acmeSink,
+				"otlp/globex": globexSink,
+			}
+
+			lp, err := NewFactory().(component.LogsProcessorFactory).CreateLogsProcessor(context.Background(),
+				component.ProcessorCreateParams{Logger: zap.NewNop()}, newTestConfig(source, "otlp"),
+				exportertest.NewNopLogsExporter())
+			require.NoError(t, err)
+			require.NoError(t, lp.Start(context.Background(), newMockHost(configmodels.LogsDataType, exporters)))
+
+			ld := pdata.NewLogs()
+			rls := ld.ResourceLogs()
+			rls.Resize(1)
+			rls.At(0).Resource().InitEmpty()
+			rls.At(0).Resource().Attributes().InsertString("X-Tenant", "acme")
+			rls.At(0).InstrumentationLibraryLogs().Resize(1)
+			rls.At(0).InstrumentationLibraryLogs().At(0).Logs().Resize(1)
+
+			require.NoError(t, lp.ConsumeLogs(contextWithTenant("acme"), ld))
+			assert.Equal(t, 0, defaultSink.LogRecordsCount())
+			assert.Equal(t, 1, acmeSink.LogRecordsCount())
+			assert.Equal(t, 0, globexSink.LogRecordsCount())
+		})
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routingprocessor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/metadata"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+var (
+	errNoFromAttribute       = errors.New("the routing processor requires the \"from_attribute\" to be set")
+	errNoRoutingTable        = errors.New("the routing processor requires at least one route in the \"table\"")
+	errRouteWithoutValue     = errors.New("every route of the routing processor requires a \"value\"")
+	errRouteWithoutExporters = errors.New("every route of the routing processor requires at least one exporter")
+)
+
+// router holds the routing configuration shared by the trace, metrics and logs processors
+// and resolves the exporters of each route once the exporters are available.
+type router struct {
+	logger *zap.Logger
+	config *Config
+}
+
+func newRouter(logger *zap.Logger, cfg *Config) (*router, error) {
+	if cfg.FromAttribute == "" {
+		return nil, errNoFromAttribute
+	}
+	switch cfg.AttributeSource {
+	case "", ContextAttributeSource, ResourceAttributeSource:
+	default:
+		return nil, fmt.Errorf("unknown attribute_source %q, must be %q or %q",
+			cfg.AttributeSource, ContextAttributeSource, ResourceAttributeSource)
+	}
+	if len(cfg.Table) == 0 {
+		return nil, errNoRoutingTable
+	}
+	seen := make(map[string]bool, len(cfg.Table))
+	for _, item := range cfg.Table {
+		if item.Value == "" {
+			return nil, errRouteWithoutValue
+		}
+		if len(item.Exporters) == 0 {
+			return nil, errRouteWithoutExporters
+		}
+		if seen[item.Value] {
+			return nil, fmt.Errorf("duplicate route for the value %q", item.Value)
+		}
+		seen[item.Value] = true
+	}
+	return &router{logger: logger, config: cfg}, nil
+}
+
+// fromResource reports whether the route value is read from the resource of the data.
+func (r *router) fromResource() bool {
+	return r.config.AttributeSource == ResourceAttributeSource
+}
+
+// valueFromContext returns the first value of the routing attribute in the metadata of the
+// incoming request, or an empty string if it is not present.
+func (r *router) valueFromContext(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	// metadata.MD.Get lower cases the key.
+	values := md.Get(r.config.FromAttribute)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// valueFromResource returns the string value of the routing attribute of the resource, or an
+// empty string if it is not present.
+func (r *router) valueFromResource(resource pdata.Resource) string {
+	if resource.IsNil() {
+		return ""
+	}
+	value, ok := resource.Attributes().Get(r.config.FromAttribute)
+	if !ok || value.Type() != pdata.AttributeValueSTRING {
+		return ""
+	}
+	return value.StringVal()
+}
+
+// resolveRoutes looks up the exporters of the default route and of every route among the
+// exporters of the given data type and passes them, in route order, to the build function.
+// It returns the index of the route of every value of the table, index 0 being the default
+// route.
+func (r *router) resolveRoutes(
+	host component.Host,
+	dataType configmodels.DataType,
+	build func(names []string, exporters []component.Exporter) error,
+) (map[string]int, error) {
+	available := make(map[string]component.Exporter)
+	for cfg, exp := range host.GetExporters()[dataType] {
+		available[cfg.Name()] = exp
+	}
+
+	lookup := func(names []string) ([]component.Exporter, error) {
+		exporters := make([]component.Exporter, 0, len(names))
+		for _, name := range names {
+			exp, ok := available[name]
+			if !ok {
+				return nil, fmt.Errorf("routing processor %q: exporter %q not found, it must also be listed in a %s pipeline",
+					r.config.Name(), name, dataType)
+			}
+			exporters = append(exporters, exp)
+		}
+		return exporters, nil
+	}
+
+	exporters, err := lookup(r.config.DefaultExporters)
+	if err != nil {
+		return nil, err
+	}
+	if err = build(r.config.DefaultExporters, exporters); err != nil {
+		return nil, err
+	}
+
+	// Route 0 is the default route, the routes of the table follow in order.
+	routes := make(map[string]int, len(r.config.Table))
+	for i, item := range r.config.Table {
+		if exporters, err = lookup(item.Exporters); err != nil {
+			return nil, err
+		}
+		if err = build(item.Exporters, exporters); err != nil {
+			return nil, err
+		}
+		routes[item.Value] = i + 1
+	}
+	return routes, nil
+}
+
+func (r *router) unsupportedExporterError(name string, dataType configmodels.DataType) error {
+	return fmt.Errorf("routing processor %q: exporter %q does not support %s in the pdata format",
+		r.config.Name(), name, dataType)
+}
@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routingprocessor
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/processor"
+)
+
+type tracesProcessor struct {
+	*router
+
+	// routes maps the values of the routing table to the index of their consumer.
+	routes map[string]int
+	// consumers contains the fan out of the exporters of every route, the first one being
+	// the default route.
+	consumers []consumer.TraceConsumer
+}
+
+var _ component.TraceProcessor = (*tracesProcessor)(nil)
+
+// Start resolves the exporters of the routes, which are only known once the pipelines are built.
+func (tp *tracesProcessor) Start(_ context.Context, host component.Host) error {
+	routes, err := tp.resolveRoutes(host, configmodels.TracesDataType,
+		func(names []string, exporters []component.Exporter) error {
+			tcs := make([]consumer.TraceConsumer, 0, len(exporters))
+			for i, exp := range exporters {
+				tc, ok := exp.(consumer.TraceConsumer)
+				if !ok {
+					return tp.unsupportedExporterError(names[i], configmodels.TracesDataType)
+				}
+				tcs = append(tcs, tc)
+			}
+			tp.consumers = append(tp.consumers, processor.NewTraceFanOutConnector(tcs))
+			return nil
+		})
+	tp.routes = routes
+	return err
+}
+
+// Shutdown is invoked during service shutdown.
+func (tp *tracesProcessor) Shutdown(context.Context) error {
+	return nil
+}
+
+// GetCapabilities returns the Capabilities assigned to this processor.
+func (tp *tracesProcessor) GetCapabilities() component.ProcessorCapabilities {
+	return component.ProcessorCapabilities{MutatesConsumedData: false}
+}
+
+// ConsumeTraces sends the traces to the exporters of the route matching the value of the
+// routing attribute.
+func (tp *tracesProcessor) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	if !tp.fromResource() {
+		return tp.consumers[tp.routes[tp.valueFromContext(ctx)]].ConsumeTraces(ctx, td)
+	}
+
+	groups := make(map[int]pdata.Traces)
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		if rs.IsNil() {
+			continue
+		}
+		route := tp.routes[tp.valueFromResource(rs.Resource())]
+		group, ok := groups[route]
+		if !ok {
+			group = pdata.NewTraces()
+			groups[route] = group
+		}
+		group.ResourceSpans().Append(&rs)
+	}
+
+	var errs []error
+	for route, group := range groups {
+		if err := tp.consumers[route].ConsumeTraces(ctx, group); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return componenterror.CombineErrors(errs)
+}
@@ -34,6 +34,27 @@ const (
 	StringAttribute PolicyType = "string_attribute"
 	// RateLimiting allows all traces until the specified limits are satisfied.
 	RateLimiting PolicyType = "rate_limiting"
+	// Latency sample traces whose duration, from the earliest span start to the latest
+	// span end, is greater than or equal to a threshold.
+	Latency PolicyType = "latency"
+	// StatusCode sample traces that have at least one span with one of the listed
+	// status codes, or with any error status code if none is listed.
+	StatusCode PolicyType = "status_code"
+	// Probabilistic samples a percentage of the traces, based on the trace ID.
+	Probabilistic PolicyType = "probabilistic"
+	// Composite combines the decisions of several sub-policies, sharing a global
+	// spans per second budget between them.
+	Composite PolicyType = "composite"
+)
+
+// CompositeOperator indicates how the decisions of the sub-policies of a composite policy are combined.
+type CompositeOperator string
+
+const (
+	// CompositeOr samples a trace if any of the sub-policies samples it.
+	CompositeOr CompositeOperator = "or"
+	// CompositeAnd samples a trace only if all the sub-policies sample it.
+	CompositeAnd CompositeOperator = "and"
 )
 
 // PolicyCfg holds the common configuration to all policies.
@@ -48,6 +69,35 @@ type PolicyCfg struct {
 	StringAttributeCfg StringAttributeCfg `mapstructure:"string_attribute"`
 	// Configs for rate limiting filter sampling policy evaluator.
 	RateLimitingCfg RateLimitingCfg `mapstructure:"rate_limiting"`
+	// Configs for latency filter sampling policy evaluator.
+	LatencyCfg LatencyCfg `mapstructure:"latency"`
+	// Configs for status code filter sampling policy evaluator.
+	StatusCodeCfg StatusCodeCfg `mapstructure:"status_code"`
+	// Configs for probabilistic sampling policy evaluator.
+	ProbabilisticCfg ProbabilisticCfg `mapstructure:"probabilistic"`
+	// Configs for composite sampling policy evaluator.
+	CompositeCfg CompositeCfg `mapstructure:"composite"`
+}
+
+// SubPolicyCfg holds the configuration of a sub-policy of a composite policy. It supports
+// all the policy types except composite.
+type SubPolicyCfg struct {
+	// Name given to the sub-policy, it is used to assign it a share of the composite rate.
+	Name string `mapstructure:"name"`
+	// Type of the policy this will be used to match the proper configuration of the policy.
+	Type PolicyType `mapstructure:"type"`
+	// Configs for numeric attribute filter sampling policy evaluator.
+	NumericAttributeCfg NumericAttributeCfg `mapstructure:"numeric_attribute"`
+	// Configs for string attribute filter sampling policy evaluator.
+	StringAttributeCfg StringAttributeCfg `mapstructure:"string_attribute"`
+	// Configs for rate limiting filter sampling policy evaluator.
+	RateLimitingCfg RateLimitingCfg `mapstructure:"rate_limiting"`
+	// Configs for latency filter sampling policy evaluator.
+	LatencyCfg LatencyCfg `mapstructure:"latency"`
+	// Configs for status code filter sampling policy evaluator.
+	StatusCodeCfg StatusCodeCfg `mapstructure:"status_code"`
+	// Configs for probabilistic sampling policy evaluator.
+	ProbabilisticCfg ProbabilisticCfg `mapstructure:"probabilistic"`
 }
 
 // NumericAttributeCfg holds the configurable settings to create a numeric attribute filter
@@ -77,6 +127,57 @@ type RateLimitingCfg struct {
 	SpansPerSecond int64 `mapstructure:"spans_per_second"`
 }
 
+// LatencyCfg holds the configurable settings to create a latency filter
+// sampling policy evaluator.
+type LatencyCfg struct {
+	// ThresholdMs is the minimum duration of a trace, in milliseconds, to be sampled.
+	ThresholdMs int64 `mapstructure:"threshold_ms"`
+}
+
+// StatusCodeCfg holds the configurable settings to create a status code filter
+// sampling policy evaluator.
+type StatusCodeCfg struct {
+	// StatusCodes is the list of span status codes, e.g. "UnknownError" or "DeadlineExceeded",
+	// that sample a trace when any of its spans has one of them. If empty, any status code
+	// other than "Ok" samples the trace.
+	StatusCodes []string `mapstructure:"status_codes"`
+}
+
+// ProbabilisticCfg holds the configurable settings to create a probabilistic
+// sampling policy evaluator.
+type ProbabilisticCfg struct {
+	// HashSalt allows one to configure the hashing salts. This is important in scenarios where multiple layers of collectors
+	// have different sampling rates: if they use the same salt all passing one layer may pass the other even if they have
+	// different sampling rates, configuring different salts avoids that.
+	HashSalt string `mapstructure:"hash_salt"`
+	// SamplingPercentage is the percentage rate at which traces are going to be sampled. Defaults to zero, i.e.: no sample.
+	// Values greater or equal 100 are treated as "sample all traces".
+	SamplingPercentage float64 `mapstructure:"sampling_percentage"`
+}
+
+// CompositeCfg holds the configurable settings to create a composite
+// sampling policy evaluator.
+type CompositeCfg struct {
+	// MaxTotalSpansPerSecond is the maximum number of spans sampled each second by the
+	// composite policy. Zero means no limit.
+	MaxTotalSpansPerSecond int64 `mapstructure:"max_total_spans_per_second"`
+	// Operator combines the decisions of the sub-policies, either "or" (the default) or "and".
+	Operator CompositeOperator `mapstructure:"operator"`
+	// SubPolicyCfgs are the policies combined by the composite policy, evaluated in order.
+	SubPolicyCfgs []SubPolicyCfg `mapstructure:"policies"`
+	// RateAllocation assigns to sub-policies a percentage of MaxTotalSpansPerSecond, only
+	// used with the "or" operator. Sub-policies without allocation are only limited by the total.
+	RateAllocation []RateAllocationCfg `mapstructure:"rate_allocation"`
+}
+
+// RateAllocationCfg assigns a share of the composite rate to a sub-policy.
+type RateAllocationCfg struct {
+	// Policy is the name of the sub-policy.
+	Policy string `mapstructure:"policy"`
+	// Percent of MaxTotalSpansPerSecond allocated to the sub-policy.
+	Percent int64 `mapstructure:"percent"`
+}
+
 // Config holds the configuration for tail-based sampling.
 type Config struct {
 	configmodels.ProcessorSettings `mapstructure:",squash"`
@@ -89,6 +190,10 @@ type Config struct {
 	// ExpectedNewTracesPerSec sets the expected number of new traces sending to the tail sampling processor
 	// per second. This helps with allocating data structures with closer to actual usage size.
 	ExpectedNewTracesPerSec uint64 `mapstructure:"expected_new_traces_per_sec"`
+	// DecisionCacheSize is the number of sampling decisions kept after the traces are removed
+	// from memory, so spans arriving late get the same decisions as the rest of their trace.
+	// Zero disables the cache.
+	DecisionCacheSize uint64 `mapstructure:"decision_cache_size"`
 	// PolicyCfgs sets the tail-based sampling policy which makes a sampling decision
 	// for a given trace when requested.
 	PolicyCfgs []PolicyCfg `mapstructure:"policies"`
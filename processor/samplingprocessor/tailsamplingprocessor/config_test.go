@@ -31,7 +31,7 @@ func TestLoadConfig(t *testing.T) {
 	factories, err := componenttest.ExampleComponents()
 	assert.NoError(t, err)
 
-	factory := &Factory{}
+	factory := NewFactory()
 	factories.Processors[factory.Type()] = factory
 
 	cfg, err := configtest.LoadConfigFile(t, path.Join(".", "testdata", "tail_sampling_config.yaml"), factories)
@@ -47,6 +47,7 @@ func TestLoadConfig(t *testing.T) {
 			DecisionWait:            10 * time.Second,
 			NumTraces:               100,
 			ExpectedNewTracesPerSec: 10,
+			DecisionCacheSize:       1000,
 			PolicyCfgs: []PolicyCfg{
 				{
 					Name: "test-policy-1",
@@ -67,6 +68,39 @@ func TestLoadConfig(t *testing.T) {
 					Type:            RateLimiting,
 					RateLimitingCfg: RateLimitingCfg{SpansPerSecond: 35},
 				},
+				{
+					Name:       "test-policy-5",
+					Type:       Latency,
+					LatencyCfg: LatencyCfg{ThresholdMs: 5000},
+				},
+				{
+					Name:          "test-policy-6",
+					Type:          StatusCode,
+					StatusCodeCfg: StatusCodeCfg{StatusCodes: []string{"UnknownError", "DeadlineExceeded"}},
+				},
+				{
+					Name:             "test-policy-7",
+					Type:             Probabilistic,
+					ProbabilisticCfg: ProbabilisticCfg{HashSalt: "custom-salt", SamplingPercentage: 5},
+				},
+				{
+					Name: "test-policy-8",
+					Type: Composite,
+					CompositeCfg: CompositeCfg{
+						MaxTotalSpansPerSecond: 1000,
+						Operator:               CompositeOr,
+						SubPolicyCfgs: []SubPolicyCfg{
+							{Name: "errors", Type: StatusCode},
+							{Name: "slow", Type: Latency, LatencyCfg: LatencyCfg{ThresholdMs: 5000}},
+							{Name: "everything-else", Type: Probabilistic, ProbabilisticCfg: ProbabilisticCfg{SamplingPercentage: 5}},
+						},
+						RateAllocation: []RateAllocationCfg{
+							{Policy: "errors", Percent: 50},
+							{Policy: "slow", Percent: 25},
+							{Policy: "everything-else", Percent: 25},
+						},
+					},
+				},
 			},
 		})
 }
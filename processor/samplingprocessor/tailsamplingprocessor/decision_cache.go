@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tailsamplingprocessor
+
+import (
+	"sync"
+	"time"
+
+	"github.com/golang/groupcache/lru"
+
+	"go.opentelemetry.io/collector/processor/samplingprocessor/tailsamplingprocessor/sampling"
+)
+
+// cachedDecision is the outcome of the sampling policies for a trace removed from memory.
+type cachedDecision struct {
+	// decisions has the decision of each policy, in the same order as the policies.
+	decisions    []sampling.Decision
+	decisionTime time.Time
+}
+
+// decisionCache keeps the sampling decisions of the most recently removed traces, so spans
+// arriving after their trace was removed from memory are not treated as a new trace.
+// A nil *decisionCache is valid and never has any decision.
+type decisionCache struct {
+	mu    sync.Mutex
+	cache *lru.Cache
+}
+
+// newDecisionCache creates a cache of at most size decisions, it returns nil if size is zero.
+func newDecisionCache(size uint64) *decisionCache {
+	if size == 0 {
+		return nil
+	}
+	return &decisionCache{cache: lru.New(int(size))}
+}
+
+func (dc *decisionCache) add(id traceKey, decision cachedDecision) {
+	if dc == nil {
+		return
+	}
+	dc.mu.Lock()
+	dc.cache.Add(id, decision)
+	dc.mu.Unlock()
+}
+
+func (dc *decisionCache) get(id traceKey) (cachedDecision, bool) {
+	if dc == nil {
+		return cachedDecision{}, false
+	}
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+	v, ok := dc.cache.Get(id)
+	if !ok {
+		return cachedDecision{}, false
+	}
+	return v.(cachedDecision), true
+}
@@ -22,7 +22,6 @@ import (
 	"sync/atomic"
 	"time"
 
-	tracepb "github.com/census-instrumentation/opencensus-proto/gen-go/trace/v1"
 	"go.opencensus.io/stats"
 	"go.opencensus.io/tag"
 	"go.uber.org/zap"
@@ -30,7 +29,8 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenterror"
 	"go.opentelemetry.io/collector/consumer"
-	"go.opentelemetry.io/collector/consumer/consumerdata"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/batchpertrace"
 	"go.opentelemetry.io/collector/processor/samplingprocessor/tailsamplingprocessor/idbatcher"
 	"go.opentelemetry.io/collector/processor/samplingprocessor/tailsamplingprocessor/sampling"
 )
@@ -54,7 +54,7 @@ type traceKey string
 // policy to sample traces.
 type tailSamplingSpanProcessor struct {
 	ctx             context.Context
-	nextConsumer    consumer.TraceConsumerOld
+	nextConsumer    consumer.TraceConsumer
 	start           sync.Once
 	maxNumTraces    uint64
 	policies        []*Policy
@@ -64,6 +64,7 @@ type tailSamplingSpanProcessor struct {
 	decisionBatcher idbatcher.Batcher
 	deleteChan      chan traceKey
 	numTracesOnMap  uint64
+	decisionCache   *decisionCache
 }
 
 const (
@@ -72,7 +73,7 @@ const (
 
 // newTraceProcessor returns a processor.TraceProcessor that will perform tail sampling according to the given
 // configuration.
-func newTraceProcessor(logger *zap.Logger, nextConsumer consumer.TraceConsumerOld, cfg Config) (component.TraceProcessorOld, error) {
+func newTraceProcessor(logger *zap.Logger, nextConsumer consumer.TraceConsumer, cfg Config) (component.TraceProcessor, error) {
 	if nextConsumer == nil {
 		return nil, componenterror.ErrNilNextConsumer
 	}
@@ -110,6 +111,7 @@ func newTraceProcessor(logger *zap.Logger, nextConsumer consumer.TraceConsumerOl
 		logger:          logger,
 		decisionBatcher: inBatcher,
 		policies:        policies,
+		decisionCache:   newDecisionCache(cfg.DecisionCacheSize),
 	}
 
 	tsp.policyTicker = &policyTicker{onTick: tsp.samplingPolicyOnTick}
@@ -119,6 +121,22 @@ func newTraceProcessor(logger *zap.Logger, nextConsumer consumer.TraceConsumerOl
 }
 
 func getPolicyEvaluator(logger *zap.Logger, cfg *PolicyCfg) (sampling.PolicyEvaluator, error) {
+	if cfg.Type == Composite {
+		return getCompositeEvaluator(logger, &cfg.CompositeCfg)
+	}
+	return getSubPolicyEvaluator(logger, &SubPolicyCfg{
+		Name:                cfg.Name,
+		Type:                cfg.Type,
+		NumericAttributeCfg: cfg.NumericAttributeCfg,
+		StringAttributeCfg:  cfg.StringAttributeCfg,
+		RateLimitingCfg:     cfg.RateLimitingCfg,
+		LatencyCfg:          cfg.LatencyCfg,
+		StatusCodeCfg:       cfg.StatusCodeCfg,
+		ProbabilisticCfg:    cfg.ProbabilisticCfg,
+	})
+}
+
+func getSubPolicyEvaluator(logger *zap.Logger, cfg *SubPolicyCfg) (sampling.PolicyEvaluator, error) {
 	switch cfg.Type {
 	case AlwaysSample:
 		return sampling.NewAlwaysSample(logger), nil
@@ -131,11 +149,73 @@ func getPolicyEvaluator(logger *zap.Logger, cfg *PolicyCfg) (sampling.PolicyEval
 	case RateLimiting:
 		rlfCfg := cfg.RateLimitingCfg
 		return sampling.NewRateLimiting(logger, rlfCfg.SpansPerSecond), nil
+	case Latency:
+		return sampling.NewLatency(logger, cfg.LatencyCfg.ThresholdMs), nil
+	case StatusCode:
+		return sampling.NewStatusCodeFilter(logger, cfg.StatusCodeCfg.StatusCodes)
+	case Probabilistic:
+		pCfg := cfg.ProbabilisticCfg
+		return sampling.NewProbabilisticSampler(logger, pCfg.HashSalt, pCfg.SamplingPercentage), nil
 	default:
 		return nil, fmt.Errorf("unknown sampling policy type %s", cfg.Type)
 	}
 }
 
+func getCompositeEvaluator(logger *zap.Logger, cfg *CompositeCfg) (sampling.PolicyEvaluator, error) {
+	var operator sampling.CompositeOperator
+	switch cfg.Operator {
+	case "", CompositeOr:
+		operator = sampling.CompositeOr
+	case CompositeAnd:
+		operator = sampling.CompositeAnd
+	default:
+		return nil, fmt.Errorf("unknown composite policy operator %q", cfg.Operator)
+	}
+
+	rateAllocation := make(map[string]int64, len(cfg.RateAllocation))
+	var totalPercent int64
+	for _, ra := range cfg.RateAllocation {
+		if ra.Percent <= 0 {
+			return nil, fmt.Errorf("composite policy rate allocation for %q must be a positive percentage", ra.Policy)
+		}
+		rateAllocation[ra.Policy] = ra.Percent
+		totalPercent += ra.Percent
+	}
+	if totalPercent > 100 {
+		return nil, fmt.Errorf("composite policy rate allocation adds up to %d%%, more than 100%%", totalPercent)
+	}
+	if totalPercent > 0 && cfg.MaxTotalSpansPerSecond <= 0 {
+		return nil, fmt.Errorf("composite policy rate allocation requires max_total_spans_per_second")
+	}
+
+	subPolicyParams := make([]sampling.SubPolicyEvalParams, 0, len(cfg.SubPolicyCfgs))
+	for i := range cfg.SubPolicyCfgs {
+		subCfg := &cfg.SubPolicyCfgs[i]
+		eval, err := getSubPolicyEvaluator(logger, subCfg)
+		if err != nil {
+			return nil, fmt.Errorf("composite sub-policy %q: %w", subCfg.Name, err)
+		}
+		var maxSpansPerSecond int64
+		if percent, ok := rateAllocation[subCfg.Name]; ok {
+			// Zero means unlimited for the evaluator, so tiny allocations are rounded up.
+			maxSpansPerSecond = cfg.MaxTotalSpansPerSecond * percent / 100
+			if maxSpansPerSecond == 0 {
+				maxSpansPerSecond = 1
+			}
+			delete(rateAllocation, subCfg.Name)
+		}
+		subPolicyParams = append(subPolicyParams, sampling.SubPolicyEvalParams{
+			Evaluator:         eval,
+			MaxSpansPerSecond: maxSpansPerSecond,
+		})
+	}
+	for name := range rateAllocation {
+		return nil, fmt.Errorf("composite policy rate allocation refers to unknown sub-policy %q", name)
+	}
+
+	return sampling.NewComposite(logger, operator, cfg.MaxTotalSpansPerSecond, subPolicyParams), nil
+}
+
 func (tsp *tailSamplingSpanProcessor) samplingPolicyOnTick() {
 	var idNotFoundOnMapCount, evaluateErrorCount, decisionSampled, decisionNotSampled int64
 	startTime := time.Now()
@@ -179,7 +259,11 @@ func (tsp *tailSamplingSpanProcessor) samplingPolicyOnTick() {
 				trace.Unlock()
 
 				for j := 0; j < len(traceBatches); j++ {
-					tsp.nextConsumer.ConsumeTraceData(policy.ctx, traceBatches[j])
+					if err := tsp.nextConsumer.ConsumeTraces(policy.ctx, traceBatches[j]); err != nil {
+						tsp.logger.Warn("Error sending sampled spans to destination",
+							zap.String("policy", policy.Name),
+							zap.Error(err))
+					}
 				}
 			case sampling.NotSampled:
 				stats.RecordWithTags(
@@ -212,28 +296,32 @@ func (tsp *tailSamplingSpanProcessor) samplingPolicyOnTick() {
 	)
 }
 
-// ConsumeTraceData is required by the SpanProcessor interface.
-func (tsp *tailSamplingSpanProcessor) ConsumeTraceData(ctx context.Context, td consumerdata.TraceData) error {
+// ConsumeTraces is required by the component.TraceProcessor interface.
+func (tsp *tailSamplingSpanProcessor) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
 	tsp.start.Do(func() {
 		tsp.logger.Info("First trace data arrived, starting tail_sampling timers")
 		tsp.policyTicker.Start(1 * time.Second)
 	})
 
-	// Groupd spans per their traceId to minimize contention on idToTrace
-	idToSpans := make(map[traceKey][]*tracepb.Span)
-	for _, span := range td.Spans {
-		if len(span.TraceId) != 16 {
-			tsp.logger.Warn("Span without valid TraceId", zap.String("SourceFormat", td.SourceFormat))
+	// Group spans per their traceId to minimize contention on idToTrace. The spans are copied
+	// since they are kept after ConsumeTraces returns.
+	var newTraceIDs int64
+	for _, batch := range batchpertrace.Split(td) {
+		if len(batch.TraceID) != 16 {
+			tsp.logger.Warn("Span without valid TraceId")
+			continue
+		}
+		id := traceKey(batch.TraceID)
+		traceTd := batch.Traces
+		if cached, ok := tsp.decisionCache.get(id); ok {
+			// The trace was already removed from memory, apply the decisions taken for it.
+			for i, policy := range tsp.policies {
+				tsp.processLateSpans(policy, cached.decisions[i], cached.decisionTime, traceTd)
+			}
 			continue
 		}
-		tk := traceKey(span.TraceId)
-		idToSpans[tk] = append(idToSpans[tk], span)
-	}
 
-	var newTraceIDs int64
-	singleTrace := len(idToSpans) == 1
-	for id, spans := range idToSpans {
-		lenSpans := int64(len(spans))
+		lenSpans := int64(traceTd.SpanCount())
 		lenPolicies := len(tsp.policies)
 		initialDecisions := make([]sampling.Decision, lenPolicies)
 		for i := 0; i < lenPolicies; i++ {
@@ -244,7 +332,7 @@ func (tsp *tailSamplingSpanProcessor) ConsumeTraceData(ctx context.Context, td c
 			ArrivalTime: time.Now(),
 			SpanCount:   lenSpans,
 		}
-		d, loaded := tsp.idToTrace.LoadOrStore(traceKey(id), initialTraceData)
+		d, loaded := tsp.idToTrace.LoadOrStore(id, initialTraceData)
 
 		actualData := d.(*sampling.TraceData)
 		if loaded {
@@ -274,34 +362,14 @@ func (tsp *tailSamplingSpanProcessor) ConsumeTraceData(ctx context.Context, td c
 			if actualDecision == sampling.Pending {
 				// Add the spans to the trace, but only once for all policy, otherwise same spans will
 				// be duplicated in the final trace.
-				traceTd := prepareTraceBatch(spans, singleTrace, td)
 				actualData.ReceivedBatches = append(actualData.ReceivedBatches, traceTd)
 				actualData.Unlock()
 				break
 			}
+			decisionTime := actualData.DecisionTime
 			actualData.Unlock()
 
-			switch actualDecision {
-			case sampling.Pending:
-				// All process for pending done above, keep the case so it doesn't go to default.
-			case sampling.Sampled:
-				// Forward the spans to the policy destinations
-				traceTd := prepareTraceBatch(spans, singleTrace, td)
-				if err := tsp.nextConsumer.ConsumeTraceData(policy.ctx, traceTd); err != nil {
-					tsp.logger.Warn("Error sending late arrived spans to destination",
-						zap.String("policy", policy.Name),
-						zap.Error(err))
-				}
-				fallthrough // so OnLateArrivingSpans is also called for decision Sampled.
-			case sampling.NotSampled:
-				policy.Evaluator.OnLateArrivingSpans(actualDecision, spans)
-				stats.Record(tsp.ctx, statLateSpanArrivalAfterDecision.M(int64(time.Since(actualData.DecisionTime)/time.Second)))
-
-			default:
-				tsp.logger.Warn("Encountered unexpected sampling decision",
-					zap.String("policy", policy.Name),
-					zap.Int("decision", int(actualDecision)))
-			}
+			tsp.processLateSpans(policy, actualDecision, decisionTime, traceTd)
 		}
 	}
 
@@ -309,6 +377,28 @@ func (tsp *tailSamplingSpanProcessor) ConsumeTraceData(ctx context.Context, td c
 	return nil
 }
 
+// processLateSpans handles spans arriving after the given policy took its decision for their trace.
+func (tsp *tailSamplingSpanProcessor) processLateSpans(policy *Policy, decision sampling.Decision, decisionTime time.Time, td pdata.Traces) {
+	switch decision {
+	case sampling.Sampled:
+		// Forward the spans to the policy destinations
+		if err := tsp.nextConsumer.ConsumeTraces(policy.ctx, td); err != nil {
+			tsp.logger.Warn("Error sending late arrived spans to destination",
+				zap.String("policy", policy.Name),
+				zap.Error(err))
+		}
+		fallthrough // so OnLateArrivingSpans is also called for decision Sampled.
+	case sampling.NotSampled:
+		policy.Evaluator.OnLateArrivingSpans(decision, collectSpans(td))
+		stats.Record(tsp.ctx, statLateSpanArrivalAfterDecision.M(int64(time.Since(decisionTime)/time.Second)))
+
+	default:
+		tsp.logger.Warn("Encountered unexpected sampling decision",
+			zap.String("policy", policy.Name),
+			zap.Int("decision", int(decision)))
+	}
+}
+
 func (tsp *tailSamplingSpanProcessor) GetCapabilities() component.ProcessorCapabilities {
 	return component.ProcessorCapabilities{MutatesConsumedData: false}
 }
@@ -337,8 +427,14 @@ func (tsp *tailSamplingSpanProcessor) dropTrace(traceID traceKey, deletionTime t
 	}
 	policiesLen := len(tsp.policies)
 	stats.Record(tsp.ctx, statTraceRemovalAgeSec.M(int64(deletionTime.Sub(trace.ArrivalTime)/time.Second)))
+	trace.Lock()
+	decisions := append([]sampling.Decision(nil), trace.Decisions...)
+	decisionTime := trace.DecisionTime
+	trace.Unlock()
+	pending := false
 	for j := 0; j < policiesLen; j++ {
-		if trace.Decisions[j] == sampling.Pending {
+		if decisions[j] == sampling.Pending {
+			pending = true
 			policy := tsp.policies[j]
 			if decision, err := policy.Evaluator.OnDroppedSpans([]byte(traceID), trace); err != nil {
 				tsp.logger.Warn("OnDroppedSpans",
@@ -348,21 +444,27 @@ func (tsp *tailSamplingSpanProcessor) dropTrace(traceID traceKey, deletionTime t
 			}
 		}
 	}
+	// Only traces with a decision from all the policies are remembered, late spans of a trace
+	// dropped before its decision start a new trace.
+	if !pending {
+		tsp.decisionCache.add(traceID, cachedDecision{decisions: decisions, decisionTime: decisionTime})
+	}
 }
 
-func prepareTraceBatch(spans []*tracepb.Span, singleTrace bool, td consumerdata.TraceData) consumerdata.TraceData {
-	var traceTd consumerdata.TraceData
-	if singleTrace {
-		// Special case no need to prepare a batch
-		traceTd = td
-	} else {
-		traceTd = consumerdata.TraceData{
-			Node:     td.Node,
-			Resource: td.Resource,
-			Spans:    spans,
+// collectSpans returns all the spans of td, it is used to notify the policies of late spans.
+func collectSpans(td pdata.Traces) []pdata.Span {
+	spans := make([]pdata.Span, 0, td.SpanCount())
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		ilss := rss.At(i).InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			ilsSpans := ilss.At(j).Spans()
+			for k := 0; k < ilsSpans.Len(); k++ {
+				spans = append(spans, ilsSpans.At(k))
+			}
 		}
 	}
-	return traceTd
+	return spans
 }
 
 // tTicker interface allows easier testing of ticker related functionality used by tailSamplingProcessor
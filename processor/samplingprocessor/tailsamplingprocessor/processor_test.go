@@ -17,14 +17,15 @@ package tailsamplingprocessor
 import (
 	"context"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
-	tracepb "github.com/census-instrumentation/opencensus-proto/gen-go/trace/v1"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 
-	"go.opentelemetry.io/collector/consumer/consumerdata"
+	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/exporter/exportertest"
 	"go.opentelemetry.io/collector/processor/samplingprocessor/tailsamplingprocessor/idbatcher"
 	"go.opentelemetry.io/collector/processor/samplingprocessor/tailsamplingprocessor/sampling"
@@ -45,10 +46,10 @@ func TestSequentialTraceArrival(t *testing.T) {
 		ExpectedNewTracesPerSec: 64,
 		PolicyCfgs:              testPolicy,
 	}
-	sp, _ := newTraceProcessor(zap.NewNop(), &exportertest.SinkTraceExporterOld{}, cfg)
+	sp, _ := newTraceProcessor(zap.NewNop(), &exportertest.SinkTraceExporter{}, cfg)
 	tsp := sp.(*tailSamplingSpanProcessor)
 	for _, batch := range batches {
-		tsp.ConsumeTraceData(context.Background(), batch)
+		tsp.ConsumeTraces(context.Background(), batch)
 	}
 
 	for i := range traceIds {
@@ -69,19 +70,17 @@ func TestConcurrentTraceArrival(t *testing.T) {
 		ExpectedNewTracesPerSec: 64,
 		PolicyCfgs:              testPolicy,
 	}
-	sp, _ := newTraceProcessor(zap.NewNop(), &exportertest.SinkTraceExporterOld{}, cfg)
+	sp, _ := newTraceProcessor(zap.NewNop(), &exportertest.SinkTraceExporter{}, cfg)
 	tsp := sp.(*tailSamplingSpanProcessor)
 	for _, batch := range batches {
 		// Add the same traceId twice.
 		wg.Add(2)
-		go func(td consumerdata.TraceData) {
-			td.SourceFormat = "test-0"
-			tsp.ConsumeTraceData(context.Background(), td)
+		go func(td pdata.Traces) {
+			tsp.ConsumeTraces(context.Background(), td)
 			wg.Done()
 		}(batch)
-		go func(td consumerdata.TraceData) {
-			td.SourceFormat = "test-1"
-			tsp.ConsumeTraceData(context.Background(), td)
+		go func(td pdata.Traces) {
+			tsp.ConsumeTraces(context.Background(), td)
 			wg.Done()
 		}(batch)
 	}
@@ -105,10 +104,10 @@ func TestSequentialTraceMapSize(t *testing.T) {
 		ExpectedNewTracesPerSec: 64,
 		PolicyCfgs:              testPolicy,
 	}
-	sp, _ := newTraceProcessor(zap.NewNop(), &exportertest.SinkTraceExporterOld{}, cfg)
+	sp, _ := newTraceProcessor(zap.NewNop(), &exportertest.SinkTraceExporter{}, cfg)
 	tsp := sp.(*tailSamplingSpanProcessor)
 	for _, batch := range batches {
-		tsp.ConsumeTraceData(context.Background(), batch)
+		tsp.ConsumeTraces(context.Background(), batch)
 	}
 
 	// On sequential insertion it is possible to know exactly which traces should be still on the map.
@@ -128,12 +127,12 @@ func TestConcurrentTraceMapSize(t *testing.T) {
 		ExpectedNewTracesPerSec: 64,
 		PolicyCfgs:              testPolicy,
 	}
-	sp, _ := newTraceProcessor(zap.NewNop(), &exportertest.SinkTraceExporterOld{}, cfg)
+	sp, _ := newTraceProcessor(zap.NewNop(), &exportertest.SinkTraceExporter{}, cfg)
 	tsp := sp.(*tailSamplingSpanProcessor)
 	for _, batch := range batches {
 		wg.Add(1)
-		go func(td consumerdata.TraceData) {
-			tsp.ConsumeTraceData(context.Background(), td)
+		go func(td pdata.Traces) {
+			tsp.ConsumeTraces(context.Background(), td)
 			wg.Done()
 		}(batch)
 	}
@@ -175,7 +174,7 @@ func TestSamplingPolicyTypicalPath(t *testing.T) {
 	// First evaluations shouldn't have anything to evaluate, until decision wait time passed.
 	for evalNum := 0; evalNum < decisionWaitSeconds; evalNum++ {
 		for ; currItem < numSpansPerBatchWindow*(evalNum+1); currItem++ {
-			tsp.ConsumeTraceData(context.Background(), batches[currItem])
+			tsp.ConsumeTraces(context.Background(), batches[currItem])
 			require.True(t, mtt.Started, "Time ticker was expected to have started")
 		}
 		tsp.samplingPolicyOnTick()
@@ -200,34 +199,30 @@ func TestSamplingPolicyTypicalPath(t *testing.T) {
 	require.Equal(t, numSpansPerBatchWindow, msp.TotalSpans, "not all spans of first window were accounted for")
 
 	// Late span of a sampled trace should be sent directly down the pipeline exporter
-	tsp.ConsumeTraceData(context.Background(), batches[0])
+	tsp.ConsumeTraces(context.Background(), batches[0])
 	expectedNumWithLateSpan := numSpansPerBatchWindow + 1
 	require.Equal(t, expectedNumWithLateSpan, msp.TotalSpans, "late span was not accounted for")
 	require.Equal(t, 1, mpe.LateArrivingSpansCount, "policy was not notified of the late span")
 }
 
-func generateIdsAndBatches(numIds int) ([][]byte, []consumerdata.TraceData) {
+func generateIdsAndBatches(numIds int) ([][]byte, []pdata.Traces) {
 	traceIds := make([][]byte, numIds)
 	for i := 0; i < numIds; i++ {
 		traceIds[i] = tracetranslator.UInt64ToByteTraceID(1, uint64(i+1))
 	}
 
-	tds := []consumerdata.TraceData{}
+	tds := []pdata.Traces{}
 	for i := range traceIds {
-		spans := make([]*tracepb.Span, i+1)
-		for j := range spans {
-			spans[j] = &tracepb.Span{
-				TraceId: traceIds[i],
-				SpanId:  tracetranslator.UInt64ToByteSpanID(uint64(i + 1)),
-			}
-		}
-
 		// Send each span in a separate batch
-		for _, span := range spans {
-			td := consumerdata.TraceData{
-				Spans:        []*tracepb.Span{span},
-				SourceFormat: "test",
-			}
+		for j := 0; j <= i; j++ {
+			td := pdata.NewTraces()
+			td.ResourceSpans().Resize(1)
+			ilss := td.ResourceSpans().At(0).InstrumentationLibrarySpans()
+			ilss.Resize(1)
+			spans := ilss.At(0).Spans()
+			spans.Resize(1)
+			spans.At(0).SetTraceID(pdata.NewTraceID(traceIds[i]))
+			spans.At(0).SetSpanID(pdata.NewSpanID(tracetranslator.UInt64ToByteSpanID(uint64(i + 1))))
 			tds = append(tds, td)
 		}
 	}
@@ -245,7 +240,7 @@ type mockPolicyEvaluator struct {
 
 var _ (sampling.PolicyEvaluator) = (*mockPolicyEvaluator)(nil)
 
-func (m *mockPolicyEvaluator) OnLateArrivingSpans(earlyDecision sampling.Decision, spans []*tracepb.Span) error {
+func (m *mockPolicyEvaluator) OnLateArrivingSpans(earlyDecision sampling.Decision, spans []pdata.Span) error {
 	m.LateArrivingSpansCount++
 	return m.NextError
 }
@@ -314,7 +309,206 @@ type mockSpanProcessor struct {
 	TotalSpans int
 }
 
-func (p *mockSpanProcessor) ConsumeTraceData(ctx context.Context, td consumerdata.TraceData) error {
-	p.TotalSpans += len(td.Spans)
+func (p *mockSpanProcessor) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	p.TotalSpans += td.SpanCount()
 	return nil
 }
+
+func TestGetPolicyEvaluator(t *testing.T) {
+	validCfgs := []PolicyCfg{
+		{Name: "latency", Type: Latency, LatencyCfg: LatencyCfg{ThresholdMs: 100}},
+		{Name: "status-code", Type: StatusCode, StatusCodeCfg: StatusCodeCfg{StatusCodes: []string{"UnknownError"}}},
+		{Name: "probabilistic", Type: Probabilistic, ProbabilisticCfg: ProbabilisticCfg{SamplingPercentage: 5}},
+		{
+			Name: "composite",
+			Type: Composite,
+			CompositeCfg: CompositeCfg{
+				MaxTotalSpansPerSecond: 100,
+				SubPolicyCfgs: []SubPolicyCfg{
+					{Name: "errors", Type: StatusCode},
+					{Name: "slow", Type: Latency, LatencyCfg: LatencyCfg{ThresholdMs: 5000}},
+				},
+				RateAllocation: []RateAllocationCfg{{Policy: "errors", Percent: 60}},
+			},
+		},
+	}
+	for i := range validCfgs {
+		eval, err := getPolicyEvaluator(zap.NewNop(), &validCfgs[i])
+		assert.NoError(t, err, validCfgs[i].Name)
+		assert.NotNil(t, eval, validCfgs[i].Name)
+	}
+
+	invalidCfgs := []struct {
+		cfg PolicyCfg
+		err string
+	}{
+		{
+			cfg: PolicyCfg{Type: "bogus"},
+			err: "unknown sampling policy type bogus",
+		},
+		{
+			cfg: PolicyCfg{Type: StatusCode, StatusCodeCfg: StatusCodeCfg{StatusCodes: []string{"Bogus"}}},
+			err: `unrecognized status code "Bogus"`,
+		},
+		{
+			cfg: PolicyCfg{Type: Composite, CompositeCfg: CompositeCfg{Operator: "xor"}},
+			err: `unknown composite policy operator "xor"`,
+		},
+		{
+			cfg: PolicyCfg{Type: Composite, CompositeCfg: CompositeCfg{
+				SubPolicyCfgs: []SubPolicyCfg{{Name: "nested", Type: Composite}},
+			}},
+			err: `composite sub-policy "nested": unknown sampling policy type composite`,
+		},
+		{
+			cfg: PolicyCfg{Type: Composite, CompositeCfg: CompositeCfg{
+				MaxTotalSpansPerSecond: 100,
+				SubPolicyCfgs:          []SubPolicyCfg{{Name: "a", Type: AlwaysSample}},
+				RateAllocation:         []RateAllocationCfg{{Policy: "b", Percent: 50}},
+			}},
+			err: `composite policy rate allocation refers to unknown sub-policy "b"`,
+		},
+		{
+			cfg: PolicyCfg{Type: Composite, CompositeCfg: CompositeCfg{
+				MaxTotalSpansPerSecond: 100,
+				SubPolicyCfgs:          []SubPolicyCfg{{Name: "a", Type: AlwaysSample}, {Name: "b", Type: AlwaysSample}},
+				RateAllocation:         []RateAllocationCfg{{Policy: "a", Percent: 60}, {Policy: "b", Percent: 50}},
+			}},
+			err: "composite policy rate allocation adds up to 110%, more than 100%",
+		},
+		{
+			cfg: PolicyCfg{Type: Composite, CompositeCfg: CompositeCfg{
+				SubPolicyCfgs:  []SubPolicyCfg{{Name: "a", Type: AlwaysSample}},
+				RateAllocation: []RateAllocationCfg{{Policy: "a", Percent: 50}},
+			}},
+			err: "composite policy rate allocation requires max_total_spans_per_second",
+		},
+		{
+			cfg: PolicyCfg{Type: Composite, CompositeCfg: CompositeCfg{
+				MaxTotalSpansPerSecond: 100,
+				SubPolicyCfgs:          []SubPolicyCfg{{Name: "a", Type: AlwaysSample}},
+				RateAllocation:         []RateAllocationCfg{{Policy: "a"}},
+			}},
+			err: `composite policy rate allocation for "a" must be a positive percentage`,
+		},
+	}
+	for _, tt := range invalidCfgs {
+		_, err := getPolicyEvaluator(zap.NewNop(), &tt.cfg)
+		assert.EqualError(t, err, tt.err)
+	}
+}
+
+func TestLateSpansUseCachedDecision(t *testing.T) {
+	for _, decision := range []sampling.Decision{sampling.Sampled, sampling.NotSampled} {
+		msp := &mockSpanProcessor{}
+		mpe := &mockPolicyEvaluator{NextDecision: decision}
+		tsp := &tailSamplingSpanProcessor{
+			ctx:             context.Background(),
+			nextConsumer:    msp,
+			maxNumTraces:    1,
+			logger:          zap.NewNop(),
+			decisionBatcher: newSyncIDBatcher(1),
+			policies:        []*Policy{{Name: "mock-policy", Evaluator: mpe, ctx: context.TODO()}},
+			deleteChan:      make(chan traceKey, 1),
+			policyTicker:    &manualTTicker{},
+			decisionCache:   newDecisionCache(10),
+		}
+
+		traceIds, batches := generateIdsAndBatches(2)
+		// batches[0] is the only span of the first trace, batches[1:] the spans of the second one.
+		require.NoError(t, tsp.ConsumeTraces(context.Background(), batches[0]))
+		tsp.samplingPolicyOnTick()
+		tsp.samplingPolicyOnTick()
+		require.Equal(t, 1, mpe.EvaluationCount)
+		sampledSpans := msp.TotalSpans
+
+		// The second trace evicts the first one, whose decision goes to the cache.
+		require.NoError(t, tsp.ConsumeTraces(context.Background(), batches[1]))
+		_, ok := tsp.idToTrace.Load(traceKey(traceIds[0]))
+		require.False(t, ok)
+
+		// A late span of the first trace gets the cached decision instead of starting a new trace.
+		require.NoError(t, tsp.ConsumeTraces(context.Background(), batches[0]))
+		_, ok = tsp.idToTrace.Load(traceKey(traceIds[0]))
+		assert.False(t, ok, "late span started a new trace")
+		assert.Equal(t, 1, mpe.LateArrivingSpansCount)
+		if decision == sampling.Sampled {
+			assert.Equal(t, sampledSpans+1, msp.TotalSpans, "late span of a sampled trace was not forwarded")
+		} else {
+			assert.Equal(t, 0, msp.TotalSpans, "late span of a not sampled trace was forwarded")
+		}
+	}
+}
+
+func TestDecisionCacheDisabled(t *testing.T) {
+	dc := newDecisionCache(0)
+	assert.Nil(t, dc)
+	dc.add("id", cachedDecision{decisions: []sampling.Decision{sampling.Sampled}})
+	_, ok := dc.get("id")
+	assert.False(t, ok)
+}
+
+func TestDecisionCacheEviction(t *testing.T) {
+	dc := newDecisionCache(2)
+	dc.add("1", cachedDecision{decisions: []sampling.Decision{sampling.Sampled}})
+	dc.add("2", cachedDecision{decisions: []sampling.Decision{sampling.NotSampled}})
+	dc.add("3", cachedDecision{decisions: []sampling.Decision{sampling.Sampled}})
+
+	_, ok := dc.get("1")
+	assert.False(t, ok)
+	cached, ok := dc.get("2")
+	require.True(t, ok)
+	assert.Equal(t, []sampling.Decision{sampling.NotSampled}, cached.decisions)
+}
+
+func TestConsumeTracesGroupsSpansByTrace(t *testing.T) {
+	traceID1 := tracetranslator.UInt64ToByteTraceID(1, 1)
+	traceID2 := tracetranslator.UInt64ToByteTraceID(1, 2)
+
+	td := pdata.NewTraces()
+	td.ResourceSpans().Resize(2)
+	for i := 0; i < 2; i++ {
+		rs := td.ResourceSpans().At(i)
+		rs.Resource().InitEmpty()
+		rs.Resource().Attributes().InsertInt("resource", int64(i))
+		rs.InstrumentationLibrarySpans().Resize(1)
+		ils := rs.InstrumentationLibrarySpans().At(0)
+		ils.InstrumentationLibrary().InitEmpty()
+		ils.InstrumentationLibrary().SetName("library")
+		spans := ils.Spans()
+		spans.Resize(3)
+		spans.At(0).SetTraceID(pdata.NewTraceID(traceID1))
+		spans.At(1).SetTraceID(pdata.NewTraceID(traceID2))
+		// Span without trace ID is ignored.
+	}
+
+	cfg := Config{
+		DecisionWait:            defaultTestDecisionWait,
+		NumTraces:               10,
+		ExpectedNewTracesPerSec: 64,
+		PolicyCfgs:              testPolicy,
+	}
+	sp, err := newTraceProcessor(zap.NewNop(), &exportertest.SinkTraceExporter{}, cfg)
+	require.NoError(t, err)
+	tsp := sp.(*tailSamplingSpanProcessor)
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), td))
+	assert.Equal(t, uint64(2), atomic.LoadUint64(&tsp.numTracesOnMap))
+	for _, id := range [][]byte{traceID1, traceID2} {
+		d, ok := tsp.idToTrace.Load(traceKey(id))
+		require.True(t, ok)
+		traceData := d.(*sampling.TraceData)
+		assert.Equal(t, int64(2), traceData.SpanCount)
+		require.Len(t, traceData.ReceivedBatches, 1)
+		trace := traceData.ReceivedBatches[0]
+		require.Equal(t, 2, trace.ResourceSpans().Len())
+		for i := 0; i < 2; i++ {
+			rs := trace.ResourceSpans().At(i)
+			v, ok := rs.Resource().Attributes().Get("resource")
+			require.True(t, ok)
+			assert.Equal(t, int64(i), v.IntVal())
+			ils := rs.InstrumentationLibrarySpans().At(0)
+			assert.Equal(t, "library", ils.InstrumentationLibrary().Name())
+			assert.Equal(t, pdata.NewTraceID(id), ils.Spans().At(0).TraceID())
+		}
+	}
+}
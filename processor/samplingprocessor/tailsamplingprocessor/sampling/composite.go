@@ -0,0 +1,161 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sampling
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// CompositeOperator indicates how the decisions of the sub-policies of a composite
+// policy are combined.
+type CompositeOperator int
+
+const (
+	// CompositeOr samples a trace if any of the sub-policies samples it.
+	CompositeOr CompositeOperator = iota
+	// CompositeAnd samples a trace only if all the sub-policies sample it.
+	CompositeAnd
+)
+
+// SubPolicyEvalParams defines the evaluator and the share of the spans per second
+// budget of a sub-policy of a composite policy.
+type SubPolicyEvalParams struct {
+	Evaluator PolicyEvaluator
+	// MaxSpansPerSecond is the maximum number of spans per second sampled on behalf
+	// of this sub-policy, zero means that only the total limit applies.
+	MaxSpansPerSecond int64
+}
+
+type subPolicy struct {
+	evaluator            PolicyEvaluator
+	maxSpansPerSecond    int64
+	spansInCurrentSecond int64
+}
+
+type composite struct {
+	subPolicies            []*subPolicy
+	operator               CompositeOperator
+	maxTotalSpansPerSecond int64
+	currentSecond          int64
+	spansInCurrentSecond   int64
+	// now returns the current second, replaced in tests.
+	now    func() int64
+	logger *zap.Logger
+}
+
+var _ PolicyEvaluator = (*composite)(nil)
+
+// NewComposite creates a policy evaluator that combines the decisions of the given
+// sub-policies with the given operator. At most maxTotalSpansPerSecond spans are sampled
+// each second, zero meaning no limit. With the CompositeOr operator the sub-policies are
+// evaluated in order and a trace is sampled on behalf of the first sub-policy that samples
+// it and has not exceeded its own share of the budget. With the CompositeAnd operator only
+// the total limit applies.
+func NewComposite(
+	logger *zap.Logger,
+	operator CompositeOperator,
+	maxTotalSpansPerSecond int64,
+	subPolicyParams []SubPolicyEvalParams,
+) PolicyEvaluator {
+	subPolicies := make([]*subPolicy, 0, len(subPolicyParams))
+	for _, params := range subPolicyParams {
+		subPolicies = append(subPolicies, &subPolicy{
+			evaluator:         params.Evaluator,
+			maxSpansPerSecond: params.MaxSpansPerSecond,
+		})
+	}
+	return &composite{
+		subPolicies:            subPolicies,
+		operator:               operator,
+		maxTotalSpansPerSecond: maxTotalSpansPerSecond,
+		now:                    func() int64 { return time.Now().Unix() },
+		logger:                 logger,
+	}
+}
+
+// OnLateArrivingSpans notifies the evaluator that the given list of spans arrived
+// after the sampling decision was already taken for the trace.
+// This gives the evaluator a chance to log any message/metrics and/or update any
+// related internal state.
+func (c *composite) OnLateArrivingSpans(earlyDecision Decision, spans []pdata.Span) error {
+	c.logger.Debug("Triggering action for late arriving spans in composite filter")
+	for _, sub := range c.subPolicies {
+		if err := sub.evaluator.OnLateArrivingSpans(earlyDecision, spans); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Evaluate looks at the trace data and returns a corresponding SamplingDecision.
+func (c *composite) Evaluate(traceID []byte, trace *TraceData) (Decision, error) {
+	c.logger.Debug("Evaluating spans in composite filter")
+	currSecond := c.now()
+	if c.currentSecond != currSecond {
+		c.currentSecond = currSecond
+		c.spansInCurrentSecond = 0
+		for _, sub := range c.subPolicies {
+			sub.spansInCurrentSecond = 0
+		}
+	}
+
+	trace.Lock()
+	spanCount := trace.SpanCount
+	trace.Unlock()
+	if c.maxTotalSpansPerSecond > 0 && c.spansInCurrentSecond+spanCount > c.maxTotalSpansPerSecond {
+		return NotSampled, nil
+	}
+
+	if c.operator == CompositeAnd {
+		for _, sub := range c.subPolicies {
+			decision, err := sub.evaluator.Evaluate(traceID, trace)
+			if err != nil {
+				return Unspecified, err
+			}
+			if decision != Sampled {
+				return NotSampled, nil
+			}
+		}
+		c.spansInCurrentSecond += spanCount
+		return Sampled, nil
+	}
+
+	for _, sub := range c.subPolicies {
+		if sub.maxSpansPerSecond > 0 && sub.spansInCurrentSecond+spanCount > sub.maxSpansPerSecond {
+			continue
+		}
+		decision, err := sub.evaluator.Evaluate(traceID, trace)
+		if err != nil {
+			return Unspecified, err
+		}
+		if decision == Sampled {
+			sub.spansInCurrentSecond += spanCount
+			c.spansInCurrentSecond += spanCount
+			return Sampled, nil
+		}
+	}
+	return NotSampled, nil
+}
+
+// OnDroppedSpans is called when the trace needs to be dropped, due to memory
+// pressure, before the decision_wait time has been reached.
+func (c *composite) OnDroppedSpans(traceID []byte, trace *TraceData) (Decision, error) {
+	c.logger.Debug("Triggering action for dropped spans in composite filter")
+	return NotSampled, nil
+}
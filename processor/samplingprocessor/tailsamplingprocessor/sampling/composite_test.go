@@ -0,0 +1,156 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sampling
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+type fixedEvaluator struct {
+	decision    Decision
+	err         error
+	evaluations int
+	lateSpans   int
+}
+
+var _ PolicyEvaluator = (*fixedEvaluator)(nil)
+
+func (f *fixedEvaluator) OnLateArrivingSpans(earlyDecision Decision, spans []pdata.Span) error {
+	f.lateSpans += len(spans)
+	return nil
+}
+
+func (f *fixedEvaluator) Evaluate(traceID []byte, trace *TraceData) (Decision, error) {
+	f.evaluations++
+	return f.decision, f.err
+}
+
+func (f *fixedEvaluator) OnDroppedSpans(traceID []byte, trace *TraceData) (Decision, error) {
+	return NotSampled, nil
+}
+
+func newTestComposite(operator CompositeOperator, maxTotal int64, params ...SubPolicyEvalParams) (*composite, *int64) {
+	c := NewComposite(zap.NewNop(), operator, maxTotal, params).(*composite)
+	second := int64(1)
+	c.now = func() int64 { return second }
+	return c, &second
+}
+
+func evaluateTrace(t *testing.T, evaluator PolicyEvaluator, trace *TraceData) Decision {
+	u, _ := uuid.NewRandom()
+	decision, err := evaluator.Evaluate(u[:], trace)
+	require.NoError(t, err)
+	return decision
+}
+
+func TestComposite_ErrorsSlowTracesAndPercentage(t *testing.T) {
+	statusCode, err := NewStatusCodeFilter(zap.NewNop(), nil)
+	require.NoError(t, err)
+	c, _ := newTestComposite(CompositeOr, 0,
+		SubPolicyEvalParams{Evaluator: statusCode},
+		SubPolicyEvalParams{Evaluator: NewLatency(zap.NewNop(), 1000)},
+		SubPolicyEvalParams{Evaluator: NewProbabilisticSampler(zap.NewNop(), "", 0)},
+	)
+
+	now := time.Now()
+	errorSpan := newTimedSpan(now, time.Millisecond)
+	errorSpan.Status().InitEmpty()
+	errorSpan.Status().SetCode(pdata.StatusCode(2))
+	assert.Equal(t, Sampled, evaluateTrace(t, c, newTraceWithSpans(errorSpan)))
+	assert.Equal(t, Sampled, evaluateTrace(t, c, newTraceWithSpans(newTimedSpan(now, 2*time.Second))))
+	assert.Equal(t, NotSampled, evaluateTrace(t, c, newTraceWithSpans(newTimedSpan(now, time.Millisecond))))
+}
+
+func TestComposite_OrRateAllocation(t *testing.T) {
+	first := &fixedEvaluator{decision: Sampled}
+	second := &fixedEvaluator{decision: Sampled}
+	c, currentSecond := newTestComposite(CompositeOr, 10,
+		SubPolicyEvalParams{Evaluator: first, MaxSpansPerSecond: 4},
+		SubPolicyEvalParams{Evaluator: second, MaxSpansPerSecond: 4},
+	)
+	trace := newTraceWithSpans(newEmptySpan(), newEmptySpan())
+
+	// Each sub-policy samples up to its share, the first one is used first.
+	for i := 0; i < 4; i++ {
+		assert.Equal(t, Sampled, evaluateTrace(t, c, trace))
+	}
+	assert.Equal(t, 2, first.evaluations)
+	assert.Equal(t, 2, second.evaluations)
+	assert.Equal(t, NotSampled, evaluateTrace(t, c, trace))
+
+	// The budget is reset every second.
+	*currentSecond++
+	assert.Equal(t, Sampled, evaluateTrace(t, c, trace))
+	assert.Equal(t, 3, first.evaluations)
+}
+
+func TestComposite_OrTotalLimit(t *testing.T) {
+	first := &fixedEvaluator{decision: NotSampled}
+	second := &fixedEvaluator{decision: Sampled}
+	c, _ := newTestComposite(CompositeOr, 3,
+		SubPolicyEvalParams{Evaluator: first},
+		SubPolicyEvalParams{Evaluator: second},
+	)
+	trace := newTraceWithSpans(newEmptySpan(), newEmptySpan())
+
+	assert.Equal(t, Sampled, evaluateTrace(t, c, trace))
+	assert.Equal(t, NotSampled, evaluateTrace(t, c, trace))
+	assert.Equal(t, 1, first.evaluations)
+	assert.Equal(t, 1, second.evaluations)
+}
+
+func TestComposite_And(t *testing.T) {
+	sampled := &fixedEvaluator{decision: Sampled}
+	notSampled := &fixedEvaluator{decision: NotSampled}
+	trace := newTraceWithSpans(newEmptySpan())
+
+	c, _ := newTestComposite(CompositeAnd, 1,
+		SubPolicyEvalParams{Evaluator: sampled},
+		SubPolicyEvalParams{Evaluator: sampled},
+	)
+	assert.Equal(t, Sampled, evaluateTrace(t, c, trace))
+	assert.Equal(t, NotSampled, evaluateTrace(t, c, trace), "total limit reached")
+
+	c, _ = newTestComposite(CompositeAnd, 0,
+		SubPolicyEvalParams{Evaluator: sampled},
+		SubPolicyEvalParams{Evaluator: notSampled},
+	)
+	assert.Equal(t, NotSampled, evaluateTrace(t, c, trace))
+}
+
+func TestComposite_EvaluateError(t *testing.T) {
+	c, _ := newTestComposite(CompositeOr, 0,
+		SubPolicyEvalParams{Evaluator: &fixedEvaluator{err: errors.New("boom")}},
+	)
+	u, _ := uuid.NewRandom()
+	_, err := c.Evaluate(u[:], newTraceWithSpans())
+	assert.EqualError(t, err, "boom")
+}
+
+func TestComposite_OnLateArrivingSpans(t *testing.T) {
+	sub := &fixedEvaluator{decision: Sampled}
+	c, _ := newTestComposite(CompositeOr, 0, SubPolicyEvalParams{Evaluator: sub})
+	require.NoError(t, c.OnLateArrivingSpans(Sampled, []pdata.Span{newEmptySpan(), newEmptySpan()}))
+	assert.Equal(t, 2, sub.lateSpans)
+}
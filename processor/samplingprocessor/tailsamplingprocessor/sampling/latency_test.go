@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sampling
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+func TestLatencyFilter(t *testing.T) {
+	filter := NewLatency(zap.NewNop(), 5000)
+	now := time.Now()
+
+	cases := []struct {
+		Desc     string
+		Trace    *TraceData
+		Decision Decision
+	}{
+		{
+			Desc:     "no spans",
+			Trace:    newTraceWithSpans(),
+			Decision: NotSampled,
+		},
+		{
+			Desc:     "span without timestamps",
+			Trace:    newTraceWithSpans(newEmptySpan()),
+			Decision: NotSampled,
+		},
+		{
+			Desc:     "trace duration shorter than threshold",
+			Trace:    newTraceWithSpans(newTimedSpan(now, 4500*time.Millisecond)),
+			Decision: NotSampled,
+		},
+		{
+			Desc:     "trace duration equal to threshold",
+			Trace:    newTraceWithSpans(newTimedSpan(now, 5000*time.Millisecond)),
+			Decision: Sampled,
+		},
+		{
+			Desc: "trace duration spread over several spans",
+			Trace: newTraceWithSpans(
+				newTimedSpan(now, 3000*time.Millisecond),
+				newTimedSpan(now.Add(2500*time.Millisecond), 3000*time.Millisecond),
+			),
+			Decision: Sampled,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Desc, func(t *testing.T) {
+			u, _ := uuid.NewRandom()
+			decision, err := filter.Evaluate(u[:], c.Trace)
+			assert.NoError(t, err)
+			assert.Equal(t, c.Decision, decision)
+		})
+	}
+}
+
+func newTimedSpan(start time.Time, duration time.Duration) pdata.Span {
+	span := newEmptySpan()
+	span.SetStartTime(pdata.TimestampUnixNano(start.UnixNano()))
+	span.SetEndTime(pdata.TimestampUnixNano(start.Add(duration).UnixNano()))
+	return span
+}
+
+func newEmptySpan() pdata.Span {
+	span := pdata.NewSpan()
+	span.InitEmpty()
+	return span
+}
+
+// newTraceWithSpans returns a trace with a single batch with the given spans, nil spans are skipped.
+func newTraceWithSpans(spans ...pdata.Span) *TraceData {
+	batch := pdata.NewTraces()
+	batch.ResourceSpans().Resize(1)
+	ilss := batch.ResourceSpans().At(0).InstrumentationLibrarySpans()
+	ilss.Resize(1)
+	var spanCount int64
+	for i := range spans {
+		if spans[i].IsNil() {
+			continue
+		}
+		ilss.At(0).Spans().Append(&spans[i])
+		spanCount++
+	}
+	return &TraceData{
+		SpanCount:       spanCount,
+		ReceivedBatches: []pdata.Traces{batch},
+	}
+}
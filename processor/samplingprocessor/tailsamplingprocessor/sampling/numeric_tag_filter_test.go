@@ -18,12 +18,11 @@ import (
 	"math"
 	"testing"
 
-	tracepb "github.com/census-instrumentation/opencensus-proto/gen-go/trace/v1"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/zap"
 
-	"go.opentelemetry.io/collector/consumer/consumerdata"
+	"go.opentelemetry.io/collector/consumer/pdata"
 )
 
 func TestNumericTagFilter(t *testing.T) {
@@ -73,24 +72,8 @@ func TestNumericTagFilter(t *testing.T) {
 }
 
 func newTraceIntAttrs(attrKey string, attrValue int64) *TraceData {
-
-	return &TraceData{
-		ReceivedBatches: []consumerdata.TraceData{
-			{
-				Spans: []*tracepb.Span{
-					{
-						Attributes: &tracepb.Span_Attributes{
-							AttributeMap: map[string]*tracepb.AttributeValue{
-								attrKey: {
-									Value: &tracepb.AttributeValue_IntValue{
-										IntValue: attrValue,
-									},
-								},
-							},
-						},
-					},
-				},
-			},
-		},
-	}
+	span := pdata.NewSpan()
+	span.InitEmpty()
+	span.Attributes().InsertInt(attrKey, attrValue)
+	return newTraceWithSpans(span)
 }
@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sampling
+
+import (
+	"hash/fnv"
+	"math"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+const defaultHashSalt = "default-hash-seed"
+
+type probabilisticSampler struct {
+	threshold uint64
+	hashSalt  string
+	logger    *zap.Logger
+}
+
+var _ PolicyEvaluator = (*probabilisticSampler)(nil)
+
+// NewProbabilisticSampler creates a policy evaluator that samples the given percentage
+// of traces. The decision is based on a hash of the trace ID, so all collectors using
+// the same hash salt take the same decision for a given trace.
+func NewProbabilisticSampler(logger *zap.Logger, hashSalt string, samplingPercentage float64) PolicyEvaluator {
+	if hashSalt == "" {
+		hashSalt = defaultHashSalt
+	}
+	return &probabilisticSampler{
+		threshold: calculateThreshold(samplingPercentage / 100),
+		hashSalt:  hashSalt,
+		logger:    logger,
+	}
+}
+
+// OnLateArrivingSpans notifies the evaluator that the given list of spans arrived
+// after the sampling decision was already taken for the trace.
+// This gives the evaluator a chance to log any message/metrics and/or update any
+// related internal state.
+func (ps *probabilisticSampler) OnLateArrivingSpans(earlyDecision Decision, spans []pdata.Span) error {
+	ps.logger.Debug("Triggering action for late arriving spans in probabilistic filter")
+	return nil
+}
+
+// Evaluate looks at the trace data and returns a corresponding SamplingDecision.
+func (ps *probabilisticSampler) Evaluate(traceID []byte, trace *TraceData) (Decision, error) {
+	ps.logger.Debug("Evaluating spans in probabilistic filter")
+	if hashTraceID(ps.hashSalt, traceID) <= ps.threshold {
+		return Sampled, nil
+	}
+	return NotSampled, nil
+}
+
+// OnDroppedSpans is called when the trace needs to be dropped, due to memory
+// pressure, before the decision_wait time has been reached.
+func (ps *probabilisticSampler) OnDroppedSpans(traceID []byte, trace *TraceData) (Decision, error) {
+	ps.logger.Debug("Triggering action for dropped spans in probabilistic filter")
+	return NotSampled, nil
+}
+
+// calculateThreshold converts a ratio into a value between 0 and MaxUint64.
+func calculateThreshold(ratio float64) uint64 {
+	switch {
+	case ratio <= 0:
+		return 0
+	case ratio >= 1:
+		return math.MaxUint64
+	}
+	// The highest float64 below 2^64 is used to avoid overflowing when converting back to uint64.
+	return uint64(ratio * math.Nextafter(math.MaxUint64, 0))
+}
+
+// hashTraceID creates a hash using the FNV-1a algorithm.
+func hashTraceID(salt string, b []byte) uint64 {
+	hasher := fnv.New64a()
+	// the implementation fnv.Write() does not return an error, see hash/fnv/fnv.go
+	_, _ = hasher.Write([]byte(salt))
+	_, _ = hasher.Write(b)
+	return hasher.Sum64()
+}
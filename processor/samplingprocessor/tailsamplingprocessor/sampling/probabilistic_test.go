@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sampling
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestProbabilisticSampler(t *testing.T) {
+	cases := []struct {
+		Desc               string
+		SamplingPercentage float64
+		HashSalt           string
+	}{
+		{Desc: "sample nothing", SamplingPercentage: 0},
+		{Desc: "sample 5 percent", SamplingPercentage: 5},
+		{Desc: "sample 33 percent with salt", SamplingPercentage: 33, HashSalt: "salt"},
+		{Desc: "sample everything", SamplingPercentage: 100},
+	}
+
+	const traceCount = 100000
+	for _, c := range cases {
+		t.Run(c.Desc, func(t *testing.T) {
+			filter := NewProbabilisticSampler(zap.NewNop(), c.HashSalt, c.SamplingPercentage)
+			sampled := 0
+			for i := 0; i < traceCount; i++ {
+				u, _ := uuid.NewRandom()
+				decision, err := filter.Evaluate(u[:], newTraceWithSpans())
+				assert.NoError(t, err)
+				if decision == Sampled {
+					sampled++
+				}
+			}
+			assert.InDelta(t, c.SamplingPercentage, float64(sampled)*100/traceCount, 0.5)
+		})
+	}
+}
+
+func TestProbabilisticSampler_SameDecisionForTraceID(t *testing.T) {
+	filter := NewProbabilisticSampler(zap.NewNop(), "", 50)
+	other := NewProbabilisticSampler(zap.NewNop(), defaultHashSalt, 50)
+	for i := 0; i < 100; i++ {
+		u, _ := uuid.NewRandom()
+		first, _ := filter.Evaluate(u[:], newTraceWithSpans())
+		second, _ := other.Evaluate(u[:], newTraceWithSpans())
+		assert.Equal(t, first, second)
+	}
+}
+
+func TestCalculateThreshold(t *testing.T) {
+	assert.Equal(t, uint64(0), calculateThreshold(-1))
+	assert.Equal(t, uint64(0), calculateThreshold(0))
+	assert.Equal(t, uint64(math.MaxUint64), calculateThreshold(1))
+	assert.Equal(t, uint64(math.MaxUint64), calculateThreshold(2))
+	assert.InDelta(t, float64(math.MaxUint64)/2, float64(calculateThreshold(0.5)), 1e6)
+}
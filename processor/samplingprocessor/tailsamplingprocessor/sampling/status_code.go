@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sampling
+
+import (
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	otlptrace "go.opentelemetry.io/collector/internal/data/opentelemetry-proto-gen/trace/v1"
+)
+
+type statusCodeFilter struct {
+	// codes is nil when any status code other than Ok must be sampled.
+	codes  map[pdata.StatusCode]struct{}
+	logger *zap.Logger
+}
+
+var _ PolicyEvaluator = (*statusCodeFilter)(nil)
+
+// NewStatusCodeFilter creates a policy evaluator that samples all traces with at least
+// one span with one of the given status codes, e.g. "UnknownError" or "DeadlineExceeded".
+// The names are case insensitive. If no status codes are given, all traces with at least
+// one span with a status code other than "Ok" are sampled. Spans without status are
+// considered to have the "Ok" status code.
+func NewStatusCodeFilter(logger *zap.Logger, statusCodes []string) (PolicyEvaluator, error) {
+	var codes map[pdata.StatusCode]struct{}
+	if len(statusCodes) > 0 {
+		codes = make(map[pdata.StatusCode]struct{}, len(statusCodes))
+	}
+	for _, name := range statusCodes {
+		found := false
+		for code, codeName := range otlptrace.Status_StatusCode_name {
+			if strings.EqualFold(name, codeName) {
+				codes[pdata.StatusCode(code)] = struct{}{}
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unrecognized status code %q", name)
+		}
+	}
+	return &statusCodeFilter{
+		codes:  codes,
+		logger: logger,
+	}, nil
+}
+
+// OnLateArrivingSpans notifies the evaluator that the given list of spans arrived
+// after the sampling decision was already taken for the trace.
+// This gives the evaluator a chance to log any message/metrics and/or update any
+// related internal state.
+func (scf *statusCodeFilter) OnLateArrivingSpans(earlyDecision Decision, spans []pdata.Span) error {
+	scf.logger.Debug("Triggering action for late arriving spans in status-code filter")
+	return nil
+}
+
+// Evaluate looks at the trace data and returns a corresponding SamplingDecision.
+func (scf *statusCodeFilter) Evaluate(traceID []byte, trace *TraceData) (Decision, error) {
+	scf.logger.Debug("Evaluating spans in status-code filter")
+	matched := hasSpanWithCondition(trace, func(_ pdata.Resource, span pdata.Span) bool {
+		code := pdata.StatusCode(otlptrace.Status_Ok)
+		if status := span.Status(); !status.IsNil() {
+			code = status.Code()
+		}
+		return scf.matches(code)
+	})
+	if matched {
+		return Sampled, nil
+	}
+	return NotSampled, nil
+}
+
+func (scf *statusCodeFilter) matches(code pdata.StatusCode) bool {
+	if scf.codes == nil {
+		return code != pdata.StatusCode(otlptrace.Status_Ok)
+	}
+	_, ok := scf.codes[code]
+	return ok
+}
+
+// OnDroppedSpans is called when the trace needs to be dropped, due to memory
+// pressure, before the decision_wait time has been reached.
+func (scf *statusCodeFilter) OnDroppedSpans(traceID []byte, trace *TraceData) (Decision, error) {
+	scf.logger.Debug("Triggering action for dropped spans in status-code filter")
+	return NotSampled, nil
+}
@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sampling
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+func TestStatusCodeFilter(t *testing.T) {
+	okSpan := newStatusSpan(pdata.StatusCode(0))
+	noStatusSpan := newEmptySpan()
+	unknownErrorSpan := newStatusSpan(pdata.StatusCode(2))
+	deadlineSpan := newStatusSpan(pdata.StatusCode(4))
+
+	cases := []struct {
+		Desc        string
+		StatusCodes []string
+		Trace       *TraceData
+		Decision    Decision
+	}{
+		{
+			Desc:     "any error, only ok spans",
+			Trace:    newTraceWithSpans(okSpan, noStatusSpan),
+			Decision: NotSampled,
+		},
+		{
+			Desc:     "any error, one error span",
+			Trace:    newTraceWithSpans(okSpan, deadlineSpan),
+			Decision: Sampled,
+		},
+		{
+			Desc:        "listed codes, no matching span",
+			StatusCodes: []string{"UnknownError"},
+			Trace:       newTraceWithSpans(okSpan, deadlineSpan),
+			Decision:    NotSampled,
+		},
+		{
+			Desc:        "listed codes, matching span",
+			StatusCodes: []string{"unknownerror", "DeadlineExceeded"},
+			Trace:       newTraceWithSpans(noStatusSpan, unknownErrorSpan),
+			Decision:    Sampled,
+		},
+		{
+			Desc:        "listed ok code, span without status",
+			StatusCodes: []string{"Ok"},
+			Trace:       newTraceWithSpans(noStatusSpan),
+			Decision:    Sampled,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Desc, func(t *testing.T) {
+			filter, err := NewStatusCodeFilter(zap.NewNop(), c.StatusCodes)
+			require.NoError(t, err)
+			u, _ := uuid.NewRandom()
+			decision, err := filter.Evaluate(u[:], c.Trace)
+			assert.NoError(t, err)
+			assert.Equal(t, c.Decision, decision)
+		})
+	}
+}
+
+func TestStatusCodeFilter_InvalidCode(t *testing.T) {
+	_, err := NewStatusCodeFilter(zap.NewNop(), []string{"Ok", "Bogus"})
+	assert.EqualError(t, err, `unrecognized status code "Bogus"`)
+}
+
+func newStatusSpan(code pdata.StatusCode) pdata.Span {
+	span := newEmptySpan()
+	span.Status().InitEmpty()
+	span.Status().SetCode(code)
+	return span
+}
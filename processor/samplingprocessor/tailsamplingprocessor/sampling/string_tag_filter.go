@@ -15,8 +15,9 @@
 package sampling
 
 import (
-	tracepb "github.com/census-instrumentation/opencensus-proto/gen-go/trace/v1"
 	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
 )
 
 type stringAttributeFilter struct {
@@ -47,7 +48,7 @@ func NewStringAttributeFilter(logger *zap.Logger, key string, values []string) P
 // after the sampling decision was already taken for the trace.
 // This gives the evaluator a chance to log any message/metrics and/or update any
 // related internal state.
-func (saf *stringAttributeFilter) OnLateArrivingSpans(earlyDecision Decision, spans []*tracepb.Span) error {
+func (saf *stringAttributeFilter) OnLateArrivingSpans(earlyDecision Decision, spans []pdata.Span) error {
 	saf.logger.Debug("Triggering action for late arriving spans in string-tag filter")
 	return nil
 }
@@ -55,36 +56,26 @@ func (saf *stringAttributeFilter) OnLateArrivingSpans(earlyDecision Decision, sp
 // Evaluate looks at the trace data and returns a corresponding SamplingDecision.
 func (saf *stringAttributeFilter) Evaluate(traceID []byte, trace *TraceData) (Decision, error) {
 	saf.logger.Debug("Evaluting spans in string-tag filter")
-	trace.Lock()
-	batches := trace.ReceivedBatches
-	trace.Unlock()
-	for _, batch := range batches {
-		node := batch.Node
-		if node != nil && node.Attributes != nil {
-			if v, ok := node.Attributes[saf.key]; ok {
-				if _, ok := saf.values[v]; ok {
-					return Sampled, nil
-				}
-			}
-		}
-		for _, span := range batch.Spans {
-			if span == nil || span.Attributes == nil {
-				continue
-			}
-			if v, ok := span.Attributes.AttributeMap[saf.key]; ok {
-				truncableStr := v.GetStringValue()
-				if truncableStr != nil {
-					if _, ok := saf.values[truncableStr.Value]; ok {
-						return Sampled, nil
-					}
-				}
-			}
+	matched := hasSpanWithCondition(trace, func(resource pdata.Resource, span pdata.Span) bool {
+		if !resource.IsNil() && saf.matches(resource.Attributes()) {
+			return true
 		}
+		return saf.matches(span.Attributes())
+	})
+	if matched {
+		return Sampled, nil
 	}
-
 	return NotSampled, nil
 }
 
+func (saf *stringAttributeFilter) matches(attrs pdata.AttributeMap) bool {
+	if v, ok := attrs.Get(saf.key); ok && v.Type() == pdata.AttributeValueSTRING {
+		_, ok := saf.values[v.StringVal()]
+		return ok
+	}
+	return false
+}
+
 // OnDroppedSpans is called when the trace needs to be dropped, due to memory
 // pressure, before the decision_wait time has been reached.
 func (saf *stringAttributeFilter) OnDroppedSpans(traceID []byte, trace *TraceData) (Decision, error) {
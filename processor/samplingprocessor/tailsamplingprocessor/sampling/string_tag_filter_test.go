@@ -17,13 +17,11 @@ package sampling
 import (
 	"testing"
 
-	commonpb "github.com/census-instrumentation/opencensus-proto/gen-go/agent/common/v1"
-	tracepb "github.com/census-instrumentation/opencensus-proto/gen-go/trace/v1"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/zap"
 
-	"go.opentelemetry.io/collector/consumer/consumerdata"
+	"go.opentelemetry.io/collector/consumer/pdata"
 )
 
 func TestStringTagFilter(t *testing.T) {
@@ -37,18 +35,18 @@ func TestStringTagFilter(t *testing.T) {
 		Decision Decision
 	}{
 		{
-			Desc:     "nonmatching node attribute key",
-			Trace:    newTraceStringAttrs(map[string]string{"non_matching": "value"}, nil),
+			Desc:     "nonmatching resource attribute key",
+			Trace:    newTraceStringAttrs(map[string]string{"non_matching": "value"}, newEmptySpan()),
 			Decision: NotSampled,
 		},
 		{
-			Desc:     "nonmatching node attribute value",
-			Trace:    newTraceStringAttrs(map[string]string{"example": "non_matching"}, nil),
+			Desc:     "nonmatching resource attribute value",
+			Trace:    newTraceStringAttrs(map[string]string{"example": "non_matching"}, newEmptySpan()),
 			Decision: NotSampled,
 		},
 		{
-			Desc:     "matching node attribute",
-			Trace:    newTraceStringAttrs(map[string]string{"example": "value"}, nil),
+			Desc:     "matching resource attribute",
+			Trace:    newTraceStringAttrs(map[string]string{"example": "value"}, newEmptySpan()),
 			Decision: Sampled,
 		},
 		{
@@ -78,33 +76,19 @@ func TestStringTagFilter(t *testing.T) {
 	}
 }
 
-func newSpan(attrKey string, attrValue string) *tracepb.Span {
-	return &tracepb.Span{
-		Attributes: &tracepb.Span_Attributes{
-			AttributeMap: map[string]*tracepb.AttributeValue{
-				attrKey: {
-					Value: &tracepb.AttributeValue_StringValue{
-						StringValue: &tracepb.TruncatableString{
-							Value: attrValue,
-						},
-					},
-				},
-			},
-		},
-	}
+func newSpan(attrKey string, attrValue string) pdata.Span {
+	span := pdata.NewSpan()
+	span.InitEmpty()
+	span.Attributes().InsertString(attrKey, attrValue)
+	return span
 }
 
-func newTraceStringAttrs(nodeAttrs map[string]string, span *tracepb.Span) *TraceData {
-	return &TraceData{
-		ReceivedBatches: []consumerdata.TraceData{
-			{
-				Node: &commonpb.Node{
-					Attributes: nodeAttrs,
-				},
-				Spans: []*tracepb.Span{
-					span,
-				},
-			},
-		},
+func newTraceStringAttrs(resourceAttrs map[string]string, span pdata.Span) *TraceData {
+	trace := newTraceWithSpans(span)
+	resource := trace.ReceivedBatches[0].ResourceSpans().At(0).Resource()
+	resource.InitEmpty()
+	for k, v := range resourceAttrs {
+		resource.Attributes().InsertString(k, v)
 	}
+	return trace
 }
@@ -0,0 +1,54 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sampling
+
+import (
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// hasSpanWithCondition returns true if shouldSample returns true for any span of the
+// trace. It stops iterating as soon as one span matches.
+func hasSpanWithCondition(trace *TraceData, shouldSample func(resource pdata.Resource, span pdata.Span) bool) bool {
+	trace.Lock()
+	batches := trace.ReceivedBatches
+	trace.Unlock()
+	for _, batch := range batches {
+		rss := batch.ResourceSpans()
+		for i := 0; i < rss.Len(); i++ {
+			rs := rss.At(i)
+			if rs.IsNil() {
+				continue
+			}
+			ilss := rs.InstrumentationLibrarySpans()
+			for j := 0; j < ilss.Len(); j++ {
+				ils := ilss.At(j)
+				if ils.IsNil() {
+					continue
+				}
+				spans := ils.Spans()
+				for k := 0; k < spans.Len(); k++ {
+					span := spans.At(k)
+					if span.IsNil() {
+						continue
+					}
+					if shouldSample(rs.Resource(), span) {
+						return true
+					}
+				}
+			}
+		}
+	}
+	return false
+}
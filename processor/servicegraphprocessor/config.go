@@ -0,0 +1,60 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicegraphprocessor
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+// StoreConfig defines the configuration of the store of the spans waiting for their peer span.
+type StoreConfig struct {
+	// MaxItems is the maximum number of unpaired spans kept in the store, the spans received
+	// when the store is full are dropped.
+	MaxItems int `mapstructure:"max_items"`
+
+	// TTL is how long a span waits for its peer span before it expires.
+	TTL time.Duration `mapstructure:"ttl"`
+}
+
+// Config defines the configuration for the Service Graph processor.
+type Config struct {
+	configmodels.ProcessorSettings `mapstructure:",squash"`
+
+	// MetricsExporter is the name of the exporter the metrics are sent to. The exporter must
+	// be listed in the exporters of a metrics pipeline.
+	// Required.
+	MetricsExporter string `mapstructure:"metrics_exporter"`
+
+	// LatencyHistogramBuckets are the upper bounds of the buckets of the request duration
+	// histogram, in increasing order. The bounds are reported in milliseconds.
+	// Optional, defaults to buckets from 2ms to 15s.
+	LatencyHistogramBuckets []time.Duration `mapstructure:"latency_histogram_buckets"`
+
+	// Store configures the store of the spans waiting for their peer span.
+	Store StoreConfig `mapstructure:"store"`
+
+	// MetricsFlushInterval is the interval at which the metrics are sent to the metrics exporter.
+	MetricsFlushInterval time.Duration `mapstructure:"metrics_flush_interval"`
+
+	// MaxEdges is the maximum number of edges, i.e. pairs of client and server services. The
+	// requests of new edges are not counted once it is reached, 0 means no limit.
+	MaxEdges int `mapstructure:"max_edges"`
+
+	// EdgeExpiration is how long an edge is kept without requests, 0 means that the edges are
+	// kept until the collector stops.
+	EdgeExpiration time.Duration `mapstructure:"edge_expiration"`
+}
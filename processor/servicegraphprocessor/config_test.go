@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicegraphprocessor
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.ExampleComponents()
+	assert.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Processors[factory.Type()] = factory
+
+	cfg, err := configtest.LoadConfigFile(t, path.Join(".", "testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t, cfg.Processors["servicegraph"],
+		&Config{
+			ProcessorSettings: configmodels.ProcessorSettings{
+				TypeVal: "servicegraph",
+				NameVal: "servicegraph",
+			},
+			MetricsExporter: "exampleexporter/metrics",
+			Store: StoreConfig{
+				MaxItems: 1000,
+				TTL:      2 * time.Second,
+			},
+			MetricsFlushInterval: 15 * time.Second,
+			MaxEdges:             10000,
+			EdgeExpiration:       5 * time.Minute,
+		})
+
+	assert.Equal(t, cfg.Processors["servicegraph/custom"],
+		&Config{
+			ProcessorSettings: configmodels.ProcessorSettings{
+				TypeVal: "servicegraph",
+				NameVal: "servicegraph/custom",
+			},
+			MetricsExporter:         "exampleexporter/metrics",
+			LatencyHistogramBuckets: []time.Duration{10 * time.Millisecond, 100 * time.Millisecond, time.Second},
+			Store: StoreConfig{
+				MaxItems: 5000,
+				TTL:      10 * time.Second,
+			},
+			MetricsFlushInterval: 30 * time.Second,
+			MaxEdges:             500,
+			EdgeExpiration:       time.Minute,
+		})
+}
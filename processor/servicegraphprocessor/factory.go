@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicegraphprocessor
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+const (
+	// The value of "type" key in configuration.
+	typeStr = "servicegraph"
+
+	defaultStoreMaxItems = 1000
+	defaultStoreTTL      = 2 * time.Second
+
+	defaultMetricsFlushInterval = 15 * time.Second
+	defaultMaxEdges             = 10000
+	defaultEdgeExpiration       = 5 * time.Minute
+)
+
+// NewFactory returns a new factory for the Service Graph processor.
+func NewFactory() component.ProcessorFactory {
+	return processorhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		processorhelper.WithTraces(createTraceProcessor))
+}
+
+func createDefaultConfig() configmodels.Processor {
+	return &Config{
+		ProcessorSettings: configmodels.ProcessorSettings{
+			TypeVal: typeStr,
+			NameVal: typeStr,
+		},
+		Store: StoreConfig{
+			MaxItems: defaultStoreMaxItems,
+			TTL:      defaultStoreTTL,
+		},
+		MetricsFlushInterval: defaultMetricsFlushInterval,
+		MaxEdges:             defaultMaxEdges,
+		EdgeExpiration:       defaultEdgeExpiration,
+	}
+}
+
+func createTraceProcessor(
+	_ context.Context,
+	params component.ProcessorCreateParams,
+	cfg configmodels.Processor,
+	nextConsumer consumer.TraceConsumer,
+) (component.TraceProcessor, error) {
+	p, err := newProcessor(params.Logger, cfg.(*Config), nextConsumer)
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
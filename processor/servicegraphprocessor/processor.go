@@ -0,0 +1,365 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicegraphprocessor
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/consumer/pdatautil"
+	"go.opentelemetry.io/collector/internal/data"
+	otlptrace "go.opentelemetry.io/collector/internal/data/opentelemetry-proto-gen/trace/v1"
+	"go.opentelemetry.io/collector/internal/processor/tracemetrics"
+	"go.opentelemetry.io/collector/internal/servicegraph"
+	"go.opentelemetry.io/collector/processor"
+)
+
+const (
+	clientLabel = "client"
+	serverLabel = "server"
+
+	requestsMetricName = "service_graph_request_total"
+	failuresMetricName = "service_graph_request_failed_total"
+	durationMetricName = "service_graph_request_duration"
+)
+
+var (
+	errNoMetricsExporter    = errors.New("the servicegraph processor requires the \"metrics_exporter\" to be set")
+	errInvalidStore         = errors.New("the servicegraph processor requires a positive \"store\" \"max_items\" and \"ttl\"")
+	errInvalidFlushInterval = errors.New("the servicegraph processor requires a positive \"metrics_flush_interval\"")
+	errNegativeEdgeSetting  = errors.New("the \"max_edges\" and \"edge_expiration\" of the servicegraph processor must not be negative")
+)
+
+type edgeKey struct {
+	client string
+	server string
+}
+
+// edgeMetrics holds the aggregated metrics of the requests between two services.
+type edgeMetrics struct {
+	requests     uint64
+	failures     uint64
+	latencySum   float64
+	bucketCounts []uint64
+	// startTime is the time the edge was created, the metrics are cumulative since then.
+	startTime pdata.TimestampUnixNano
+	// lastUpdate is the time of the last request of the edge, used to expire it.
+	lastUpdate time.Time
+}
+
+type processorImp struct {
+	logger       *zap.Logger
+	config       *Config
+	nextConsumer consumer.TraceConsumer
+
+	// latencyBounds are the upper bounds of the latency buckets, in milliseconds.
+	latencyBounds []float64
+	now           func() time.Time
+
+	// metricsExporter is resolved in Start, once the exporters are built.
+	metricsExporter consumer.MetricsConsumer
+	flusher         *tracemetrics.Flusher
+	unregister      func()
+
+	mu    sync.Mutex
+	store *store
+	// edges holds the cumulative metrics of the edges.
+	edges   map[edgeKey]*edgeMetrics
+	expired uint64
+	dropped uint64
+	// droppedRequests is the number of requests not counted since the last flush as their edge
+	// would exceed the maximum number of edges.
+	droppedRequests uint64
+}
+
+var _ component.TraceProcessor = (*processorImp)(nil)
+
+func newProcessor(logger *zap.Logger, cfg *Config, nextConsumer consumer.TraceConsumer) (*processorImp, error) {
+	if cfg.MetricsExporter == "" {
+		return nil, errNoMetricsExporter
+	}
+	if cfg.Store.MaxItems <= 0 || cfg.Store.TTL <= 0 {
+		return nil, errInvalidStore
+	}
+	if cfg.MetricsFlushInterval <= 0 {
+		return nil, errInvalidFlushInterval
+	}
+	if cfg.MaxEdges < 0 || cfg.EdgeExpiration < 0 {
+		return nil, errNegativeEdgeSetting
+	}
+
+	bounds, err := tracemetrics.LatencyBounds(cfg.LatencyHistogramBuckets)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &processorImp{
+		logger:        logger,
+		config:        cfg,
+		nextConsumer:  nextConsumer,
+		latencyBounds: bounds,
+		now:           time.Now,
+		edges:         make(map[edgeKey]*edgeMetrics),
+	}
+	p.store = newStore(cfg.Store.MaxItems, cfg.Store.TTL, p.onComplete)
+	return p, nil
+}
+
+// Start looks up the metrics exporter, which is only available once the pipelines are built,
+// starts sending the metrics every metrics_flush_interval and makes the graph available to the
+// zPages.
+func (p *processorImp) Start(_ context.Context, host component.Host) error {
+	exporter, err := tracemetrics.MetricsExporter(host, p.config, p.config.MetricsExporter)
+	if err != nil {
+		return err
+	}
+	p.metricsExporter = exporter
+	p.flusher = tracemetrics.NewFlusher(p.config.MetricsFlushInterval, func() {
+		p.exportMetrics(context.Background())
+	})
+	p.flusher.Start()
+	p.unregister = servicegraph.Register(p.config.Name(), p.graph)
+	return nil
+}
+
+// Shutdown stops the periodic flush, sends the metrics one last time and removes the graph
+// from the zPages.
+func (p *processorImp) Shutdown(ctx context.Context) error {
+	if p.unregister != nil {
+		p.unregister()
+	}
+	if p.flusher == nil {
+		return nil
+	}
+	p.flusher.Stop()
+	p.exportMetrics(ctx)
+	return nil
+}
+
+// GetCapabilities returns the Capabilities assigned to this processor.
+func (p *processorImp) GetCapabilities() component.ProcessorCapabilities {
+	return component.ProcessorCapabilities{MutatesConsumedData: false}
+}
+
+// ConsumeTraces pairs the client and server spans of the traces and forwards the traces to the
+// next consumer. The metrics of the service graph are sent separately, every
+// metrics_flush_interval.
+func (p *processorImp) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	p.aggregate(td)
+	return p.nextConsumer.ConsumeTraces(ctx, td)
+}
+
+// exportMetrics sends the cumulative metrics of the edges to the metrics exporter, if any.
+func (p *processorImp) exportMetrics(ctx context.Context) {
+	md, droppedRequests, ok := p.collectMetrics()
+	if droppedRequests > 0 {
+		p.logger.Warn("Dropped requests of new service graph edges, the maximum number of edges is reached",
+			zap.Uint64("dropped_requests", droppedRequests), zap.Int("max_edges", p.config.MaxEdges))
+	}
+	if !ok {
+		return
+	}
+	if err := p.metricsExporter.ConsumeMetrics(ctx, md); err != nil {
+		p.logger.Warn("Failed to export service graph metrics", zap.Error(err))
+	}
+}
+
+// collectMetrics expires the edges without requests for edge_expiration and returns the
+// metrics of the remaining ones, or false if there is none, along with the number of requests
+// dropped since the last call.
+func (p *processorImp) collectMetrics() (pdata.Metrics, uint64, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	if p.config.EdgeExpiration > 0 {
+		for key, em := range p.edges {
+			if now.Sub(em.lastUpdate) >= p.config.EdgeExpiration {
+				delete(p.edges, key)
+			}
+		}
+	}
+
+	droppedRequests := p.droppedRequests
+	p.droppedRequests = 0
+	if len(p.edges) == 0 {
+		return pdata.Metrics{}, droppedRequests, false
+	}
+	return p.buildMetrics(now), droppedRequests, true
+}
+
+// aggregate adds the requests between services found in the traces to the service graph.
+func (p *processorImp) aggregate(td pdata.Traces) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	p.expired += uint64(p.store.expire(now))
+
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		if rs.IsNil() {
+			continue
+		}
+		serviceName := processor.ServiceNameForResource(rs.Resource())
+		ilss := rs.InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			ils := ilss.At(j)
+			if ils.IsNil() {
+				continue
+			}
+			spans := ils.Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				if span.IsNil() {
+					continue
+				}
+				p.aggregateSpan(serviceName, span, now)
+			}
+		}
+	}
+}
+
+// aggregateSpan stores the side of the request described by a client or server span. A client
+// span is paired with the server span whose parent is the client span.
+func (p *processorImp) aggregateSpan(serviceName string, span pdata.Span, now time.Time) {
+	var key string
+	var setSide func(e *edge, side *edgeSide)
+	switch span.Kind() {
+	case pdata.SpanKindCLIENT:
+		key = string(span.TraceID()) + string(span.SpanID())
+		setSide = func(e *edge, side *edgeSide) { e.client = side }
+	case pdata.SpanKindSERVER:
+		if len(span.ParentSpanID()) == 0 {
+			// A root server span has no client to pair with.
+			return
+		}
+		key = string(span.TraceID()) + string(span.ParentSpanID())
+		setSide = func(e *edge, side *edgeSide) { e.server = side }
+	default:
+		return
+	}
+
+	side := &edgeSide{
+		service: serviceName,
+		failed:  !span.Status().IsNil() && span.Status().Code() != pdata.StatusCode(otlptrace.Status_Ok),
+	}
+	if span.EndTime() > span.StartTime() {
+		side.latencyMs = tracemetrics.DurationToMillis(time.Duration(span.EndTime() - span.StartTime()))
+	}
+
+	if err := p.store.upsert(key, func(e *edge) { setSide(e, side) }, now); err != nil {
+		p.dropped++
+		p.logger.Debug("Dropping span of the service graph", zap.Error(err))
+	}
+}
+
+// onComplete adds a complete request to the metrics of its edge. The latency is the one seen
+// by the client.
+func (p *processorImp) onComplete(e *edge) {
+	now := p.now()
+	key := edgeKey{client: e.client.service, server: e.server.service}
+	em, ok := p.edges[key]
+	if !ok {
+		if p.config.MaxEdges > 0 && len(p.edges) >= p.config.MaxEdges {
+			p.droppedRequests++
+			return
+		}
+		em = &edgeMetrics{
+			bucketCounts: make([]uint64, len(p.latencyBounds)+1),
+			startTime:    tracemetrics.ToTimestamp(now),
+		}
+		p.edges[key] = em
+	}
+	em.lastUpdate = now
+	em.requests++
+	if e.client.failed || e.server.failed {
+		em.failures++
+	}
+	em.latencySum += e.client.latencyMs
+	// The bucket of a value equal to a bound is the bucket of that bound.
+	em.bucketCounts[sort.SearchFloat64s(p.latencyBounds, e.client.latencyMs)]++
+}
+
+// graph returns the current snapshot of the service graph.
+func (p *processorImp) graph() servicegraph.Graph {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	edges := make([]servicegraph.Edge, 0, len(p.edges))
+	for key, em := range p.edges {
+		edges = append(edges, servicegraph.Edge{
+			Client:       key.client,
+			Server:       key.server,
+			Requests:     em.requests,
+			Failures:     em.failures,
+			LatencySumMs: em.latencySum,
+		})
+	}
+	servicegraph.SortEdges(edges)
+	return servicegraph.Graph{Edges: edges, Expired: p.expired, Dropped: p.dropped}
+}
+
+// buildMetrics returns the cumulative request, failure and duration metrics of all the edges.
+func (p *processorImp) buildMetrics(now time.Time) pdata.Metrics {
+	md := data.NewMetricData()
+	rms := md.ResourceMetrics()
+	rms.Resize(1)
+	ilms := rms.At(0).InstrumentationLibraryMetrics()
+	ilms.Resize(1)
+	metrics := ilms.At(0).Metrics()
+	metrics.Resize(3)
+
+	timestamp := tracemetrics.ToTimestamp(now)
+
+	requests := metrics.At(0)
+	tracemetrics.InitMetricDescriptor(requests, requestsMetricName, "Number of requests between two services", "1", pdata.MetricTypeMonotonicInt64)
+	failures := metrics.At(1)
+	tracemetrics.InitMetricDescriptor(failures, failuresMetricName, "Number of failed requests between two services", "1", pdata.MetricTypeMonotonicInt64)
+	duration := metrics.At(2)
+	tracemetrics.InitMetricDescriptor(duration, durationMetricName, "Duration of the requests between two services, as seen by the client", "ms", pdata.MetricTypeHistogram)
+
+	// Sort the edges so the data points are reported in a stable order.
+	keys := make([]edgeKey, 0, len(p.edges))
+	for key := range p.edges {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].client != keys[j].client {
+			return keys[i].client < keys[j].client
+		}
+		return keys[i].server < keys[j].server
+	})
+
+	for _, key := range keys {
+		em := p.edges[key]
+		labels := map[string]string{clientLabel: key.client, serverLabel: key.server}
+
+		tracemetrics.AppendInt64DataPoint(requests.Int64DataPoints(), labels, em.startTime, timestamp, int64(em.requests))
+		tracemetrics.AppendInt64DataPoint(failures.Int64DataPoints(), labels, em.startTime, timestamp, int64(em.failures))
+		tracemetrics.AppendHistogramDataPoint(duration.HistogramDataPoints(), labels, em.startTime, timestamp,
+			em.requests, em.latencySum, p.latencyBounds, em.bucketCounts)
+	}
+	return pdatautil.MetricsFromInternalMetrics(md)
+}
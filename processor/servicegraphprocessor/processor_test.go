@@ -0,0 +1,317 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicegraphprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/consumer/pdatautil"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	otlptrace "go.opentelemetry.io/collector/internal/data/opentelemetry-proto-gen/trace/v1"
+	"go.opentelemetry.io/collector/internal/servicegraph"
+	"go.opentelemetry.io/collector/translator/conventions"
+)
+
+// mockHost is a host exposing a fixed set of metrics exporters.
+type mockHost struct {
+	component.Host
+	exporters map[configmodels.Exporter]component.Exporter
+}
+
+func (m *mockHost) GetExporters() map[configmodels.DataType]map[configmodels.Exporter]component.Exporter {
+	return map[configmodels.DataType]map[configmodels.Exporter]component.Exporter{
+		configmodels.MetricsDataType: m.exporters,
+	}
+}
+
+func newMockHost(exporters map[string]component.Exporter) component.Host {
+	exps := make(map[configmodels.Exporter]component.Exporter, len(exporters))
+	for name, exp := range exporters {
+		exps[&configmodels.ExporterSettings{TypeVal: "prometheus", NameVal: name}] = exp
+	}
+	return &mockHost{Host: componenttest.NewNopHost(), exporters: exps}
+}
+
+func newTestProcessor(t *testing.T, cfg *Config, metricsSink component.Exporter, tracesSink *exportertest.SinkTraceExporter, now *time.Time) *processorImp {
+	tp, err := NewFactory().CreateTraceProcessor(context.Background(),
+		component.ProcessorCreateParams{Logger: zap.NewNop()}, tracesSink, cfg)
+	require.NoError(t, err)
+	p := tp.(*processorImp)
+	p.now = func() time.Time { return *now }
+	require.NoError(t, p.Start(context.Background(), newMockHost(map[string]component.Exporter{
+		"prometheus": metricsSink,
+	})))
+	return p
+}
+
+type testSpan struct {
+	service  string
+	kind     pdata.SpanKind
+	traceID  byte
+	spanID   byte
+	parentID byte
+	failed   bool
+	duration time.Duration
+}
+
+func newTraces(spans ...testSpan) pdata.Traces {
+	td := pdata.NewTraces()
+	rss := td.ResourceSpans()
+	rss.Resize(len(spans))
+	for i, s := range spans {
+		rs := rss.At(i)
+		rs.Resource().InitEmpty()
+		rs.Resource().Attributes().InsertString(conventions.AttributeServiceName, s.service)
+		rs.InstrumentationLibrarySpans().Resize(1)
+		ss := rs.InstrumentationLibrarySpans().At(0).Spans()
+		ss.Resize(1)
+		span := ss.At(0)
+		span.SetName("span")
+		span.SetKind(s.kind)
+		span.SetTraceID(pdata.NewTraceID([]byte{s.traceID, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1}))
+		span.SetSpanID(pdata.NewSpanID([]byte{s.spanID, 0, 0, 0, 0, 0, 0, 1}))
+		if s.parentID != 0 {
+			span.SetParentSpanID(pdata.NewSpanID([]byte{s.parentID, 0, 0, 0, 0, 0, 0, 1}))
+		}
+		start := time.Unix(1600000000, 0)
+		span.SetStartTime(pdata.TimestampUnixNano(uint64(start.UnixNano())))
+		span.SetEndTime(pdata.TimestampUnixNano(uint64(start.Add(s.duration).UnixNano())))
+		if s.failed {
+			span.Status().InitEmpty()
+			span.Status().SetCode(pdata.StatusCode(otlptrace.Status_InternalError))
+		}
+	}
+	return td
+}
+
+// dataPoint is a comparable summary of an int64 or histogram data point.
+type dataPoint struct {
+	labels  map[string]string
+	value   int64
+	sum     float64
+	buckets []uint64
+}
+
+func collectMetrics(t *testing.T, md pdata.Metrics) map[string][]dataPoint {
+	result := make(map[string][]dataPoint)
+	rms := pdatautil.MetricsToInternalMetrics(md).ResourceMetrics()
+	require.Equal(t, 1, rms.Len())
+	metrics := rms.At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+	for i := 0; i < metrics.Len(); i++ {
+		metric := metrics.At(i)
+		name := metric.MetricDescriptor().Name()
+		idps := metric.Int64DataPoints()
+		for j := 0; j < idps.Len(); j++ {
+			dp := idps.At(j)
+			labels := make(map[string]string)
+			dp.LabelsMap().ForEach(func(k string, v pdata.StringValue) { labels[k] = v.Value() })
+			result[name] = append(result[name], dataPoint{labels: labels, value: dp.Value()})
+		}
+		hdps := metric.HistogramDataPoints()
+		for j := 0; j < hdps.Len(); j++ {
+			dp := hdps.At(j)
+			labels := make(map[string]string)
+			dp.LabelsMap().ForEach(func(k string, v pdata.StringValue) { labels[k] = v.Value() })
+			var buckets []uint64
+			for k := 0; k < dp.Buckets().Len(); k++ {
+				buckets = append(buckets, dp.Buckets().At(k).Count())
+			}
+			result[name] = append(result[name], dataPoint{labels: labels, value: int64(dp.Count()), sum: dp.Sum(), buckets: buckets})
+		}
+	}
+	return result
+}
+
+func TestProcessorBuildsServiceGraph(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.MetricsExporter = "prometheus"
+	cfg.LatencyHistogramBuckets = []time.Duration{10 * time.Millisecond, 100 * time.Millisecond}
+
+	now := time.Unix(1600000000, 0)
+	metricsSink := new(exportertest.SinkMetricsExporter)
+	tracesSink := new(exportertest.SinkTraceExporter)
+	p := newTestProcessor(t, cfg, metricsSink, tracesSink, &now)
+	defer p.Shutdown(context.Background())
+
+	// Only the client spans arrive first, there is no edge yet.
+	td := newTraces(
+		testSpan{service: "frontend", kind: pdata.SpanKindSERVER, traceID: 1, spanID: 1},
+		testSpan{service: "frontend", kind: pdata.SpanKindCLIENT, traceID: 1, spanID: 2, parentID: 1, duration: 50 * time.Millisecond},
+		testSpan{service: "frontend", kind: pdata.SpanKindCLIENT, traceID: 1, spanID: 3, parentID: 1, duration: 5 * time.Millisecond},
+		testSpan{service: "frontend", kind: pdata.SpanKindINTERNAL, traceID: 1, spanID: 4, parentID: 1},
+	)
+	require.NoError(t, p.ConsumeTraces(context.Background(), td))
+	require.Len(t, tracesSink.AllTraces(), 1)
+	assert.Equal(t, td, tracesSink.AllTraces()[0])
+	p.exportMetrics(context.Background())
+	assert.Len(t, metricsSink.AllMetrics(), 0)
+	assert.Equal(t, 2, p.store.len())
+
+	// The server spans complete the edges.
+	require.NoError(t, p.ConsumeTraces(context.Background(), newTraces(
+		testSpan{service: "cart", kind: pdata.SpanKindSERVER, traceID: 1, spanID: 5, parentID: 2, duration: 40 * time.Millisecond},
+		testSpan{service: "cart", kind: pdata.SpanKindSERVER, traceID: 1, spanID: 6, parentID: 3, failed: true, duration: 4 * time.Millisecond},
+		testSpan{service: "payment", kind: pdata.SpanKindSERVER, traceID: 2, spanID: 7, parentID: 9},
+	)))
+	assert.Equal(t, 1, p.store.len())
+
+	// The metrics are only sent on flush.
+	assert.Len(t, metricsSink.AllMetrics(), 0)
+	p.exportMetrics(context.Background())
+	labels := map[string]string{clientLabel: "frontend", serverLabel: "cart"}
+	require.Len(t, metricsSink.AllMetrics(), 1)
+	got := collectMetrics(t, metricsSink.AllMetrics()[0])
+	assert.Equal(t, []dataPoint{{labels: labels, value: 2}}, got[requestsMetricName])
+	assert.Equal(t, []dataPoint{{labels: labels, value: 1}}, got[failuresMetricName])
+	assert.Equal(t, []dataPoint{{labels: labels, value: 2, sum: 55, buckets: []uint64{1, 1, 0}}}, got[durationMetricName])
+
+	// The unpaired server span expires.
+	now = now.Add(cfg.Store.TTL)
+	require.NoError(t, p.ConsumeTraces(context.Background(), newTraces(
+		testSpan{service: "checkout", kind: pdata.SpanKindCLIENT, traceID: 2, spanID: 9, parentID: 8, duration: time.Second},
+	)))
+	assert.Equal(t, 1, p.store.len())
+
+	assert.Equal(t, []servicegraph.NamedGraph{{
+		Name: "servicegraph",
+		Graph: servicegraph.Graph{
+			Edges:   []servicegraph.Edge{{Client: "frontend", Server: "cart", Requests: 2, Failures: 1, LatencySumMs: 55}},
+			Expired: 1,
+		},
+	}}, servicegraph.Snapshots())
+}
+
+func TestProcessorStoreFull(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.MetricsExporter = "prometheus"
+	cfg.Store.MaxItems = 1
+
+	now := time.Unix(1600000000, 0)
+	p := newTestProcessor(t, cfg, new(exportertest.SinkMetricsExporter), new(exportertest.SinkTraceExporter), &now)
+	defer p.Shutdown(context.Background())
+
+	require.NoError(t, p.ConsumeTraces(context.Background(), newTraces(
+		testSpan{service: "frontend", kind: pdata.SpanKindCLIENT, traceID: 1, spanID: 2},
+		testSpan{service: "frontend", kind: pdata.SpanKindCLIENT, traceID: 1, spanID: 3},
+	)))
+	assert.Equal(t, uint64(1), p.graph().Dropped)
+}
+
+func TestProcessorShutdownUnregisters(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.MetricsExporter = "prometheus"
+
+	now := time.Unix(1600000000, 0)
+	p := newTestProcessor(t, cfg, new(exportertest.SinkMetricsExporter), new(exportertest.SinkTraceExporter), &now)
+	assert.Len(t, servicegraph.Snapshots(), 1)
+	require.NoError(t, p.Shutdown(context.Background()))
+	assert.Len(t, servicegraph.Snapshots(), 0)
+}
+
+func TestStartMetricsExporterNotFound(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.MetricsExporter = "otlp"
+
+	tp, err := NewFactory().CreateTraceProcessor(context.Background(),
+		component.ProcessorCreateParams{Logger: zap.NewNop()}, exportertest.NewNopTraceExporter(), cfg)
+	require.NoError(t, err)
+
+	err = tp.Start(context.Background(), newMockHost(map[string]component.Exporter{
+		"prometheus": new(exportertest.SinkMetricsExporter),
+	}))
+	assert.EqualError(t, err, `servicegraph processor "servicegraph": metrics exporter "otlp" not found, it must be listed in a metrics pipeline, available exporters: [prometheus]`)
+}
+
+func TestProcessorMaxEdges(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.MetricsExporter = "prometheus"
+	cfg.MaxEdges = 1
+
+	now := time.Unix(1600000000, 0)
+	metricsSink := new(exportertest.SinkMetricsExporter)
+	p := newTestProcessor(t, cfg, metricsSink, new(exportertest.SinkTraceExporter), &now)
+	defer p.Shutdown(context.Background())
+
+	require.NoError(t, p.ConsumeTraces(context.Background(), newTraces(
+		testSpan{service: "frontend", kind: pdata.SpanKindCLIENT, traceID: 1, spanID: 2},
+		testSpan{service: "cart", kind: pdata.SpanKindSERVER, traceID: 1, spanID: 3, parentID: 2},
+		testSpan{service: "frontend", kind: pdata.SpanKindCLIENT, traceID: 1, spanID: 4},
+		testSpan{service: "payment", kind: pdata.SpanKindSERVER, traceID: 1, spanID: 5, parentID: 4},
+	)))
+	assert.EqualValues(t, 1, p.droppedRequests)
+	p.exportMetrics(context.Background())
+	assert.Zero(t, p.droppedRequests)
+
+	got := collectMetrics(t, metricsSink.AllMetrics()[0])
+	assert.Equal(t, []dataPoint{{labels: map[string]string{clientLabel: "frontend", serverLabel: "cart"}, value: 1}}, got[requestsMetricName])
+}
+
+func TestProcessorEdgeExpiration(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.MetricsExporter = "prometheus"
+	cfg.EdgeExpiration = time.Minute
+
+	now := time.Unix(1600000000, 0)
+	metricsSink := new(exportertest.SinkMetricsExporter)
+	p := newTestProcessor(t, cfg, metricsSink, new(exportertest.SinkTraceExporter), &now)
+	defer p.Shutdown(context.Background())
+
+	require.NoError(t, p.ConsumeTraces(context.Background(), newTraces(
+		testSpan{service: "frontend", kind: pdata.SpanKindCLIENT, traceID: 1, spanID: 2},
+		testSpan{service: "cart", kind: pdata.SpanKindSERVER, traceID: 1, spanID: 3, parentID: 2},
+	)))
+	now = now.Add(30 * time.Second)
+	require.NoError(t, p.ConsumeTraces(context.Background(), newTraces(
+		testSpan{service: "frontend", kind: pdata.SpanKindCLIENT, traceID: 2, spanID: 2},
+		testSpan{service: "payment", kind: pdata.SpanKindSERVER, traceID: 2, spanID: 3, parentID: 2},
+	)))
+
+	now = now.Add(45 * time.Second)
+	p.exportMetrics(context.Background())
+	got := collectMetrics(t, metricsSink.AllMetrics()[0])
+	assert.Equal(t, []dataPoint{{labels: map[string]string{clientLabel: "frontend", serverLabel: "payment"}, value: 1}}, got[requestsMetricName])
+
+	// Once all the edges expired, nothing is sent.
+	now = now.Add(time.Minute)
+	p.exportMetrics(context.Background())
+	assert.Len(t, metricsSink.AllMetrics(), 1)
+	assert.Empty(t, p.graph().Edges)
+}
+
+func TestProcessorShutdownFlushes(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.MetricsExporter = "prometheus"
+
+	now := time.Unix(1600000000, 0)
+	metricsSink := new(exportertest.SinkMetricsExporter)
+	p := newTestProcessor(t, cfg, metricsSink, new(exportertest.SinkTraceExporter), &now)
+
+	require.NoError(t, p.ConsumeTraces(context.Background(), newTraces(
+		testSpan{service: "frontend", kind: pdata.SpanKindCLIENT, traceID: 1, spanID: 2},
+		testSpan{service: "cart", kind: pdata.SpanKindSERVER, traceID: 1, spanID: 3, parentID: 2},
+	)))
+	require.NoError(t, p.Shutdown(context.Background()))
+	require.Len(t, metricsSink.AllMetrics(), 1)
+}
@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicegraphprocessor
+
+import (
+	"container/list"
+	"errors"
+	"time"
+)
+
+var errStoreFull = errors.New("the store of unpaired spans is full")
+
+// edgeSide holds what one side of a request knows about it.
+type edgeSide struct {
+	service   string
+	latencyMs float64
+	failed    bool
+}
+
+// edge is a request between two services, complete once both its client and server sides are
+// known.
+type edge struct {
+	key        string
+	client     *edgeSide
+	server     *edgeSide
+	expiration time.Time
+}
+
+func (e *edge) isComplete() bool {
+	return e.client != nil && e.server != nil
+}
+
+// store keeps the edges waiting for their peer side, up to maxItems edges, each for at most
+// ttl. It is not safe for concurrent use.
+type store struct {
+	// edges holds the edges in expiration order, as all have the same ttl.
+	edges    *list.List
+	index    map[string]*list.Element
+	maxItems int
+	ttl      time.Duration
+
+	// onComplete is called with the edges once both sides are known.
+	onComplete func(e *edge)
+}
+
+func newStore(maxItems int, ttl time.Duration, onComplete func(e *edge)) *store {
+	return &store{
+		edges:      list.New(),
+		index:      make(map[string]*list.Element),
+		maxItems:   maxItems,
+		ttl:        ttl,
+		onComplete: onComplete,
+	}
+}
+
+// len returns the number of edges waiting for their peer side.
+func (s *store) len() int {
+	return s.edges.Len()
+}
+
+// upsert updates the edge of the given key, creating it if it does not exist. The edge is
+// removed from the store and passed to onComplete once it is complete. It returns
+// errStoreFull if the edge does not exist and the store is full.
+func (s *store) upsert(key string, update func(e *edge), now time.Time) error {
+	if elem, ok := s.index[key]; ok {
+		e := elem.Value.(*edge)
+		update(e)
+		if e.isComplete() {
+			s.edges.Remove(elem)
+			delete(s.index, key)
+			s.onComplete(e)
+		}
+		return nil
+	}
+
+	e := &edge{key: key, expiration: now.Add(s.ttl)}
+	update(e)
+	if e.isComplete() {
+		s.onComplete(e)
+		return nil
+	}
+	if s.edges.Len() >= s.maxItems {
+		return errStoreFull
+	}
+	s.index[key] = s.edges.PushBack(e)
+	return nil
+}
+
+// expire removes the edges that expired at the given time and returns their number.
+func (s *store) expire(now time.Time) int {
+	expired := 0
+	for elem := s.edges.Front(); elem != nil; elem = s.edges.Front() {
+		e := elem.Value.(*edge)
+		if now.Before(e.expiration) {
+			break
+		}
+		s.edges.Remove(elem)
+		delete(s.index, e.key)
+		expired++
+	}
+	return expired
+}
@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package servicegraphprocessor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setClient(service string) func(e *edge) {
+	return func(e *edge) { e.client = &edgeSide{service: service} }
+}
+
+func setServer(service string) func(e *edge) {
+	return func(e *edge) { e.server = &edgeSide{service: service} }
+}
+
+func TestStorePairsSides(t *testing.T) {
+	var completed []*edge
+	s := newStore(10, time.Second, func(e *edge) { completed = append(completed, e) })
+	now := time.Unix(1600000000, 0)
+
+	require.NoError(t, s.upsert("a", setClient("frontend"), now))
+	require.NoError(t, s.upsert("b", setServer("payment"), now))
+	assert.Equal(t, 2, s.len())
+	assert.Len(t, completed, 0)
+
+	require.NoError(t, s.upsert("a", setServer("cart"), now))
+	assert.Equal(t, 1, s.len())
+	require.Len(t, completed, 1)
+	assert.Equal(t, "frontend", completed[0].client.service)
+	assert.Equal(t, "cart", completed[0].server.service)
+
+	require.NoError(t, s.upsert("b", setClient("checkout"), now))
+	assert.Equal(t, 0, s.len())
+	require.Len(t, completed, 2)
+	assert.Equal(t, "checkout", completed[1].client.service)
+	assert.Equal(t, "payment", completed[1].server.service)
+}
+
+func TestStoreFull(t *testing.T) {
+	var completed []*edge
+	s := newStore(1, time.Second, func(e *edge) { completed = append(completed, e) })
+	now := time.Unix(1600000000, 0)
+
+	require.NoError(t, s.upsert("a", setClient("frontend"), now))
+	assert.Equal(t, errStoreFull, s.upsert("b", setClient("frontend"), now))
+	assert.Equal(t, 1, s.len())
+
+	// The peer of an edge in the store is still accepted.
+	require.NoError(t, s.upsert("a", setServer("cart"), now))
+	assert.Len(t, completed, 1)
+	assert.Equal(t, 0, s.len())
+}
+
+func TestStoreExpire(t *testing.T) {
+	var completed []*edge
+	s := newStore(10, time.Second, func(e *edge) { completed = append(completed, e) })
+	now := time.Unix(1600000000, 0)
+
+	require.NoError(t, s.upsert("a", setClient("frontend"), now))
+	require.NoError(t, s.upsert("b", setClient("frontend"), now.Add(500*time.Millisecond)))
+
+	assert.Equal(t, 0, s.expire(now.Add(999*time.Millisecond)))
+	assert.Equal(t, 1, s.expire(now.Add(time.Second)))
+	assert.Equal(t, 1, s.len())
+
+	// The peer of an expired edge starts a new edge.
+	require.NoError(t, s.upsert("a", setServer("cart"), now.Add(time.Second)))
+	assert.Len(t, completed, 0)
+	assert.Equal(t, 2, s.len())
+
+	assert.Equal(t, 2, s.expire(now.Add(time.Hour)))
+	assert.Equal(t, 0, s.len())
+}
@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spanmetricsprocessor
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+// Dimension defines a span attribute added as a label to the metrics.
+type Dimension struct {
+	// Name of the span attribute, also used as the label name.
+	Name string `mapstructure:"name"`
+
+	// Default is the value of the label when the span does not have the attribute. The label is
+	// omitted if the span does not have the attribute and no default is set.
+	Default *string `mapstructure:"default"`
+}
+
+// Config defines the configuration for the Span Metrics processor.
+type Config struct {
+	configmodels.ProcessorSettings `mapstructure:",squash"`
+
+	// MetricsExporter is the name of the exporter the metrics are sent to. The exporter must
+	// be listed in the exporters of a metrics pipeline.
+	// Required.
+	MetricsExporter string `mapstructure:"metrics_exporter"`
+
+	// LatencyHistogramBuckets are the upper bounds of the buckets of the latency histogram,
+	// in increasing order. The bounds are reported in milliseconds.
+	// Optional, defaults to buckets from 2ms to 15s.
+	LatencyHistogramBuckets []time.Duration `mapstructure:"latency_histogram_buckets"`
+
+	// Dimensions are the span attributes added as labels to the metrics, in addition to the
+	// service name, span name, span kind and status code.
+	// Optional.
+	Dimensions []Dimension `mapstructure:"dimensions"`
+
+	// MetricsFlushInterval is the interval at which the metrics are sent to the metrics exporter.
+	MetricsFlushInterval time.Duration `mapstructure:"metrics_flush_interval"`
+
+	// MaxSeries is the maximum number of series, i.e. distinct combinations of label values. The
+	// spans of new series are not counted once it is reached, 0 means no limit.
+	MaxSeries int `mapstructure:"max_series"`
+
+	// SeriesExpiration is how long a series is kept without receiving spans, 0 means that the
+	// series are kept until the collector stops.
+	SeriesExpiration time.Duration `mapstructure:"series_expiration"`
+}
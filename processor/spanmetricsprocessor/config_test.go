@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spanmetricsprocessor
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.ExampleComponents()
+	assert.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Processors[factory.Type()] = factory
+
+	cfg, err := configtest.LoadConfigFile(t, path.Join(".", "testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t, cfg.Processors["spanmetrics"],
+		&Config{
+			ProcessorSettings: configmodels.ProcessorSettings{
+				TypeVal: "spanmetrics",
+				NameVal: "spanmetrics",
+			},
+			MetricsExporter:      "exampleexporter/metrics",
+			MetricsFlushInterval: 15 * time.Second,
+			MaxSeries:            10000,
+			SeriesExpiration:     5 * time.Minute,
+		})
+
+	defaultMethod := "GET"
+	assert.Equal(t, cfg.Processors["spanmetrics/full"],
+		&Config{
+			ProcessorSettings: configmodels.ProcessorSettings{
+				TypeVal: "spanmetrics",
+				NameVal: "spanmetrics/full",
+			},
+			MetricsExporter: "exampleexporter/metrics",
+			LatencyHistogramBuckets: []time.Duration{
+				2 * time.Millisecond,
+				6 * time.Millisecond,
+				10 * time.Millisecond,
+				100 * time.Millisecond,
+				250 * time.Millisecond,
+			},
+			Dimensions: []Dimension{
+				{Name: "http.method", Default: &defaultMethod},
+				{Name: "http.status_code"},
+			},
+			MetricsFlushInterval: 30 * time.Second,
+			MaxSeries:            500,
+			SeriesExpiration:     time.Minute,
+		})
+}
@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spanmetricsprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configcheck"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/internal/processor/tracemetrics"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	factory := NewFactory()
+
+	cfg := factory.CreateDefaultConfig()
+	assert.NotNil(t, cfg, "failed to create default config")
+	assert.NoError(t, configcheck.ValidateConfig(cfg))
+}
+
+func TestCreateProcessor(t *testing.T) {
+	factory := NewFactory()
+
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.MetricsExporter = "prometheus"
+
+	creationParams := component.ProcessorCreateParams{Logger: zap.NewNop()}
+	tp, err := factory.CreateTraceProcessor(context.Background(), creationParams, exportertest.NewNopTraceExporter(), cfg)
+	assert.NoError(t, err)
+	assert.NotNil(t, tp)
+
+	mp, err := factory.CreateMetricsProcessor(context.Background(), creationParams, exportertest.NewNopMetricsExporter(), cfg)
+	assert.Error(t, err)
+	assert.Nil(t, mp)
+}
+
+func TestCreateProcessorInvalidConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(cfg *Config)
+		errMsg string
+	}{
+		{
+			name:   "no metrics_exporter",
+			modify: func(cfg *Config) { cfg.MetricsExporter = "" },
+			errMsg: errNoMetricsExporter.Error(),
+		},
+		{
+			name: "unsorted buckets",
+			modify: func(cfg *Config) {
+				cfg.LatencyHistogramBuckets = []time.Duration{10 * time.Millisecond, 5 * time.Millisecond}
+			},
+			errMsg: tracemetrics.ErrUnsortedBuckets.Error(),
+		},
+		{
+			name:   "no metrics_flush_interval",
+			modify: func(cfg *Config) { cfg.MetricsFlushInterval = 0 },
+			errMsg: errInvalidFlushInterval.Error(),
+		},
+		{
+			name:   "negative max_series",
+			modify: func(cfg *Config) { cfg.MaxSeries = -1 },
+			errMsg: errNegativeSeriesSetting.Error(),
+		},
+		{
+			name:   "dimension without name",
+			modify: func(cfg *Config) { cfg.Dimensions = []Dimension{{}} },
+			errMsg: errDimensionWithNoName.Error(),
+		},
+		{
+			name:   "duplicate dimension",
+			modify: func(cfg *Config) { cfg.Dimensions = []Dimension{{Name: "a"}, {Name: "a"}} },
+			errMsg: `duplicate dimension "a"`,
+		},
+	}
+
+	factory := NewFactory()
+	creationParams := component.ProcessorCreateParams{Logger: zap.NewNop()}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := factory.CreateDefaultConfig().(*Config)
+			cfg.MetricsExporter = "prometheus"
+			tt.modify(cfg)
+
+			tp, err := factory.CreateTraceProcessor(context.Background(), creationParams, exportertest.NewNopTraceExporter(), cfg)
+			require.EqualError(t, err, tt.errMsg)
+			assert.Nil(t, tp)
+		})
+	}
+}
@@ -0,0 +1,347 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spanmetricsprocessor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/consumer/pdatautil"
+	"go.opentelemetry.io/collector/internal/data"
+	otlptrace "go.opentelemetry.io/collector/internal/data/opentelemetry-proto-gen/trace/v1"
+	"go.opentelemetry.io/collector/internal/processor/tracemetrics"
+	"go.opentelemetry.io/collector/processor"
+)
+
+const (
+	serviceNameLabel = "service.name"
+	operationLabel   = "operation"
+	spanKindLabel    = "span.kind"
+	statusCodeLabel  = "status.code"
+
+	callsMetricName   = "calls_total"
+	errorsMetricName  = "errors_total"
+	latencyMetricName = "latency"
+
+	// keySeparator separates the label values in the key of the aggregated metrics.
+	keySeparator = "\u0000"
+)
+
+var (
+	errNoMetricsExporter     = errors.New("the spanmetrics processor requires the \"metrics_exporter\" to be set")
+	errDimensionWithNoName   = errors.New("every dimension of the spanmetrics processor requires a \"name\"")
+	errInvalidFlushInterval  = errors.New("the spanmetrics processor requires a positive \"metrics_flush_interval\"")
+	errNegativeSeriesSetting = errors.New("the \"max_series\" and \"series_expiration\" of the spanmetrics processor must not be negative")
+)
+
+// spanMetrics holds the aggregated metrics of the spans sharing the same labels.
+type spanMetrics struct {
+	labels       map[string]string
+	isError      bool
+	calls        int64
+	latencySum   float64
+	bucketCounts []uint64
+	// startTime is the time the series was created, the metrics are cumulative since then.
+	startTime pdata.TimestampUnixNano
+	// lastUpdate is the time of the last span of the series, used to expire it.
+	lastUpdate time.Time
+}
+
+type processorImp struct {
+	logger       *zap.Logger
+	config       *Config
+	nextConsumer consumer.TraceConsumer
+
+	// latencyBounds are the upper bounds of the latency buckets, in milliseconds.
+	latencyBounds []float64
+	now           func() time.Time
+
+	// metricsExporter is resolved in Start, once the exporters are built.
+	metricsExporter consumer.MetricsConsumer
+	flusher         *tracemetrics.Flusher
+
+	mu sync.Mutex
+	// metrics holds the cumulative metrics of the series, keyed by the values of their labels.
+	metrics map[string]*spanMetrics
+	// dropped is the number of spans not aggregated since the last flush as their series would
+	// exceed the maximum number of series.
+	dropped uint64
+}
+
+var _ component.TraceProcessor = (*processorImp)(nil)
+
+func newProcessor(logger *zap.Logger, cfg *Config, nextConsumer consumer.TraceConsumer) (*processorImp, error) {
+	if cfg.MetricsExporter == "" {
+		return nil, errNoMetricsExporter
+	}
+	if cfg.MetricsFlushInterval <= 0 {
+		return nil, errInvalidFlushInterval
+	}
+	if cfg.MaxSeries < 0 || cfg.SeriesExpiration < 0 {
+		return nil, errNegativeSeriesSetting
+	}
+
+	bounds, err := tracemetrics.LatencyBounds(cfg.LatencyHistogramBuckets)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(cfg.Dimensions))
+	for _, dim := range cfg.Dimensions {
+		if dim.Name == "" {
+			return nil, errDimensionWithNoName
+		}
+		if seen[dim.Name] {
+			return nil, fmt.Errorf("duplicate dimension %q", dim.Name)
+		}
+		seen[dim.Name] = true
+	}
+
+	return &processorImp{
+		logger:        logger,
+		config:        cfg,
+		nextConsumer:  nextConsumer,
+		latencyBounds: bounds,
+		now:           time.Now,
+		metrics:       make(map[string]*spanMetrics),
+	}, nil
+}
+
+// Start looks up the metrics exporter, which is only available once the pipelines are built,
+// and starts sending the metrics every metrics_flush_interval.
+func (p *processorImp) Start(_ context.Context, host component.Host) error {
+	exporter, err := tracemetrics.MetricsExporter(host, p.config, p.config.MetricsExporter)
+	if err != nil {
+		return err
+	}
+	p.metricsExporter = exporter
+	p.flusher = tracemetrics.NewFlusher(p.config.MetricsFlushInterval, func() {
+		p.exportMetrics(context.Background())
+	})
+	p.flusher.Start()
+	return nil
+}
+
+// Shutdown stops the periodic flush and sends the metrics one last time.
+func (p *processorImp) Shutdown(ctx context.Context) error {
+	if p.flusher == nil {
+		return nil
+	}
+	p.flusher.Stop()
+	p.exportMetrics(ctx)
+	return nil
+}
+
+// GetCapabilities returns the Capabilities assigned to this processor.
+func (p *processorImp) GetCapabilities() component.ProcessorCapabilities {
+	return component.ProcessorCapabilities{MutatesConsumedData: false}
+}
+
+// ConsumeTraces aggregates the metrics of the spans and forwards the traces to the next
+// consumer. The metrics are sent separately, every metrics_flush_interval.
+func (p *processorImp) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	p.aggregate(td)
+	return p.nextConsumer.ConsumeTraces(ctx, td)
+}
+
+// exportMetrics sends the cumulative metrics of the series to the metrics exporter, if any.
+func (p *processorImp) exportMetrics(ctx context.Context) {
+	md, dropped, ok := p.collectMetrics()
+	if dropped > 0 {
+		p.logger.Warn("Dropped spans of new span metrics series, the maximum number of series is reached",
+			zap.Uint64("dropped_spans", dropped), zap.Int("max_series", p.config.MaxSeries))
+	}
+	if !ok {
+		return
+	}
+	if err := p.metricsExporter.ConsumeMetrics(ctx, md); err != nil {
+		p.logger.Warn("Failed to export span metrics", zap.Error(err))
+	}
+}
+
+// collectMetrics expires the series without spans for series_expiration and returns the
+// metrics of the remaining ones, or false if there is none, along with the number of spans
+// dropped since the last call.
+func (p *processorImp) collectMetrics() (pdata.Metrics, uint64, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	if p.config.SeriesExpiration > 0 {
+		for key, sm := range p.metrics {
+			if now.Sub(sm.lastUpdate) >= p.config.SeriesExpiration {
+				delete(p.metrics, key)
+			}
+		}
+	}
+
+	dropped := p.dropped
+	p.dropped = 0
+	if len(p.metrics) == 0 {
+		return pdata.Metrics{}, dropped, false
+	}
+	return p.buildMetrics(now), dropped, true
+}
+
+// aggregate adds the spans to the aggregated metrics.
+func (p *processorImp) aggregate(td pdata.Traces) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		if rs.IsNil() {
+			continue
+		}
+		serviceName := processor.ServiceNameForResource(rs.Resource())
+		ilss := rs.InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			ils := ilss.At(j)
+			if ils.IsNil() {
+				continue
+			}
+			spans := ils.Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				if span.IsNil() {
+					continue
+				}
+				p.aggregateSpan(serviceName, span, now)
+			}
+		}
+	}
+}
+
+func (p *processorImp) aggregateSpan(serviceName string, span pdata.Span, now time.Time) {
+	// A span without status is considered to have the Ok status code.
+	code := pdata.StatusCode(otlptrace.Status_Ok)
+	if !span.Status().IsNil() {
+		code = span.Status().Code()
+	}
+
+	labels := map[string]string{
+		serviceNameLabel: serviceName,
+		operationLabel:   span.Name(),
+		spanKindLabel:    span.Kind().String(),
+		statusCodeLabel:  code.String(),
+	}
+	values := []string{serviceName, span.Name(), labels[spanKindLabel], labels[statusCodeLabel]}
+	attrs := span.Attributes()
+	for _, dim := range p.config.Dimensions {
+		if attr, ok := attrs.Get(dim.Name); ok {
+			labels[dim.Name] = attributeValueToString(attr)
+		} else if dim.Default != nil {
+			labels[dim.Name] = *dim.Default
+		} else {
+			// Distinguish a missing label from an empty value.
+			values = append(values, keySeparator)
+			continue
+		}
+		values = append(values, labels[dim.Name])
+	}
+
+	key := strings.Join(values, keySeparator)
+	sm, ok := p.metrics[key]
+	if !ok {
+		if p.config.MaxSeries > 0 && len(p.metrics) >= p.config.MaxSeries {
+			p.dropped++
+			return
+		}
+		sm = &spanMetrics{
+			labels:       labels,
+			isError:      code != pdata.StatusCode(otlptrace.Status_Ok),
+			bucketCounts: make([]uint64, len(p.latencyBounds)+1),
+			startTime:    tracemetrics.ToTimestamp(now),
+		}
+		p.metrics[key] = sm
+	}
+
+	latency := float64(0)
+	if span.EndTime() > span.StartTime() {
+		latency = tracemetrics.DurationToMillis(time.Duration(span.EndTime() - span.StartTime()))
+	}
+	sm.lastUpdate = now
+	sm.calls++
+	sm.latencySum += latency
+	// The bucket of a value equal to a bound is the bucket of that bound.
+	sm.bucketCounts[sort.SearchFloat64s(p.latencyBounds, latency)]++
+}
+
+// buildMetrics returns the cumulative calls, errors and latency metrics of all the series.
+func (p *processorImp) buildMetrics(now time.Time) pdata.Metrics {
+	md := data.NewMetricData()
+	rms := md.ResourceMetrics()
+	rms.Resize(1)
+	ilms := rms.At(0).InstrumentationLibraryMetrics()
+	ilms.Resize(1)
+	metrics := ilms.At(0).Metrics()
+	metrics.Resize(3)
+
+	timestamp := tracemetrics.ToTimestamp(now)
+
+	calls := metrics.At(0)
+	tracemetrics.InitMetricDescriptor(calls, callsMetricName, "Number of spans", "1", pdata.MetricTypeMonotonicInt64)
+	errs := metrics.At(1)
+	tracemetrics.InitMetricDescriptor(errs, errorsMetricName, "Number of spans with an error status code", "1", pdata.MetricTypeMonotonicInt64)
+	latency := metrics.At(2)
+	tracemetrics.InitMetricDescriptor(latency, latencyMetricName, "Duration of the spans", "ms", pdata.MetricTypeHistogram)
+
+	// Sort the keys so the data points are reported in a stable order.
+	keys := make([]string, 0, len(p.metrics))
+	for key := range p.metrics {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		sm := p.metrics[key]
+
+		tracemetrics.AppendInt64DataPoint(calls.Int64DataPoints(), sm.labels, sm.startTime, timestamp, sm.calls)
+		if sm.isError {
+			tracemetrics.AppendInt64DataPoint(errs.Int64DataPoints(), sm.labels, sm.startTime, timestamp, sm.calls)
+		}
+		tracemetrics.AppendHistogramDataPoint(latency.HistogramDataPoints(), sm.labels, sm.startTime, timestamp,
+			uint64(sm.calls), sm.latencySum, p.latencyBounds, sm.bucketCounts)
+	}
+	return pdatautil.MetricsFromInternalMetrics(md)
+}
+
+func attributeValueToString(attr pdata.AttributeValue) string {
+	switch attr.Type() {
+	case pdata.AttributeValueSTRING:
+		return attr.StringVal()
+	case pdata.AttributeValueINT:
+		return strconv.FormatInt(attr.IntVal(), 10)
+	case pdata.AttributeValueDOUBLE:
+		return strconv.FormatFloat(attr.DoubleVal(), 'f', -1, 64)
+	case pdata.AttributeValueBOOL:
+		return strconv.FormatBool(attr.BoolVal())
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,379 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spanmetricsprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/consumer/pdatautil"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	otlptrace "go.opentelemetry.io/collector/internal/data/opentelemetry-proto-gen/trace/v1"
+	"go.opentelemetry.io/collector/translator/conventions"
+)
+
+// mockHost is a host exposing a fixed set of metrics exporters.
+type mockHost struct {
+	component.Host
+	exporters map[configmodels.Exporter]component.Exporter
+}
+
+func (m *mockHost) GetExporters() map[configmodels.DataType]map[configmodels.Exporter]component.Exporter {
+	return map[configmodels.DataType]map[configmodels.Exporter]component.Exporter{
+		configmodels.MetricsDataType: m.exporters,
+	}
+}
+
+func newMockHost(exporters map[string]component.Exporter) component.Host {
+	exps := make(map[configmodels.Exporter]component.Exporter, len(exporters))
+	for name, exp := range exporters {
+		exps[&configmodels.ExporterSettings{TypeVal: "prometheus", NameVal: name}] = exp
+	}
+	return &mockHost{Host: componenttest.NewNopHost(), exporters: exps}
+}
+
+func newTestProcessor(t *testing.T, cfg *Config, metricsSink component.Exporter, tracesSink *exportertest.SinkTraceExporter) *processorImp {
+	tp, err := NewFactory().CreateTraceProcessor(context.Background(),
+		component.ProcessorCreateParams{Logger: zap.NewNop()}, tracesSink, cfg)
+	require.NoError(t, err)
+	require.NoError(t, tp.Start(context.Background(), newMockHost(map[string]component.Exporter{
+		"prometheus": metricsSink,
+		"logging":    new(exportertest.SinkMetricsExporter),
+	})))
+	t.Cleanup(func() { tp.(*processorImp).flusher.Stop() })
+	return tp.(*processorImp)
+}
+
+type testSpan struct {
+	service  string
+	name     string
+	kind     pdata.SpanKind
+	code     otlptrace.Status_StatusCode
+	duration time.Duration
+	attrs    map[string]pdata.AttributeValue
+}
+
+func newTraces(spans ...testSpan) pdata.Traces {
+	td := pdata.NewTraces()
+	rss := td.ResourceSpans()
+	rss.Resize(len(spans))
+	for i, s := range spans {
+		rs := rss.At(i)
+		rs.Resource().InitEmpty()
+		rs.Resource().Attributes().InsertString(conventions.AttributeServiceName, s.service)
+		rs.InstrumentationLibrarySpans().Resize(1)
+		ss := rs.InstrumentationLibrarySpans().At(0).Spans()
+		ss.Resize(1)
+		span := ss.At(0)
+		span.SetName(s.name)
+		span.SetKind(s.kind)
+		start := time.Unix(1600000000, 0)
+		span.SetStartTime(pdata.TimestampUnixNano(uint64(start.UnixNano())))
+		span.SetEndTime(pdata.TimestampUnixNano(uint64(start.Add(s.duration).UnixNano())))
+		if s.code != otlptrace.Status_Ok {
+			span.Status().InitEmpty()
+			span.Status().SetCode(pdata.StatusCode(s.code))
+		}
+		span.Attributes().InitFromMap(s.attrs)
+	}
+	return td
+}
+
+// dataPoint is a comparable summary of an int64 or histogram data point.
+type dataPoint struct {
+	labels  map[string]string
+	value   int64
+	sum     float64
+	buckets []uint64
+}
+
+func collectMetrics(t *testing.T, md pdata.Metrics) map[string][]dataPoint {
+	result := make(map[string][]dataPoint)
+	rms := pdatautil.MetricsToInternalMetrics(md).ResourceMetrics()
+	require.Equal(t, 1, rms.Len())
+	metrics := rms.At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+	for i := 0; i < metrics.Len(); i++ {
+		metric := metrics.At(i)
+		name := metric.MetricDescriptor().Name()
+		result[name] = []dataPoint{}
+		idps := metric.Int64DataPoints()
+		for j := 0; j < idps.Len(); j++ {
+			dp := idps.At(j)
+			labels := make(map[string]string)
+			dp.LabelsMap().ForEach(func(k string, v pdata.StringValue) { labels[k] = v.Value() })
+			result[name] = append(result[name], dataPoint{labels: labels, value: dp.Value()})
+		}
+		hdps := metric.HistogramDataPoints()
+		for j := 0; j < hdps.Len(); j++ {
+			dp := hdps.At(j)
+			labels := make(map[string]string)
+			dp.LabelsMap().ForEach(func(k string, v pdata.StringValue) { labels[k] = v.Value() })
+			var buckets []uint64
+			for k := 0; k < dp.Buckets().Len(); k++ {
+				buckets = append(buckets, dp.Buckets().At(k).Count())
+			}
+			result[name] = append(result[name], dataPoint{labels: labels, value: int64(dp.Count()), sum: dp.Sum(), buckets: buckets})
+		}
+	}
+	return result
+}
+
+func TestProcessorAggregatesSpans(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.MetricsExporter = "prometheus"
+	cfg.LatencyHistogramBuckets = []time.Duration{10 * time.Millisecond, 100 * time.Millisecond}
+
+	metricsSink := new(exportertest.SinkMetricsExporter)
+	tracesSink := new(exportertest.SinkTraceExporter)
+	tp := newTestProcessor(t, cfg, metricsSink, tracesSink)
+
+	td := newTraces(
+		testSpan{service: "cart", name: "GET /cart", kind: pdata.SpanKindSERVER, duration: 5 * time.Millisecond},
+		testSpan{service: "cart", name: "GET /cart", kind: pdata.SpanKindSERVER, duration: 50 * time.Millisecond},
+		testSpan{service: "cart", name: "GET /cart", kind: pdata.SpanKindSERVER, code: otlptrace.Status_InternalError, duration: 500 * time.Millisecond},
+	)
+	require.NoError(t, tp.ConsumeTraces(context.Background(), td))
+	require.Len(t, tracesSink.AllTraces(), 1)
+	assert.Equal(t, td, tracesSink.AllTraces()[0])
+	// The metrics are only sent on flush.
+	assert.Len(t, metricsSink.AllMetrics(), 0)
+	tp.exportMetrics(context.Background())
+
+	okLabels := map[string]string{
+		serviceNameLabel: "cart",
+		operationLabel:   "GET /cart",
+		spanKindLabel:    "SERVER",
+		statusCodeLabel:  "Ok",
+	}
+	errLabels := map[string]string{
+		serviceNameLabel: "cart",
+		operationLabel:   "GET /cart",
+		spanKindLabel:    "SERVER",
+		statusCodeLabel:  "InternalError",
+	}
+
+	require.Len(t, metricsSink.AllMetrics(), 1)
+	got := collectMetrics(t, metricsSink.AllMetrics()[0])
+	assert.ElementsMatch(t, []dataPoint{
+		{labels: okLabels, value: 2},
+		{labels: errLabels, value: 1},
+	}, got[callsMetricName])
+	assert.Equal(t, []dataPoint{{labels: errLabels, value: 1}}, got[errorsMetricName])
+	assert.ElementsMatch(t, []dataPoint{
+		{labels: okLabels, value: 2, sum: 55, buckets: []uint64{1, 1, 0}},
+		{labels: errLabels, value: 1, sum: 500, buckets: []uint64{0, 0, 1}},
+	}, got[latencyMetricName])
+
+	// The metrics are cumulative.
+	require.NoError(t, tp.ConsumeTraces(context.Background(), newTraces(
+		testSpan{service: "cart", name: "GET /cart", kind: pdata.SpanKindSERVER, duration: 10 * time.Millisecond},
+	)))
+	tp.exportMetrics(context.Background())
+	require.Len(t, metricsSink.AllMetrics(), 2)
+	got = collectMetrics(t, metricsSink.AllMetrics()[1])
+	assert.ElementsMatch(t, []dataPoint{
+		{labels: okLabels, value: 3},
+		{labels: errLabels, value: 1},
+	}, got[callsMetricName])
+	assert.ElementsMatch(t, []dataPoint{
+		{labels: okLabels, value: 3, sum: 65, buckets: []uint64{2, 1, 0}},
+		{labels: errLabels, value: 1, sum: 500, buckets: []uint64{0, 0, 1}},
+	}, got[latencyMetricName])
+}
+
+func TestProcessorDimensions(t *testing.T) {
+	defaultMethod := "GET"
+	cfg := createDefaultConfig().(*Config)
+	cfg.MetricsExporter = "prometheus"
+	cfg.Dimensions = []Dimension{
+		{Name: "http.method", Default: &defaultMethod},
+		{Name: "http.status_code"},
+	}
+
+	metricsSink := new(exportertest.SinkMetricsExporter)
+	tp := newTestProcessor(t, cfg, metricsSink, new(exportertest.SinkTraceExporter))
+
+	require.NoError(t, tp.ConsumeTraces(context.Background(), newTraces(
+		testSpan{service: "cart", name: "checkout", attrs: map[string]pdata.AttributeValue{
+			"http.method":      pdata.NewAttributeValueString("POST"),
+			"http.status_code": pdata.NewAttributeValueInt(201),
+		}},
+		testSpan{service: "cart", name: "checkout"},
+	)))
+	tp.exportMetrics(context.Background())
+
+	got := collectMetrics(t, metricsSink.AllMetrics()[0])
+	assert.ElementsMatch(t, []dataPoint{
+		{labels: map[string]string{
+			serviceNameLabel:   "cart",
+			operationLabel:     "checkout",
+			spanKindLabel:      "SPAN_KIND_UNSPECIFIED",
+			statusCodeLabel:    "Ok",
+			"http.method":      "POST",
+			"http.status_code": "201",
+		}, value: 1},
+		{labels: map[string]string{
+			serviceNameLabel: "cart",
+			operationLabel:   "checkout",
+			spanKindLabel:    "SPAN_KIND_UNSPECIFIED",
+			statusCodeLabel:  "Ok",
+			"http.method":    "GET",
+		}, value: 1},
+	}, got[callsMetricName])
+}
+
+func TestProcessorMetricsExportError(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.MetricsExporter = "prometheus"
+
+	metricsSink := new(exportertest.SinkMetricsExporter)
+	metricsSink.SetConsumeMetricsError(assert.AnError)
+	tracesSink := new(exportertest.SinkTraceExporter)
+	tp := newTestProcessor(t, cfg, metricsSink, tracesSink)
+
+	// The traces are forwarded even if the metrics cannot be exported.
+	require.NoError(t, tp.ConsumeTraces(context.Background(), newTraces(testSpan{service: "cart", name: "checkout"})))
+	tp.exportMetrics(context.Background())
+	assert.Len(t, tracesSink.AllTraces(), 1)
+}
+
+func TestProcessorNoSpans(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.MetricsExporter = "prometheus"
+
+	metricsSink := new(exportertest.SinkMetricsExporter)
+	tp := newTestProcessor(t, cfg, metricsSink, new(exportertest.SinkTraceExporter))
+
+	require.NoError(t, tp.ConsumeTraces(context.Background(), pdata.NewTraces()))
+	tp.exportMetrics(context.Background())
+	assert.Len(t, metricsSink.AllMetrics(), 0)
+}
+
+func TestProcessorFlushInterval(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.MetricsExporter = "prometheus"
+	cfg.MetricsFlushInterval = 10 * time.Millisecond
+
+	metricsSink := new(exportertest.SinkMetricsExporter)
+	tp := newTestProcessor(t, cfg, metricsSink, new(exportertest.SinkTraceExporter))
+
+	require.NoError(t, tp.ConsumeTraces(context.Background(), newTraces(testSpan{service: "cart", name: "checkout"})))
+	assert.Eventually(t, func() bool { return len(metricsSink.AllMetrics()) > 0 }, time.Second, 5*time.Millisecond)
+
+	// The metrics are sent a last time on shutdown.
+	require.NoError(t, tp.Shutdown(context.Background()))
+	count := len(metricsSink.AllMetrics())
+	time.Sleep(30 * time.Millisecond)
+	assert.Len(t, metricsSink.AllMetrics(), count)
+}
+
+func TestProcessorShutdownFlushes(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.MetricsExporter = "prometheus"
+
+	metricsSink := new(exportertest.SinkMetricsExporter)
+	tp := newTestProcessor(t, cfg, metricsSink, new(exportertest.SinkTraceExporter))
+
+	require.NoError(t, tp.ConsumeTraces(context.Background(), newTraces(testSpan{service: "cart", name: "checkout"})))
+	require.NoError(t, tp.Shutdown(context.Background()))
+	require.Len(t, metricsSink.AllMetrics(), 1)
+}
+
+func TestProcessorMaxSeries(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.MetricsExporter = "prometheus"
+	cfg.MaxSeries = 1
+
+	metricsSink := new(exportertest.SinkMetricsExporter)
+	tp := newTestProcessor(t, cfg, metricsSink, new(exportertest.SinkTraceExporter))
+
+	require.NoError(t, tp.ConsumeTraces(context.Background(), newTraces(
+		testSpan{service: "cart", name: "checkout"},
+		testSpan{service: "cart", name: "list"},
+		testSpan{service: "cart", name: "checkout"},
+	)))
+	assert.EqualValues(t, 1, tp.dropped)
+	tp.exportMetrics(context.Background())
+	assert.Zero(t, tp.dropped)
+
+	got := collectMetrics(t, metricsSink.AllMetrics()[0])
+	require.Len(t, got[callsMetricName], 1)
+	assert.Equal(t, "checkout", got[callsMetricName][0].labels[operationLabel])
+	assert.EqualValues(t, 2, got[callsMetricName][0].value)
+}
+
+func TestProcessorSeriesExpiration(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.MetricsExporter = "prometheus"
+	cfg.SeriesExpiration = time.Minute
+
+	metricsSink := new(exportertest.SinkMetricsExporter)
+	tp := newTestProcessor(t, cfg, metricsSink, new(exportertest.SinkTraceExporter))
+	now := time.Unix(1600000000, 0)
+	tp.now = func() time.Time { return now }
+
+	require.NoError(t, tp.ConsumeTraces(context.Background(), newTraces(testSpan{service: "cart", name: "checkout"})))
+	now = now.Add(30 * time.Second)
+	require.NoError(t, tp.ConsumeTraces(context.Background(), newTraces(testSpan{service: "cart", name: "list"})))
+
+	now = now.Add(45 * time.Second)
+	tp.exportMetrics(context.Background())
+	got := collectMetrics(t, metricsSink.AllMetrics()[0])
+	require.Len(t, got[callsMetricName], 1)
+	assert.Equal(t, "list", got[callsMetricName][0].labels[operationLabel])
+
+	// Once all the series expired, nothing is sent.
+	now = now.Add(time.Minute)
+	tp.exportMetrics(context.Background())
+	assert.Len(t, metricsSink.AllMetrics(), 1)
+	assert.Empty(t, tp.metrics)
+}
+
+func TestStartMetricsExporterNotFound(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.MetricsExporter = "otlp"
+
+	tp, err := NewFactory().CreateTraceProcessor(context.Background(),
+		component.ProcessorCreateParams{Logger: zap.NewNop()}, exportertest.NewNopTraceExporter(), cfg)
+	require.NoError(t, err)
+
+	err = tp.Start(context.Background(), newMockHost(map[string]component.Exporter{
+		"prometheus": new(exportertest.SinkMetricsExporter),
+		"logging":    new(exportertest.SinkMetricsExporter),
+	}))
+	assert.EqualError(t, err, `spanmetrics processor "spanmetrics": metrics exporter "otlp" not found, it must be listed in a metrics pipeline, available exporters: [logging prometheus]`)
+}
+
+func TestStartOldMetricsExporter(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.MetricsExporter = "prometheus"
+
+	metricsSink := new(exportertest.SinkMetricsExporterOld)
+	tp := newTestProcessor(t, cfg, metricsSink, new(exportertest.SinkTraceExporter))
+
+	require.NoError(t, tp.ConsumeTraces(context.Background(), newTraces(testSpan{service: "cart", name: "checkout"})))
+	tp.exportMetrics(context.Background())
+	require.Len(t, metricsSink.AllMetrics(), 1)
+	assert.Len(t, metricsSink.AllMetrics()[0].Metrics, 3)
+}
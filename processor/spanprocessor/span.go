@@ -23,7 +23,6 @@ import (
 
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/internal/processor/filterspan"
-	"go.opentelemetry.io/collector/processor"
 )
 
 type spanProcessor struct {
@@ -87,7 +86,6 @@ func (sp *spanProcessor) ProcessTraces(_ context.Context, td pdata.Traces) (pdat
 		if rs.IsNil() {
 			continue
 		}
-		serviceName := processor.ServiceNameForResource(rs.Resource())
 		ilss := rss.At(i).InstrumentationLibrarySpans()
 		for j := 0; j < ilss.Len(); j++ {
 			ils := ilss.At(j)
@@ -101,7 +99,7 @@ func (sp *spanProcessor) ProcessTraces(_ context.Context, td pdata.Traces) (pdat
 					continue
 				}
 
-				if sp.skipSpan(s, serviceName) {
+				if sp.skipSpan(s, rs.Resource()) {
 					continue
 				}
 				sp.processFromAttributes(s)
@@ -236,17 +234,17 @@ func (sp *spanProcessor) processToAttributes(span pdata.Span) {
 // The logic determining if a span should be processed is set
 // in the attribute configuration with the include and exclude settings.
 // Include properties are checked before exclude settings are checked.
-func (sp *spanProcessor) skipSpan(span pdata.Span, serviceName string) bool {
+func (sp *spanProcessor) skipSpan(span pdata.Span, resource pdata.Resource) bool {
 	if sp.include != nil {
 		// A false returned in this case means the span should not be processed.
-		if include := sp.include.MatchSpan(span, serviceName); !include {
+		if include := sp.include.MatchSpan(span, resource); !include {
 			return true
 		}
 	}
 
 	if sp.exclude != nil {
 		// A true returned in this case means the span should not be processed.
-		if exclude := sp.exclude.MatchSpan(span, serviceName); exclude {
+		if exclude := sp.exclude.MatchSpan(span, resource); exclude {
 			return true
 		}
 	}
@@ -629,3 +629,44 @@ func TestSpanProcessor_skipSpan(t *testing.T) {
 		runIndividualTestCase(t, tc, tp)
 	}
 }
+
+func TestSpanProcessor_skipSpanExpr(t *testing.T) {
+	testCases := []testCase{
+		{
+			serviceName: "bankss",
+			inputName:   "url/url",
+			outputName:  "url/url",
+		},
+		{
+			serviceName: "banks",
+			inputName:   "www.test.com/code",
+			outputName:  "{operation_website}",
+			outputAttributes: map[string]pdata.AttributeValue{
+				"operation_website": pdata.NewAttributeValueString("www.test.com/code"),
+			},
+		},
+		{
+			serviceName: "banks",
+			inputName:   "donot/change",
+			outputName:  "donot/change",
+		},
+	}
+
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	oCfg := cfg.(*Config)
+	oCfg.Include = &filterspan.MatchProperties{
+		Expr: `resource["service.name"] == "banks" && name =~ "/" && name != "donot/change"`,
+	}
+	oCfg.Rename.ToAttributes = &ToAttributes{
+		Rules: []string{`(?P<operation_website>.*?)$`},
+	}
+	tp, err := factory.CreateTraceProcessor(
+		context.Background(), component.ProcessorCreateParams{Logger: zap.NewNop()}, exportertest.NewNopTraceExporter(), oCfg)
+	require.Nil(t, err)
+	require.NotNil(t, tp)
+
+	for _, tc := range testCases {
+		runIndividualTestCase(t, tc, tp)
+	}
+}
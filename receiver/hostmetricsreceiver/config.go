@@ -15,16 +15,13 @@
 package hostmetricsreceiver
 
 import (
-	"time"
-
-	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal"
+	"go.opentelemetry.io/collector/receiver/scraperhelper"
 )
 
 // Config defines configuration for HostMetrics receiver.
 type Config struct {
-	configmodels.ReceiverSettings `mapstructure:",squash"`
+	scraperhelper.ScraperControllerSettings `mapstructure:",squash"`
 
-	CollectionInterval time.Duration              `mapstructure:"collection_interval"`
-	Scrapers           map[string]internal.Config `mapstructure:"-"`
+	Scrapers map[string]internal.Config `mapstructure:"-"`
 }
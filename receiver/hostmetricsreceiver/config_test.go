@@ -27,6 +27,7 @@ import (
 	"go.opentelemetry.io/collector/config/configtest"
 	"go.opentelemetry.io/collector/internal/processor/filterset"
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal"
+	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/cgroupscraper"
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/cpuscraper"
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/diskscraper"
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/filesystemscraper"
@@ -36,6 +37,7 @@ import (
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/processesscraper"
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/processscraper"
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/swapscraper"
+	"go.opentelemetry.io/collector/receiver/scraperhelper"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -61,11 +63,15 @@ func TestLoadConfig(t *testing.T) {
 
 	r1 := cfg.Receivers["hostmetrics/customname"].(*Config)
 	expectedConfig := &Config{
-		ReceiverSettings: configmodels.ReceiverSettings{
-			TypeVal: typeStr,
-			NameVal: "hostmetrics/customname",
+		ScraperControllerSettings: scraperhelper.ScraperControllerSettings{
+			ReceiverSettings: configmodels.ReceiverSettings{
+				TypeVal: typeStr,
+				NameVal: "hostmetrics/customname",
+			},
+			CollectionInterval: 30 * time.Second,
+			InitialDelay:       5 * time.Second,
+			Timeout:            10 * time.Second,
 		},
-		CollectionInterval: 30 * time.Second,
 		Scrapers: map[string]internal.Config{
 			cpuscraper.TypeStr:        &cpuscraper.Config{},
 			diskscraper.TypeStr:       &diskscraper.Config{},
@@ -83,6 +89,15 @@ func TestLoadConfig(t *testing.T) {
 			processscraper.TypeStr: &processscraper.Config{
 				Include: processscraper.MatchConfig{
 					Names:  []string{"test2", "test3"},
+					Owners: []string{"^root$"},
+					Config: filterset.Config{MatchType: "regexp"},
+				},
+				MaxProcesses: 100,
+			},
+			cgroupscraper.TypeStr: &cgroupscraper.Config{
+				RootPath: "/host/sys/fs/cgroup",
+				Exclude: cgroupscraper.MatchConfig{
+					Paths:  []string{"^/system\\.slice/"},
 					Config: filterset.Config{MatchType: "regexp"},
 				},
 			},
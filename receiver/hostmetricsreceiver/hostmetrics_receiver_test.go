@@ -27,9 +27,11 @@ import (
 	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/consumer/pdatautil"
 	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/obsreport/obsreporttest"
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal"
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/cpuscraper"
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/diskscraper"
@@ -40,6 +42,7 @@ import (
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/processesscraper"
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/processscraper"
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/swapscraper"
+	"go.opentelemetry.io/collector/receiver/scraperhelper"
 )
 
 var standardMetrics = []string{
@@ -67,6 +70,12 @@ var resourceMetrics = []string{
 	"process.memory.physical_usage",
 	"process.memory.virtual_usage",
 	"process.disk.io",
+	"process.threads",
+	"process.start_time",
+}
+
+var systemSpecificResourceMetrics = map[string][]string{
+	"linux": {"process.open_file_descriptors", "process.context_switches"},
 }
 
 var systemSpecificMetrics = map[string][]string{
@@ -96,7 +105,9 @@ func TestGatherMetrics_EndToEnd(t *testing.T) {
 	sink := &exportertest.SinkMetricsExporter{}
 
 	config := &Config{
-		CollectionInterval: 100 * time.Millisecond,
+		ScraperControllerSettings: scraperhelper.ScraperControllerSettings{
+			CollectionInterval: 100 * time.Millisecond,
+		},
 		Scrapers: map[string]internal.Config{
 			cpuscraper.TypeStr:        &cpuscraper.Config{},
 			diskscraper.TypeStr:       &diskscraper.Config{},
@@ -176,8 +187,10 @@ func assertIncludesResourceMetrics(t *testing.T, got pdata.Metrics) {
 		appendMapInto(returnedMetrics, getReturnedMetricNames(metrics))
 	}
 
-	assert.Equal(t, len(resourceMetrics), len(returnedMetrics))
-	for _, expected := range resourceMetrics {
+	// the expected list of metrics returned is os dependent
+	expectedMetrics := append(resourceMetrics, systemSpecificResourceMetrics[runtime.GOOS]...)
+	assert.Equal(t, len(expectedMetrics), len(returnedMetrics))
+	for _, expected := range expectedMetrics {
 		assert.Contains(t, returnedMetrics, expected)
 	}
 }
@@ -208,33 +221,31 @@ const mockResourceTypeStr = "mockresource"
 type mockConfig struct{}
 
 type mockFactory struct{ mock.Mock }
-type mockScraper struct{ mock.Mock }
 
 func (m *mockFactory) CreateDefaultConfig() internal.Config { return &mockConfig{} }
-func (m *mockFactory) CreateMetricsScraper(ctx context.Context, logger *zap.Logger, cfg internal.Config) (internal.Scraper, error) {
+func (m *mockFactory) CreateMetricsScraper(ctx context.Context, logger *zap.Logger, cfg internal.Config) (scraperhelper.MetricsScraper, error) {
 	args := m.MethodCalled("CreateMetricsScraper")
-	return args.Get(0).(internal.Scraper), args.Error(1)
+	return args.Get(0).(scraperhelper.MetricsScraper), args.Error(1)
 }
 
-func (m *mockScraper) Initialize(ctx context.Context) error { return nil }
-func (m *mockScraper) Close(ctx context.Context) error      { return nil }
-func (m *mockScraper) ScrapeMetrics(ctx context.Context) (pdata.MetricSlice, error) {
-	return pdata.NewMetricSlice(), errors.New("err1")
+func newMockScraper() scraperhelper.MetricsScraper {
+	return scraperhelper.NewMetricsScraper(mockTypeStr, func(context.Context) (pdata.MetricSlice, error) {
+		return pdata.NewMetricSlice(), errors.New("err1")
+	})
 }
 
 type mockResourceFactory struct{ mock.Mock }
-type mockResourceScraper struct{ mock.Mock }
 
 func (m *mockResourceFactory) CreateDefaultConfig() internal.Config { return &mockConfig{} }
-func (m *mockResourceFactory) CreateMetricsScraper(ctx context.Context, logger *zap.Logger, cfg internal.Config) (internal.ResourceScraper, error) {
+func (m *mockResourceFactory) CreateMetricsScraper(ctx context.Context, logger *zap.Logger, cfg internal.Config) (scraperhelper.ResourceMetricsScraper, error) {
 	args := m.MethodCalled("CreateMetricsScraper")
-	return args.Get(0).(internal.ResourceScraper), args.Error(1)
+	return args.Get(0).(scraperhelper.ResourceMetricsScraper), args.Error(1)
 }
 
-func (m *mockResourceScraper) Initialize(ctx context.Context) error { return nil }
-func (m *mockResourceScraper) Close(ctx context.Context) error      { return nil }
-func (m *mockResourceScraper) ScrapeMetrics(ctx context.Context) (pdata.ResourceMetricsSlice, error) {
-	return pdata.NewResourceMetricsSlice(), errors.New("err2")
+func newMockResourceScraper() scraperhelper.ResourceMetricsScraper {
+	return scraperhelper.NewResourceMetricsScraper(mockResourceTypeStr, func(context.Context) (pdata.ResourceMetricsSlice, error) {
+		return pdata.NewResourceMetricsSlice(), errors.New("err2")
+	})
 }
 
 func TestGatherMetrics_ScraperKeyConfigError(t *testing.T) {
@@ -250,7 +261,7 @@ func TestGatherMetrics_ScraperKeyConfigError(t *testing.T) {
 
 func TestGatherMetrics_CreateMetricsScraperError(t *testing.T) {
 	mFactory := &mockFactory{}
-	mFactory.On("CreateMetricsScraper").Return(&mockScraper{}, errors.New("err1"))
+	mFactory.On("CreateMetricsScraper").Return(newMockScraper(), errors.New("err1"))
 	var mockFactories = map[string]internal.ScraperFactory{mockTypeStr: mFactory}
 	var mockResourceFactories = map[string]internal.ResourceScraperFactory{}
 
@@ -262,7 +273,7 @@ func TestGatherMetrics_CreateMetricsScraperError(t *testing.T) {
 
 func TestGatherMetrics_CreateMetricsResourceScraperError(t *testing.T) {
 	mResourceFactory := &mockResourceFactory{}
-	mResourceFactory.On("CreateMetricsScraper").Return(&mockResourceScraper{}, errors.New("err1"))
+	mResourceFactory.On("CreateMetricsScraper").Return(newMockResourceScraper(), errors.New("err1"))
 	var mockFactories = map[string]internal.ScraperFactory{}
 	var mockResourceFactories = map[string]internal.ResourceScraperFactory{mockTypeStr: mResourceFactory}
 
@@ -273,10 +284,14 @@ func TestGatherMetrics_CreateMetricsResourceScraperError(t *testing.T) {
 }
 
 func TestGatherMetrics_Error(t *testing.T) {
+	doneFn, err := obsreporttest.SetupRecordedMetricsTest()
+	require.NoError(t, err)
+	defer doneFn()
+
 	mFactory := &mockFactory{}
-	mFactory.On("CreateMetricsScraper").Return(&mockScraper{}, nil)
+	mFactory.On("CreateMetricsScraper").Return(newMockScraper(), nil)
 	mResourceFactory := &mockResourceFactory{}
-	mResourceFactory.On("CreateMetricsScraper").Return(&mockResourceScraper{}, nil)
+	mResourceFactory.On("CreateMetricsScraper").Return(newMockResourceScraper(), nil)
 
 	var mockFactories = map[string]internal.ScraperFactory{mockTypeStr: mFactory}
 	var mockResourceFactories = map[string]internal.ResourceScraperFactory{mockResourceTypeStr: mResourceFactory}
@@ -284,6 +299,10 @@ func TestGatherMetrics_Error(t *testing.T) {
 	sink := &exportertest.SinkMetricsExporter{}
 
 	config := &Config{
+		ScraperControllerSettings: scraperhelper.ScraperControllerSettings{
+			ReceiverSettings:   configmodels.ReceiverSettings{NameVal: typeStr},
+			CollectionInterval: time.Hour,
+		},
 		Scrapers: map[string]internal.Config{
 			mockTypeStr:         &mockConfig{},
 			mockResourceTypeStr: &mockConfig{},
@@ -293,8 +312,10 @@ func TestGatherMetrics_Error(t *testing.T) {
 	receiver, err := newHostMetricsReceiver(context.Background(), zap.NewNop(), config, mockFactories, mockResourceFactories, sink)
 	require.NoError(t, err)
 
-	receiver.initializeScrapers(context.Background(), componenttest.NewNopHost())
-	receiver.scrapeMetrics(context.Background())
+	// the scrapers are run once on start, and not again for an hour
+	require.NoError(t, receiver.Start(context.Background(), componenttest.NewNopHost()))
+	require.Eventually(t, func() bool { return len(sink.AllMetrics()) > 0 }, time.Second, 10*time.Millisecond)
+	require.NoError(t, receiver.Shutdown(context.Background()))
 
 	got := sink.AllMetrics()
 
@@ -306,21 +327,40 @@ func TestGatherMetrics_Error(t *testing.T) {
 	require.Equal(t, 1, ilm.Len())
 	metrics := ilm.At(0).Metrics()
 	require.Equal(t, 0, metrics.Len())
+
+	obsreporttest.CheckScraperMetricsViews(t, typeStr, mockTypeStr, 0, 0, 1)
+	obsreporttest.CheckScraperMetricsViews(t, typeStr, mockResourceTypeStr, 0, 0, 1)
 }
 
 func benchmarkScrapeMetrics(b *testing.B, cfg *Config) {
-	sink := &exportertest.SinkMetricsExporter{}
+	ctx := context.Background()
+
+	var metricsScrapers []scraperhelper.MetricsScraper
+	var resourceMetricsScrapers []scraperhelper.ResourceMetricsScraper
+	for key, scraperCfg := range cfg.Scrapers {
+		scraper, ok, err := createHostMetricsScraper(ctx, zap.NewNop(), key, scraperCfg, factories)
+		require.NoError(b, err)
+		if ok {
+			require.NoError(b, scraper.Initialize(ctx))
+			metricsScrapers = append(metricsScrapers, scraper)
+			continue
+		}
 
-	receiver, _ := newHostMetricsReceiver(context.Background(), zap.NewNop(), cfg, factories, resourceFactories, sink)
-	receiver.initializeScrapers(context.Background(), componenttest.NewNopHost())
+		resourceScraper, ok, err := createResourceMetricsScraper(ctx, zap.NewNop(), key, scraperCfg, resourceFactories)
+		require.NoError(b, err)
+		require.True(b, ok)
+		require.NoError(b, resourceScraper.Initialize(ctx))
+		resourceMetricsScrapers = append(resourceMetricsScrapers, resourceScraper)
+	}
 
 	b.ResetTimer()
 	for n := 0; n < b.N; n++ {
-		receiver.scrapeMetrics(context.Background())
-	}
-
-	if len(sink.AllMetrics()) == 0 {
-		b.Fail()
+		for _, scraper := range metricsScrapers {
+			_, _ = scraper.Scrape(ctx, typeStr)
+		}
+		for _, scraper := range resourceMetricsScrapers {
+			_, _ = scraper.Scrape(ctx, typeStr)
+		}
 	}
 }
 
@@ -0,0 +1,129 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cgroupscraper
+
+import (
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// resource attributes
+
+const (
+	cgroupPathAttributeName = "cgroup.path"
+)
+
+// labels
+
+const (
+	deviceLabelName    = "device"
+	directionLabelName = "direction"
+	stateLabelName     = "state"
+)
+
+// direction label values
+
+const (
+	readDirectionLabelValue  = "read"
+	writeDirectionLabelValue = "write"
+)
+
+// state label values
+
+const (
+	userStateLabelValue   = "user"
+	systemStateLabelValue = "system"
+)
+
+// descriptors
+
+var cpuTimeDescriptor = func() pdata.MetricDescriptor {
+	descriptor := pdata.NewMetricDescriptor()
+	descriptor.InitEmpty()
+	descriptor.SetName("cgroup.cpu.time")
+	descriptor.SetDescription("Total CPU seconds used by the tasks of the cgroup broken down by different states.")
+	descriptor.SetUnit("s")
+	descriptor.SetType(pdata.MetricTypeMonotonicDouble)
+	return descriptor
+}()
+
+var cpuPeriodsDescriptor = func() pdata.MetricDescriptor {
+	descriptor := pdata.NewMetricDescriptor()
+	descriptor.InitEmpty()
+	descriptor.SetName("cgroup.cpu.periods")
+	descriptor.SetDescription("Number of CPU bandwidth enforcement periods that elapsed.")
+	descriptor.SetUnit("1")
+	descriptor.SetType(pdata.MetricTypeMonotonicInt64)
+	return descriptor
+}()
+
+var cpuThrottledPeriodsDescriptor = func() pdata.MetricDescriptor {
+	descriptor := pdata.NewMetricDescriptor()
+	descriptor.InitEmpty()
+	descriptor.SetName("cgroup.cpu.throttled_periods")
+	descriptor.SetDescription("Number of CPU bandwidth enforcement periods in which the cgroup was throttled.")
+	descriptor.SetUnit("1")
+	descriptor.SetType(pdata.MetricTypeMonotonicInt64)
+	return descriptor
+}()
+
+var cpuThrottledTimeDescriptor = func() pdata.MetricDescriptor {
+	descriptor := pdata.NewMetricDescriptor()
+	descriptor.InitEmpty()
+	descriptor.SetName("cgroup.cpu.throttled_time")
+	descriptor.SetDescription("Total time the tasks of the cgroup were throttled.")
+	descriptor.SetUnit("s")
+	descriptor.SetType(pdata.MetricTypeMonotonicDouble)
+	return descriptor
+}()
+
+var memoryUsageDescriptor = func() pdata.MetricDescriptor {
+	descriptor := pdata.NewMetricDescriptor()
+	descriptor.InitEmpty()
+	descriptor.SetName("cgroup.memory.usage")
+	descriptor.SetDescription("Memory used by the tasks of the cgroup, including the page cache.")
+	descriptor.SetUnit("bytes")
+	descriptor.SetType(pdata.MetricTypeInt64)
+	return descriptor
+}()
+
+var memoryLimitDescriptor = func() pdata.MetricDescriptor {
+	descriptor := pdata.NewMetricDescriptor()
+	descriptor.InitEmpty()
+	descriptor.SetName("cgroup.memory.limit")
+	descriptor.SetDescription("Memory limit of the cgroup, not reported if unlimited.")
+	descriptor.SetUnit("bytes")
+	descriptor.SetType(pdata.MetricTypeInt64)
+	return descriptor
+}()
+
+var diskIODescriptor = func() pdata.MetricDescriptor {
+	descriptor := pdata.NewMetricDescriptor()
+	descriptor.InitEmpty()
+	descriptor.SetName("cgroup.disk.io")
+	descriptor.SetDescription("Disk bytes transferred by the tasks of the cgroup.")
+	descriptor.SetUnit("bytes")
+	descriptor.SetType(pdata.MetricTypeMonotonicInt64)
+	return descriptor
+}()
+
+var diskOpsDescriptor = func() pdata.MetricDescriptor {
+	descriptor := pdata.NewMetricDescriptor()
+	descriptor.InitEmpty()
+	descriptor.SetName("cgroup.disk.ops")
+	descriptor.SetDescription("Disk operations count of the tasks of the cgroup.")
+	descriptor.SetUnit("1")
+	descriptor.SetType(pdata.MetricTypeMonotonicInt64)
+	return descriptor
+}()
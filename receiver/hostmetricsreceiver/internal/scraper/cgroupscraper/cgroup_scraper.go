@@ -0,0 +1,222 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cgroupscraper
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/shirou/gopsutil/host"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/processor/filterset"
+)
+
+// scraper for Cgroup Metrics
+type scraper struct {
+	config    *Config
+	rootPath  string
+	includeFS filterset.FilterSet
+	excludeFS filterset.FilterSet
+
+	// cgroups holds the cgroups found by the last scrape by path.
+	cgroups map[string]*cgroupState
+	// lastScrapeTime is the time of the last scrape, or the boot time before the first scrape.
+	lastScrapeTime pdata.TimestampUnixNano
+
+	// for mocking
+	bootTime func() (uint64, error)
+	now      func() time.Time
+}
+
+// cgroupState is the state kept for a cgroup between scrapes.
+type cgroupState struct {
+	info os.FileInfo
+	// startTime is the start time of the cumulative metrics of the cgroup.
+	startTime pdata.TimestampUnixNano
+}
+
+// newCgroupScraper creates a Cgroup Scraper
+func newCgroupScraper(cfg *Config) (*scraper, error) {
+	scraper := &scraper{config: cfg, rootPath: cfg.RootPath, bootTime: host.BootTime, now: time.Now}
+	if scraper.rootPath == "" {
+		scraper.rootPath = defaultRootPath
+	}
+
+	var err error
+
+	if len(cfg.Include.Paths) > 0 {
+		scraper.includeFS, err = filterset.CreateFilterSet(cfg.Include.Paths, &cfg.Include.Config)
+		if err != nil {
+			return nil, errors.Wrap(err, "error creating cgroup include filters")
+		}
+	}
+
+	if len(cfg.Exclude.Paths) > 0 {
+		scraper.excludeFS, err = filterset.CreateFilterSet(cfg.Exclude.Paths, &cfg.Exclude.Config)
+		if err != nil {
+			return nil, errors.Wrap(err, "error creating cgroup exclude filters")
+		}
+	}
+
+	return scraper, nil
+}
+
+// Initialize
+func (s *scraper) Initialize(_ context.Context) error {
+	bootTime, err := s.bootTime()
+	if err != nil {
+		return err
+	}
+
+	s.lastScrapeTime = pdata.TimestampUnixNano(bootTime * 1e9)
+	s.cgroups = map[string]*cgroupState{}
+	return nil
+}
+
+// Close
+func (s *scraper) Close(_ context.Context) error {
+	return nil
+}
+
+// ScrapeMetrics
+func (s *scraper) ScrapeMetrics(_ context.Context) (pdata.ResourceMetricsSlice, error) {
+	stats, err := readCgroups(s.rootPath, s.matches)
+
+	now := pdata.TimestampUnixNano(uint64(s.now().UnixNano()))
+	cgroups := make(map[string]*cgroupState, len(stats))
+	rms := pdata.NewResourceMetricsSlice()
+	rms.Resize(len(stats))
+	for i, st := range stats {
+		rm := rms.At(i)
+		initializeResource(rm.Resource(), st.path)
+
+		state := s.cgroupState(st)
+		cgroups[st.path] = state
+		ilms := rm.InstrumentationLibraryMetrics()
+		ilms.Resize(1)
+		appendCgroupMetrics(ilms.At(0).Metrics(), state.startTime, now, st)
+	}
+	s.cgroups = cgroups
+	s.lastScrapeTime = now
+
+	return rms, err
+}
+
+// cgroupState returns the state of the cgroup found by the last scrape at the same path. A new state
+// is returned for a cgroup that appeared or was recreated since the last scrape, its counters started
+// from zero after the last scrape, which is used as start time. The cgroups found by the first scrape
+// use the boot time.
+func (s *scraper) cgroupState(stats *cgroupStats) *cgroupState {
+	if state, ok := s.cgroups[stats.path]; ok && os.SameFile(state.info, stats.info) {
+		return state
+	}
+	return &cgroupState{info: stats.info, startTime: s.lastScrapeTime}
+}
+
+// matches returns true if the metrics of the cgroup path should be generated.
+func (s *scraper) matches(path string) bool {
+	return (s.includeFS == nil || s.includeFS.Matches(path)) &&
+		(s.excludeFS == nil || !s.excludeFS.Matches(path))
+}
+
+func initializeResource(resource pdata.Resource, path string) {
+	resource.InitEmpty()
+	attr := resource.Attributes()
+	attr.InsertString(cgroupPathAttributeName, path)
+}
+
+func appendCgroupMetrics(metrics pdata.MetricSlice, startTime, now pdata.TimestampUnixNano, stats *cgroupStats) {
+	if stats.cpuTimes != nil {
+		metric := appendMetric(metrics, cpuTimeDescriptor)
+		ddps := metric.DoubleDataPoints()
+		ddps.Resize(2)
+		initializeDoubleDataPoint(ddps.At(0), startTime, now, stats.cpuTimes.user, stateLabelName, userStateLabelValue)
+		initializeDoubleDataPoint(ddps.At(1), startTime, now, stats.cpuTimes.system, stateLabelName, systemStateLabelValue)
+	}
+
+	if stats.throttling != nil {
+		appendInt64Metric(metrics, cpuPeriodsDescriptor, startTime, now, stats.throttling.periods)
+		appendInt64Metric(metrics, cpuThrottledPeriodsDescriptor, startTime, now, stats.throttling.throttledPeriods)
+		metric := appendMetric(metrics, cpuThrottledTimeDescriptor)
+		ddps := metric.DoubleDataPoints()
+		ddps.Resize(1)
+		initializeDoubleDataPoint(ddps.At(0), startTime, now, stats.throttling.throttledTime, "", "")
+	}
+
+	if stats.memory != nil {
+		appendInt64Metric(metrics, memoryUsageDescriptor, 0, now, stats.memory.usage)
+		if stats.memory.limit > 0 {
+			appendInt64Metric(metrics, memoryLimitDescriptor, 0, now, stats.memory.limit)
+		}
+	}
+
+	if len(stats.io) > 0 {
+		ioMetric := appendMetric(metrics, diskIODescriptor)
+		opsMetric := appendMetric(metrics, diskOpsDescriptor)
+		ioDataPoints := ioMetric.Int64DataPoints()
+		ioDataPoints.Resize(2 * len(stats.io))
+		opsDataPoints := opsMetric.Int64DataPoints()
+		opsDataPoints.Resize(2 * len(stats.io))
+		for i, io := range stats.io {
+			initializeDiskDataPoint(ioDataPoints.At(2*i+0), startTime, now, io.device, readDirectionLabelValue, io.readBytes)
+			initializeDiskDataPoint(ioDataPoints.At(2*i+1), startTime, now, io.device, writeDirectionLabelValue, io.writeBytes)
+			initializeDiskDataPoint(opsDataPoints.At(2*i+0), startTime, now, io.device, readDirectionLabelValue, io.readOps)
+			initializeDiskDataPoint(opsDataPoints.At(2*i+1), startTime, now, io.device, writeDirectionLabelValue, io.writeOps)
+		}
+	}
+}
+
+func appendMetric(metrics pdata.MetricSlice, descriptor pdata.MetricDescriptor) pdata.Metric {
+	idx := metrics.Len()
+	metrics.Resize(idx + 1)
+	metric := metrics.At(idx)
+	descriptor.CopyTo(metric.MetricDescriptor())
+	return metric
+}
+
+// appendInt64Metric appends a metric with a single data point, the start time is only set if
+// it is not 0.
+func appendInt64Metric(metrics pdata.MetricSlice, descriptor pdata.MetricDescriptor, startTime, now pdata.TimestampUnixNano, value int64) {
+	metric := appendMetric(metrics, descriptor)
+	idps := metric.Int64DataPoints()
+	idps.Resize(1)
+	dataPoint := idps.At(0)
+	if startTime != 0 {
+		dataPoint.SetStartTime(startTime)
+	}
+	dataPoint.SetTimestamp(now)
+	dataPoint.SetValue(value)
+}
+
+func initializeDoubleDataPoint(dataPoint pdata.DoubleDataPoint, startTime, now pdata.TimestampUnixNano, value float64, labelName, labelValue string) {
+	if labelName != "" {
+		dataPoint.LabelsMap().Insert(labelName, labelValue)
+	}
+	dataPoint.SetStartTime(startTime)
+	dataPoint.SetTimestamp(now)
+	dataPoint.SetValue(value)
+}
+
+func initializeDiskDataPoint(dataPoint pdata.Int64DataPoint, startTime, now pdata.TimestampUnixNano, device, direction string, value int64) {
+	labelsMap := dataPoint.LabelsMap()
+	labelsMap.Insert(deviceLabelName, device)
+	labelsMap.Insert(directionLabelName, direction)
+	dataPoint.SetStartTime(startTime)
+	dataPoint.SetTimestamp(now)
+	dataPoint.SetValue(value)
+}
@@ -0,0 +1,293 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cgroupscraper
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/processor/filterset"
+	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal"
+)
+
+const bootTime = 100
+
+func TestScrapeMetrics(t *testing.T) {
+	type testCase struct {
+		name          string
+		config        Config
+		expectedPaths []string
+		newErrRegex   string
+		scrapeErr     bool
+	}
+
+	testCases := []testCase{
+		{
+			name:          "Cgroup v1",
+			config:        Config{RootPath: filepath.Join("testdata", "v1")},
+			expectedPaths: []string{"/", "/docker/abc"},
+		},
+		{
+			name:          "Cgroup v2",
+			config:        Config{RootPath: filepath.Join("testdata", "v2")},
+			expectedPaths: []string{"/", "/init.scope", "/system.slice/docker-abc.scope"},
+		},
+		{
+			name: "Include Filter",
+			config: Config{
+				RootPath: filepath.Join("testdata", "v2"),
+				Include:  MatchConfig{filterset.Config{MatchType: "regexp"}, []string{"^/system\\.slice/"}},
+			},
+			expectedPaths: []string{"/system.slice/docker-abc.scope"},
+		},
+		{
+			name: "Exclude Filter",
+			config: Config{
+				RootPath: filepath.Join("testdata", "v1"),
+				Exclude:  MatchConfig{filterset.Config{MatchType: "strict"}, []string{"/"}},
+			},
+			expectedPaths: []string{"/docker/abc"},
+		},
+		{
+			name:        "Invalid Include Filter",
+			config:      Config{Include: MatchConfig{Paths: []string{"test"}}},
+			newErrRegex: "^error creating cgroup include filters:",
+		},
+		{
+			name:        "Invalid Exclude Filter",
+			config:      Config{Exclude: MatchConfig{Paths: []string{"test"}}},
+			newErrRegex: "^error creating cgroup exclude filters:",
+		},
+		{
+			name:      "Missing Root",
+			config:    Config{RootPath: filepath.Join("testdata", "missing")},
+			scrapeErr: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			scraper, err := newCgroupScraper(&test.config)
+			if test.newErrRegex != "" {
+				require.Error(t, err)
+				require.Regexp(t, test.newErrRegex, err)
+				return
+			}
+			require.NoError(t, err, "Failed to create cgroup scraper: %v", err)
+			scraper.bootTime = func() (uint64, error) { return bootTime, nil }
+
+			err = scraper.Initialize(context.Background())
+			require.NoError(t, err, "Failed to initialize cgroup scraper: %v", err)
+			defer func() { assert.NoError(t, scraper.Close(context.Background())) }()
+
+			resourceMetrics, err := scraper.ScrapeMetrics(context.Background())
+			if test.scrapeErr {
+				assert.Error(t, err)
+				assert.Equal(t, 0, resourceMetrics.Len())
+				return
+			}
+			require.NoError(t, err, "Failed to scrape metrics: %v", err)
+
+			var paths []string
+			for i := 0; i < resourceMetrics.Len(); i++ {
+				path, ok := resourceMetrics.At(i).Resource().Attributes().Get(cgroupPathAttributeName)
+				require.True(t, ok)
+				paths = append(paths, path.StringVal())
+			}
+			assert.Equal(t, test.expectedPaths, paths)
+		})
+	}
+}
+
+func TestScrapeMetricsV1(t *testing.T) {
+	// The blkio statistics of the cgroup are empty, there is no disk metric.
+	metrics := scrapeCgroup(t, filepath.Join("testdata", "v1"), "/docker/abc")
+	require.Equal(t, 6, metrics.Len())
+
+	assertCPUTimeMetric(t, metrics.At(0), 15, 2.5)
+	assertInt64Metric(t, metrics.At(1), cpuPeriodsDescriptor, 1200)
+	assertInt64Metric(t, metrics.At(2), cpuThrottledPeriodsDescriptor, 30)
+	assertThrottledTimeMetric(t, metrics.At(3), 4.5)
+	assertInt64Metric(t, metrics.At(4), memoryUsageDescriptor, 104857600)
+	assertInt64Metric(t, metrics.At(5), memoryLimitDescriptor, 536870912)
+
+	// The root cgroup has no memory limit.
+	root := scrapeCgroup(t, filepath.Join("testdata", "v1"), "/")
+	require.Equal(t, 7, root.Len())
+	assertCPUTimeMetric(t, root.At(0), 1234.56, 654.32)
+	assertInt64Metric(t, root.At(4), memoryUsageDescriptor, 8589934592)
+	assertDiskMetric(t, root.At(5), diskIODescriptor, []int64{1048576, 2097152})
+	assertDiskMetric(t, root.At(6), diskOpsDescriptor, []int64{100, 200})
+}
+
+func TestScrapeMetricsV2(t *testing.T) {
+	metrics := scrapeCgroup(t, filepath.Join("testdata", "v2"), "/system.slice/docker-abc.scope")
+	require.Equal(t, 8, metrics.Len())
+
+	assertCPUTimeMetric(t, metrics.At(0), 15, 2.5)
+	assertInt64Metric(t, metrics.At(1), cpuPeriodsDescriptor, 1200)
+	assertInt64Metric(t, metrics.At(2), cpuThrottledPeriodsDescriptor, 30)
+	assertThrottledTimeMetric(t, metrics.At(3), 4.5)
+	assertInt64Metric(t, metrics.At(4), memoryUsageDescriptor, 104857600)
+	assertInt64Metric(t, metrics.At(5), memoryLimitDescriptor, 536870912)
+	assertDiskMetric(t, metrics.At(6), diskIODescriptor, []int64{4096, 8192, 16384, 0})
+	assertDiskMetric(t, metrics.At(7), diskOpsDescriptor, []int64{1, 2, 4, 0})
+	internal.AssertInt64MetricLabelHasValue(t, metrics.At(6), 2, deviceLabelName, "259:0")
+
+	// No memory limit and no I/O.
+	initScope := scrapeCgroup(t, filepath.Join("testdata", "v2"), "/init.scope")
+	require.Equal(t, 5, initScope.Len())
+	assertInt64Metric(t, initScope.At(4), memoryUsageDescriptor, 20971520)
+
+	// The root cgroup has no throttling nor memory statistics.
+	root := scrapeCgroup(t, filepath.Join("testdata", "v2"), "/")
+	require.Equal(t, 3, root.Len())
+	assertCPUTimeMetric(t, root.At(0), 1000, 500)
+	assertDiskMetric(t, root.At(1), diskIODescriptor, []int64{1048576, 2097152})
+	assertDiskMetric(t, root.At(2), diskOpsDescriptor, []int64{100, 200})
+}
+
+func TestReadCgroupsErrors(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cgroup")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	writeFile(t, filepath.Join(dir, "cgroup.controllers"), "cpu memory\n")
+	writeFile(t, filepath.Join(dir, "cpu.stat"), "usage_usec 10\nuser_usec 5\nsystem_usec 5\n")
+	writeFile(t, filepath.Join(dir, "invalid", "cpu.stat"), "usage_usec ten\n")
+
+	stats, err := readCgroups(dir, func(string) bool { return true })
+	require.Error(t, err)
+	assert.Regexp(t, `error reading cgroup "/invalid"`, err)
+	// The statistics of the valid cgroups are still returned.
+	require.Len(t, stats, 1)
+	assert.Equal(t, "/", stats[0].path)
+}
+
+func TestScrapeMetricsStartTime(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cgroup")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	writeFile(t, filepath.Join(dir, "cgroup.controllers"), "cpu memory\n")
+	writeFile(t, filepath.Join(dir, "cpu.stat"), "usage_usec 10\nuser_usec 5\nsystem_usec 5\n")
+
+	scraper, err := newCgroupScraper(&Config{RootPath: dir})
+	require.NoError(t, err)
+	scraper.bootTime = func() (uint64, error) { return bootTime, nil }
+	scrapeTime := time.Unix(1000, 0)
+	scraper.now = func() time.Time { return scrapeTime }
+	require.NoError(t, scraper.Initialize(context.Background()))
+
+	scrape := func() map[string]pdata.TimestampUnixNano {
+		resourceMetrics, err := scraper.ScrapeMetrics(context.Background())
+		require.NoError(t, err)
+		startTimes := map[string]pdata.TimestampUnixNano{}
+		for i := 0; i < resourceMetrics.Len(); i++ {
+			path, ok := resourceMetrics.At(i).Resource().Attributes().Get(cgroupPathAttributeName)
+			require.True(t, ok)
+			metric := resourceMetrics.At(i).InstrumentationLibraryMetrics().At(0).Metrics().At(0)
+			internal.AssertDescriptorEqual(t, cpuTimeDescriptor, metric.MetricDescriptor())
+			startTimes[path.StringVal()] = metric.DoubleDataPoints().At(0).StartTime()
+		}
+		return startTimes
+	}
+
+	// The cgroups found by the first scrape use the boot time.
+	assert.Equal(t, map[string]pdata.TimestampUnixNano{"/": bootTime * 1e9}, scrape())
+
+	// A cgroup that appears between two scrapes starts at the time of the previous scrape.
+	writeFile(t, filepath.Join(dir, "docker", "abc", "cpu.stat"), "usage_usec 10\nuser_usec 5\nsystem_usec 5\n")
+	scrapeTime = time.Unix(1010, 0)
+	assert.Equal(t, map[string]pdata.TimestampUnixNano{"/": bootTime * 1e9, "/docker/abc": 1000 * 1e9}, scrape())
+
+	scrapeTime = time.Unix(1020, 0)
+	assert.Equal(t, map[string]pdata.TimestampUnixNano{"/": bootTime * 1e9, "/docker/abc": 1000 * 1e9}, scrape())
+
+	// A cgroup recreated at the same path between two scrapes also starts at the time of the previous
+	// scrape. The new directory is created before the old one is removed, so they are different files.
+	writeFile(t, filepath.Join(dir, "docker", "new", "cpu.stat"), "usage_usec 2\nuser_usec 1\nsystem_usec 1\n")
+	require.NoError(t, os.RemoveAll(filepath.Join(dir, "docker", "abc")))
+	require.NoError(t, os.Rename(filepath.Join(dir, "docker", "new"), filepath.Join(dir, "docker", "abc")))
+	scrapeTime = time.Unix(1030, 0)
+	assert.Equal(t, map[string]pdata.TimestampUnixNano{"/": bootTime * 1e9, "/docker/abc": 1020 * 1e9}, scrape())
+}
+
+func scrapeCgroup(t *testing.T, root string, path string) pdata.MetricSlice {
+	scraper, err := newCgroupScraper(&Config{
+		RootPath: root,
+		Include:  MatchConfig{filterset.Config{MatchType: "strict"}, []string{path}},
+	})
+	require.NoError(t, err)
+	scraper.bootTime = func() (uint64, error) { return bootTime, nil }
+	require.NoError(t, scraper.Initialize(context.Background()))
+
+	resourceMetrics, err := scraper.ScrapeMetrics(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, resourceMetrics.Len())
+	ilms := resourceMetrics.At(0).InstrumentationLibraryMetrics()
+	require.Equal(t, 1, ilms.Len())
+	return ilms.At(0).Metrics()
+}
+
+func assertCPUTimeMetric(t *testing.T, metric pdata.Metric, user, system float64) {
+	internal.AssertDescriptorEqual(t, cpuTimeDescriptor, metric.MetricDescriptor())
+	internal.AssertDoubleMetricStartTimeEquals(t, metric, pdata.TimestampUnixNano(bootTime*1e9))
+	ddps := metric.DoubleDataPoints()
+	require.Equal(t, 2, ddps.Len())
+	internal.AssertDoubleMetricLabelHasValue(t, metric, 0, stateLabelName, userStateLabelValue)
+	internal.AssertDoubleMetricLabelHasValue(t, metric, 1, stateLabelName, systemStateLabelValue)
+	assert.InDelta(t, user, ddps.At(0).Value(), 1e-9)
+	assert.InDelta(t, system, ddps.At(1).Value(), 1e-9)
+}
+
+func assertThrottledTimeMetric(t *testing.T, metric pdata.Metric, value float64) {
+	internal.AssertDescriptorEqual(t, cpuThrottledTimeDescriptor, metric.MetricDescriptor())
+	internal.AssertDoubleMetricStartTimeEquals(t, metric, pdata.TimestampUnixNano(bootTime*1e9))
+	require.Equal(t, 1, metric.DoubleDataPoints().Len())
+	assert.InDelta(t, value, metric.DoubleDataPoints().At(0).Value(), 1e-9)
+}
+
+func assertInt64Metric(t *testing.T, metric pdata.Metric, descriptor pdata.MetricDescriptor, value int64) {
+	internal.AssertDescriptorEqual(t, descriptor, metric.MetricDescriptor())
+	require.Equal(t, 1, metric.Int64DataPoints().Len())
+	assert.Equal(t, value, metric.Int64DataPoints().At(0).Value())
+}
+
+func assertDiskMetric(t *testing.T, metric pdata.Metric, descriptor pdata.MetricDescriptor, values []int64) {
+	internal.AssertDescriptorEqual(t, descriptor, metric.MetricDescriptor())
+	internal.AssertInt64MetricStartTimeEquals(t, metric, pdata.TimestampUnixNano(bootTime*1e9))
+	idps := metric.Int64DataPoints()
+	require.Equal(t, len(values), idps.Len())
+	for i, value := range values {
+		assert.Equal(t, value, idps.At(i).Value())
+	}
+	internal.AssertInt64MetricLabelHasValue(t, metric, 0, deviceLabelName, "8:0")
+	internal.AssertInt64MetricLabelHasValue(t, metric, 0, directionLabelName, readDirectionLabelValue)
+	internal.AssertInt64MetricLabelHasValue(t, metric, 1, directionLabelName, writeDirectionLabelValue)
+}
+
+func writeFile(t *testing.T, file string, content string) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(file), 0700))
+	require.NoError(t, ioutil.WriteFile(file, []byte(content), 0600))
+}
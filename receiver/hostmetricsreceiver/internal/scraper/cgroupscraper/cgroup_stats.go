@@ -0,0 +1,471 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cgroupscraper
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"go.opentelemetry.io/collector/component/componenterror"
+)
+
+const (
+	// Clock ticks per second of cpuacct.stat, USER_HZ is 100 on all the supported architectures.
+	userHZ = 100
+
+	// cgroup v1 reports the absence of memory limit as the largest multiple of the page size,
+	// any limit above this threshold is considered unlimited.
+	unlimitedMemoryV1 = 1 << 62
+)
+
+// cgroupStats holds the statistics read from the files of a cgroup, nil if the controller is
+// not available for the cgroup.
+type cgroupStats struct {
+	path string
+	// info describes the directory of the cgroup in the first hierarchy it was found in, it
+	// identifies the cgroup across scrapes.
+	info       os.FileInfo
+	cpuTimes   *cpuTimesStats
+	throttling *cpuThrottlingStats
+	memory     *memoryStats
+	io         []*ioStats
+}
+
+type cpuTimesStats struct {
+	user   float64
+	system float64
+}
+
+type cpuThrottlingStats struct {
+	periods          int64
+	throttledPeriods int64
+	throttledTime    float64
+}
+
+type memoryStats struct {
+	usage int64
+	// limit is 0 if the cgroup has no memory limit.
+	limit int64
+}
+
+type ioStats struct {
+	device     string
+	readBytes  int64
+	writeBytes int64
+	readOps    int64
+	writeOps   int64
+}
+
+func (s *cgroupStats) empty() bool {
+	return s.cpuTimes == nil && s.throttling == nil && s.memory == nil && len(s.io) == 0
+}
+
+// cgroupReader reads the statistics of one or more controllers from the directory of a cgroup
+// into stats.
+type cgroupReader func(dir string, stats *cgroupStats) error
+
+// readCgroups reads the statistics of the cgroups whose path is accepted by match, sorted by path.
+// The cgroup v2 unified hierarchy is used if it is mounted at root, otherwise the cgroup v1
+// hierarchies of the cpu, cpuacct, memory and blkio controllers.
+func readCgroups(root string, match func(path string) bool) ([]*cgroupStats, error) {
+	if _, err := os.Stat(root); err != nil {
+		return nil, err
+	}
+
+	byPath := map[string]*cgroupStats{}
+	var errs []error
+	if isCgroupV2(root) {
+		errs = walkHierarchy(root, match, byPath, readCgroupV2)
+	} else {
+		hierarchies := []struct {
+			dirs   []string
+			reader cgroupReader
+		}{
+			{dirs: []string{"cpu,cpuacct", "cpuacct,cpu", "cpuacct"}, reader: readCPUAcctV1},
+			{dirs: []string{"cpu,cpuacct", "cpuacct,cpu", "cpu"}, reader: readCPUV1},
+			{dirs: []string{"memory"}, reader: readMemoryV1},
+			{dirs: []string{"blkio"}, reader: readBlkioV1},
+		}
+		for _, h := range hierarchies {
+			dir := findHierarchy(root, h.dirs)
+			if dir == "" {
+				continue
+			}
+			errs = append(errs, walkHierarchy(dir, match, byPath, h.reader)...)
+		}
+	}
+
+	stats := make([]*cgroupStats, 0, len(byPath))
+	for _, s := range byPath {
+		if !s.empty() {
+			stats = append(stats, s)
+		}
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].path < stats[j].path
+	})
+	return stats, componenterror.CombineErrors(errs)
+}
+
+// isCgroupV2 returns true if root is the mount point of the cgroup v2 unified hierarchy.
+func isCgroupV2(root string) bool {
+	_, err := os.Stat(filepath.Join(root, "cgroup.controllers"))
+	return err == nil
+}
+
+// findHierarchy returns the directory of the first of the candidate cgroup v1 hierarchies
+// found under root, with symbolic links resolved, or "" if there is none.
+func findHierarchy(root string, candidates []string) string {
+	for _, c := range candidates {
+		dir, err := filepath.EvalSymlinks(filepath.Join(root, c))
+		if err != nil {
+			continue
+		}
+		if fi, err := os.Stat(dir); err == nil && fi.IsDir() {
+			return dir
+		}
+	}
+	return ""
+}
+
+// walkHierarchy reads all the cgroups of a hierarchy accepted by match into byPath. The path
+// of a cgroup is relative to the root of its hierarchy, "/" being the root cgroup.
+func walkHierarchy(root string, match func(path string) bool, byPath map[string]*cgroupStats, reader cgroupReader) []error {
+	var errs []error
+	err := filepath.Walk(root, func(dir string, info os.FileInfo, err error) error {
+		if err != nil {
+			// The cgroup may have been removed while walking the hierarchy.
+			if !os.IsNotExist(err) {
+				errs = append(errs, err)
+			}
+			return nil
+		}
+		if !info.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, dir)
+		if err != nil {
+			errs = append(errs, err)
+			return nil
+		}
+		path := "/"
+		if rel != "." {
+			path += filepath.ToSlash(rel)
+		}
+		if !match(path) {
+			return nil
+		}
+
+		stats, ok := byPath[path]
+		if !ok {
+			stats = &cgroupStats{path: path, info: info}
+			byPath[path] = stats
+		}
+		if err := reader(dir, stats); err != nil {
+			errs = append(errs, errors.Wrapf(err, "error reading cgroup %q", path))
+		}
+		return nil
+	})
+	if err != nil {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+func readCgroupV2(dir string, stats *cgroupStats) error {
+	var errs []error
+
+	cpuStat, err := readFlatKeyed(filepath.Join(dir, "cpu.stat"))
+	switch {
+	case os.IsNotExist(err):
+	case err != nil:
+		errs = append(errs, err)
+	default:
+		if user, ok := cpuStat["user_usec"]; ok {
+			stats.cpuTimes = &cpuTimesStats{
+				user:   float64(user) / 1e6,
+				system: float64(cpuStat["system_usec"]) / 1e6,
+			}
+		}
+		// The throttling statistics are only reported if the cpu controller is enabled.
+		if periods, ok := cpuStat["nr_periods"]; ok {
+			stats.throttling = &cpuThrottlingStats{
+				periods:          int64(periods),
+				throttledPeriods: int64(cpuStat["nr_throttled"]),
+				throttledTime:    float64(cpuStat["throttled_usec"]) / 1e6,
+			}
+		}
+	}
+
+	usage, err := readSingleValue(filepath.Join(dir, "memory.current"))
+	switch {
+	case os.IsNotExist(err):
+	case err != nil:
+		errs = append(errs, err)
+	default:
+		memory, err := parseMemoryV2(usage, filepath.Join(dir, "memory.max"))
+		if err != nil {
+			errs = append(errs, err)
+		} else {
+			stats.memory = memory
+		}
+	}
+
+	io, err := readIOStatV2(filepath.Join(dir, "io.stat"))
+	switch {
+	case os.IsNotExist(err):
+	case err != nil:
+		errs = append(errs, err)
+	default:
+		stats.io = io
+	}
+
+	return componenterror.CombineErrors(errs)
+}
+
+func parseMemoryV2(usage string, maxFile string) (*memoryStats, error) {
+	memory := &memoryStats{}
+	var err error
+	if memory.usage, err = strconv.ParseInt(usage, 10, 64); err != nil {
+		return nil, err
+	}
+	limit, err := readSingleValue(maxFile)
+	if err != nil {
+		return nil, err
+	}
+	if limit != "max" {
+		if memory.limit, err = strconv.ParseInt(limit, 10, 64); err != nil {
+			return nil, err
+		}
+	}
+	return memory, nil
+}
+
+// readIOStatV2 reads an io.stat file, with one line per device such as
+// "8:0 rbytes=90112 wbytes=0 rios=3 wios=0 dbytes=0 dios=0".
+func readIOStatV2(file string) ([]*ioStats, error) {
+	var io []*ioStats
+	err := scanLines(file, func(fields []string) error {
+		if len(fields) < 2 {
+			return nil
+		}
+		device := &ioStats{device: fields[0]}
+		for _, field := range fields[1:] {
+			kv := strings.SplitN(field, "=", 2)
+			if len(kv) != 2 {
+				continue
+			}
+			value, err := strconv.ParseInt(kv[1], 10, 64)
+			if err != nil {
+				return err
+			}
+			switch kv[0] {
+			case "rbytes":
+				device.readBytes = value
+			case "wbytes":
+				device.writeBytes = value
+			case "rios":
+				device.readOps = value
+			case "wios":
+				device.writeOps = value
+			}
+		}
+		io = append(io, device)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return io, nil
+}
+
+func readCPUAcctV1(dir string, stats *cgroupStats) error {
+	cpuacctStat, err := readFlatKeyed(filepath.Join(dir, "cpuacct.stat"))
+	if err != nil {
+		return ignoreNotExist(err)
+	}
+	stats.cpuTimes = &cpuTimesStats{
+		user:   float64(cpuacctStat["user"]) / userHZ,
+		system: float64(cpuacctStat["system"]) / userHZ,
+	}
+	return nil
+}
+
+func readCPUV1(dir string, stats *cgroupStats) error {
+	cpuStat, err := readFlatKeyed(filepath.Join(dir, "cpu.stat"))
+	if err != nil {
+		return ignoreNotExist(err)
+	}
+	if periods, ok := cpuStat["nr_periods"]; ok {
+		stats.throttling = &cpuThrottlingStats{
+			periods:          int64(periods),
+			throttledPeriods: int64(cpuStat["nr_throttled"]),
+			throttledTime:    float64(cpuStat["throttled_time"]) / 1e9,
+		}
+	}
+	return nil
+}
+
+func readMemoryV1(dir string, stats *cgroupStats) error {
+	usage, err := readSingleValue(filepath.Join(dir, "memory.usage_in_bytes"))
+	if err != nil {
+		return ignoreNotExist(err)
+	}
+	memory := &memoryStats{}
+	if memory.usage, err = strconv.ParseInt(usage, 10, 64); err != nil {
+		return err
+	}
+
+	limit, err := readSingleValue(filepath.Join(dir, "memory.limit_in_bytes"))
+	if err != nil {
+		return err
+	}
+	value, err := strconv.ParseUint(limit, 10, 64)
+	if err != nil {
+		return err
+	}
+	if value < unlimitedMemoryV1 {
+		memory.limit = int64(value)
+	}
+	stats.memory = memory
+	return nil
+}
+
+func readBlkioV1(dir string, stats *cgroupStats) error {
+	byDevice := map[string]*ioStats{}
+	var devices []string
+	device := func(name string) *ioStats {
+		d, ok := byDevice[name]
+		if !ok {
+			d = &ioStats{device: name}
+			byDevice[name] = d
+			devices = append(devices, name)
+		}
+		return d
+	}
+
+	err := readBlkioFileV1(filepath.Join(dir, "blkio.throttle.io_service_bytes"), func(name, op string, value int64) {
+		switch op {
+		case "Read":
+			device(name).readBytes = value
+		case "Write":
+			device(name).writeBytes = value
+		}
+	})
+	if err != nil {
+		return ignoreNotExist(err)
+	}
+	err = readBlkioFileV1(filepath.Join(dir, "blkio.throttle.io_serviced"), func(name, op string, value int64) {
+		switch op {
+		case "Read":
+			device(name).readOps = value
+		case "Write":
+			device(name).writeOps = value
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	stats.io = make([]*ioStats, 0, len(devices))
+	for _, name := range devices {
+		stats.io = append(stats.io, byDevice[name])
+	}
+	return nil
+}
+
+// readBlkioFileV1 reads a blkio file, with one line per device and operation such as
+// "8:0 Read 90112", and a last "Total" line.
+func readBlkioFileV1(file string, fn func(device, op string, value int64)) error {
+	return scanLines(file, func(fields []string) error {
+		if len(fields) != 3 {
+			return nil
+		}
+		value, err := strconv.ParseInt(fields[2], 10, 64)
+		if err != nil {
+			return err
+		}
+		fn(fields[0], fields[1], value)
+		return nil
+	})
+}
+
+// readFlatKeyed reads a file with one "key value" pair per line.
+func readFlatKeyed(file string) (map[string]uint64, error) {
+	values := map[string]uint64{}
+	err := scanLines(file, func(fields []string) error {
+		if len(fields) != 2 {
+			return fmt.Errorf("unexpected line %q", strings.Join(fields, " "))
+		}
+		value, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			return err
+		}
+		values[fields[0]] = value
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// readSingleValue reads a file containing a single value.
+func readSingleValue(file string) (string, error) {
+	content, err := ioutil.ReadFile(file)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(content)), nil
+}
+
+// scanLines calls fn with the fields of each non-empty line of the file.
+func scanLines(file string, fn func(fields []string) error) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if err := fn(fields); err != nil {
+			return errors.Wrapf(err, "error parsing %s", file)
+		}
+	}
+	return scanner.Err()
+}
+
+// ignoreNotExist returns nil if the error is due to a missing file, as the files of a
+// controller only exist if the controller is enabled for the cgroup.
+func ignoreNotExist(err error) error {
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cgroupscraper
+
+import (
+	"context"
+	"errors"
+	"runtime"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal"
+	"go.opentelemetry.io/collector/receiver/scraperhelper"
+)
+
+// This file implements Factory for Cgroup scraper.
+
+const (
+	// The value of "type" key in configuration.
+	TypeStr = "cgroup"
+
+	defaultRootPath = "/sys/fs/cgroup"
+)
+
+// Factory is the Factory for scraper.
+type Factory struct {
+}
+
+// CreateDefaultConfig creates the default configuration for the Scraper.
+func (f *Factory) CreateDefaultConfig() internal.Config {
+	return &Config{RootPath: defaultRootPath}
+}
+
+// CreateMetricsScraper creates a resource scraper based on provided config.
+func (f *Factory) CreateMetricsScraper(
+	_ context.Context,
+	_ *zap.Logger,
+	config internal.Config,
+) (scraperhelper.ResourceMetricsScraper, error) {
+	if runtime.GOOS != "linux" {
+		return nil, errors.New("cgroup scraper only available on Linux")
+	}
+
+	cfg := config.(*Config)
+	cs, err := newCgroupScraper(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return scraperhelper.NewResourceMetricsScraper(
+		TypeStr,
+		cs.ScrapeMetrics,
+		scraperhelper.WithInitialize(cs.Initialize),
+		scraperhelper.WithClose(cs.Close),
+	), nil
+}
@@ -12,28 +12,34 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package obsreportscraper
+package cgroupscraper
 
 import (
+	"context"
+	"runtime"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
 )
 
-func TestSpanName(t *testing.T) {
-	scraperTypes := map[string]string{
-		"cpu":        "cpuscraper.ScrapeMetrics",
-		"disk":       "diskscraper.ScrapeMetrics",
-		"load":       "loadscraper.ScrapeMetrics",
-		"filesystem": "filesystemscraper.ScrapeMetrics",
-		"memory":     "memoryscraper.ScrapeMetrics",
-		"network":    "networkscraper.ScrapeMetrics",
-		"processes":  "processesscraper.ScrapeMetrics",
-		"swap":       "swapscraper.ScrapeMetrics",
-		"process":    "processscraper.ScrapeMetrics",
-	}
+func TestCreateDefaultConfig(t *testing.T) {
+	factory := &Factory{}
+	cfg := factory.CreateDefaultConfig()
+	assert.Equal(t, &Config{RootPath: "/sys/fs/cgroup"}, cfg)
+}
+
+func TestCreateMetricsScraper(t *testing.T) {
+	factory := &Factory{}
+	cfg := &Config{}
+
+	scraper, err := factory.CreateMetricsScraper(context.Background(), zap.NewNop(), cfg)
 
-	for typeStr, spanName := range scraperTypes {
-		assert.Equal(t, spanName, scrapeMetricsSpanName(typeStr))
+	if runtime.GOOS == "linux" {
+		assert.NoError(t, err)
+		assert.NotNil(t, scraper)
+	} else {
+		assert.Error(t, err)
+		assert.Nil(t, scraper)
 	}
 }
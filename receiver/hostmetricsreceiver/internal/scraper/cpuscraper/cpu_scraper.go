@@ -21,9 +21,12 @@ import (
 	"github.com/shirou/gopsutil/cpu"
 	"github.com/shirou/gopsutil/host"
 
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/pdata"
 )
 
+const metricsLen = 1
+
 // scraper for CPU Metrics
 type scraper struct {
 	config    *Config
@@ -61,10 +64,10 @@ func (s *scraper) ScrapeMetrics(_ context.Context) (pdata.MetricSlice, error) {
 
 	cpuTimes, err := s.times( /*percpu=*/ true)
 	if err != nil {
-		return metrics, err
+		return metrics, consumererror.NewPartialScrapeError(err, metricsLen)
 	}
 
-	metrics.Resize(1)
+	metrics.Resize(metricsLen)
 	initializeCPUTimeMetric(metrics.At(0), s.startTime, cpuTimes)
 	return metrics, nil
 }
@@ -24,10 +24,16 @@ import (
 	"github.com/shirou/gopsutil/disk"
 	"github.com/shirou/gopsutil/host"
 
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/internal/processor/filterset"
 )
 
+const (
+	standardMetricsLen = 4
+	metricsLen         = standardMetricsLen + systemSpecificMetricsLen
+)
+
 // scraper for Disk Metrics
 type scraper struct {
 	config    *Config
@@ -85,14 +91,14 @@ func (s *scraper) ScrapeMetrics(_ context.Context) (pdata.MetricSlice, error) {
 
 	ioCounters, err := s.ioCounters()
 	if err != nil {
-		return metrics, err
+		return metrics, consumererror.NewPartialScrapeError(err, metricsLen)
 	}
 
 	// filter devices by name
 	ioCounters = s.filterByDevice(ioCounters)
 
 	if len(ioCounters) > 0 {
-		metrics.Resize(4 + systemSpecificMetricsLen)
+		metrics.Resize(metricsLen)
 		initializeDiskIOMetric(metrics.At(0), s.startTime, ioCounters)
 		initializeDiskOpsMetric(metrics.At(1), s.startTime, ioCounters)
 		initializeDiskTimeMetric(metrics.At(2), s.startTime, ioCounters)
@@ -21,10 +21,12 @@ import (
 	"time"
 
 	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/internal/processor/filterset"
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/third_party/telegraf/win_perf_counters"
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/windows/pdh"
+	"go.opentelemetry.io/collector/receiver/scraperhelper"
 )
 
 const (
@@ -212,20 +214,21 @@ func (s *scraper) ScrapeMetrics(_ context.Context) (pdata.MetricSlice, error) {
 
 	err := s.scrapeAndAppendDiskIOMetric(metrics, durationSinceLastScraped)
 	if err != nil {
-		errors = append(errors, err)
+		errors = append(errors, consumererror.NewPartialScrapeError(err, 1))
 	}
 
 	err = s.scrapeAndAppendDiskOpsMetric(metrics, durationSinceLastScraped)
 	if err != nil {
-		errors = append(errors, err)
+		// the disk ops and disk time metrics
+		errors = append(errors, consumererror.NewPartialScrapeError(err, 2))
 	}
 
 	err = s.scrapeAndAppendDiskPendingOperationsMetric(metrics)
 	if err != nil {
-		errors = append(errors, err)
+		errors = append(errors, consumererror.NewPartialScrapeError(err, 1))
 	}
 
-	return metrics, componenterror.CombineErrors(errors)
+	return metrics, scraperhelper.CombineScrapeErrors(errors)
 }
 
 func (s *scraper) scrapeAndAppendDiskIOMetric(metrics pdata.MetricSlice, durationSinceLastScraped float64) error {
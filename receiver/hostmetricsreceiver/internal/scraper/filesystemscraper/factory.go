@@ -20,7 +20,7 @@ import (
 	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal"
-	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/obsreportscraper"
+	"go.opentelemetry.io/collector/receiver/scraperhelper"
 )
 
 // This file implements Factory for FileSystem scraper.
@@ -49,11 +49,16 @@ func (f *Factory) CreateMetricsScraper(
 	ctx context.Context,
 	_ *zap.Logger,
 	config internal.Config,
-) (internal.Scraper, error) {
+) (scraperhelper.MetricsScraper, error) {
 	scraper, err := newFileSystemScraper(ctx, config.(*Config))
 	if err != nil {
 		return nil, err
 	}
 
-	return obsreportscraper.WrapScraper(scraper, TypeStr), nil
+	return scraperhelper.NewMetricsScraper(
+		TypeStr,
+		scraper.ScrapeMetrics,
+		scraperhelper.WithInitialize(scraper.Initialize),
+		scraperhelper.WithClose(scraper.Close),
+	), nil
 }
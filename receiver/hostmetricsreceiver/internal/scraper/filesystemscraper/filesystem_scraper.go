@@ -22,10 +22,16 @@ import (
 	"github.com/shirou/gopsutil/disk"
 
 	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/internal/processor/filterset"
 )
 
+const (
+	standardMetricsLen = 1
+	metricsLen         = standardMetricsLen + systemSpecificMetricsLen
+)
+
 // scraper for FileSystem Metrics
 type scraper struct {
 	config    *Config
@@ -82,7 +88,7 @@ func (s *scraper) ScrapeMetrics(_ context.Context) (pdata.MetricSlice, error) {
 	// omit logical (virtual) filesystems (not relevant for windows)
 	partitions, err := s.partitions( /*all=*/ false)
 	if err != nil {
-		return metrics, err
+		return metrics, consumererror.NewPartialScrapeError(err, metricsLen)
 	}
 
 	var errors []error
@@ -101,13 +107,23 @@ func (s *scraper) ScrapeMetrics(_ context.Context) (pdata.MetricSlice, error) {
 	usages = s.filterByDevice(usages)
 
 	if len(usages) > 0 {
-		metrics.Resize(1 + systemSpecificMetricsLen)
+		metrics.Resize(metricsLen)
 
 		initializeFileSystemUsageMetric(metrics.At(0), usages)
 		appendSystemSpecificMetrics(metrics, 1, usages)
 	}
 
-	return metrics, componenterror.CombineErrors(errors)
+	if len(errors) > 0 {
+		// the metrics are still generated for the remaining filesystems, so
+		// only count them as failed if no filesystem could be read
+		failed := 0
+		if len(usages) == 0 {
+			failed = metricsLen
+		}
+		return metrics, consumererror.NewPartialScrapeError(componenterror.CombineErrors(errors), failed)
+	}
+
+	return metrics, nil
 }
 
 func initializeFileSystemUsageMetric(metric pdata.Metric, deviceUsages []*deviceUsage) {
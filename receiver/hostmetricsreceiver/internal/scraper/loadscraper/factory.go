@@ -20,7 +20,7 @@ import (
 	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal"
-	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/obsreportscraper"
+	"go.opentelemetry.io/collector/receiver/scraperhelper"
 )
 
 // This file implements Factory for Load scraper.
@@ -44,7 +44,13 @@ func (f *Factory) CreateMetricsScraper(
 	ctx context.Context,
 	logger *zap.Logger,
 	config internal.Config,
-) (internal.Scraper, error) {
+) (scraperhelper.MetricsScraper, error) {
 	cfg := config.(*Config)
-	return obsreportscraper.WrapScraper(newLoadScraper(ctx, logger, cfg), TypeStr), nil
+	s := newLoadScraper(ctx, logger, cfg)
+	return scraperhelper.NewMetricsScraper(
+		TypeStr,
+		s.ScrapeMetrics,
+		scraperhelper.WithInitialize(s.Initialize),
+		scraperhelper.WithClose(s.Close),
+	), nil
 }
@@ -20,9 +20,12 @@ import (
 
 	"go.uber.org/zap"
 
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/pdata"
 )
 
+const metricsLen = 3
+
 // scraper for Load Metrics
 type scraper struct {
 	logger *zap.Logger
@@ -50,10 +53,10 @@ func (s *scraper) ScrapeMetrics(_ context.Context) (pdata.MetricSlice, error) {
 
 	avgLoadValues, err := getSampledLoadAverages()
 	if err != nil {
-		return metrics, err
+		return metrics, consumererror.NewPartialScrapeError(err, metricsLen)
 	}
 
-	metrics.Resize(3)
+	metrics.Resize(metricsLen)
 	initializeLoadMetric(metrics.At(0), loadAvg1MDescriptor, avgLoadValues.Load1)
 	initializeLoadMetric(metrics.At(1), loadAvg5mDescriptor, avgLoadValues.Load5)
 	initializeLoadMetric(metrics.At(2), loadAvg15mDescriptor, avgLoadValues.Load15)
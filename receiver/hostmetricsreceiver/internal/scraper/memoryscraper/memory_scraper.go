@@ -20,9 +20,12 @@ import (
 
 	"github.com/shirou/gopsutil/mem"
 
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/pdata"
 )
 
+const metricsLen = 1
+
 // scraper for Memory Metrics
 type scraper struct {
 	config *Config
@@ -52,10 +55,10 @@ func (s *scraper) ScrapeMetrics(_ context.Context) (pdata.MetricSlice, error) {
 
 	memInfo, err := s.virtualMemory()
 	if err != nil {
-		return metrics, err
+		return metrics, consumererror.NewPartialScrapeError(err, metricsLen)
 	}
 
-	metrics.Resize(1)
+	metrics.Resize(metricsLen)
 	initializeMemoryUsageMetric(metrics.At(0), memInfo)
 	return metrics, nil
 }
@@ -22,9 +22,15 @@ import (
 	"github.com/shirou/gopsutil/host"
 	"github.com/shirou/gopsutil/net"
 
-	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/internal/processor/filterset"
+	"go.opentelemetry.io/collector/receiver/scraperhelper"
+)
+
+const (
+	networkMetricsLen     = 4
+	connectionsMetricsLen = 1
 )
 
 // scraper for Network Metrics
@@ -87,15 +93,15 @@ func (s *scraper) ScrapeMetrics(_ context.Context) (pdata.MetricSlice, error) {
 
 	err := s.scrapeAndAppendNetworkCounterMetrics(metrics, s.startTime)
 	if err != nil {
-		errors = append(errors, err)
+		errors = append(errors, consumererror.NewPartialScrapeError(err, networkMetricsLen))
 	}
 
 	err = s.scrapeAndAppendNetworkTCPConnectionsMetric(metrics)
 	if err != nil {
-		errors = append(errors, err)
+		errors = append(errors, consumererror.NewPartialScrapeError(err, connectionsMetricsLen))
 	}
 
-	return metrics, componenterror.CombineErrors(errors)
+	return metrics, scraperhelper.CombineScrapeErrors(errors)
 }
 
 func (s *scraper) scrapeAndAppendNetworkCounterMetrics(metrics pdata.MetricSlice, startTime pdata.TimestampUnixNano) error {
@@ -110,7 +116,7 @@ func (s *scraper) scrapeAndAppendNetworkCounterMetrics(metrics pdata.MetricSlice
 
 	if len(ioCounters) > 0 {
 		startIdx := metrics.Len()
-		metrics.Resize(startIdx + 4)
+		metrics.Resize(startIdx + networkMetricsLen)
 		initializeNetworkPacketsMetric(metrics.At(startIdx+0), networkPacketsDescriptor, startTime, ioCounters)
 		initializeNetworkDroppedPacketsMetric(metrics.At(startIdx+1), networkDroppedPacketsDescriptor, startTime, ioCounters)
 		initializeNetworkErrorsMetric(metrics.At(startIdx+2), networkErrorsDescriptor, startTime, ioCounters)
@@ -182,7 +188,7 @@ func (s *scraper) scrapeAndAppendNetworkTCPConnectionsMetric(metrics pdata.Metri
 	connectionStatusCounts := getTCPConnectionStatusCounts(connections)
 
 	startIdx := metrics.Len()
-	metrics.Resize(startIdx + 1)
+	metrics.Resize(startIdx + connectionsMetricsLen)
 	initializeNetworkTCPConnectionsMetric(metrics.At(startIdx), connectionStatusCounts)
 	return nil
 }
@@ -23,6 +23,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/internal/processor/filterset"
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal"
@@ -40,6 +41,7 @@ func TestScrapeMetrics(t *testing.T) {
 		newErrRegex          string
 		initializationErr    string
 		expectedErr          string
+		expectedErrCount     int
 	}
 
 	testCases := []testCase{
@@ -74,14 +76,16 @@ func TestScrapeMetrics(t *testing.T) {
 			initializationErr: "err1",
 		},
 		{
-			name:           "IOCounters Error",
-			ioCountersFunc: func(bool) ([]net.IOCountersStat, error) { return nil, errors.New("err2") },
-			expectedErr:    "err2",
+			name:             "IOCounters Error",
+			ioCountersFunc:   func(bool) ([]net.IOCountersStat, error) { return nil, errors.New("err2") },
+			expectedErr:      "err2",
+			expectedErrCount: networkMetricsLen,
 		},
 		{
-			name:            "Connections Error",
-			connectionsFunc: func(string) ([]net.ConnectionStat, error) { return nil, errors.New("err3") },
-			expectedErr:     "err3",
+			name:             "Connections Error",
+			connectionsFunc:  func(string) ([]net.ConnectionStat, error) { return nil, errors.New("err3") },
+			expectedErr:      "err3",
+			expectedErrCount: connectionsMetricsLen,
 		},
 	}
 
@@ -116,6 +120,11 @@ func TestScrapeMetrics(t *testing.T) {
 			metrics, err := scraper.ScrapeMetrics(context.Background())
 			if test.expectedErr != "" {
 				assert.EqualError(t, err, test.expectedErr)
+				isPartial := consumererror.IsPartialScrapeError(err)
+				assert.True(t, isPartial)
+				if isPartial {
+					assert.Equal(t, test.expectedErrCount, err.(consumererror.PartialScrapeError).Failed)
+				}
 				return
 			}
 			require.NoError(t, err, "Failed to scrape metrics: %v", err)
@@ -20,6 +20,8 @@ import (
 	"go.opentelemetry.io/collector/consumer/pdata"
 )
 
+const systemSpecificMetricsLen = 0
+
 func appendSystemSpecificProcessesMetrics(metrics pdata.MetricSlice, startIndex int, miscFunc getMiscStats) error {
 	return nil
 }
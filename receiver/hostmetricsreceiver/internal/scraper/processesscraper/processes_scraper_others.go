@@ -21,16 +21,19 @@ import (
 
 	"github.com/shirou/gopsutil/load"
 
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/pdata"
 )
 
+const systemSpecificMetricsLen = 2
+
 func appendSystemSpecificProcessesMetrics(metrics pdata.MetricSlice, startIndex int, miscFunc getMiscStats) error {
 	misc, err := miscFunc()
 	if err != nil {
-		return err
+		return consumererror.NewPartialScrapeError(err, systemSpecificMetricsLen)
 	}
 
-	metrics.Resize(startIndex + 2)
+	metrics.Resize(startIndex + systemSpecificMetricsLen)
 	initializeProcessesRunningMetric(metrics.At(startIndex+0), misc)
 	initializeProcessesBlockedMetric(metrics.At(startIndex+1), misc)
 	return nil
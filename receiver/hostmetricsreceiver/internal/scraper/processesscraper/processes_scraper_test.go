@@ -24,6 +24,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal"
 )
@@ -69,6 +70,11 @@ func TestScrapeMetrics(t *testing.T) {
 			metrics, err := scraper.ScrapeMetrics(context.Background())
 			if len(expectedMetrics) > 0 && test.expectedErr != "" {
 				assert.EqualError(t, err, test.expectedErr)
+				isPartial := consumererror.IsPartialScrapeError(err)
+				assert.True(t, isPartial)
+				if isPartial {
+					assert.Equal(t, systemSpecificMetricsLen, err.(consumererror.PartialScrapeError).Failed)
+				}
 				return
 			}
 			require.NoError(t, err, "Failed to scrape metrics: %v", err)
@@ -23,15 +23,25 @@ import (
 type Config struct {
 	internal.ConfigSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
 
-	// Include specifies a filter on the process names that should be included from the generated metrics.
-	// Exclude specifies a filter on the process names that should be excluded from the generated metrics.
+	// Include specifies a filter on the processes that should be included from the generated metrics.
+	// Exclude specifies a filter on the processes that should be excluded from the generated metrics.
 	// If neither `include` or `exclude` are set, process metrics will be generated for all processes.
 	Include MatchConfig `mapstructure:"include"`
 	Exclude MatchConfig `mapstructure:"exclude"`
+
+	// MaxProcesses limits the number of processes metrics are generated for. If more
+	// processes match, the ones that have been running the longest are reported.
+	// If not set or 0, metrics will be generated for all matching processes.
+	MaxProcesses int `mapstructure:"max_processes"`
 }
 
+// MatchConfig matches processes on their executable name, command line, owner
+// and cgroup. A process matches if it matches every property that is set.
 type MatchConfig struct {
 	filterset.Config `mapstructure:",squash"`
 
-	Names []string `mapstructure:"names"`
+	Names        []string `mapstructure:"names"`
+	CommandLines []string `mapstructure:"command_lines"`
+	Owners       []string `mapstructure:"owners"`
+	Cgroups      []string `mapstructure:"cgroups"`
 }
@@ -22,7 +22,7 @@ import (
 	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal"
-	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/scraper/obsreportscraper"
+	"go.opentelemetry.io/collector/receiver/scraperhelper"
 )
 
 // This file implements Factory for Process scraper.
@@ -46,7 +46,7 @@ func (f *Factory) CreateMetricsScraper(
 	ctx context.Context,
 	_ *zap.Logger,
 	config internal.Config,
-) (internal.ResourceScraper, error) {
+) (scraperhelper.ResourceMetricsScraper, error) {
 	if runtime.GOOS != "linux" && runtime.GOOS != "windows" {
 		return nil, errors.New("process scraper only available on Linux or Windows")
 	}
@@ -56,5 +56,10 @@ func (f *Factory) CreateMetricsScraper(
 	if err != nil {
 		return nil, err
 	}
-	return obsreportscraper.WrapResourceScraper(ps, TypeStr), nil
+	return scraperhelper.NewResourceMetricsScraper(
+		TypeStr,
+		ps.ScrapeMetrics,
+		scraperhelper.WithInitialize(ps.Initialize),
+		scraperhelper.WithClose(ps.Close),
+	), nil
 }
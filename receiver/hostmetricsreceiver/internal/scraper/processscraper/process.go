@@ -33,6 +33,8 @@ type processMetadata struct {
 	executable *executableMetadata
 	command    *commandMetadata
 	username   string
+	cgroup     string
+	createTime int64
 	handle     processHandle
 }
 
@@ -47,14 +49,25 @@ type commandMetadata struct {
 	commandLineSlice []string
 }
 
+// fullCommandLine returns the command line as a single string.
+func (m *commandMetadata) fullCommandLine() string {
+	if m.commandLineSlice != nil {
+		// TODO insert slice here once this is supported by the data model
+		// (see https://github.com/open-telemetry/opentelemetry-collector/pull/1142)
+		return strings.Join(m.commandLineSlice, " ")
+	}
+	return m.commandLine
+}
+
 func (m *processMetadata) initializeResource(resource pdata.Resource) {
 	resource.InitEmpty()
 	attr := resource.Attributes()
-	attr.InitEmptyWithCapacity(6)
+	attr.InitEmptyWithCapacity(7)
 	m.insertPid(attr)
 	m.insertExecutable(attr)
 	m.insertCommand(attr)
 	m.insertUsername(attr)
+	m.insertCgroup(attr)
 }
 
 func (m *processMetadata) insertPid(attr pdata.AttributeMap) {
@@ -72,13 +85,7 @@ func (m *processMetadata) insertCommand(attr pdata.AttributeMap) {
 	}
 
 	attr.InsertString(conventions.AttributeProcessCommand, m.command.command)
-	if m.command.commandLineSlice != nil {
-		// TODO insert slice here once this is supported by the data model
-		// (see https://github.com/open-telemetry/opentelemetry-collector/pull/1142)
-		attr.InsertString(conventions.AttributeProcessCommandLine, strings.Join(m.command.commandLineSlice, " "))
-	} else {
-		attr.InsertString(conventions.AttributeProcessCommandLine, m.command.commandLine)
-	}
+	attr.InsertString(conventions.AttributeProcessCommandLine, m.command.fullCommandLine())
 }
 
 func (m *processMetadata) insertUsername(attr pdata.AttributeMap) {
@@ -89,6 +96,14 @@ func (m *processMetadata) insertUsername(attr pdata.AttributeMap) {
 	attr.InsertString(conventions.AttributeProcessUsername, m.username)
 }
 
+func (m *processMetadata) insertCgroup(attr pdata.AttributeMap) {
+	if m.cgroup == "" {
+		return
+	}
+
+	attr.InsertString(cgroupPathAttributeName, m.cgroup)
+}
+
 // processHandles provides a wrapper around []*process.Process
 // to support testing
 
@@ -107,6 +122,10 @@ type processHandle interface {
 	Times() (*cpu.TimesStat, error)
 	MemoryInfo() (*process.MemoryInfoStat, error)
 	IOCounters() (*process.IOCountersStat, error)
+	NumFDs() (int32, error)
+	NumThreads() (int32, error)
+	NumCtxSwitches() (*process.NumCtxSwitchesStat, error)
+	CreateTime() (int64, error)
 }
 
 type gopsProcessHandles struct {
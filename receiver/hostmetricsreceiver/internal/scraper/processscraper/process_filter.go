@@ -0,0 +1,88 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package processscraper
+
+import (
+	"go.opentelemetry.io/collector/internal/processor/filterset"
+)
+
+// processFilter matches processes against the properties set in a MatchConfig.
+// A process matches if it matches every property that is set.
+type processFilter struct {
+	names        filterset.FilterSet
+	commandLines filterset.FilterSet
+	owners       filterset.FilterSet
+	cgroups      filterset.FilterSet
+}
+
+// newProcessFilter creates a processFilter from the provided config. It returns
+// nil if the config does not set any property.
+func newProcessFilter(cfg *MatchConfig) (*processFilter, error) {
+	var err error
+	filter := &processFilter{}
+	if filter.names, err = createFilterSet(cfg.Names, &cfg.Config); err != nil {
+		return nil, err
+	}
+	if filter.commandLines, err = createFilterSet(cfg.CommandLines, &cfg.Config); err != nil {
+		return nil, err
+	}
+	if filter.owners, err = createFilterSet(cfg.Owners, &cfg.Config); err != nil {
+		return nil, err
+	}
+	if filter.cgroups, err = createFilterSet(cfg.Cgroups, &cfg.Config); err != nil {
+		return nil, err
+	}
+
+	if filter.names == nil && filter.commandLines == nil && filter.owners == nil && filter.cgroups == nil {
+		return nil, nil
+	}
+	return filter, nil
+}
+
+func createFilterSet(filters []string, cfg *filterset.Config) (filterset.FilterSet, error) {
+	if len(filters) == 0 {
+		return nil, nil
+	}
+	return filterset.CreateFilterSet(filters, cfg)
+}
+
+// matchesName returns whether the executable name matches the filter.
+// It is true if no name filter is set.
+func (f *processFilter) matchesName(name string) bool {
+	return f.names == nil || f.names.Matches(name)
+}
+
+// namesOnly returns whether the filter only matches on executable names, in which
+// case matchesName is sufficient to determine whether a process matches.
+func (f *processFilter) namesOnly() bool {
+	return f.commandLines == nil && f.owners == nil && f.cgroups == nil
+}
+
+// matches returns whether the process matches every property set on the filter.
+func (f *processFilter) matches(md *processMetadata) bool {
+	if !f.matchesName(md.executable.name) {
+		return false
+	}
+	if f.commandLines != nil && (md.command == nil || !f.commandLines.Matches(md.command.fullCommandLine())) {
+		return false
+	}
+	if f.owners != nil && !f.owners.Matches(md.username) {
+		return false
+	}
+	if f.cgroups != nil && !f.cgroups.Matches(md.cgroup) {
+		return false
+	}
+	return true
+}
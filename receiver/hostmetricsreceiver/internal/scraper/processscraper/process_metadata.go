@@ -18,11 +18,18 @@ import (
 	"go.opentelemetry.io/collector/consumer/pdata"
 )
 
+// resource attributes
+
+const (
+	cgroupPathAttributeName = "cgroup.path"
+)
+
 // labels
 
 const (
 	directionLabelName = "direction"
 	stateLabelName     = "state"
+	typeLabelName      = "type"
 )
 
 // direction label values
@@ -40,6 +47,13 @@ const (
 	waitStateLabelValue   = "wait"
 )
 
+// type label values
+
+const (
+	voluntaryTypeLabelValue   = "voluntary"
+	involuntaryTypeLabelValue = "involuntary"
+)
+
 // descriptors
 
 var cpuTimeDescriptor = func() pdata.MetricDescriptor {
@@ -81,3 +95,43 @@ var diskIODescriptor = func() pdata.MetricDescriptor {
 	descriptor.SetType(pdata.MetricTypeMonotonicInt64)
 	return descriptor
 }()
+
+var openFileDescriptorsDescriptor = func() pdata.MetricDescriptor {
+	descriptor := pdata.NewMetricDescriptor()
+	descriptor.InitEmpty()
+	descriptor.SetName("process.open_file_descriptors")
+	descriptor.SetDescription("Number of file descriptors in use by the process.")
+	descriptor.SetUnit("1")
+	descriptor.SetType(pdata.MetricTypeInt64)
+	return descriptor
+}()
+
+var threadsDescriptor = func() pdata.MetricDescriptor {
+	descriptor := pdata.NewMetricDescriptor()
+	descriptor.InitEmpty()
+	descriptor.SetName("process.threads")
+	descriptor.SetDescription("Process threads count.")
+	descriptor.SetUnit("1")
+	descriptor.SetType(pdata.MetricTypeInt64)
+	return descriptor
+}()
+
+var contextSwitchesDescriptor = func() pdata.MetricDescriptor {
+	descriptor := pdata.NewMetricDescriptor()
+	descriptor.InitEmpty()
+	descriptor.SetName("process.context_switches")
+	descriptor.SetDescription("Number of times the process has been context switched.")
+	descriptor.SetUnit("1")
+	descriptor.SetType(pdata.MetricTypeMonotonicInt64)
+	return descriptor
+}()
+
+var startTimeDescriptor = func() pdata.MetricDescriptor {
+	descriptor := pdata.NewMetricDescriptor()
+	descriptor.InitEmpty()
+	descriptor.SetName("process.start_time")
+	descriptor.SetDescription("Time the process started, in seconds since the Unix epoch.")
+	descriptor.SetUnit("s")
+	descriptor.SetType(pdata.MetricTypeDouble)
+	return descriptor
+}()
@@ -16,6 +16,8 @@ package processscraper
 
 import (
 	"context"
+	"fmt"
+	"sort"
 	"time"
 
 	"github.com/pkg/errors"
@@ -23,41 +25,47 @@ import (
 	"github.com/shirou/gopsutil/host"
 	"github.com/shirou/gopsutil/process"
 
-	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/pdata"
-	"go.opentelemetry.io/collector/internal/processor/filterset"
+	"go.opentelemetry.io/collector/receiver/scraperhelper"
 )
 
 // scraper for Process Metrics
 type scraper struct {
 	config    *Config
 	startTime pdata.TimestampUnixNano
-	includeFS filterset.FilterSet
-	excludeFS filterset.FilterSet
+	include   *processFilter
+	exclude   *processFilter
 
 	// for mocking
 	bootTime          func() (uint64, error)
 	getProcessHandles func() (processHandles, error)
+	getProcessCgroup  func(pid int32) (string, error)
 }
 
 // newProcessScraper creates a Process Scraper
 func newProcessScraper(cfg *Config) (*scraper, error) {
-	scraper := &scraper{config: cfg, bootTime: host.BootTime, getProcessHandles: getProcessHandlesInternal}
+	scraper := &scraper{
+		config:            cfg,
+		bootTime:          host.BootTime,
+		getProcessHandles: getProcessHandlesInternal,
+		getProcessCgroup:  getProcessCgroup,
+	}
+
+	if cfg.MaxProcesses < 0 {
+		return nil, fmt.Errorf("max_processes must not be negative: %d", cfg.MaxProcesses)
+	}
 
 	var err error
 
-	if len(cfg.Include.Names) > 0 {
-		scraper.includeFS, err = filterset.CreateFilterSet(cfg.Include.Names, &cfg.Include.Config)
-		if err != nil {
-			return nil, errors.Wrap(err, "error creating process include filters")
-		}
+	scraper.include, err = newProcessFilter(&cfg.Include)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating process include filters")
 	}
 
-	if len(cfg.Exclude.Names) > 0 {
-		scraper.excludeFS, err = filterset.CreateFilterSet(cfg.Exclude.Names, &cfg.Exclude.Config)
-		if err != nil {
-			return nil, errors.Wrap(err, "error creating process exclude filters")
-		}
+	scraper.exclude, err = newProcessFilter(&cfg.Exclude)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating process exclude filters")
 	}
 
 	return scraper, nil
@@ -99,19 +107,27 @@ func (s *scraper) ScrapeMetrics(_ context.Context) (pdata.ResourceMetricsSlice,
 		metrics := ilms.At(0).Metrics()
 
 		if err = scrapeAndAppendCPUTimeMetric(metrics, s.startTime, md.handle); err != nil {
-			errs = append(errs, errors.Wrapf(err, "error reading cpu times for process %q (pid %v)", md.executable.name, md.pid))
+			errs = append(errs, consumererror.NewPartialScrapeError(errors.Wrapf(err, "error reading cpu times for process %q (pid %v)", md.executable.name, md.pid), 1))
 		}
 
 		if err = scrapeAndAppendMemoryUsageMetrics(metrics, md.handle); err != nil {
-			errs = append(errs, errors.Wrapf(err, "error reading memory info for process %q (pid %v)", md.executable.name, md.pid))
+			errs = append(errs, consumererror.NewPartialScrapeError(errors.Wrapf(err, "error reading memory info for process %q (pid %v)", md.executable.name, md.pid), 2))
 		}
 
 		if err = scrapeAndAppendDiskIOMetric(metrics, s.startTime, md.handle); err != nil {
-			errs = append(errs, errors.Wrapf(err, "error reading disk usage for process %q (pid %v)", md.executable.name, md.pid))
+			errs = append(errs, consumererror.NewPartialScrapeError(errors.Wrapf(err, "error reading disk usage for process %q (pid %v)", md.executable.name, md.pid), 1))
+		}
+
+		if err = scrapeAndAppendThreadsMetric(metrics, md.handle); err != nil {
+			errs = append(errs, consumererror.NewPartialScrapeError(errors.Wrapf(err, "error reading thread count for process %q (pid %v)", md.executable.name, md.pid), 1))
 		}
+
+		errs = append(errs, scrapeAndAppendSystemSpecificMetrics(metrics, s.startTime, md)...)
+
+		appendStartTimeMetric(metrics, md.createTime)
 	}
 
-	return rms, componenterror.CombineErrors(errs)
+	return rms, scraperhelper.CombineScrapeErrors(errs)
 }
 
 // getProcessMetadata returns a slice of processMetadata, including handles,
@@ -126,6 +142,8 @@ func (s *scraper) getProcessMetadata() ([]*processMetadata, error) {
 
 	var errs []error
 	metadata := make([]*processMetadata, 0, handles.Len())
+	// errors reading the metadata of each process, only reported for processes that are kept
+	metadataErrs := make([][]error, 0, handles.Len())
 	for i := 0; i < handles.Len(); i++ {
 		pid := handles.Pid(i)
 		handle := handles.At(i)
@@ -136,20 +154,34 @@ func (s *scraper) getProcessMetadata() ([]*processMetadata, error) {
 			continue
 		}
 
-		// filter processes by name
-		if (s.includeFS != nil && !s.includeFS.Matches(executable.name)) ||
-			(s.excludeFS != nil && s.excludeFS.Matches(executable.name)) {
+		// filter processes by name first to avoid reading the remaining metadata
+		// of processes that cannot match
+		if (s.include != nil && !s.include.matchesName(executable.name)) ||
+			(s.exclude != nil && s.exclude.namesOnly() && s.exclude.matchesName(executable.name)) {
 			continue
 		}
 
+		var mdErrs []error
+
 		command, err := getProcessCommand(handle)
 		if err != nil {
-			errs = append(errs, errors.Wrapf(err, "error reading command for process %q (pid %v)", executable.name, pid))
+			mdErrs = append(mdErrs, errors.Wrapf(err, "error reading command for process %q (pid %v)", executable.name, pid))
 		}
 
 		username, err := handle.Username()
 		if err != nil {
-			errs = append(errs, errors.Wrapf(err, "error reading username for process %q (pid %v)", executable.name, pid))
+			mdErrs = append(mdErrs, errors.Wrapf(err, "error reading username for process %q (pid %v)", executable.name, pid))
+		}
+
+		cgroup, err := s.getProcessCgroup(pid)
+		if err != nil {
+			mdErrs = append(mdErrs, errors.Wrapf(err, "error reading cgroup for process %q (pid %v)", executable.name, pid))
+		}
+
+		createTime, err := handle.CreateTime()
+		if err != nil {
+			// the start time metric cannot be generated without the create time
+			mdErrs = append(mdErrs, consumererror.NewPartialScrapeError(errors.Wrapf(err, "error reading create time for process %q (pid %v)", executable.name, pid), 1))
 		}
 
 		md := &processMetadata{
@@ -157,13 +189,64 @@ func (s *scraper) getProcessMetadata() ([]*processMetadata, error) {
 			executable: executable,
 			command:    command,
 			username:   username,
+			cgroup:     cgroup,
+			createTime: createTime,
 			handle:     handle,
 		}
 
+		// filter processes by the remaining properties
+		if (s.include != nil && !s.include.matches(md)) ||
+			(s.exclude != nil && s.exclude.matches(md)) {
+			continue
+		}
+
 		metadata = append(metadata, md)
+		metadataErrs = append(metadataErrs, mdErrs)
+	}
+
+	metadata, metadataErrs = limitProcesses(metadata, metadataErrs, s.config.MaxProcesses)
+	for _, mdErrs := range metadataErrs {
+		errs = append(errs, mdErrs...)
 	}
 
-	return metadata, componenterror.CombineErrors(errs)
+	return metadata, scraperhelper.CombineScrapeErrors(errs)
+}
+
+// limitProcesses keeps the maxProcesses processes that have been running the
+// longest, along with the errors reading their metadata. Processes with an
+// unknown create time are dropped first. It keeps all processes if maxProcesses
+// is 0.
+func limitProcesses(metadata []*processMetadata, metadataErrs [][]error, maxProcesses int) ([]*processMetadata, [][]error) {
+	if maxProcesses == 0 || len(metadata) <= maxProcesses {
+		return metadata, metadataErrs
+	}
+
+	indices := make([]int, len(metadata))
+	for i := range indices {
+		indices[i] = i
+	}
+	sort.SliceStable(indices, func(i, j int) bool {
+		mi, mj := metadata[indices[i]], metadata[indices[j]]
+		if (mi.createTime == 0) != (mj.createTime == 0) {
+			return mj.createTime == 0
+		}
+		if mi.createTime != mj.createTime {
+			return mi.createTime < mj.createTime
+		}
+		return mi.pid < mj.pid
+	})
+
+	// keep the remaining processes in their original order
+	indices = indices[:maxProcesses]
+	sort.Ints(indices)
+
+	limited := make([]*processMetadata, 0, maxProcesses)
+	limitedErrs := make([][]error, 0, maxProcesses)
+	for _, i := range indices {
+		limited = append(limited, metadata[i])
+		limitedErrs = append(limitedErrs, metadataErrs[i])
+	}
+	return limited, limitedErrs
 }
 
 func scrapeAndAppendCPUTimeMetric(metrics pdata.MetricSlice, startTime pdata.TimestampUnixNano, handle processHandle) error {
@@ -212,6 +295,88 @@ func initializeMemoryUsageDataPoint(dataPoint pdata.Int64DataPoint, usage int64)
 	dataPoint.SetValue(usage)
 }
 
+func scrapeAndAppendThreadsMetric(metrics pdata.MetricSlice, handle processHandle) error {
+	threads, err := handle.NumThreads()
+	if err != nil {
+		return err
+	}
+
+	startIdx := metrics.Len()
+	metrics.Resize(startIdx + 1)
+	initializeGaugeMetric(metrics.At(startIdx), threadsDescriptor, int64(threads))
+	return nil
+}
+
+func scrapeAndAppendOpenFileDescriptorsMetric(metrics pdata.MetricSlice, handle processHandle) error {
+	fds, err := handle.NumFDs()
+	if err != nil {
+		return err
+	}
+
+	startIdx := metrics.Len()
+	metrics.Resize(startIdx + 1)
+	initializeGaugeMetric(metrics.At(startIdx), openFileDescriptorsDescriptor, int64(fds))
+	return nil
+}
+
+func initializeGaugeMetric(metric pdata.Metric, descriptor pdata.MetricDescriptor, value int64) {
+	descriptor.CopyTo(metric.MetricDescriptor())
+
+	idps := metric.Int64DataPoints()
+	idps.Resize(1)
+	dataPoint := idps.At(0)
+	dataPoint.SetTimestamp(pdata.TimestampUnixNano(uint64(time.Now().UnixNano())))
+	dataPoint.SetValue(value)
+}
+
+func scrapeAndAppendContextSwitchesMetric(metrics pdata.MetricSlice, startTime pdata.TimestampUnixNano, handle processHandle) error {
+	ctxSwitches, err := handle.NumCtxSwitches()
+	if err != nil {
+		return err
+	}
+
+	startIdx := metrics.Len()
+	metrics.Resize(startIdx + 1)
+	initializeContextSwitchesMetric(metrics.At(startIdx), startTime, ctxSwitches)
+	return nil
+}
+
+func initializeContextSwitchesMetric(metric pdata.Metric, startTime pdata.TimestampUnixNano, ctxSwitches *process.NumCtxSwitchesStat) {
+	contextSwitchesDescriptor.CopyTo(metric.MetricDescriptor())
+
+	idps := metric.Int64DataPoints()
+	idps.Resize(2)
+	initializeContextSwitchesDataPoint(idps.At(0), startTime, ctxSwitches.Voluntary, voluntaryTypeLabelValue)
+	initializeContextSwitchesDataPoint(idps.At(1), startTime, ctxSwitches.Involuntary, involuntaryTypeLabelValue)
+}
+
+func initializeContextSwitchesDataPoint(dataPoint pdata.Int64DataPoint, startTime pdata.TimestampUnixNano, value int64, typeLabel string) {
+	labelsMap := dataPoint.LabelsMap()
+	labelsMap.Insert(typeLabelName, typeLabel)
+	dataPoint.SetStartTime(startTime)
+	dataPoint.SetTimestamp(pdata.TimestampUnixNano(uint64(time.Now().UnixNano())))
+	dataPoint.SetValue(value)
+}
+
+// appendStartTimeMetric appends the process start time, given as the create time
+// in milliseconds since the Unix epoch, unless it could not be read.
+func appendStartTimeMetric(metrics pdata.MetricSlice, createTime int64) {
+	if createTime == 0 {
+		return
+	}
+
+	startIdx := metrics.Len()
+	metrics.Resize(startIdx + 1)
+	metric := metrics.At(startIdx)
+	startTimeDescriptor.CopyTo(metric.MetricDescriptor())
+
+	ddps := metric.DoubleDataPoints()
+	ddps.Resize(1)
+	dataPoint := ddps.At(0)
+	dataPoint.SetTimestamp(pdata.TimestampUnixNano(uint64(time.Now().UnixNano())))
+	dataPoint.SetValue(float64(createTime) / 1e3)
+}
+
 func scrapeAndAppendDiskIOMetric(metrics pdata.MetricSlice, startTime pdata.TimestampUnixNano, handle processHandle) error {
 	io, err := handle.IOCounters()
 	if err != nil {
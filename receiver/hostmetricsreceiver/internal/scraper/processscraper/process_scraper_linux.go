@@ -17,10 +17,17 @@
 package processscraper
 
 import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/pkg/errors"
 	"github.com/shirou/gopsutil/cpu"
 
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/pdata"
 )
 
@@ -69,3 +76,59 @@ func getProcessCommand(proc processHandle) (*commandMetadata, error) {
 	command := &commandMetadata{command: cmd, commandLineSlice: cmdline}
 	return command, nil
 }
+
+func scrapeAndAppendSystemSpecificMetrics(metrics pdata.MetricSlice, startTime pdata.TimestampUnixNano, md *processMetadata) []error {
+	var errs []error
+
+	if err := scrapeAndAppendOpenFileDescriptorsMetric(metrics, md.handle); err != nil {
+		errs = append(errs, consumererror.NewPartialScrapeError(errors.Wrapf(err, "error reading open file descriptors for process %q (pid %v)", md.executable.name, md.pid), 1))
+	}
+
+	if err := scrapeAndAppendContextSwitchesMetric(metrics, startTime, md.handle); err != nil {
+		errs = append(errs, consumererror.NewPartialScrapeError(errors.Wrapf(err, "error reading context switches for process %q (pid %v)", md.executable.name, md.pid), 1))
+	}
+
+	return errs
+}
+
+// getProcessCgroup returns the cgroup of the process read from /proc/<pid>/cgroup,
+// preferring the cgroup v1 cpu hierarchy over the v2 unified hierarchy, as the
+// cgroup scraper does.
+func getProcessCgroup(pid int32) (string, error) {
+	procPath := os.Getenv("HOST_PROC")
+	if procPath == "" {
+		procPath = "/proc"
+	}
+
+	f, err := os.Open(filepath.Join(procPath, strconv.Itoa(int(pid)), "cgroup"))
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	return parseProcessCgroup(bufio.NewScanner(f))
+}
+
+func parseProcessCgroup(scanner *bufio.Scanner) (string, error) {
+	var unified string
+	for scanner.Scan() {
+		// each line is formatted as "hierarchy-ID:controller-list:cgroup-path"
+		fields := strings.SplitN(scanner.Text(), ":", 3)
+		if len(fields) != 3 {
+			continue
+		}
+
+		if fields[0] == "0" && fields[1] == "" {
+			unified = fields[2]
+			continue
+		}
+
+		for _, controller := range strings.Split(fields[1], ",") {
+			if controller == "cpu" {
+				return fields[2], nil
+			}
+		}
+	}
+
+	return unified, scanner.Err()
+}
@@ -0,0 +1,69 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build linux
+
+package processscraper
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseProcessCgroup(t *testing.T) {
+	type testCase struct {
+		name     string
+		content  string
+		expected string
+	}
+
+	testCases := []testCase{
+		{
+			name:     "Unified",
+			content:  "0::/system.slice/docker-abc.scope\n",
+			expected: "/system.slice/docker-abc.scope",
+		},
+		{
+			name: "V1",
+			content: "12:memory:/docker/abc\n" +
+				"4:cpu,cpuacct:/docker/abc\n" +
+				"1:name=systemd:/docker/abc\n",
+			expected: "/docker/abc",
+		},
+		{
+			name: "Hybrid",
+			content: "4:cpu,cpuacct:/user.slice\n" +
+				"1:name=systemd:/user.slice/user-1000.slice/session-1.scope\n" +
+				"0::/user.slice/user-1000.slice/session-1.scope\n",
+			expected: "/user.slice",
+		},
+		{
+			name:     "No Cpu Hierarchy",
+			content:  "12:memory:/docker/abc\n",
+			expected: "",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			cgroup, err := parseProcessCgroup(bufio.NewScanner(strings.NewReader(test.content)))
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, cgroup)
+		})
+	}
+}
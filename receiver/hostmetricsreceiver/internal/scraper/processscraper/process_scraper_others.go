@@ -34,3 +34,11 @@ func getProcessExecutable(proc processHandle) (*executableMetadata, error) {
 func getProcessCommand(proc processHandle) (*commandMetadata, error) {
 	return nil, nil
 }
+
+func scrapeAndAppendSystemSpecificMetrics(metrics pdata.MetricSlice, startTime pdata.TimestampUnixNano, md *processMetadata) []error {
+	return nil
+}
+
+func getProcessCgroup(pid int32) (string, error) {
+	return "", nil
+}
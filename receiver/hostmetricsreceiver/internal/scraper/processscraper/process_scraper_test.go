@@ -74,6 +74,12 @@ func TestScrapeMetrics(t *testing.T) {
 	assertMemoryUsageMetricValid(t, physicalMemoryUsageDescriptor, resourceMetrics)
 	assertMemoryUsageMetricValid(t, virtualMemoryUsageDescriptor, resourceMetrics)
 	assertDiskIOMetricValid(t, resourceMetrics, expectedStartTime)
+	assertGaugeMetricValid(t, threadsDescriptor, resourceMetrics)
+	assertStartTimeMetricValid(t, resourceMetrics)
+	if runtime.GOOS == "linux" {
+		assertGaugeMetricValid(t, openFileDescriptorsDescriptor, resourceMetrics)
+		assertContextSwitchesMetricValid(t, resourceMetrics, expectedStartTime)
+	}
 }
 
 func assertResourceAttributes(t *testing.T, resourceMetrics pdata.ResourceMetricsSlice) {
@@ -116,6 +122,29 @@ func assertDiskIOMetricValid(t *testing.T, resourceMetrics pdata.ResourceMetrics
 	internal.AssertInt64MetricLabelHasValue(t, diskIOMetric, 1, directionLabelName, writeDirectionLabelValue)
 }
 
+func assertGaugeMetricValid(t *testing.T, descriptor pdata.MetricDescriptor, resourceMetrics pdata.ResourceMetricsSlice) {
+	metric := getMetric(t, descriptor, resourceMetrics)
+	internal.AssertDescriptorEqual(t, descriptor, metric.MetricDescriptor())
+	assert.Equal(t, 1, metric.Int64DataPoints().Len())
+}
+
+func assertContextSwitchesMetricValid(t *testing.T, resourceMetrics pdata.ResourceMetricsSlice, startTime pdata.TimestampUnixNano) {
+	contextSwitchesMetric := getMetric(t, contextSwitchesDescriptor, resourceMetrics)
+	internal.AssertDescriptorEqual(t, contextSwitchesDescriptor, contextSwitchesMetric.MetricDescriptor())
+	if startTime != 0 {
+		internal.AssertInt64MetricStartTimeEquals(t, contextSwitchesMetric, startTime)
+	}
+	internal.AssertInt64MetricLabelHasValue(t, contextSwitchesMetric, 0, typeLabelName, voluntaryTypeLabelValue)
+	internal.AssertInt64MetricLabelHasValue(t, contextSwitchesMetric, 1, typeLabelName, involuntaryTypeLabelValue)
+}
+
+func assertStartTimeMetricValid(t *testing.T, resourceMetrics pdata.ResourceMetricsSlice) {
+	startTimeMetric := getMetric(t, startTimeDescriptor, resourceMetrics)
+	internal.AssertDescriptorEqual(t, startTimeDescriptor, startTimeMetric.MetricDescriptor())
+	require.Equal(t, 1, startTimeMetric.DoubleDataPoints().Len())
+	assert.Greater(t, startTimeMetric.DoubleDataPoints().At(0).Value(), float64(0))
+}
+
 func getMetric(t *testing.T, descriptor pdata.MetricDescriptor, rms pdata.ResourceMetricsSlice) pdata.Metric {
 	for i := 0; i < rms.Len(); i++ {
 		metrics := getMetricSlice(t, rms.At(i))
@@ -147,6 +176,13 @@ func TestScrapeMetrics_NewError(t *testing.T) {
 	_, err = newProcessScraper(&Config{Exclude: MatchConfig{Names: []string{"test"}}})
 	require.Error(t, err)
 	require.Regexp(t, "^error creating process exclude filters:", err.Error())
+
+	_, err = newProcessScraper(&Config{Include: MatchConfig{Owners: []string{"test"}}})
+	require.Error(t, err)
+	require.Regexp(t, "^error creating process include filters:", err.Error())
+
+	_, err = newProcessScraper(&Config{MaxProcesses: -1})
+	require.EqualError(t, err, "max_processes must not be negative: -1")
 }
 
 func TestScrapeMetrics_GetProcessesError(t *testing.T) {
@@ -168,9 +204,13 @@ func TestScrapeMetrics_GetProcessesError(t *testing.T) {
 
 type processHandlesMock struct {
 	handles []*processHandleMock
+	pids    []int32
 }
 
 func (p *processHandlesMock) Pid(index int) int32 {
+	if p.pids != nil {
+		return p.pids[index]
+	}
 	return 1
 }
 
@@ -226,6 +266,26 @@ func (p *processHandleMock) IOCounters() (*process.IOCountersStat, error) {
 	return args.Get(0).(*process.IOCountersStat), args.Error(1)
 }
 
+func (p *processHandleMock) NumFDs() (int32, error) {
+	args := p.MethodCalled("NumFDs")
+	return args.Get(0).(int32), args.Error(1)
+}
+
+func (p *processHandleMock) NumThreads() (int32, error) {
+	args := p.MethodCalled("NumThreads")
+	return args.Get(0).(int32), args.Error(1)
+}
+
+func (p *processHandleMock) NumCtxSwitches() (*process.NumCtxSwitchesStat, error) {
+	args := p.MethodCalled("NumCtxSwitches")
+	return args.Get(0).(*process.NumCtxSwitchesStat), args.Error(1)
+}
+
+func (p *processHandleMock) CreateTime() (int64, error) {
+	args := p.MethodCalled("CreateTime")
+	return args.Get(0).(int64), args.Error(1)
+}
+
 func newDefaultHandleMock() *processHandleMock {
 	handleMock := &processHandleMock{}
 	handleMock.On("Username").Return("username", nil)
@@ -234,9 +294,17 @@ func newDefaultHandleMock() *processHandleMock {
 	handleMock.On("Times").Return(&cpu.TimesStat{}, nil)
 	handleMock.On("MemoryInfo").Return(&process.MemoryInfoStat{}, nil)
 	handleMock.On("IOCounters").Return(&process.IOCountersStat{}, nil)
+	handleMock.On("NumFDs").Return(int32(0), nil)
+	handleMock.On("NumThreads").Return(int32(0), nil)
+	handleMock.On("NumCtxSwitches").Return(&process.NumCtxSwitchesStat{}, nil)
+	handleMock.On("CreateTime").Return(int64(0), nil)
 	return handleMock
 }
 
+func getProcessCgroupMock(pid int32) (string, error) {
+	return "/", nil
+}
+
 func TestScrapeMetrics_Filtered(t *testing.T) {
 	skipTestOnUnsupportedOS(t)
 
@@ -316,6 +384,7 @@ func TestScrapeMetrics_Filtered(t *testing.T) {
 			scraper.getProcessHandles = func() (processHandles, error) {
 				return &processHandlesMock{handles: handles}, nil
 			}
+			scraper.getProcessCgroup = getProcessCgroupMock
 
 			resourceMetrics, err := scraper.ScrapeMetrics(context.Background())
 			require.NoError(t, err)
@@ -343,6 +412,11 @@ func TestScrapeMetrics_ProcessErrors(t *testing.T) {
 		timesError      error
 		memoryInfoError error
 		ioCountersError error
+		cgroupError     error
+		createTimeError error
+		threadsError    error
+		fdsError        error
+		ctxSwitchError  error
 		expectedError   string
 	}
 
@@ -383,6 +457,34 @@ func TestScrapeMetrics_ProcessErrors(t *testing.T) {
 			ioCountersError: errors.New("err6"),
 			expectedError:   `error reading disk usage for process "test" (pid 1): err6`,
 		},
+		{
+			name:          "Cgroup Error",
+			osFilter:      "windows",
+			cgroupError:   errors.New("err7"),
+			expectedError: `error reading cgroup for process "test" (pid 1): err7`,
+		},
+		{
+			name:            "Create Time Error",
+			createTimeError: errors.New("err8"),
+			expectedError:   `error reading create time for process "test" (pid 1): err8`,
+		},
+		{
+			name:          "Threads Error",
+			threadsError:  errors.New("err9"),
+			expectedError: `error reading thread count for process "test" (pid 1): err9`,
+		},
+		{
+			name:          "File Descriptors Error",
+			osFilter:      "windows",
+			fdsError:      errors.New("err10"),
+			expectedError: `error reading open file descriptors for process "test" (pid 1): err10`,
+		},
+		{
+			name:           "Context Switches Error",
+			osFilter:       "windows",
+			ctxSwitchError: errors.New("err11"),
+			expectedError:  `error reading context switches for process "test" (pid 1): err11`,
+		},
 		{
 			name:            "Multiple Errors",
 			cmdlineError:    errors.New("err2"),
@@ -415,6 +517,11 @@ func TestScrapeMetrics_ProcessErrors(t *testing.T) {
 				username = ""
 			}
 
+			createTime := int64(1000)
+			if test.createTimeError != nil {
+				createTime = 0
+			}
+
 			handleMock := &processHandleMock{}
 			handleMock.On("Name").Return("test", test.nameError)
 			handleMock.On("Exe").Return("test", test.exeError)
@@ -424,10 +531,17 @@ func TestScrapeMetrics_ProcessErrors(t *testing.T) {
 			handleMock.On("Times").Return(&cpu.TimesStat{}, test.timesError)
 			handleMock.On("MemoryInfo").Return(&process.MemoryInfoStat{}, test.memoryInfoError)
 			handleMock.On("IOCounters").Return(&process.IOCountersStat{}, test.ioCountersError)
+			handleMock.On("NumFDs").Return(int32(0), test.fdsError)
+			handleMock.On("NumThreads").Return(int32(0), test.threadsError)
+			handleMock.On("NumCtxSwitches").Return(&process.NumCtxSwitchesStat{}, test.ctxSwitchError)
+			handleMock.On("CreateTime").Return(createTime, test.createTimeError)
 
 			scraper.getProcessHandles = func() (processHandles, error) {
 				return &processHandlesMock{handles: []*processHandleMock{handleMock}}, nil
 			}
+			scraper.getProcessCgroup = func(pid int32) (string, error) {
+				return "", test.cgroupError
+			}
 
 			resourceMetrics, err := scraper.ScrapeMetrics(context.Background())
 			assert.EqualError(t, err, test.expectedError)
@@ -437,14 +551,15 @@ func TestScrapeMetrics_ProcessErrors(t *testing.T) {
 			} else {
 				require.Equal(t, 1, resourceMetrics.Len())
 				metrics := getMetricSlice(t, resourceMetrics.At(0))
-				expectedLen := getExpectedLengthOfReturnedMetrics(test.timesError, test.memoryInfoError, test.ioCountersError)
+				expectedLen := getExpectedLengthOfReturnedMetrics(test.timesError, test.memoryInfoError, test.ioCountersError,
+					test.threadsError, test.fdsError, test.ctxSwitchError, test.createTimeError)
 				assert.Equal(t, expectedLen, metrics.Len())
 			}
 		})
 	}
 }
 
-func getExpectedLengthOfReturnedMetrics(timeError, memError, diskError error) int {
+func getExpectedLengthOfReturnedMetrics(timeError, memError, diskError, threadsError, fdsError, ctxSwitchError, createTimeError error) int {
 	expectedLen := 0
 	if timeError == nil {
 		expectedLen++
@@ -455,5 +570,236 @@ func getExpectedLengthOfReturnedMetrics(timeError, memError, diskError error) in
 	if diskError == nil {
 		expectedLen++
 	}
+	if threadsError == nil {
+		expectedLen++
+	}
+	if runtime.GOOS == "linux" {
+		if fdsError == nil {
+			expectedLen++
+		}
+		if ctxSwitchError == nil {
+			expectedLen++
+		}
+	}
+	if createTimeError == nil {
+		expectedLen++
+	}
 	return expectedLen
 }
+
+func TestScrapeMetrics_FilteredByProperties(t *testing.T) {
+	skipTestOnUnsupportedOS(t)
+
+	type testProcess struct {
+		name    string
+		cmdline string
+		owner   string
+		cgroup  string
+	}
+
+	processes := []testProcess{
+		{name: "test1", cmdline: "test1 --config a.yaml", owner: "root", cgroup: "/system.slice/test1.service"},
+		{name: "test2", cmdline: "test2 --config b.yaml", owner: "user", cgroup: "/user.slice"},
+		{name: "test3", cmdline: "test3 --config a.yaml", owner: "user", cgroup: "/system.slice/test3.service"},
+	}
+
+	type testCase struct {
+		name          string
+		include       MatchConfig
+		exclude       MatchConfig
+		expectedNames []string
+		skipWindows   bool
+	}
+
+	testCases := []testCase{
+		{
+			name:          "Include Command Line",
+			include:       MatchConfig{CommandLines: []string{"--config a\\.yaml"}},
+			expectedNames: []string{"test1", "test3"},
+		},
+		{
+			name:          "Include Owner",
+			include:       MatchConfig{Owners: []string{"^user$"}},
+			expectedNames: []string{"test2", "test3"},
+		},
+		{
+			name:          "Include Cgroup",
+			include:       MatchConfig{Cgroups: []string{"^/system\\.slice/"}},
+			expectedNames: []string{"test1", "test3"},
+			skipWindows:   true,
+		},
+		{
+			name:          "Include Owner & Command Line",
+			include:       MatchConfig{Owners: []string{"^user$"}, CommandLines: []string{"--config a\\.yaml"}},
+			expectedNames: []string{"test3"},
+		},
+		{
+			name:          "Include Name, Exclude Owner",
+			include:       MatchConfig{Names: []string{"test[12]"}},
+			exclude:       MatchConfig{Owners: []string{"^root$"}},
+			expectedNames: []string{"test2"},
+		},
+		{
+			name:          "Exclude Name & Cgroup",
+			exclude:       MatchConfig{Names: []string{"test3"}, Cgroups: []string{"^/system\\.slice/"}},
+			expectedNames: []string{"test1", "test2"},
+			skipWindows:   true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			if test.skipWindows && runtime.GOOS == "windows" {
+				t.Skipf("skipping test %v on %v", test.name, runtime.GOOS)
+			}
+
+			test.include.Config = filterset.Config{MatchType: filterset.Regexp}
+			test.exclude.Config = filterset.Config{MatchType: filterset.Regexp}
+
+			scraper, err := newProcessScraper(&Config{Include: test.include, Exclude: test.exclude})
+			require.NoError(t, err, "Failed to create process scraper: %v", err)
+			err = scraper.Initialize(context.Background())
+			require.NoError(t, err, "Failed to initialize process scraper: %v", err)
+			defer func() { assert.NoError(t, scraper.Close(context.Background())) }()
+
+			handles := make([]*processHandleMock, 0, len(processes))
+			pids := make([]int32, 0, len(processes))
+			cgroups := map[int32]string{}
+			for i, p := range processes {
+				handleMock := &processHandleMock{}
+				handleMock.On("Name").Return(p.name, nil)
+				handleMock.On("Exe").Return(p.name, nil)
+				handleMock.On("Username").Return(p.owner, nil)
+				handleMock.On("Cmdline").Return(p.cmdline, nil)
+				handleMock.On("CmdlineSlice").Return(strings.Split(p.cmdline, " "), nil)
+				handleMock.On("Times").Return(&cpu.TimesStat{}, nil)
+				handleMock.On("MemoryInfo").Return(&process.MemoryInfoStat{}, nil)
+				handleMock.On("IOCounters").Return(&process.IOCountersStat{}, nil)
+				handleMock.On("NumFDs").Return(int32(0), nil)
+				handleMock.On("NumThreads").Return(int32(0), nil)
+				handleMock.On("NumCtxSwitches").Return(&process.NumCtxSwitchesStat{}, nil)
+				handleMock.On("CreateTime").Return(int64(0), nil)
+				handles = append(handles, handleMock)
+
+				pid := int32(i + 1)
+				pids = append(pids, pid)
+				cgroups[pid] = p.cgroup
+			}
+
+			scraper.getProcessHandles = func() (processHandles, error) {
+				return &processHandlesMock{handles: handles, pids: pids}, nil
+			}
+			scraper.getProcessCgroup = func(pid int32) (string, error) {
+				return cgroups[pid], nil
+			}
+
+			resourceMetrics, err := scraper.ScrapeMetrics(context.Background())
+			require.NoError(t, err)
+
+			require.Equal(t, len(test.expectedNames), resourceMetrics.Len())
+			for i, expectedName := range test.expectedNames {
+				rm := resourceMetrics.At(i)
+				name, _ := rm.Resource().Attributes().Get(conventions.AttributeProcessExecutableName)
+				assert.Equal(t, expectedName, name.StringVal())
+			}
+		})
+	}
+}
+
+func TestScrapeMetrics_MaxProcesses(t *testing.T) {
+	skipTestOnUnsupportedOS(t)
+
+	type testProcess struct {
+		name            string
+		createTime      int64
+		createTimeError error
+	}
+
+	processes := []testProcess{
+		{name: "test1", createTime: 3000},
+		{name: "test2", createTimeError: errors.New("err1")},
+		{name: "test3", createTime: 1000},
+		{name: "test4", createTime: 2000},
+		{name: "test5", createTime: 1000},
+	}
+
+	type testCase struct {
+		name          string
+		maxProcesses  int
+		expectedNames []string
+		expectedError string
+	}
+
+	testCases := []testCase{
+		{
+			name:          "Unlimited",
+			expectedNames: []string{"test1", "test2", "test3", "test4", "test5"},
+			expectedError: `error reading create time for process "test2" (pid 2): err1`,
+		},
+		{
+			name:          "Limit Above Count",
+			maxProcesses:  10,
+			expectedNames: []string{"test1", "test2", "test3", "test4", "test5"},
+			expectedError: `error reading create time for process "test2" (pid 2): err1`,
+		},
+		{
+			name:          "Limit Oldest",
+			maxProcesses:  2,
+			expectedNames: []string{"test3", "test5"},
+		},
+		{
+			name:          "Limit Unknown Create Time Last",
+			maxProcesses:  4,
+			expectedNames: []string{"test1", "test3", "test4", "test5"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			scraper, err := newProcessScraper(&Config{MaxProcesses: test.maxProcesses})
+			require.NoError(t, err, "Failed to create process scraper: %v", err)
+			err = scraper.Initialize(context.Background())
+			require.NoError(t, err, "Failed to initialize process scraper: %v", err)
+			defer func() { assert.NoError(t, scraper.Close(context.Background())) }()
+
+			handles := make([]*processHandleMock, 0, len(processes))
+			pids := make([]int32, 0, len(processes))
+			for i, p := range processes {
+				handleMock := &processHandleMock{}
+				handleMock.On("Name").Return(p.name, nil)
+				handleMock.On("Exe").Return(p.name, nil)
+				handleMock.On("Username").Return("username", nil)
+				handleMock.On("Cmdline").Return("cmdline", nil)
+				handleMock.On("CmdlineSlice").Return([]string{"cmdline"}, nil)
+				handleMock.On("Times").Return(&cpu.TimesStat{}, nil)
+				handleMock.On("MemoryInfo").Return(&process.MemoryInfoStat{}, nil)
+				handleMock.On("IOCounters").Return(&process.IOCountersStat{}, nil)
+				handleMock.On("NumFDs").Return(int32(0), nil)
+				handleMock.On("NumThreads").Return(int32(0), nil)
+				handleMock.On("NumCtxSwitches").Return(&process.NumCtxSwitchesStat{}, nil)
+				handleMock.On("CreateTime").Return(p.createTime, p.createTimeError)
+				handles = append(handles, handleMock)
+				pids = append(pids, int32(i+1))
+			}
+
+			scraper.getProcessHandles = func() (processHandles, error) {
+				return &processHandlesMock{handles: handles, pids: pids}, nil
+			}
+			scraper.getProcessCgroup = getProcessCgroupMock
+
+			resourceMetrics, err := scraper.ScrapeMetrics(context.Background())
+			if test.expectedError != "" {
+				assert.EqualError(t, err, test.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, len(test.expectedNames), resourceMetrics.Len())
+			for i, expectedName := range test.expectedNames {
+				rm := resourceMetrics.At(i)
+				name, _ := rm.Resource().Attributes().Get(conventions.AttributeProcessExecutableName)
+				assert.Equal(t, expectedName, name.StringVal())
+			}
+		})
+	}
+}
@@ -70,3 +70,11 @@ func getProcessCommand(proc processHandle) (*commandMetadata, error) {
 	command := &commandMetadata{command: cmd, commandLine: cmdline}
 	return command, nil
 }
+
+func scrapeAndAppendSystemSpecificMetrics(metrics pdata.MetricSlice, startTime pdata.TimestampUnixNano, md *processMetadata) []error {
+	return nil
+}
+
+func getProcessCgroup(pid int32) (string, error) {
+	return "", nil
+}
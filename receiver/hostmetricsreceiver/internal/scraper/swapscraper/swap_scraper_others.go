@@ -23,8 +23,14 @@ import (
 	"github.com/shirou/gopsutil/host"
 	"github.com/shirou/gopsutil/mem"
 
-	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/receiver/scraperhelper"
+)
+
+const (
+	swapUsageMetricsLen = 1
+	pagingMetricsLen    = 2
 )
 
 // scraper for Swap Metrics
@@ -67,15 +73,15 @@ func (s *scraper) ScrapeMetrics(_ context.Context) (pdata.MetricSlice, error) {
 
 	err := s.scrapeAndAppendSwapUsageMetric(metrics)
 	if err != nil {
-		errors = append(errors, err)
+		errors = append(errors, consumererror.NewPartialScrapeError(err, swapUsageMetricsLen))
 	}
 
 	err = s.scrapeAndAppendPagingMetrics(metrics)
 	if err != nil {
-		errors = append(errors, err)
+		errors = append(errors, consumererror.NewPartialScrapeError(err, pagingMetricsLen))
 	}
 
-	return metrics, componenterror.CombineErrors(errors)
+	return metrics, scraperhelper.CombineScrapeErrors(errors)
 }
 
 func (s *scraper) scrapeAndAppendSwapUsageMetric(metrics pdata.MetricSlice) error {
@@ -85,7 +91,7 @@ func (s *scraper) scrapeAndAppendSwapUsageMetric(metrics pdata.MetricSlice) erro
 	}
 
 	idx := metrics.Len()
-	metrics.Resize(idx + 1)
+	metrics.Resize(idx + swapUsageMetricsLen)
 	initializeSwapUsageMetric(metrics.At(idx), vmem)
 	return nil
 }
@@ -114,7 +120,7 @@ func (s *scraper) scrapeAndAppendPagingMetrics(metrics pdata.MetricSlice) error
 	}
 
 	idx := metrics.Len()
-	metrics.Resize(idx + 2)
+	metrics.Resize(idx + pagingMetricsLen)
 	initializePagingMetric(metrics.At(idx+0), s.startTime, swap)
 	initializePageFaultsMetric(metrics.At(idx+1), s.startTime, swap)
 	return nil
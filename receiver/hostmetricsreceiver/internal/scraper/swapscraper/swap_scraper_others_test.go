@@ -25,6 +25,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/pdata"
 )
 
@@ -37,6 +38,7 @@ func TestScrapeMetrics_Errors(t *testing.T) {
 		expectedStartTime pdata.TimestampUnixNano
 		initializationErr string
 		expectedError     string
+		expectedErrCount  int
 	}
 
 	testCases := []testCase{
@@ -49,17 +51,20 @@ func TestScrapeMetrics_Errors(t *testing.T) {
 			name:              "virtualMemoryError",
 			virtualMemoryFunc: func() (*mem.VirtualMemoryStat, error) { return nil, errors.New("err1") },
 			expectedError:     "err1",
+			expectedErrCount:  swapUsageMetricsLen,
 		},
 		{
 			name:           "swapMemoryError",
-			swapMemoryFunc: func() (*mem.SwapMemoryStat, error) { return nil, errors.New("err2") },
-			expectedError:  "err2",
+			swapMemoryFunc:   func() (*mem.SwapMemoryStat, error) { return nil, errors.New("err2") },
+			expectedError:    "err2",
+			expectedErrCount: pagingMetricsLen,
 		},
 		{
 			name:              "multipleErrors",
 			virtualMemoryFunc: func() (*mem.VirtualMemoryStat, error) { return nil, errors.New("err1") },
 			swapMemoryFunc:    func() (*mem.SwapMemoryStat, error) { return nil, errors.New("err2") },
 			expectedError:     "[err1; err2]",
+			expectedErrCount:  swapUsageMetricsLen + pagingMetricsLen,
 		},
 	}
 
@@ -87,6 +92,11 @@ func TestScrapeMetrics_Errors(t *testing.T) {
 			metrics, err := scraper.ScrapeMetrics(context.Background())
 			if test.expectedError != "" {
 				assert.EqualError(t, err, test.expectedError)
+				isPartial := consumererror.IsPartialScrapeError(err)
+				assert.True(t, isPartial)
+				if isPartial {
+					assert.Equal(t, test.expectedErrCount, err.(consumererror.PartialScrapeError).Failed)
+				}
 				return
 			}
 
@@ -22,8 +22,15 @@ import (
 	"time"
 
 	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/pdata"
 	"go.opentelemetry.io/collector/receiver/hostmetricsreceiver/internal/windows/pdh"
+	"go.opentelemetry.io/collector/receiver/scraperhelper"
+)
+
+const (
+	swapUsageMetricsLen = 1
+	pagingMetricsLen    = 1
 )
 
 const (
@@ -97,15 +104,15 @@ func (s *scraper) ScrapeMetrics(_ context.Context) (pdata.MetricSlice, error) {
 
 	err := s.scrapeAndAppendSwapUsageMetric(metrics)
 	if err != nil {
-		errors = append(errors, err)
+		errors = append(errors, consumererror.NewPartialScrapeError(err, swapUsageMetricsLen))
 	}
 
 	err = s.scrapeAndAppendPagingMetric(metrics)
 	if err != nil {
-		errors = append(errors, err)
+		errors = append(errors, consumererror.NewPartialScrapeError(err, pagingMetricsLen))
 	}
 
-	return metrics, componenterror.CombineErrors(errors)
+	return metrics, scraperhelper.CombineScrapeErrors(errors)
 }
 
 func (s *scraper) scrapeAndAppendSwapUsageMetric(metrics pdata.MetricSlice) error {
@@ -115,7 +122,7 @@ func (s *scraper) scrapeAndAppendSwapUsageMetric(metrics pdata.MetricSlice) erro
 	}
 
 	idx := metrics.Len()
-	metrics.Resize(idx + 1)
+	metrics.Resize(idx + swapUsageMetricsLen)
 	initializeSwapUsageMetric(metrics.At(idx), pageFiles)
 	return nil
 }
@@ -161,7 +168,7 @@ func (s *scraper) scrapeAndAppendPagingMetric(metrics pdata.MetricSlice) error {
 	s.cumulativePageWrites += (pageWritesPerSecValues[0].Value * durationSinceLastScraped)
 
 	idx := metrics.Len()
-	metrics.Resize(idx + 1)
+	metrics.Resize(idx + pagingMetricsLen)
 	initializePagingMetric(metrics.At(idx), s.startTime, s.cumulativePageReads, s.cumulativePageWrites)
 	return nil
 }
@@ -0,0 +1,282 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaegerreceiver
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/jaegertracing/jaeger/cmd/collector/app/sampling/strategystore"
+	"github.com/jaegertracing/jaeger/thrift-gen/sampling"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/translator/conventions"
+)
+
+const (
+	// maxProbabilityIncreaseFactor caps how fast a probability can grow in a
+	// single calculation, so that a quiet interval does not cause a spike.
+	maxProbabilityIncreaseFactor = 2.0
+
+	// maxOperationsPerService bounds the number of per-operation strategies
+	// kept for a single service. Further operations use the service default.
+	maxOperationsPerService = 1000
+)
+
+var _ strategystore.StrategyStore = (*adaptiveStrategyStore)(nil)
+
+// serviceThroughput counts the root spans of a service received since the
+// last calculation.
+type serviceThroughput struct {
+	total      uint64
+	operations map[string]uint64
+}
+
+// serviceProbabilities holds the sampling probabilities served for a service.
+type serviceProbabilities struct {
+	defaultProbability float64
+	operations         map[string]float64
+}
+
+// adaptiveStrategyStore is a sampling strategy store that computes per-service
+// and per-operation probabilities from the observed root span throughput so
+// that each of them produces about TargetSpansPerSecond sampled spans.
+type adaptiveStrategyStore struct {
+	cfg    AdaptiveSamplingConfig
+	logger *zap.Logger
+	now    func() time.Time
+
+	mu              sync.Mutex
+	throughput      map[string]*serviceThroughput
+	probabilities   map[string]*serviceProbabilities
+	lastCalculation time.Time
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+func newAdaptiveStrategyStore(cfg AdaptiveSamplingConfig, logger *zap.Logger) *adaptiveStrategyStore {
+	return &adaptiveStrategyStore{
+		cfg:           cfg,
+		logger:        logger,
+		now:           time.Now,
+		throughput:    make(map[string]*serviceThroughput),
+		probabilities: make(map[string]*serviceProbabilities),
+		stopCh:        make(chan struct{}),
+	}
+}
+
+// start launches the periodic recalculation of the probabilities.
+func (s *adaptiveStrategyStore) start() {
+	s.mu.Lock()
+	s.lastCalculation = s.now()
+	s.mu.Unlock()
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		ticker := time.NewTicker(s.cfg.CalculationInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				s.calculate()
+			case <-s.stopCh:
+				return
+			}
+		}
+	}()
+}
+
+// shutdown stops the periodic recalculation started by start.
+func (s *adaptiveStrategyStore) shutdown() {
+	close(s.stopCh)
+	s.wg.Wait()
+}
+
+// observe records the root spans contained in td.
+func (s *adaptiveStrategyStore) observe(td pdata.Traces) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		if rs.IsNil() || rs.Resource().IsNil() {
+			continue
+		}
+		service, ok := rs.Resource().Attributes().Get(conventions.AttributeServiceName)
+		if !ok || service.StringVal() == "" {
+			continue
+		}
+
+		ilss := rs.InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			ils := ilss.At(j)
+			if ils.IsNil() {
+				continue
+			}
+			spans := ils.Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				if span.IsNil() || !isRootSpan(span) {
+					continue
+				}
+				s.record(service.StringVal(), span.Name())
+			}
+		}
+	}
+}
+
+func (s *adaptiveStrategyStore) record(service, operation string) {
+	tp, ok := s.throughput[service]
+	if !ok {
+		tp = &serviceThroughput{operations: make(map[string]uint64)}
+		s.throughput[service] = tp
+	}
+	tp.total++
+	if _, ok := tp.operations[operation]; ok || len(tp.operations) < maxOperationsPerService {
+		tp.operations[operation]++
+	}
+}
+
+// calculate updates the probabilities from the throughput observed since the
+// previous calculation and resets the throughput counters.
+func (s *adaptiveStrategyStore) calculate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	elapsed := now.Sub(s.lastCalculation).Seconds()
+	s.lastCalculation = now
+	if elapsed <= 0 {
+		return
+	}
+
+	for service, tp := range s.throughput {
+		probs, ok := s.probabilities[service]
+		if !ok {
+			probs = &serviceProbabilities{
+				defaultProbability: s.cfg.DefaultSamplingProbability,
+				operations:         make(map[string]float64),
+			}
+			s.probabilities[service] = probs
+		}
+
+		// New operations were sampled with the default served so far.
+		previousDefault := probs.defaultProbability
+		probs.defaultProbability = s.adjust(previousDefault, float64(tp.total)/elapsed)
+		for operation, count := range tp.operations {
+			current, ok := probs.operations[operation]
+			if !ok {
+				if len(probs.operations) >= maxOperationsPerService {
+					continue
+				}
+				current = previousDefault
+			}
+			probs.operations[operation] = s.adjust(current, float64(count)/elapsed)
+		}
+	}
+	s.throughput = make(map[string]*serviceThroughput)
+
+	s.logger.Debug("Recalculated adaptive sampling probabilities", zap.Int("services", len(s.probabilities)))
+}
+
+// adjust returns the probability that brings the observed rate of sampled
+// spans, obtained with the current probability, to the configured target.
+func (s *adaptiveStrategyStore) adjust(current, sampledPerSecond float64) float64 {
+	if sampledPerSecond == 0 {
+		return current
+	}
+	p := current * s.cfg.TargetSpansPerSecond / sampledPerSecond
+	if p > current*maxProbabilityIncreaseFactor {
+		p = current * maxProbabilityIncreaseFactor
+	}
+	if p < s.cfg.MinSamplingProbability {
+		p = s.cfg.MinSamplingProbability
+	}
+	if p > 1 {
+		p = 1
+	}
+	return p
+}
+
+// GetSamplingStrategy implements strategystore.StrategyStore.
+func (s *adaptiveStrategyStore) GetSamplingStrategy(_ context.Context, serviceName string) (*sampling.SamplingStrategyResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	probs, ok := s.probabilities[serviceName]
+	if !ok {
+		return &sampling.SamplingStrategyResponse{
+			StrategyType: sampling.SamplingStrategyType_PROBABILISTIC,
+			ProbabilisticSampling: &sampling.ProbabilisticSamplingStrategy{
+				SamplingRate: s.cfg.DefaultSamplingProbability,
+			},
+		}, nil
+	}
+
+	operations := make([]string, 0, len(probs.operations))
+	for operation := range probs.operations {
+		operations = append(operations, operation)
+	}
+	sort.Strings(operations)
+
+	strategies := make([]*sampling.OperationSamplingStrategy, 0, len(operations))
+	for _, operation := range operations {
+		strategies = append(strategies, &sampling.OperationSamplingStrategy{
+			Operation: operation,
+			ProbabilisticSampling: &sampling.ProbabilisticSamplingStrategy{
+				SamplingRate: probs.operations[operation],
+			},
+		})
+	}
+
+	return &sampling.SamplingStrategyResponse{
+		StrategyType: sampling.SamplingStrategyType_PROBABILISTIC,
+		ProbabilisticSampling: &sampling.ProbabilisticSamplingStrategy{
+			SamplingRate: probs.defaultProbability,
+		},
+		OperationSampling: &sampling.PerOperationSamplingStrategies{
+			DefaultSamplingProbability: probs.defaultProbability,
+			PerOperationStrategies:     strategies,
+		},
+	}, nil
+}
+
+func isRootSpan(span pdata.Span) bool {
+	for _, b := range span.ParentSpanID() {
+		if b != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// adaptiveSamplingObserver feeds the received traces to the adaptive strategy
+// store before passing them to the next consumer.
+type adaptiveSamplingObserver struct {
+	store        *adaptiveStrategyStore
+	nextConsumer consumer.TraceConsumer
+}
+
+func (o *adaptiveSamplingObserver) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	o.store.observe(td)
+	return o.nextConsumer.ConsumeTraces(ctx, td)
+}
@@ -0,0 +1,205 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaegerreceiver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jaegertracing/jaeger/thrift-gen/sampling"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/translator/conventions"
+)
+
+type testClock struct {
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	return c.now
+}
+
+func newTestAdaptiveStore(cfg AdaptiveSamplingConfig) (*adaptiveStrategyStore, *testClock) {
+	clock := &testClock{now: time.Unix(1000, 0)}
+	store := newAdaptiveStrategyStore(cfg, zap.NewNop())
+	store.now = clock.Now
+	store.lastCalculation = clock.now
+	return store, clock
+}
+
+// generateRootSpans returns traces with count root spans and one child span
+// per root span for the given service and operation.
+func generateRootSpans(service, operation string, count int) pdata.Traces {
+	td := pdata.NewTraces()
+	td.ResourceSpans().Resize(1)
+	rs := td.ResourceSpans().At(0)
+	rs.Resource().InitEmpty()
+	rs.Resource().Attributes().InsertString(conventions.AttributeServiceName, service)
+	rs.InstrumentationLibrarySpans().Resize(1)
+	spans := rs.InstrumentationLibrarySpans().At(0).Spans()
+	spans.Resize(2 * count)
+	for i := 0; i < count; i++ {
+		root := spans.At(2 * i)
+		root.SetName(operation)
+		root.SetSpanID(pdata.SpanID([]byte{1, 2, 3, 4, 5, 6, 7, byte(i)}))
+
+		child := spans.At(2*i + 1)
+		child.SetName(operation + "-child")
+		child.SetParentSpanID(root.SpanID())
+	}
+	return td
+}
+
+func TestAdaptiveStrategyStore_UnknownService(t *testing.T) {
+	store, _ := newTestAdaptiveStore(AdaptiveSamplingConfig{
+		TargetSpansPerSecond:       1,
+		DefaultSamplingProbability: 0.001,
+		MinSamplingProbability:     0.00001,
+	})
+
+	resp, err := store.GetSamplingStrategy(context.Background(), "unknown")
+	require.NoError(t, err)
+	assert.Equal(t, sampling.SamplingStrategyType_PROBABILISTIC, resp.StrategyType)
+	assert.Equal(t, 0.001, resp.ProbabilisticSampling.SamplingRate)
+	assert.Nil(t, resp.OperationSampling)
+}
+
+func TestAdaptiveStrategyStore_Calculate(t *testing.T) {
+	store, clock := newTestAdaptiveStore(AdaptiveSamplingConfig{
+		TargetSpansPerSecond:       1,
+		DefaultSamplingProbability: 0.1,
+		MinSamplingProbability:     0.001,
+	})
+
+	// 100 sampled root spans over 10s is 10 spans/s, ten times the target.
+	store.observe(generateRootSpans("svc", "busy", 100))
+	// 5 sampled root spans over 10s is half the target, the increase is capped.
+	store.observe(generateRootSpans("svc", "quiet", 5))
+	clock.now = clock.now.Add(10 * time.Second)
+	store.calculate()
+
+	resp, err := store.GetSamplingStrategy(context.Background(), "svc")
+	require.NoError(t, err)
+	require.NotNil(t, resp.OperationSampling)
+	// The service produced 10.5 spans/s overall.
+	assert.InDelta(t, 0.1/10.5, resp.OperationSampling.DefaultSamplingProbability, 1e-9)
+	assert.InDelta(t, 0.1/10.5, resp.ProbabilisticSampling.SamplingRate, 1e-9)
+
+	ops := resp.OperationSampling.PerOperationStrategies
+	require.Len(t, ops, 2)
+	assert.Equal(t, "busy", ops[0].Operation)
+	assert.InDelta(t, 0.01, ops[0].ProbabilisticSampling.SamplingRate, 1e-9)
+	assert.Equal(t, "quiet", ops[1].Operation)
+	assert.InDelta(t, 0.2, ops[1].ProbabilisticSampling.SamplingRate, 1e-9)
+
+	// Nothing observed keeps the previous probabilities.
+	clock.now = clock.now.Add(10 * time.Second)
+	store.calculate()
+	resp, err = store.GetSamplingStrategy(context.Background(), "svc")
+	require.NoError(t, err)
+	assert.InDelta(t, 0.01, resp.OperationSampling.PerOperationStrategies[0].ProbabilisticSampling.SamplingRate, 1e-9)
+	assert.InDelta(t, 0.2, resp.OperationSampling.PerOperationStrategies[1].ProbabilisticSampling.SamplingRate, 1e-9)
+}
+
+func TestAdaptiveStrategyStore_Bounds(t *testing.T) {
+	store, clock := newTestAdaptiveStore(AdaptiveSamplingConfig{
+		TargetSpansPerSecond:       1,
+		DefaultSamplingProbability: 0.8,
+		MinSamplingProbability:     0.01,
+	})
+
+	store.observe(generateRootSpans("svc", "flood", 250))
+	store.observe(generateRootSpans("svc", "rare", 1))
+	clock.now = clock.now.Add(2 * time.Second)
+	store.calculate()
+
+	resp, err := store.GetSamplingStrategy(context.Background(), "svc")
+	require.NoError(t, err)
+	ops := resp.OperationSampling.PerOperationStrategies
+	require.Len(t, ops, 2)
+	assert.Equal(t, 0.01, ops[0].ProbabilisticSampling.SamplingRate, "probability must not go below the minimum")
+	assert.Equal(t, 1.0, ops[1].ProbabilisticSampling.SamplingRate, "probability must not go above one")
+}
+
+func TestAdaptiveStrategyStore_IgnoresSpansWithoutService(t *testing.T) {
+	store, clock := newTestAdaptiveStore(AdaptiveSamplingConfig{
+		TargetSpansPerSecond:       1,
+		DefaultSamplingProbability: 0.5,
+		MinSamplingProbability:     0.01,
+	})
+
+	td := generateRootSpans("", "op", 10)
+	store.observe(td)
+	td.ResourceSpans().At(0).Resource().Attributes().Delete(conventions.AttributeServiceName)
+	store.observe(td)
+	store.observe(pdata.NewTraces())
+	clock.now = clock.now.Add(time.Second)
+	store.calculate()
+
+	assert.Empty(t, store.probabilities)
+}
+
+func TestAdaptiveStrategyStore_MaxOperations(t *testing.T) {
+	store, clock := newTestAdaptiveStore(AdaptiveSamplingConfig{
+		TargetSpansPerSecond:       1,
+		DefaultSamplingProbability: 0.5,
+		MinSamplingProbability:     0.01,
+	})
+
+	for i := 0; i < maxOperationsPerService+10; i++ {
+		store.record("svc", string(rune('a'+i%26))+time.Duration(i).String())
+	}
+	clock.now = clock.now.Add(time.Second)
+	store.calculate()
+
+	assert.Len(t, store.probabilities["svc"].operations, maxOperationsPerService)
+}
+
+func TestAdaptiveStrategyStore_StartShutdown(t *testing.T) {
+	store := newAdaptiveStrategyStore(AdaptiveSamplingConfig{
+		TargetSpansPerSecond:       1,
+		DefaultSamplingProbability: 0.5,
+		MinSamplingProbability:     0.01,
+		CalculationInterval:        time.Millisecond,
+	}, zap.NewNop())
+	store.start()
+
+	store.observe(generateRootSpans("svc", "op", 10))
+	assert.Eventually(t, func() bool {
+		resp, err := store.GetSamplingStrategy(context.Background(), "svc")
+		return err == nil && resp.OperationSampling != nil
+	}, 5*time.Second, time.Millisecond)
+
+	store.shutdown()
+}
+
+func TestAdaptiveSamplingObserver(t *testing.T) {
+	store, _ := newTestAdaptiveStore(AdaptiveSamplingConfig{})
+	sink := new(exportertest.SinkTraceExporter)
+	observer := &adaptiveSamplingObserver{store: store, nextConsumer: sink}
+
+	require.NoError(t, observer.ConsumeTraces(context.Background(), generateRootSpans("svc", "op", 3)))
+
+	assert.Equal(t, 6, sink.SpansCount())
+	require.Contains(t, store.throughput, "svc")
+	assert.Equal(t, uint64(3), store.throughput["svc"].total)
+	assert.Equal(t, map[string]uint64{"op": 3}, store.throughput["svc"].operations)
+}
@@ -15,6 +15,8 @@
 package jaegerreceiver
 
 import (
+	"time"
+
 	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/config/configmodels"
@@ -29,6 +31,23 @@ type RemoteSamplingConfig struct {
 	HostEndpoint                  string `mapstructure:"host_endpoint"`
 	StrategyFile                  string `mapstructure:"strategy_file"`
 	configgrpc.GRPCClientSettings `mapstructure:",squash"`
+	// Adaptive, when set, makes the receiver compute sampling strategies from
+	// the span throughput it observes instead of proxying or serving a file.
+	Adaptive *AdaptiveSamplingConfig `mapstructure:"adaptive"`
+}
+
+// AdaptiveSamplingConfig defines how adaptive sampling probabilities are computed.
+type AdaptiveSamplingConfig struct {
+	// TargetSpansPerSecond is the number of sampled root spans per second
+	// that each service and operation should produce.
+	TargetSpansPerSecond float64 `mapstructure:"target_spans_per_second"`
+	// DefaultSamplingProbability is served for services and operations for
+	// which no throughput has been observed yet.
+	DefaultSamplingProbability float64 `mapstructure:"default_sampling_probability"`
+	// MinSamplingProbability is the lowest probability that is ever served.
+	MinSamplingProbability float64 `mapstructure:"min_sampling_probability"`
+	// CalculationInterval is how often the probabilities are recomputed.
+	CalculationInterval time.Duration `mapstructure:"calculation_interval"`
 }
 
 type Protocols struct {
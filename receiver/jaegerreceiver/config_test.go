@@ -17,6 +17,7 @@ package jaegerreceiver
 import (
 	"path"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -41,7 +42,7 @@ func TestLoadConfig(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, cfg)
 
-	assert.Equal(t, len(cfg.Receivers), 4)
+	assert.Equal(t, len(cfg.Receivers), 5)
 
 	r1 := cfg.Receivers["jaeger/customname"].(*Config)
 	assert.Equal(t, r1,
@@ -148,6 +149,31 @@ func TestLoadConfig(t *testing.T) {
 				},
 			},
 		})
+
+	rAdaptive := cfg.Receivers["jaeger/adaptive"].(*Config)
+	assert.Equal(t, rAdaptive,
+		&Config{
+			ReceiverSettings: configmodels.ReceiverSettings{
+				TypeVal: typeStr,
+				NameVal: "jaeger/adaptive",
+			},
+			Protocols: Protocols{
+				GRPC: &configgrpc.GRPCServerSettings{
+					NetAddr: confignet.NetAddr{
+						Endpoint:  defaultGRPCBindEndpoint,
+						Transport: "tcp",
+					},
+				},
+			},
+			RemoteSampling: &RemoteSamplingConfig{
+				Adaptive: &AdaptiveSamplingConfig{
+					TargetSpansPerSecond:       10,
+					DefaultSamplingProbability: 0.01,
+					MinSamplingProbability:     0.0001,
+					CalculationInterval:        30 * time.Second,
+				},
+			},
+		})
 }
 
 func TestFailedLoadConfig(t *testing.T) {
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"net"
 	"strconv"
+	"time"
 
 	"github.com/spf13/viper"
 
@@ -49,6 +50,12 @@ const (
 	defaultThriftCompactBindEndpoint   = "0.0.0.0:6831"
 	defaultThriftBinaryBindEndpoint    = "0.0.0.0:6832"
 	defaultAgentRemoteSamplingHTTPPort = 5778
+
+	// Defaults for adaptive remote sampling.
+	defaultAdaptiveTargetSpansPerSecond        = 1.0
+	defaultAdaptiveSamplingProbability         = 0.001
+	defaultAdaptiveMinSamplingProbability      = 0.00001
+	defaultAdaptiveSamplingCalculationInterval = time.Minute
 )
 
 func NewFactory() component.ReceiverFactory {
@@ -193,9 +200,25 @@ func createTraceReceiver(
 	}
 
 	if remoteSamplingConfig != nil {
-		config.RemoteSamplingClientSettings = remoteSamplingConfig.GRPCClientSettings
-		if len(config.RemoteSamplingClientSettings.Endpoint) == 0 {
-			config.RemoteSamplingClientSettings.Endpoint = defaultGRPCBindEndpoint
+		if remoteSamplingConfig.Adaptive != nil {
+			// adaptive strategies are computed locally so there is nothing to proxy to or load
+			if len(remoteSamplingConfig.StrategyFile) != 0 {
+				return nil, fmt.Errorf("adaptive sampling cannot be combined with a strategy file")
+			}
+			if len(remoteSamplingConfig.Endpoint) != 0 {
+				return nil, fmt.Errorf("adaptive sampling cannot be combined with a remote sampling endpoint")
+			}
+
+			adaptiveCfg, err := adaptiveSamplingWithDefaults(*remoteSamplingConfig.Adaptive)
+			if err != nil {
+				return nil, err
+			}
+			config.AdaptiveSampling = &adaptiveCfg
+		} else {
+			config.RemoteSamplingClientSettings = remoteSamplingConfig.GRPCClientSettings
+			if len(config.RemoteSamplingClientSettings.Endpoint) == 0 {
+				config.RemoteSamplingClientSettings.Endpoint = defaultGRPCBindEndpoint
+			}
 		}
 
 		if len(remoteSamplingConfig.HostEndpoint) == 0 {
@@ -234,6 +257,37 @@ func createTraceReceiver(
 	return New(rCfg.Name(), &config, nextConsumer, params)
 }
 
+// adaptiveSamplingWithDefaults fills the unset fields of the adaptive sampling
+// config with their defaults and validates the result.
+func adaptiveSamplingWithDefaults(cfg AdaptiveSamplingConfig) (AdaptiveSamplingConfig, error) {
+	if cfg.TargetSpansPerSecond == 0 {
+		cfg.TargetSpansPerSecond = defaultAdaptiveTargetSpansPerSecond
+	}
+	if cfg.DefaultSamplingProbability == 0 {
+		cfg.DefaultSamplingProbability = defaultAdaptiveSamplingProbability
+	}
+	if cfg.MinSamplingProbability == 0 {
+		cfg.MinSamplingProbability = defaultAdaptiveMinSamplingProbability
+	}
+	if cfg.CalculationInterval == 0 {
+		cfg.CalculationInterval = defaultAdaptiveSamplingCalculationInterval
+	}
+
+	if cfg.TargetSpansPerSecond < 0 {
+		return cfg, fmt.Errorf("adaptive sampling target_spans_per_second must be positive")
+	}
+	if cfg.CalculationInterval < 0 {
+		return cfg, fmt.Errorf("adaptive sampling calculation_interval must be positive")
+	}
+	if cfg.MinSamplingProbability < 0 || cfg.MinSamplingProbability > 1 {
+		return cfg, fmt.Errorf("adaptive sampling min_sampling_probability must be between 0 and 1")
+	}
+	if cfg.DefaultSamplingProbability < cfg.MinSamplingProbability || cfg.DefaultSamplingProbability > 1 {
+		return cfg, fmt.Errorf("adaptive sampling default_sampling_probability must be between min_sampling_probability and 1")
+	}
+	return cfg, nil
+}
+
 // extract the port number from string in "address:port" format. If the
 // port number cannot be extracted returns an error.
 func extractPortFromEndpoint(endpoint string) (int, error) {
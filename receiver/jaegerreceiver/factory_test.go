@@ -18,8 +18,10 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/component"
@@ -322,3 +324,91 @@ func TestCustomUnmarshalErrors(t *testing.T) {
 	err = fu.Unmarshal(config.NewViper(), &RemoteSamplingConfig{})
 	assert.Error(t, err, "should not have been able to marshal to a non-jaegerreceiver config")
 }
+
+func TestAdaptiveSamplingConfigPropagation(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	rCfg := cfg.(*Config)
+
+	rCfg.RemoteSampling = &RemoteSamplingConfig{
+		Adaptive: &AdaptiveSamplingConfig{
+			TargetSpansPerSecond: 5,
+		},
+	}
+	params := component.ReceiverCreateParams{Logger: zap.NewNop()}
+	r, err := factory.CreateTraceReceiver(context.Background(), params, cfg, nil)
+	require.NoError(t, err, "create trace receiver should not error")
+
+	jr := r.(*jReceiver)
+	assert.Empty(t, jr.config.RemoteSamplingClientSettings.Endpoint, "adaptive sampling must not proxy")
+	assert.Equal(t, defaultAgentRemoteSamplingHTTPPort, jr.config.AgentHTTPPort, "agent http port should be default")
+	assert.Equal(t, &AdaptiveSamplingConfig{
+		TargetSpansPerSecond:       5,
+		DefaultSamplingProbability: defaultAdaptiveSamplingProbability,
+		MinSamplingProbability:     defaultAdaptiveMinSamplingProbability,
+		CalculationInterval:        defaultAdaptiveSamplingCalculationInterval,
+	}, jr.config.AdaptiveSampling)
+	assert.NotNil(t, jr.adaptiveSampling)
+}
+
+func TestAdaptiveSamplingInvalidConfig(t *testing.T) {
+	tests := []struct {
+		name           string
+		remoteSampling *RemoteSamplingConfig
+	}{
+		{
+			name: "with strategy file",
+			remoteSampling: &RemoteSamplingConfig{
+				StrategyFile: "strategies.json",
+				Adaptive:     &AdaptiveSamplingConfig{},
+			},
+		},
+		{
+			name: "with remote endpoint",
+			remoteSampling: &RemoteSamplingConfig{
+				GRPCClientSettings: configgrpc.GRPCClientSettings{
+					Endpoint: "localhost:1234",
+				},
+				Adaptive: &AdaptiveSamplingConfig{},
+			},
+		},
+		{
+			name: "negative target",
+			remoteSampling: &RemoteSamplingConfig{
+				Adaptive: &AdaptiveSamplingConfig{TargetSpansPerSecond: -1},
+			},
+		},
+		{
+			name: "negative calculation interval",
+			remoteSampling: &RemoteSamplingConfig{
+				Adaptive: &AdaptiveSamplingConfig{CalculationInterval: -time.Second},
+			},
+		},
+		{
+			name: "min probability above one",
+			remoteSampling: &RemoteSamplingConfig{
+				Adaptive: &AdaptiveSamplingConfig{MinSamplingProbability: 2},
+			},
+		},
+		{
+			name: "default probability below min",
+			remoteSampling: &RemoteSamplingConfig{
+				Adaptive: &AdaptiveSamplingConfig{
+					DefaultSamplingProbability: 0.001,
+					MinSamplingProbability:     0.01,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+			cfg.(*Config).RemoteSampling = tt.remoteSampling
+			params := component.ReceiverCreateParams{Logger: zap.NewNop()}
+			_, err := factory.CreateTraceReceiver(context.Background(), params, cfg, nil)
+			assert.Error(t, err, "create trace receiver should error")
+		})
+	}
+}
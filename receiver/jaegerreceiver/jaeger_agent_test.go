@@ -16,6 +16,7 @@ package jaegerreceiver
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net"
 	"net/http"
@@ -24,6 +25,7 @@ import (
 
 	"contrib.go.opencensus.io/exporter/jaeger"
 	"github.com/jaegertracing/jaeger/proto-gen/api_v2"
+	"github.com/jaegertracing/jaeger/thrift-gen/sampling"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
@@ -131,6 +133,54 @@ func (*mockSamplingHandler) GetSamplingStrategy(context.Context, *api_v2.Samplin
 	return &api_v2.SamplingStrategyResponse{StrategyType: api_v2.SamplingStrategyType_PROBABILISTIC}, nil
 }
 
+func TestJaegerHTTPAdaptiveSampling(t *testing.T) {
+	port := testutil.GetAvailablePort(t)
+	config := &Configuration{
+		AgentHTTPPort: int(port),
+		AdaptiveSampling: &AdaptiveSamplingConfig{
+			TargetSpansPerSecond:       1,
+			DefaultSamplingProbability: 0.5,
+			MinSamplingProbability:     0.001,
+			CalculationInterval:        time.Hour,
+		},
+	}
+	params := component.ReceiverCreateParams{Logger: zap.NewNop()}
+	sink := new(exportertest.SinkTraceExporter)
+	r, err := New(jaegerAgent, config, sink, params)
+	assert.NoError(t, err, "Failed to create new Jaeger Receiver")
+	defer r.Shutdown(context.Background())
+
+	err = r.Start(context.Background(), componenttest.NewNopHost())
+	assert.NoError(t, err, "Start failed")
+
+	// allow http server to start
+	err = testutil.WaitForPort(t, port)
+	assert.NoError(t, err, "WaitForPort failed")
+
+	jr := r.(*jReceiver)
+	require.NoError(t, jr.nextConsumer.ConsumeTraces(context.Background(), generateRootSpans("test", "op", 10)))
+	assert.Equal(t, 20, sink.SpansCount())
+	jr.adaptiveSampling.now = func() time.Time { return jr.adaptiveSampling.lastCalculation.Add(time.Second) }
+	jr.adaptiveSampling.calculate()
+
+	resp, err := http.Get(fmt.Sprintf("http://localhost:%d/sampling?service=test", port))
+	require.NoError(t, err, "should not have failed to make request")
+	defer resp.Body.Close()
+	assert.Equal(t, 200, resp.StatusCode, "should have returned 200")
+
+	var strategy sampling.SamplingStrategyResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&strategy))
+	require.NotNil(t, strategy.OperationSampling)
+	require.Len(t, strategy.OperationSampling.PerOperationStrategies, 1)
+	assert.Equal(t, "op", strategy.OperationSampling.PerOperationStrategies[0].Operation)
+	assert.InDelta(t, 0.05, strategy.OperationSampling.PerOperationStrategies[0].ProbabilisticSampling.SamplingRate, 1e-9)
+
+	resp, err = http.Get(fmt.Sprintf("http://localhost:%d/baggageRestrictions?service=test", port))
+	require.NoError(t, err, "should not have failed to make request")
+	defer resp.Body.Close()
+	assert.Equal(t, 200, resp.StatusCode, "should have returned 200")
+}
+
 func TestJaegerHTTP(t *testing.T) {
 	s, addr := initializeGRPCTestServer(t, func(s *grpc.Server) {
 		api_v2.RegisterSamplingManagerServer(s, &mockSamplingHandler{})
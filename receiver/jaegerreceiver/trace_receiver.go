@@ -33,6 +33,7 @@ import (
 	"github.com/jaegertracing/jaeger/cmd/agent/app/servers/thriftudp"
 	"github.com/jaegertracing/jaeger/cmd/collector/app/handler"
 	collectorSampling "github.com/jaegertracing/jaeger/cmd/collector/app/sampling"
+	"github.com/jaegertracing/jaeger/cmd/collector/app/sampling/strategystore"
 	staticStrategyStore "github.com/jaegertracing/jaeger/plugin/sampling/strategystore/static"
 	"github.com/jaegertracing/jaeger/proto-gen/api_v2"
 	"github.com/jaegertracing/jaeger/thrift-gen/agent"
@@ -66,6 +67,7 @@ type Configuration struct {
 	AgentHTTPPort                int
 	RemoteSamplingClientSettings configgrpc.GRPCClientSettings
 	RemoteSamplingStrategyFile   string
+	AdaptiveSampling             *AdaptiveSamplingConfig
 }
 
 // Receiver type is used to receive spans that were originally intended to be sent to Jaeger.
@@ -86,6 +88,7 @@ type jReceiver struct {
 	collectorServer *http.Server
 
 	agentSamplingManager *jSamplingConfig.SamplingManager
+	adaptiveSampling     *adaptiveStrategyStore
 	agentProcessors      []processors.Processor
 	agentServer          *http.Server
 
@@ -125,12 +128,20 @@ func New(
 	nextConsumer consumer.TraceConsumer,
 	params component.ReceiverCreateParams,
 ) (component.TraceReceiver, error) {
-	return &jReceiver{
+	jr := &jReceiver{
 		config:       config,
 		nextConsumer: nextConsumer,
 		instanceName: instanceName,
 		logger:       params.Logger,
-	}, nil
+	}
+	if config != nil && config.AdaptiveSampling != nil {
+		jr.adaptiveSampling = newAdaptiveStrategyStore(*config.AdaptiveSampling, params.Logger)
+		jr.nextConsumer = &adaptiveSamplingObserver{
+			store:        jr.adaptiveSampling,
+			nextConsumer: nextConsumer,
+		}
+	}
+	return jr, nil
 }
 
 func (jr *jReceiver) agentCompactThriftAddr() string {
@@ -199,6 +210,10 @@ func (jr *jReceiver) Start(_ context.Context, host component.Host) error {
 
 	var err = componenterror.ErrAlreadyStarted
 	jr.startOnce.Do(func() {
+		if jr.adaptiveSampling != nil {
+			jr.adaptiveSampling.start()
+		}
+
 		if err = jr.startAgent(host); err != nil && err != componenterror.ErrAlreadyStarted {
 			return
 		}
@@ -239,6 +254,9 @@ func (jr *jReceiver) Shutdown(context.Context) error {
 			jr.grpc.Stop()
 			jr.grpc = nil
 		}
+		if jr.adaptiveSampling != nil {
+			jr.adaptiveSampling.shutdown()
+		}
 		err = componenterror.CombineErrors(errs)
 	})
 
@@ -280,10 +298,16 @@ func (h *agentHandler) EmitBatch(ctx context.Context, batch *jaeger.Batch) error
 }
 
 func (jr *jReceiver) GetSamplingStrategy(ctx context.Context, serviceName string) (*sampling.SamplingStrategyResponse, error) {
+	if jr.adaptiveSampling != nil {
+		return jr.adaptiveSampling.GetSamplingStrategy(ctx, serviceName)
+	}
 	return jr.agentSamplingManager.GetSamplingStrategy(ctx, serviceName)
 }
 
 func (jr *jReceiver) GetBaggageRestrictions(ctx context.Context, serviceName string) ([]*baggage.BaggageRestriction, error) {
+	if jr.agentSamplingManager == nil {
+		return nil, nil
+	}
 	br, err := jr.agentSamplingManager.GetBaggageRestrictions(ctx, serviceName)
 	if err != nil {
 		// Baggage restrictions are not yet implemented - refer to - https://github.com/jaegertracing/jaeger/issues/373
@@ -490,11 +514,14 @@ func (jr *jReceiver) startCollector(host component.Host) error {
 		api_v2.RegisterCollectorServiceServer(jr.grpc, jr)
 
 		// init and register sampling strategy store
-		ss, gerr := staticStrategyStore.NewStrategyStore(staticStrategyStore.Options{
-			StrategiesFile: jr.config.RemoteSamplingStrategyFile,
-		}, jr.logger)
-		if gerr != nil {
-			return fmt.Errorf("failed to create collector strategy store: %v", gerr)
+		var ss strategystore.StrategyStore = jr.adaptiveSampling
+		if jr.adaptiveSampling == nil {
+			ss, gerr = staticStrategyStore.NewStrategyStore(staticStrategyStore.Options{
+				StrategiesFile: jr.config.RemoteSamplingStrategyFile,
+			}, jr.logger)
+			if gerr != nil {
+				return fmt.Errorf("failed to create collector strategy store: %v", gerr)
+			}
 		}
 		api_v2.RegisterSamplingManagerServer(jr.grpc, collectorSampling.NewGRPCHandler(ss))
 
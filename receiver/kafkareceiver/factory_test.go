@@ -0,0 +1,57 @@
+// Copyright 2020 The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkareceiver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configcheck"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.NotNil(t, cfg, "failed to create default config")
+	assert.NoError(t, configcheck.ValidateConfig(cfg))
+	assert.Equal(t, []string{defaultBroker}, cfg.Brokers)
+	assert.Equal(t, defaultTopic, cfg.Topic)
+	assert.Equal(t, defaultGroupID, cfg.GroupID)
+}
+
+func TestCreateTraceReceiver(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Brokers = []string{"invalid:9092"}
+	cfg.ProtocolVersion = "2.0.0"
+	// this disables contacting the broker so we can successfully create the receiver
+	cfg.Metadata.Full = false
+	r, err := createTraceReceiver(context.Background(), component.ReceiverCreateParams{}, cfg, exportertest.NewNopTraceExporter())
+	require.NoError(t, err)
+	assert.NotNil(t, r)
+}
+
+func TestCreateTraceReceiver_error(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Brokers = []string{"invalid:9092"}
+	cfg.ProtocolVersion = "2.0.0"
+	// we get the error because the receiver tries to fetch the metadata from the invalid broker
+	r, err := createTraceReceiver(context.Background(), component.ReceiverCreateParams{}, cfg, exportertest.NewNopTraceExporter())
+	require.Error(t, err)
+	assert.Nil(t, r)
+}
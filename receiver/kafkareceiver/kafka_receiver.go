@@ -0,0 +1,198 @@
+// Copyright 2020 The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkareceiver
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/obsreport"
+)
+
+const (
+	transport = "kafka"
+
+	// initialRejoinBackoff and maxRejoinBackoff bound the time waited before leaving the
+	// session after the next consumer failed, see consumerGroupHandler.rejoinBackoff.
+	initialRejoinBackoff = 100 * time.Millisecond
+	maxRejoinBackoff     = 30 * time.Second
+)
+
+// kafkaConsumer uses sarama to consume and handle messages from kafka.
+type kafkaConsumer struct {
+	name              string
+	consumerGroup     sarama.ConsumerGroup
+	nextConsumer      consumer.TraceConsumer
+	topics            []string
+	cancelConsumeLoop context.CancelFunc
+	consumeLoopWG     sync.WaitGroup
+	unmarshaller      unmarshaller
+
+	logger *zap.Logger
+}
+
+var _ component.Receiver = (*kafkaConsumer)(nil)
+
+func newReceiver(config Config, params component.ReceiverCreateParams, nextConsumer consumer.TraceConsumer) (*kafkaConsumer, error) {
+	c := sarama.NewConfig()
+	c.Metadata.Full = config.Metadata.Full
+	c.Metadata.Retry.Max = config.Metadata.Retry.Max
+	c.Metadata.Retry.Backoff = config.Metadata.Retry.Backoff
+	if config.ProtocolVersion != "" {
+		version, err := sarama.ParseKafkaVersion(config.ProtocolVersion)
+		if err != nil {
+			return nil, err
+		}
+		c.Version = version
+	}
+	client, err := sarama.NewConsumerGroup(config.Brokers, config.GroupID, c)
+	if err != nil {
+		return nil, err
+	}
+	return &kafkaConsumer{
+		name:          config.Name(),
+		consumerGroup: client,
+		topics:        []string{config.Topic},
+		nextConsumer:  nextConsumer,
+		unmarshaller:  &protoUnmarshaller{},
+		logger:        params.Logger,
+	}, nil
+}
+
+func (c *kafkaConsumer) Start(context.Context, component.Host) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	c.cancelConsumeLoop = cancel
+	consumerGroup := &consumerGroupHandler{
+		name:           c.name,
+		logger:         c.logger,
+		unmarshaller:   c.unmarshaller,
+		nextConsumer:   c.nextConsumer,
+		initialBackoff: initialRejoinBackoff,
+		maxBackoff:     maxRejoinBackoff,
+	}
+	c.consumeLoopWG.Add(1)
+	go c.consumeLoop(ctx, consumerGroup)
+	return nil
+}
+
+func (c *kafkaConsumer) consumeLoop(ctx context.Context, handler sarama.ConsumerGroupHandler) {
+	defer c.consumeLoopWG.Done()
+	for {
+		// Consume returns when the session ends, e.g. on a rebalance or when the handler
+		// fails, in which case the group is joined again and the messages that were not
+		// marked are consumed again.
+		if err := c.consumerGroup.Consume(ctx, c.topics, handler); err != nil {
+			c.logger.Error("Error from consumer", zap.Error(err))
+		}
+		// check if context was cancelled, signaling that the consumer should stop
+		if ctx.Err() != nil {
+			c.logger.Info("Consumer stopped", zap.Error(ctx.Err()))
+			return
+		}
+	}
+}
+
+func (c *kafkaConsumer) Shutdown(context.Context) error {
+	if c.cancelConsumeLoop != nil {
+		c.cancelConsumeLoop()
+	}
+	err := c.consumerGroup.Close()
+	c.consumeLoopWG.Wait()
+	return err
+}
+
+type consumerGroupHandler struct {
+	name         string
+	unmarshaller unmarshaller
+	nextConsumer consumer.TraceConsumer
+
+	// initialBackoff and maxBackoff bound the time waited after the next consumer failed.
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	// failures is the number of consecutive failures of the next consumer, accessed atomically.
+	failures int64
+
+	logger *zap.Logger
+}
+
+var _ sarama.ConsumerGroupHandler = (*consumerGroupHandler)(nil)
+
+func (c *consumerGroupHandler) Setup(session sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+func (c *consumerGroupHandler) Cleanup(session sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+func (c *consumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	c.logger.Info("Starting consumer group", zap.Int32("partition", claim.Partition()))
+	for message := range claim.Messages() {
+		c.logger.Debug("Kafka message claimed",
+			zap.String("topic", message.Topic),
+			zap.Int32("partition", message.Partition),
+			zap.Int64("offset", message.Offset),
+			zap.Time("timestamp", message.Timestamp))
+
+		ctx := obsreport.ReceiverContext(session.Context(), c.name, transport, c.name)
+		ctx = obsreport.StartTraceDataReceiveOp(ctx, c.name, transport)
+		traces, err := c.unmarshaller.Unmarshal(message.Value)
+		if err != nil {
+			// The message can never be decoded, mark it so it is not consumed again.
+			c.logger.Error("Failed to unmarshall message", zap.Error(err))
+			obsreport.EndTraceDataReceiveOp(ctx, c.unmarshaller.Encoding(), 0, err)
+			session.MarkMessage(message, "")
+			continue
+		}
+
+		err = c.nextConsumer.ConsumeTraces(ctx, traces)
+		obsreport.EndTraceDataReceiveOp(ctx, c.unmarshaller.Encoding(), traces.SpanCount(), err)
+		if err != nil {
+			// Do not mark the message, it is consumed again when the group is joined again.
+			// Wait before ending the session, so that a failing next consumer does not make
+			// the group rebalance in a tight loop.
+			select {
+			case <-time.After(c.rejoinBackoff()):
+			case <-session.Context().Done():
+			}
+			return err
+		}
+		atomic.StoreInt64(&c.failures, 0)
+		// The offset is committed only after the next consumer accepted the data.
+		session.MarkMessage(message, "")
+	}
+	return nil
+}
+
+// rejoinBackoff records a failure of the next consumer and returns the time to wait before
+// the group is joined again. It doubles for each consecutive failure, up to maxBackoff.
+func (c *consumerGroupHandler) rejoinBackoff() time.Duration {
+	failures := atomic.AddInt64(&c.failures, 1)
+	backoff := c.initialBackoff
+	for i := int64(1); i < failures && backoff < c.maxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > c.maxBackoff {
+		backoff = c.maxBackoff
+	}
+	return backoff
+}
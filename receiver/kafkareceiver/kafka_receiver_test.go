@@ -0,0 +1,300 @@
+// Copyright 2020 The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkareceiver
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	otlptrace "go.opentelemetry.io/collector/internal/data/opentelemetry-proto-gen/collector/trace/v1"
+	"go.opentelemetry.io/collector/internal/data/testdata"
+)
+
+func TestNewReceiver_version_err(t *testing.T) {
+	c := Config{
+		ProtocolVersion: "none",
+	}
+	r, err := newReceiver(c, component.ReceiverCreateParams{}, exportertest.NewNopTraceExporter())
+	assert.Error(t, err)
+	assert.Nil(t, r)
+}
+
+func TestReceiverStart(t *testing.T) {
+	testClient := &testConsumerGroup{consumed: make(chan struct{}, 1)}
+	c := kafkaConsumer{
+		nextConsumer:  exportertest.NewNopTraceExporter(),
+		logger:        zap.NewNop(),
+		consumerGroup: testClient,
+	}
+
+	require.NoError(t, c.Start(context.Background(), componenttest.NewNopHost()))
+	<-testClient.consumed
+	require.NoError(t, c.Shutdown(context.Background()))
+	assert.True(t, testClient.closed)
+}
+
+func TestReceiverStartConsume(t *testing.T) {
+	testClient := &testConsumerGroup{consumed: make(chan struct{}, 1)}
+	c := kafkaConsumer{
+		nextConsumer:  exportertest.NewNopTraceExporter(),
+		logger:        zap.NewNop(),
+		consumerGroup: testClient,
+	}
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	c.cancelConsumeLoop = cancelFunc
+	c.consumeLoopWG.Add(1)
+	go c.consumeLoop(ctx, &consumerGroupHandler{})
+	<-testClient.consumed
+	<-testClient.consumed
+	require.NoError(t, c.Shutdown(context.Background()))
+}
+
+func TestConsumerGroupHandler(t *testing.T) {
+	sink := &exportertest.SinkTraceExporter{}
+	c := consumerGroupHandler{
+		unmarshaller: &protoUnmarshaller{},
+		logger:       zap.NewNop(),
+		nextConsumer: sink,
+	}
+
+	testSession := &testConsumerGroupSession{}
+	require.NoError(t, c.Setup(testSession))
+
+	groupClaim := &testConsumerGroupClaim{
+		messageChan: make(chan *sarama.ConsumerMessage),
+	}
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		assert.NoError(t, c.ConsumeClaim(testSession, groupClaim))
+		wg.Done()
+	}()
+
+	td := testdata.GenerateTraceDataTwoSpansSameResource()
+	groupClaim.messageChan <- &sarama.ConsumerMessage{Offset: 1, Value: marshalTraces(t, td)}
+	close(groupClaim.messageChan)
+	wg.Wait()
+
+	assert.Equal(t, 2, sink.SpansCount())
+	assert.Equal(t, []int64{1}, testSession.markedOffsets())
+	require.NoError(t, c.Cleanup(testSession))
+}
+
+func TestConsumerGroupHandler_error_unmarshall(t *testing.T) {
+	sink := &exportertest.SinkTraceExporter{}
+	c := consumerGroupHandler{
+		unmarshaller: &protoUnmarshaller{},
+		logger:       zap.NewNop(),
+		nextConsumer: sink,
+	}
+
+	testSession := &testConsumerGroupSession{}
+	groupClaim := &testConsumerGroupClaim{
+		messageChan: make(chan *sarama.ConsumerMessage),
+	}
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		assert.NoError(t, c.ConsumeClaim(testSession, groupClaim))
+		wg.Done()
+	}()
+
+	groupClaim.messageChan <- &sarama.ConsumerMessage{Offset: 1, Value: []byte("!@#")}
+	groupClaim.messageChan <- &sarama.ConsumerMessage{Offset: 2, Value: marshalTraces(t, testdata.GenerateTraceDataOneSpan())}
+	close(groupClaim.messageChan)
+	wg.Wait()
+
+	// The message that cannot be decoded is skipped.
+	assert.Equal(t, 1, sink.SpansCount())
+	assert.Equal(t, []int64{1, 2}, testSession.markedOffsets())
+}
+
+func TestConsumerGroupHandler_error_nextConsumer(t *testing.T) {
+	sink := &exportertest.SinkTraceExporter{}
+	consumerError := fmt.Errorf("failed to consume")
+	sink.SetConsumeTraceError(consumerError)
+	c := consumerGroupHandler{
+		unmarshaller:   &protoUnmarshaller{},
+		logger:         zap.NewNop(),
+		nextConsumer:   sink,
+		initialBackoff: 20 * time.Millisecond,
+		maxBackoff:     time.Second,
+	}
+
+	testSession := &testConsumerGroupSession{}
+	groupClaim := &testConsumerGroupClaim{
+		messageChan: make(chan *sarama.ConsumerMessage, 1),
+	}
+	groupClaim.messageChan <- &sarama.ConsumerMessage{Offset: 1, Value: marshalTraces(t, testdata.GenerateTraceDataOneSpan())}
+
+	start := time.Now()
+	err := c.ConsumeClaim(testSession, groupClaim)
+	assert.EqualError(t, err, consumerError.Error())
+	// The session only ends after the backoff.
+	assert.GreaterOrEqual(t, int64(time.Since(start)), int64(20*time.Millisecond))
+	// The message is not marked so it is consumed again.
+	assert.Empty(t, testSession.markedOffsets())
+}
+
+func TestConsumerGroupHandler_rejoinBackoff(t *testing.T) {
+	sink := &exportertest.SinkTraceExporter{}
+	c := consumerGroupHandler{
+		unmarshaller:   &protoUnmarshaller{},
+		logger:         zap.NewNop(),
+		nextConsumer:   sink,
+		initialBackoff: 100 * time.Millisecond,
+		maxBackoff:     300 * time.Millisecond,
+	}
+
+	assert.Equal(t, 100*time.Millisecond, c.rejoinBackoff())
+	assert.Equal(t, 200*time.Millisecond, c.rejoinBackoff())
+	assert.Equal(t, 300*time.Millisecond, c.rejoinBackoff())
+	assert.Equal(t, 300*time.Millisecond, c.rejoinBackoff())
+
+	// A message accepted by the next consumer resets the backoff.
+	groupClaim := &testConsumerGroupClaim{
+		messageChan: make(chan *sarama.ConsumerMessage, 1),
+	}
+	groupClaim.messageChan <- &sarama.ConsumerMessage{Offset: 1, Value: marshalTraces(t, testdata.GenerateTraceDataOneSpan())}
+	close(groupClaim.messageChan)
+	require.NoError(t, c.ConsumeClaim(&testConsumerGroupSession{}, groupClaim))
+	assert.Equal(t, 100*time.Millisecond, c.rejoinBackoff())
+}
+
+func marshalTraces(t *testing.T, td pdata.Traces) []byte {
+	request := &otlptrace.ExportTraceServiceRequest{
+		ResourceSpans: pdata.TracesToOtlp(td),
+	}
+	bts, err := request.Marshal()
+	require.NoError(t, err)
+	return bts
+}
+
+type testConsumerGroupClaim struct {
+	messageChan chan *sarama.ConsumerMessage
+}
+
+var _ sarama.ConsumerGroupClaim = (*testConsumerGroupClaim)(nil)
+
+const (
+	testTopic               = "otlp_spans"
+	testPartition           = 5
+	testInitialOffset       = 6
+	testHighWatermarkOffset = 4
+)
+
+func (t testConsumerGroupClaim) Topic() string {
+	return testTopic
+}
+
+func (t testConsumerGroupClaim) Partition() int32 {
+	return testPartition
+}
+
+func (t testConsumerGroupClaim) InitialOffset() int64 {
+	return testInitialOffset
+}
+
+func (t testConsumerGroupClaim) HighWaterMarkOffset() int64 {
+	return testHighWatermarkOffset
+}
+
+func (t testConsumerGroupClaim) Messages() <-chan *sarama.ConsumerMessage {
+	return t.messageChan
+}
+
+type testConsumerGroupSession struct {
+	mu     sync.Mutex
+	marked []int64
+}
+
+var _ sarama.ConsumerGroupSession = (*testConsumerGroupSession)(nil)
+
+func (t *testConsumerGroupSession) markedOffsets() []int64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.marked
+}
+
+func (t *testConsumerGroupSession) Claims() map[string][]int32 {
+	panic("implement me")
+}
+
+func (t *testConsumerGroupSession) MemberID() string {
+	panic("implement me")
+}
+
+func (t *testConsumerGroupSession) GenerationID() int32 {
+	panic("implement me")
+}
+
+func (t *testConsumerGroupSession) MarkOffset(string, int32, int64, string) {
+	panic("implement me")
+}
+
+func (t *testConsumerGroupSession) ResetOffset(string, int32, int64, string) {
+	panic("implement me")
+}
+
+func (t *testConsumerGroupSession) MarkMessage(msg *sarama.ConsumerMessage, _ string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.marked = append(t.marked, msg.Offset)
+}
+
+func (t *testConsumerGroupSession) Context() context.Context {
+	return context.Background()
+}
+
+type testConsumerGroup struct {
+	consumed chan struct{}
+	closed   bool
+}
+
+var _ sarama.ConsumerGroup = (*testConsumerGroup)(nil)
+
+func (t *testConsumerGroup) Consume(ctx context.Context, _ []string, _ sarama.ConsumerGroupHandler) error {
+	select {
+	case t.consumed <- struct{}{}:
+	case <-ctx.Done():
+	}
+	// Simulate the end of a session.
+	select {
+	case <-time.After(10 * time.Millisecond):
+	case <-ctx.Done():
+	}
+	return nil
+}
+
+func (t *testConsumerGroup) Errors() <-chan error {
+	panic("implement me")
+}
+
+func (t *testConsumerGroup) Close() error {
+	t.closed = true
+	return nil
+}
@@ -31,4 +31,9 @@ type Config struct {
 
 	// Protocols is the configuration for the supported protocols, currently gRPC and HTTP (Proto and JSON).
 	Protocols `mapstructure:"protocols"`
+
+	// ForwardedHeaders are the HTTP headers of the OTLP/HTTP requests forwarded to the metadata
+	// of the incoming context, e.g. the header used by the routing processor. Other headers, such
+	// as the credentials of the client, are not forwarded.
+	ForwardedHeaders []string `mapstructure:"forwarded_headers"`
 }
@@ -19,6 +19,7 @@ import (
 	"errors"
 	"net"
 	"net/http"
+	"net/textproto"
 	"sync"
 
 	gatewayruntime "github.com/grpc-ecosystem/grpc-gateway/runtime"
@@ -67,12 +68,32 @@ func New(cfg *Config) (*Receiver, error) {
 	if cfg.HTTP != nil {
 		r.gatewayMux = gatewayruntime.NewServeMux(
 			gatewayruntime.WithMarshalerOption("application/x-protobuf", &xProtobufMarshaler{}),
+			gatewayruntime.WithIncomingHeaderMatcher(newIncomingHeaderMatcher(cfg.ForwardedHeaders)),
 		)
 	}
 
 	return r, nil
 }
 
+// newIncomingHeaderMatcher returns a matcher forwarding the given HTTP headers to the metadata of
+// the incoming context, so that they are available to the processors as they are for gRPC
+// requests, e.g. for routing. Other headers are handled by the default matcher.
+func newIncomingHeaderMatcher(headers []string) gatewayruntime.HeaderMatcherFunc {
+	forwarded := make(map[string]struct{}, len(headers))
+	for _, header := range headers {
+		forwarded[textproto.CanonicalMIMEHeaderKey(header)] = struct{}{}
+	}
+	return func(key string) (string, bool) {
+		if mdKey, ok := gatewayruntime.DefaultHeaderMatcher(key); ok {
+			return mdKey, true
+		}
+		if _, ok := forwarded[textproto.CanonicalMIMEHeaderKey(key)]; ok {
+			return key, true
+		}
+		return "", false
+	}
+}
+
 // Start runs the trace receiver on the gRPC server. Currently
 // it also enables the metrics receiver too.
 func (r *Receiver) Start(ctx context.Context, host component.Host) error {
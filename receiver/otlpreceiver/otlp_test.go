@@ -21,6 +21,7 @@ import (
 	"io/ioutil"
 	"net"
 	"net/http"
+	"sync"
 	"testing"
 	"time"
 
@@ -29,6 +30,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
 	"go.opentelemetry.io/collector/component"
@@ -227,6 +229,63 @@ func TestProtoHttp(t *testing.T) {
 
 }
 
+// metadataSink records the metadata of the incoming context of the traces it consumes.
+type metadataSink struct {
+	exportertest.SinkTraceExporter
+	mu sync.Mutex
+	md []metadata.MD
+}
+
+func (ms *metadataSink) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	md, _ := metadata.FromIncomingContext(ctx)
+	ms.mu.Lock()
+	ms.md = append(ms.md, md)
+	ms.mu.Unlock()
+	return ms.SinkTraceExporter.ConsumeTraces(ctx, td)
+}
+
+func TestHTTPHeadersInContext(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+
+	sink := new(metadataSink)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.SetName(otlpReceiver)
+	cfg.HTTP.Endpoint = addr
+	cfg.GRPC = nil
+	cfg.ForwardedHeaders = []string{"x-tenant"}
+	ocr := newReceiver(t, factory, cfg, sink, nil)
+
+	require.NoError(t, ocr.Start(context.Background(), componenttest.NewNopHost()), "Failed to start trace receiver")
+	defer ocr.Shutdown(context.Background())
+
+	// Wait for the servers to start
+	<-time.After(10 * time.Millisecond)
+
+	traceProto := collectortrace.ExportTraceServiceRequest{
+		ResourceSpans: pdata.TracesToOtlp(testdata.GenerateTraceDataOneSpan()),
+	}
+	traceBytes, err := traceProto.Marshal()
+	require.NoError(t, err)
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("http://%s/v1/trace", addr), bytes.NewBuffer(traceBytes))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("X-Tenant", "acme")
+	req.Header.Set("X-Api-Key", "secret")
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, 200, resp.StatusCode)
+
+	sink.mu.Lock()
+	defer sink.mu.Unlock()
+	require.Len(t, sink.md, 1)
+	assert.Equal(t, []string{"acme"}, sink.md[0].Get("x-tenant"))
+	assert.Empty(t, sink.md[0].Get("x-api-key"))
+}
+
 func TestGRPCNewPortAlreadyUsed(t *testing.T) {
 	addr := testutil.GetAvailableLocalAddress(t)
 	ln, err := net.Listen("tcp", addr)
@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scraperhelper
+
+import (
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+)
+
+// CombineScrapeErrors converts a list of errors into one error. If any of the
+// errors is a consumererror.PartialScrapeError, the combined error is a
+// PartialScrapeError with the sum of their failed counts, so the scrapers can
+// report how many metrics failed to be scraped.
+func CombineScrapeErrors(errs []error) error {
+	partialScrapeErr := false
+	for _, err := range errs {
+		if consumererror.IsPartialScrapeError(err) {
+			partialScrapeErr = true
+			break
+		}
+	}
+
+	if !partialScrapeErr {
+		return componenterror.CombineErrors(errs)
+	}
+
+	if len(errs) == 1 {
+		return errs[0]
+	}
+
+	failedScrapeCount := 0
+	errMsgs := make([]string, 0, len(errs))
+	for _, err := range errs {
+		if partialErr, ok := err.(consumererror.PartialScrapeError); ok {
+			failedScrapeCount += partialErr.Failed
+		}
+		errMsgs = append(errMsgs, err.Error())
+	}
+
+	return consumererror.NewPartialScrapeError(fmt.Errorf("[%s]", strings.Join(errMsgs, "; ")), failedScrapeCount)
+}
@@ -0,0 +1,45 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scraperhelper
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
+)
+
+func TestCombineScrapeErrors(t *testing.T) {
+	assert.NoError(t, CombineScrapeErrors(nil))
+
+	err := CombineScrapeErrors([]error{errors.New("foo"), errors.New("bar")})
+	assert.EqualError(t, err, "[foo; bar]")
+	assert.False(t, consumererror.IsPartialScrapeError(err))
+
+	partialErr := consumererror.NewPartialScrapeError(errors.New("foo"), 2)
+	assert.Equal(t, partialErr, CombineScrapeErrors([]error{partialErr}))
+
+	err = CombineScrapeErrors([]error{
+		consumererror.NewPartialScrapeError(errors.New("foo"), 2),
+		errors.New("bar"),
+		consumererror.NewPartialScrapeError(errors.New("baz"), 3),
+	})
+	require.True(t, consumererror.IsPartialScrapeError(err))
+	assert.EqualError(t, err, "[foo; bar; baz]")
+	assert.Equal(t, 5, err.(consumererror.PartialScrapeError).Failed)
+}
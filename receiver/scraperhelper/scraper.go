@@ -0,0 +1,216 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scraperhelper
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/obsreport"
+)
+
+// ScrapeMetrics scrapes metrics.
+type ScrapeMetrics func(context.Context) (pdata.MetricSlice, error)
+
+// ScrapeResourceMetrics scrapes resource metrics.
+type ScrapeResourceMetrics func(context.Context) (pdata.ResourceMetricsSlice, error)
+
+// Initialize performs any timely initialization tasks such as
+// setting up performance counters for initial collection.
+type Initialize func(ctx context.Context) error
+
+// Close should clean up any unmanaged resources such as
+// performance counter handles.
+type Close func(ctx context.Context) error
+
+// ScraperOption apply changes to internal options.
+type ScraperOption func(*baseScraper)
+
+// BaseScraper is the interface shared by all the scrapers run by a scraper
+// controller.
+type BaseScraper interface {
+	// Name returns the scraper name, used to tag its observability metrics.
+	Name() string
+
+	// CollectionInterval returns the interval at which the scraper is run.
+	// Zero means the collection interval of the scraper controller.
+	CollectionInterval() time.Duration
+
+	// Initialize performs any timely initialization tasks such as
+	// setting up performance counters for initial collection.
+	Initialize(ctx context.Context) error
+
+	// Close should clean up any unmanaged resources such as
+	// performance counter handles.
+	Close(ctx context.Context) error
+}
+
+// MetricsScraper is an interface for scrapers that scrape metrics.
+type MetricsScraper interface {
+	BaseScraper
+
+	// Scrape returns the scraped metrics and records the scraper
+	// observability metrics, tagged with the given receiver name. If errors
+	// occur scraping some metrics, an error should be returned, but any
+	// metrics that were successfully scraped should still be returned.
+	Scrape(ctx context.Context, receiverName string) (pdata.MetricSlice, error)
+}
+
+// ResourceMetricsScraper is an interface for scrapers that scrape resource
+// metrics.
+type ResourceMetricsScraper interface {
+	BaseScraper
+
+	// Scrape returns the scraped metrics per resource and records the
+	// scraper observability metrics, tagged with the given receiver name.
+	// If errors occur scraping some metrics, an error should be returned,
+	// but any metrics that were successfully scraped should still be
+	// returned.
+	Scrape(ctx context.Context, receiverName string) (pdata.ResourceMetricsSlice, error)
+}
+
+type baseScraper struct {
+	name               string
+	collectionInterval time.Duration
+	initialize         Initialize
+	close              Close
+}
+
+func (b baseScraper) Name() string {
+	return b.name
+}
+
+func (b baseScraper) CollectionInterval() time.Duration {
+	return b.collectionInterval
+}
+
+func (b baseScraper) Initialize(ctx context.Context) error {
+	if b.initialize == nil {
+		return nil
+	}
+	return b.initialize(ctx)
+}
+
+func (b baseScraper) Close(ctx context.Context) error {
+	if b.close == nil {
+		return nil
+	}
+	return b.close(ctx)
+}
+
+// WithInitialize sets the function that will be called on startup.
+func WithInitialize(initialize Initialize) ScraperOption {
+	return func(o *baseScraper) {
+		o.initialize = initialize
+	}
+}
+
+// WithClose sets the function that will be called on shutdown.
+func WithClose(close Close) ScraperOption {
+	return func(o *baseScraper) {
+		o.close = close
+	}
+}
+
+// WithCollectionInterval overrides the collection interval of the scraper
+// controller for this scraper.
+func WithCollectionInterval(collectionInterval time.Duration) ScraperOption {
+	return func(o *baseScraper) {
+		o.collectionInterval = collectionInterval
+	}
+}
+
+type metricsScraper struct {
+	baseScraper
+	scrapeMetrics ScrapeMetrics
+}
+
+var _ MetricsScraper = (*metricsScraper)(nil)
+
+// NewMetricsScraper creates a MetricsScraper that calls the given scrape
+// function and records the scraper observability metrics for each call.
+func NewMetricsScraper(
+	name string,
+	scrape ScrapeMetrics,
+	options ...ScraperOption,
+) MetricsScraper {
+	ms := &metricsScraper{
+		baseScraper:   baseScraper{name: name},
+		scrapeMetrics: scrape,
+	}
+
+	for _, op := range options {
+		op(&ms.baseScraper)
+	}
+
+	return ms
+}
+
+func (ms metricsScraper) Scrape(ctx context.Context, receiverName string) (pdata.MetricSlice, error) {
+	ctx = obsreport.ScraperContext(ctx, receiverName, ms.Name())
+	ctx = obsreport.StartMetricsScrapeOp(ctx, receiverName, ms.Name())
+	metrics, err := ms.scrapeMetrics(ctx)
+	obsreport.EndMetricsScrapeOp(ctx, metrics.Len(), err)
+	return metrics, err
+}
+
+type resourceMetricsScraper struct {
+	baseScraper
+	scrapeResourceMetrics ScrapeResourceMetrics
+}
+
+var _ ResourceMetricsScraper = (*resourceMetricsScraper)(nil)
+
+// NewResourceMetricsScraper creates a ResourceMetricsScraper that calls the
+// given scrape function and records the scraper observability metrics for
+// each call.
+func NewResourceMetricsScraper(
+	name string,
+	scrape ScrapeResourceMetrics,
+	options ...ScraperOption,
+) ResourceMetricsScraper {
+	rms := &resourceMetricsScraper{
+		baseScraper:           baseScraper{name: name},
+		scrapeResourceMetrics: scrape,
+	}
+
+	for _, op := range options {
+		op(&rms.baseScraper)
+	}
+
+	return rms
+}
+
+func (rms resourceMetricsScraper) Scrape(ctx context.Context, receiverName string) (pdata.ResourceMetricsSlice, error) {
+	ctx = obsreport.ScraperContext(ctx, receiverName, rms.Name())
+	ctx = obsreport.StartMetricsScrapeOp(ctx, receiverName, rms.Name())
+	resourceMetrics, err := rms.scrapeResourceMetrics(ctx)
+	obsreport.EndMetricsScrapeOp(ctx, metricCount(resourceMetrics), err)
+	return resourceMetrics, err
+}
+
+func metricCount(resourceMetrics pdata.ResourceMetricsSlice) int {
+	count := 0
+
+	for i := 0; i < resourceMetrics.Len(); i++ {
+		ilm := resourceMetrics.At(i).InstrumentationLibraryMetrics()
+		for j := 0; j < ilm.Len(); j++ {
+			count += ilm.At(j).Metrics().Len()
+		}
+	}
+
+	return count
+}
@@ -0,0 +1,98 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scraperhelper
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/internal/data/testdata"
+	"go.opentelemetry.io/collector/obsreport/obsreporttest"
+)
+
+func TestNewMetricsScraper(t *testing.T) {
+	doneFn, err := obsreporttest.SetupRecordedMetricsTest()
+	require.NoError(t, err)
+	defer doneFn()
+
+	initialized, closed := false, false
+	scraper := NewMetricsScraper(
+		"test",
+		func(context.Context) (pdata.MetricSlice, error) { return singleMetric(), nil },
+		WithInitialize(func(context.Context) error { initialized = true; return nil }),
+		WithClose(func(context.Context) error { closed = true; return nil }),
+		WithCollectionInterval(time.Second),
+	)
+
+	assert.Equal(t, "test", scraper.Name())
+	assert.Equal(t, time.Second, scraper.CollectionInterval())
+
+	require.NoError(t, scraper.Initialize(context.Background()))
+	assert.True(t, initialized)
+
+	metrics, err := scraper.Scrape(context.Background(), "receiver")
+	require.NoError(t, err)
+	assert.Equal(t, 1, metrics.Len())
+
+	require.NoError(t, scraper.Close(context.Background()))
+	assert.True(t, closed)
+
+	obsreporttest.CheckScraperMetricsViews(t, "receiver", "test", 1, 0, 0)
+}
+
+func TestNewMetricsScraper_Defaults(t *testing.T) {
+	scraper := NewMetricsScraper("test", func(context.Context) (pdata.MetricSlice, error) { return singleMetric(), nil })
+
+	assert.Equal(t, time.Duration(0), scraper.CollectionInterval())
+	assert.NoError(t, scraper.Initialize(context.Background()))
+	assert.NoError(t, scraper.Close(context.Background()))
+}
+
+func TestNewResourceMetricsScraper_PartialScrapeError(t *testing.T) {
+	doneFn, err := obsreporttest.SetupRecordedMetricsTest()
+	require.NoError(t, err)
+	defer doneFn()
+
+	scrapeErr := consumererror.NewPartialScrapeError(errors.New("err1"), 2)
+	scraper := NewResourceMetricsScraper(
+		"test",
+		func(context.Context) (pdata.ResourceMetricsSlice, error) {
+			return testdata.GenerateMetricDataOneMetric().ResourceMetrics(), scrapeErr
+		},
+		WithInitialize(func(context.Context) error { return errors.New("err2") }),
+		WithClose(func(context.Context) error { return errors.New("err3") }),
+	)
+
+	assert.EqualError(t, scraper.Initialize(context.Background()), "err2")
+
+	resourceMetrics, err := scraper.Scrape(context.Background(), "receiver")
+	assert.Equal(t, scrapeErr, err)
+	assert.Equal(t, 1, resourceMetrics.Len())
+
+	assert.EqualError(t, scraper.Close(context.Background()), "err3")
+
+	obsreporttest.CheckScraperMetricsViews(t, "receiver", "test", 1, 2, 1)
+}
+
+func singleMetric() pdata.MetricSlice {
+	return testdata.GenerateMetricDataOneMetric().ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+}
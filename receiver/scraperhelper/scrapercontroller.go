@@ -0,0 +1,327 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scraperhelper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"go.opencensus.io/trace"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdatautil"
+	"go.opentelemetry.io/collector/internal/data"
+)
+
+// ScraperControllerOption apply changes to internal options.
+type ScraperControllerOption func(*controller)
+
+// AddMetricsScraper adds a scraper to be run at its own collection interval,
+// or at the collection interval of the controller if it has none. The
+// scraped metrics are passed to the next consumer.
+func AddMetricsScraper(scraper MetricsScraper) ScraperControllerOption {
+	return func(o *controller) {
+		o.metricsScrapers = append(o.metricsScrapers, scraper)
+	}
+}
+
+// AddResourceMetricsScraper adds a resource scraper to be run at its own
+// collection interval, or at the collection interval of the controller if it
+// has none. The scraped resource metrics are passed to the next consumer.
+func AddResourceMetricsScraper(scraper ResourceMetricsScraper) ScraperControllerOption {
+	return func(o *controller) {
+		o.resourceMetricScrapers = append(o.resourceMetricScrapers, scraper)
+	}
+}
+
+type controller struct {
+	name               string
+	logger             *zap.Logger
+	collectionInterval time.Duration
+	initialDelay       time.Duration
+	timeout            time.Duration
+	nextConsumer       consumer.MetricsConsumer
+
+	metricsScrapers        []MetricsScraper
+	resourceMetricScrapers []ResourceMetricsScraper
+
+	groups []*scraperGroup
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+// scraperGroup holds the scrapers that are run together at the same
+// collection interval, their metrics being passed to the next consumer in a
+// single call.
+type scraperGroup struct {
+	collectionInterval     time.Duration
+	metricsScrapers        []MetricsScraper
+	resourceMetricScrapers []ResourceMetricsScraper
+}
+
+// NewScraperControllerReceiver creates a Receiver with the configured options,
+// that can control multiple scrapers. Scrapers that share a collection
+// interval are run together, each interval on its own schedule.
+func NewScraperControllerReceiver(
+	cfg *ScraperControllerSettings,
+	logger *zap.Logger,
+	nextConsumer consumer.MetricsConsumer,
+	options ...ScraperControllerOption,
+) (component.Receiver, error) {
+	if nextConsumer == nil {
+		return nil, componenterror.ErrNilNextConsumer
+	}
+
+	if cfg.CollectionInterval <= 0 {
+		return nil, errors.New("collection_interval must be a positive duration")
+	}
+
+	if cfg.InitialDelay < 0 {
+		return nil, errors.New("initial_delay must not be negative")
+	}
+
+	if cfg.Timeout < 0 {
+		return nil, errors.New("timeout must not be negative")
+	}
+
+	sc := &controller{
+		name:               cfg.Name(),
+		logger:             logger,
+		collectionInterval: cfg.CollectionInterval,
+		initialDelay:       cfg.InitialDelay,
+		timeout:            cfg.Timeout,
+		nextConsumer:       nextConsumer,
+	}
+
+	for _, op := range options {
+		op(sc)
+	}
+
+	if err := sc.groupScrapers(); err != nil {
+		return nil, err
+	}
+
+	return sc, nil
+}
+
+// groupScrapers groups the scrapers by collection interval.
+func (sc *controller) groupScrapers() error {
+	groups := map[time.Duration]*scraperGroup{}
+	group := func(scraper BaseScraper) (*scraperGroup, error) {
+		interval := scraper.CollectionInterval()
+		if interval < 0 {
+			return nil, fmt.Errorf("collection interval of scraper %q must not be negative", scraper.Name())
+		}
+		if interval == 0 {
+			interval = sc.collectionInterval
+		}
+
+		g, ok := groups[interval]
+		if !ok {
+			g = &scraperGroup{collectionInterval: interval}
+			groups[interval] = g
+			sc.groups = append(sc.groups, g)
+		}
+		return g, nil
+	}
+
+	for _, scraper := range sc.metricsScrapers {
+		g, err := group(scraper)
+		if err != nil {
+			return err
+		}
+		g.metricsScrapers = append(g.metricsScrapers, scraper)
+	}
+
+	for _, scraper := range sc.resourceMetricScrapers {
+		g, err := group(scraper)
+		if err != nil {
+			return err
+		}
+		g.resourceMetricScrapers = append(g.resourceMetricScrapers, scraper)
+	}
+
+	sort.Slice(sc.groups, func(i, j int) bool {
+		return sc.groups[i].collectionInterval < sc.groups[j].collectionInterval
+	})
+	return nil
+}
+
+// Start the receiver, invoked during service start.
+func (sc *controller) Start(ctx context.Context, _ component.Host) error {
+	for _, scraper := range sc.allScrapers() {
+		if err := scraper.Initialize(ctx); err != nil {
+			return err
+		}
+	}
+
+	sc.done = make(chan struct{})
+	for _, g := range sc.groups {
+		sc.wg.Add(1)
+		go sc.runGroup(g)
+	}
+
+	return nil
+}
+
+// Shutdown the receiver, invoked during service shutdown.
+func (sc *controller) Shutdown(ctx context.Context) error {
+	if sc.done != nil {
+		close(sc.done)
+		sc.wg.Wait()
+	}
+
+	var errs []error
+	for _, scraper := range sc.allScrapers() {
+		if err := scraper.Close(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return componenterror.CombineErrors(errs)
+}
+
+// runGroup scrapes the metrics of the group after the initial delay, and
+// then every collection interval until the receiver is shut down. The
+// context given to Start is not used, as it may be canceled once Start
+// returns.
+func (sc *controller) runGroup(g *scraperGroup) {
+	defer sc.wg.Done()
+
+	if sc.initialDelay > 0 {
+		delay := time.NewTimer(sc.initialDelay)
+		select {
+		case <-delay.C:
+		case <-sc.done:
+			delay.Stop()
+			return
+		}
+	}
+
+	sc.scrapeMetricsAndReport(context.Background(), g)
+
+	ticker := time.NewTicker(g.collectionInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			sc.scrapeMetricsAndReport(context.Background(), g)
+		case <-sc.done:
+			return
+		}
+	}
+}
+
+func (sc *controller) scrapeMetricsAndReport(ctx context.Context, g *scraperGroup) {
+	ctx, span := trace.StartSpan(ctx, sc.name+".ScrapeMetrics")
+	defer span.End()
+
+	var errs []error
+	metricData := data.NewMetricData()
+
+	if err := sc.scrapeAndAppendMetrics(ctx, g, metricData); err != nil {
+		errs = append(errs, err)
+	}
+
+	if err := sc.scrapeAndAppendResourceMetrics(ctx, g, metricData); err != nil {
+		errs = append(errs, err)
+	}
+
+	if len(errs) > 0 {
+		span.SetStatus(trace.Status{Code: trace.StatusCodeDataLoss, Message: fmt.Sprintf("Error(s) when scraping metrics: %v", componenterror.CombineErrors(errs))})
+	}
+
+	if err := sc.nextConsumer.ConsumeMetrics(ctx, pdatautil.MetricsFromInternalMetrics(metricData)); err != nil {
+		span.SetStatus(trace.Status{Code: trace.StatusCodeDataLoss, Message: fmt.Sprintf("Unable to process metrics: %v", err)})
+		sc.logger.Error("Failed to send scraped metrics", zap.Error(err))
+	}
+}
+
+func (sc *controller) scrapeAndAppendMetrics(ctx context.Context, g *scraperGroup, metricData data.MetricData) error {
+	if len(g.metricsScrapers) == 0 {
+		return nil
+	}
+
+	rms := metricData.ResourceMetrics()
+	rms.Resize(1)
+	ilms := rms.At(0).InstrumentationLibraryMetrics()
+	ilms.Resize(1)
+	metrics := ilms.At(0).Metrics()
+
+	var errs []error
+	for _, scraper := range g.metricsScrapers {
+		scraperCtx, cancel := sc.scrapeContext(ctx)
+		scraperMetrics, err := scraper.Scrape(scraperCtx, sc.name)
+		cancel()
+		if err != nil {
+			errs = append(errs, err)
+		}
+
+		scraperMetrics.MoveAndAppendTo(metrics)
+	}
+
+	return componenterror.CombineErrors(errs)
+}
+
+func (sc *controller) scrapeAndAppendResourceMetrics(ctx context.Context, g *scraperGroup, metricData data.MetricData) error {
+	if len(g.resourceMetricScrapers) == 0 {
+		return nil
+	}
+
+	rms := metricData.ResourceMetrics()
+
+	var errs []error
+	for _, scraper := range g.resourceMetricScrapers {
+		scraperCtx, cancel := sc.scrapeContext(ctx)
+		scraperResourceMetrics, err := scraper.Scrape(scraperCtx, sc.name)
+		cancel()
+		if err != nil {
+			errs = append(errs, err)
+		}
+
+		scraperResourceMetrics.MoveAndAppendTo(rms)
+	}
+
+	return componenterror.CombineErrors(errs)
+}
+
+// scrapeContext returns the context of a single call to a scraper, bounded
+// by the configured timeout if any.
+func (sc *controller) scrapeContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if sc.timeout > 0 {
+		return context.WithTimeout(ctx, sc.timeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+func (sc *controller) allScrapers() []BaseScraper {
+	allScrapers := make([]BaseScraper, 0, len(sc.metricsScrapers)+len(sc.resourceMetricScrapers))
+	for _, scraper := range sc.metricsScrapers {
+		allScrapers = append(allScrapers, scraper)
+	}
+	for _, scraper := range sc.resourceMetricScrapers {
+		allScrapers = append(allScrapers, scraper)
+	}
+	return allScrapers
+}
@@ -0,0 +1,245 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scraperhelper
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/consumer/pdatautil"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/internal/data/testdata"
+	"go.opentelemetry.io/collector/obsreport/obsreporttest"
+)
+
+const receiverName = "receiver"
+
+type testScraper struct {
+	mu       sync.Mutex
+	calls    int
+	deadline bool
+}
+
+func (ts *testScraper) scrape(ctx context.Context) (pdata.MetricSlice, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	ts.calls++
+	_, ts.deadline = ctx.Deadline()
+	return singleMetric(), nil
+}
+
+func (ts *testScraper) scrapeResource(ctx context.Context) (pdata.ResourceMetricsSlice, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	ts.calls++
+	_, ts.deadline = ctx.Deadline()
+	return testdata.GenerateMetricDataOneMetric().ResourceMetrics(), errors.New("err1")
+}
+
+func (ts *testScraper) callCount() int {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return ts.calls
+}
+
+func (ts *testScraper) hadDeadline() bool {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return ts.deadline
+}
+
+func testSettings() *ScraperControllerSettings {
+	cfg := DefaultScraperControllerSettings(receiverName)
+	cfg.CollectionInterval = 10 * time.Millisecond
+	cfg.InitialDelay = 0
+	return &cfg
+}
+
+func TestNewScraperControllerReceiver_Errors(t *testing.T) {
+	validScraper := NewMetricsScraper("test", (&testScraper{}).scrape)
+
+	tests := []struct {
+		name        string
+		cfg         func(cfg *ScraperControllerSettings)
+		nilConsumer bool
+		options     []ScraperControllerOption
+		expectedErr string
+	}{
+		{
+			name:        "Nil Consumer",
+			nilConsumer: true,
+			options:     []ScraperControllerOption{AddMetricsScraper(validScraper)},
+			expectedErr: "nil nextConsumer",
+		},
+		{
+			name:        "Zero Collection Interval",
+			cfg:         func(cfg *ScraperControllerSettings) { cfg.CollectionInterval = 0 },
+			options:     []ScraperControllerOption{AddMetricsScraper(validScraper)},
+			expectedErr: "collection_interval must be a positive duration",
+		},
+		{
+			name:        "Negative Initial Delay",
+			cfg:         func(cfg *ScraperControllerSettings) { cfg.InitialDelay = -time.Second },
+			options:     []ScraperControllerOption{AddMetricsScraper(validScraper)},
+			expectedErr: "initial_delay must not be negative",
+		},
+		{
+			name:        "Negative Timeout",
+			cfg:         func(cfg *ScraperControllerSettings) { cfg.Timeout = -time.Second },
+			options:     []ScraperControllerOption{AddMetricsScraper(validScraper)},
+			expectedErr: "timeout must not be negative",
+		},
+		{
+			name: "Negative Scraper Collection Interval",
+			options: []ScraperControllerOption{AddResourceMetricsScraper(
+				NewResourceMetricsScraper("negative", (&testScraper{}).scrapeResource, WithCollectionInterval(-time.Second)),
+			)},
+			expectedErr: `collection interval of scraper "negative" must not be negative`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := testSettings()
+			if test.cfg != nil {
+				test.cfg(cfg)
+			}
+
+			var nextConsumer consumer.MetricsConsumer = &exportertest.SinkMetricsExporter{}
+			if test.nilConsumer {
+				nextConsumer = nil
+			}
+
+			_, err := NewScraperControllerReceiver(cfg, zap.NewNop(), nextConsumer, test.options...)
+			assert.EqualError(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestScraperController(t *testing.T) {
+	doneFn, err := obsreporttest.SetupRecordedMetricsTest()
+	require.NoError(t, err)
+	defer doneFn()
+
+	fast := &testScraper{}
+	slow := &testScraper{}
+	resource := &testScraper{}
+
+	cfg := testSettings()
+	cfg.Timeout = time.Second
+
+	sink := &exportertest.SinkMetricsExporter{}
+	receiver, err := NewScraperControllerReceiver(
+		cfg,
+		zap.NewNop(),
+		sink,
+		AddMetricsScraper(NewMetricsScraper("fast", fast.scrape)),
+		AddMetricsScraper(NewMetricsScraper("slow", slow.scrape, WithCollectionInterval(time.Hour))),
+		AddResourceMetricsScraper(NewResourceMetricsScraper("resource", resource.scrapeResource)),
+	)
+	require.NoError(t, err)
+
+	ctx, cancelFn := context.WithCancel(context.Background())
+	require.NoError(t, receiver.Start(ctx, componenttest.NewNopHost()))
+
+	// canceling the context provided to Start should not cancel any async processes initiated by the receiver
+	cancelFn()
+
+	require.Eventually(t, func() bool { return fast.callCount() >= 3 && resource.callCount() >= 3 }, time.Second, 5*time.Millisecond)
+	require.NoError(t, receiver.Shutdown(context.Background()))
+
+	// the slow scraper only ran once, without initial delay, as its
+	// interval never elapsed
+	assert.Equal(t, 1, slow.callCount())
+	assert.True(t, fast.hadDeadline())
+
+	fastCalls := fast.callCount()
+	resourceCalls := resource.callCount()
+
+	// the fast and resource scrapers share an interval, so their metrics are
+	// sent together, one metric on a resource without attributes followed by
+	// the resource metrics
+	var fastGroupMetrics, slowGroupMetrics int
+	for _, metrics := range sink.AllMetrics() {
+		rms := pdatautil.MetricsToInternalMetrics(metrics).ResourceMetrics()
+		if rms.Len() == 2 {
+			fastGroupMetrics++
+		} else {
+			assert.Equal(t, 1, rms.Len())
+			slowGroupMetrics++
+		}
+	}
+	assert.Equal(t, fastCalls, fastGroupMetrics)
+	assert.Equal(t, 1, slowGroupMetrics)
+
+	obsreporttest.CheckScraperMetricsViews(t, receiverName, "fast", int64(fastCalls), 0, 0)
+	obsreporttest.CheckScraperMetricsViews(t, receiverName, "slow", 1, 0, 0)
+	obsreporttest.CheckScraperMetricsViews(t, receiverName, "resource", int64(resourceCalls), 0, int64(resourceCalls))
+}
+
+func TestScraperController_InitialDelay(t *testing.T) {
+	scraper := &testScraper{}
+
+	cfg := testSettings()
+	cfg.InitialDelay = time.Hour
+
+	receiver, err := NewScraperControllerReceiver(cfg, zap.NewNop(), &exportertest.SinkMetricsExporter{}, AddMetricsScraper(NewMetricsScraper("test", scraper.scrape)))
+	require.NoError(t, err)
+
+	require.NoError(t, receiver.Start(context.Background(), componenttest.NewNopHost()))
+	time.Sleep(50 * time.Millisecond)
+	require.NoError(t, receiver.Shutdown(context.Background()))
+
+	assert.Equal(t, 0, scraper.callCount())
+	assert.False(t, scraper.hadDeadline())
+}
+
+func TestScraperController_InitializeAndCloseErrors(t *testing.T) {
+	scraper := &testScraper{}
+	closeCalls := 0
+
+	receiver, err := NewScraperControllerReceiver(
+		testSettings(),
+		zap.NewNop(),
+		&exportertest.SinkMetricsExporter{},
+		AddMetricsScraper(NewMetricsScraper(
+			"test",
+			scraper.scrape,
+			WithInitialize(func(context.Context) error { return errors.New("err1") }),
+			WithClose(func(context.Context) error { closeCalls++; return errors.New("err2") }),
+		)),
+		AddResourceMetricsScraper(NewResourceMetricsScraper(
+			"resource",
+			scraper.scrapeResource,
+			WithClose(func(context.Context) error { closeCalls++; return errors.New("err3") }),
+		)),
+	)
+	require.NoError(t, err)
+
+	assert.EqualError(t, receiver.Start(context.Background(), componenttest.NewNopHost()), "err1")
+	assert.EqualError(t, receiver.Shutdown(context.Background()), "[err2; err3]")
+	assert.Equal(t, 2, closeCalls)
+	assert.Equal(t, 0, scraper.callCount())
+}
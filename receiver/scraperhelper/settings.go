@@ -0,0 +1,52 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scraperhelper
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+// ScraperControllerSettings defines common settings for a scraper controller
+// configuration. Scraper controller receivers can embed this struct, instead
+// of configmodels.ReceiverSettings, and extend it with more fields if needed.
+type ScraperControllerSettings struct {
+	configmodels.ReceiverSettings `mapstructure:",squash"`
+
+	// CollectionInterval is the interval at which the scrapers are run,
+	// unless a scraper was created with its own collection interval.
+	CollectionInterval time.Duration `mapstructure:"collection_interval"`
+	// InitialDelay is the delay between starting the receiver and the first
+	// scrape, after which the scrapers are run every collection interval.
+	InitialDelay time.Duration `mapstructure:"initial_delay"`
+	// Timeout bounds the duration of each call to a scraper. Zero means no
+	// timeout.
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+// DefaultScraperControllerSettings returns default scraper controller
+// settings with a collection interval of one minute and an initial delay of
+// one second.
+func DefaultScraperControllerSettings(cfgType configmodels.Type) ScraperControllerSettings {
+	return ScraperControllerSettings{
+		ReceiverSettings: configmodels.ReceiverSettings{
+			NameVal: string(cfgType),
+			TypeVal: cfgType,
+		},
+		CollectionInterval: time.Minute,
+		InitialDelay:       time.Second,
+	}
+}
@@ -22,6 +22,7 @@ import (
 const (
 	// flags
 	configCfg      = "config"
+	configWatchCfg = "config-watch"
 	memBallastFlag = "mem-ballast-size-mib"
 
 	kindLogKey        = "component_kind"
@@ -35,12 +36,14 @@ const (
 
 var (
 	configFile     *string
+	configWatch    *bool
 	memBallastSize *uint
 )
 
 // Flags adds flags related to basic building of the collector application to the given flagset.
 func Flags(flags *flag.FlagSet) {
 	configFile = flags.String(configCfg, "", "Path to the config file")
+	configWatch = flags.Bool(configWatchCfg, false, "Reload the configuration when the config file changes")
 	memBallastSize = flags.Uint(memBallastFlag, 0,
 		fmt.Sprintf("Flag to specify size of memory (MiB) ballast to set. Ballast is not used when this is not specified. "+
 			"default settings: 0"))
@@ -51,6 +54,11 @@ func GetConfigFile() string {
 	return *configFile
 }
 
+// WatchConfigFile returns true if the configuration must be reloaded when the config file changes.
+func WatchConfigFile() bool {
+	return *configWatch
+}
+
 // MemBallastSize returns the size of memory ballast to use in MBs
 func MemBallastSize() int {
 	return int(*memBallastSize)
@@ -81,6 +81,10 @@ func (exp *builtExporter) GetMetricExporter() component.MetricsExporterBase {
 	return exp.me
 }
 
+func (exp *builtExporter) GetLogExporter() component.LogsExporter {
+	return exp.le
+}
+
 // Exporters is a map of exporters created from exporter configs.
 type Exporters map[configmodels.Exporter]*builtExporter
 
@@ -116,6 +120,7 @@ func (exps Exporters) ToMapByDataType() map[configmodels.DataType]map[configmode
 
 	exportersMap[configmodels.TracesDataType] = make(map[configmodels.Exporter]component.Exporter, len(exps))
 	exportersMap[configmodels.MetricsDataType] = make(map[configmodels.Exporter]component.Exporter, len(exps))
+	exportersMap[configmodels.LogsDataType] = make(map[configmodels.Exporter]component.Exporter, len(exps))
 
 	for cfg, exp := range exps {
 		te := exp.GetTraceExporter()
@@ -126,6 +131,10 @@ func (exps Exporters) ToMapByDataType() map[configmodels.DataType]map[configmode
 		if me != nil {
 			exportersMap[configmodels.MetricsDataType][cfg] = me
 		}
+		le := exp.GetLogExporter()
+		if le != nil {
+			exportersMap[configmodels.LogsDataType][cfg] = le
+		}
 	}
 
 	return exportersMap
@@ -140,6 +140,11 @@ func TestExportersBuilder_BuildLogs(t *testing.T) {
 	assert.Nil(t, e1.te)
 	assert.Nil(t, e1.me)
 
+	// Ensure the logs exporter is exposed to the other components.
+	expMap := exporters.ToMapByDataType()
+	assert.Equal(t, e1.le, expMap[configmodels.LogsDataType][cfg.Exporters["exampleexporter"]])
+	assert.Len(t, expMap[configmodels.TracesDataType], 0)
+
 	// Ensure it can be started.
 	err = exporters.StartAll(context.Background(), componenttest.NewNopHost())
 	assert.NoError(t, err)
@@ -0,0 +1,172 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builder
+
+import (
+	"context"
+	"sync/atomic"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumerdata"
+	"go.opentelemetry.io/collector/consumer/pdata"
+)
+
+// pipelineEntry is the consumer that receivers attached to a pipeline send data to. It forwards
+// the data to the first processor of the pipeline (or to the exporters if there are no processors).
+// The next consumer can be swapped when the pipeline is rebuilt after a configuration reload, so the
+// attached receivers keep running.
+type pipelineEntry struct {
+	next atomic.Value
+}
+
+// entryNext is the value stored in pipelineEntry.next, atomic.Value requires a consistent concrete type.
+type entryNext struct {
+	consumer interface{}
+}
+
+func (pe *pipelineEntry) load() interface{} {
+	return pe.next.Load().(entryNext).consumer
+}
+
+func (pe *pipelineEntry) store(next interface{}) {
+	pe.next.Store(entryNext{consumer: next})
+}
+
+// swappableEntry is implemented by all the pipeline entries.
+type swappableEntry interface {
+	// swapWith makes the entry forward to the consumer of the other entry. Returns false if the
+	// consumer of the other entry is not of the same type, in which case the entry is unchanged.
+	swapWith(other interface{}) bool
+}
+
+type traceEntry struct {
+	pipelineEntry
+}
+
+func (te *traceEntry) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	return te.load().(consumer.TraceConsumer).ConsumeTraces(ctx, td)
+}
+
+func (te *traceEntry) swapWith(other interface{}) bool {
+	if o, ok := other.(*traceEntry); ok {
+		te.store(o.load())
+		return true
+	}
+	return false
+}
+
+type traceEntryOld struct {
+	pipelineEntry
+}
+
+func (te *traceEntryOld) ConsumeTraceData(ctx context.Context, td consumerdata.TraceData) error {
+	return te.load().(consumer.TraceConsumerOld).ConsumeTraceData(ctx, td)
+}
+
+func (te *traceEntryOld) swapWith(other interface{}) bool {
+	if o, ok := other.(*traceEntryOld); ok {
+		te.store(o.load())
+		return true
+	}
+	return false
+}
+
+type metricsEntry struct {
+	pipelineEntry
+}
+
+func (me *metricsEntry) ConsumeMetrics(ctx context.Context, md pdata.Metrics) error {
+	return me.load().(consumer.MetricsConsumer).ConsumeMetrics(ctx, md)
+}
+
+func (me *metricsEntry) swapWith(other interface{}) bool {
+	if o, ok := other.(*metricsEntry); ok {
+		me.store(o.load())
+		return true
+	}
+	return false
+}
+
+type metricsEntryOld struct {
+	pipelineEntry
+}
+
+func (me *metricsEntryOld) ConsumeMetricsData(ctx context.Context, md consumerdata.MetricsData) error {
+	return me.load().(consumer.MetricsConsumerOld).ConsumeMetricsData(ctx, md)
+}
+
+func (me *metricsEntryOld) swapWith(other interface{}) bool {
+	if o, ok := other.(*metricsEntryOld); ok {
+		me.store(o.load())
+		return true
+	}
+	return false
+}
+
+type logsEntry struct {
+	pipelineEntry
+}
+
+func (le *logsEntry) ConsumeLogs(ctx context.Context, ld pdata.Logs) error {
+	return le.load().(consumer.LogsConsumer).ConsumeLogs(ctx, ld)
+}
+
+func (le *logsEntry) swapWith(other interface{}) bool {
+	if o, ok := other.(*logsEntry); ok {
+		le.store(o.load())
+		return true
+	}
+	return false
+}
+
+// newTraceEntry returns an entry of the same consumer type as tc, or nil if tc is nil.
+func newTraceEntry(tc consumer.TraceConsumerBase) consumer.TraceConsumerBase {
+	if tc == nil {
+		return nil
+	}
+	if _, ok := tc.(consumer.TraceConsumer); ok {
+		te := &traceEntry{}
+		te.store(tc)
+		return te
+	}
+	te := &traceEntryOld{}
+	te.store(tc)
+	return te
+}
+
+// newMetricsEntry returns an entry of the same consumer type as mc, or nil if mc is nil.
+func newMetricsEntry(mc consumer.MetricsConsumerBase) consumer.MetricsConsumerBase {
+	if mc == nil {
+		return nil
+	}
+	if _, ok := mc.(consumer.MetricsConsumer); ok {
+		me := &metricsEntry{}
+		me.store(mc)
+		return me
+	}
+	me := &metricsEntryOld{}
+	me.store(mc)
+	return me
+}
+
+// newLogsEntry returns an entry for lc, or nil if lc is nil.
+func newLogsEntry(lc consumer.LogsConsumer) consumer.LogsConsumer {
+	if lc == nil {
+		return nil
+	}
+	le := &logsEntry{}
+	le.store(lc)
+	return le
+}
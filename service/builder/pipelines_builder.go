@@ -26,6 +26,7 @@ import (
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/converter"
 	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/service/internal/datatap"
 )
 
 // builtPipeline is a pipeline that is built based on a config.
@@ -42,6 +43,10 @@ type builtPipeline struct {
 	MutatesConsumedData bool
 
 	processors []component.Processor
+
+	// usesHostExporters is set to true if any processor looked up the exporters through the host
+	// when it was started, such processors keep references to the exporters.
+	usesHostExporters bool
 }
 
 // BuiltPipelines is a map of build pipelines created from pipeline configs.
@@ -54,8 +59,9 @@ func (bps BuiltPipelines) StartProcessors(ctx context.Context, host component.Ho
 		// This is important so that processors that are earlier in the pipeline and
 		// reference processors that are later in the pipeline do not start sending
 		// data to later pipelines which are not yet started.
+		pipelineHost := &exportersLookupHost{Host: host, bp: bp}
 		for i := len(bp.processors) - 1; i >= 0; i-- {
-			if err := bp.processors[i].Start(ctx, host); err != nil {
+			if err := bp.processors[i].Start(ctx, pipelineHost); err != nil {
 				return err
 			}
 		}
@@ -64,6 +70,17 @@ func (bps BuiltPipelines) StartProcessors(ctx context.Context, host component.Ho
 	return nil
 }
 
+// exportersLookupHost records whether the processors of a pipeline looked up the exporters.
+type exportersLookupHost struct {
+	component.Host
+	bp *builtPipeline
+}
+
+func (h *exportersLookupHost) GetExporters() map[configmodels.DataType]map[configmodels.Exporter]component.Exporter {
+	h.bp.usesHostExporters = true
+	return h.Host.GetExporters()
+}
+
 func (bps BuiltPipelines) ShutdownProcessors(ctx context.Context) error {
 	var errs []error
 	for _, bp := range bps {
@@ -154,10 +171,11 @@ func (pb *PipelinesBuilder) buildPipeline(pipelineCfg *configmodels.Pipeline,
 		// which we will build in the next loop iteration).
 		var err error
 		componentLogger := pb.logger.With(zap.String(kindLogKey, kindLogsProcessor), zap.String(typeLogKey, string(procCfg.Type())), zap.String(nameLogKey, procCfg.Name()))
+		tapID := datatap.ProcessorID(pipelineCfg.Name, procName)
 		switch pipelineCfg.InputType {
 		case configmodels.TracesDataType:
 			var proc component.TraceProcessorBase
-			proc, err = createTraceProcessor(factory, componentLogger, procCfg, tc)
+			proc, err = createTraceProcessor(factory, componentLogger, procCfg, datatap.WrapTraceConsumer(tapID, tc))
 			if proc != nil {
 				mutatesConsumedData = mutatesConsumedData || proc.GetCapabilities().MutatesConsumedData
 			}
@@ -165,7 +183,7 @@ func (pb *PipelinesBuilder) buildPipeline(pipelineCfg *configmodels.Pipeline,
 			tc = proc
 		case configmodels.MetricsDataType:
 			var proc component.MetricsProcessorBase
-			proc, err = createMetricsProcessor(factory, componentLogger, procCfg, mc)
+			proc, err = createMetricsProcessor(factory, componentLogger, procCfg, datatap.WrapMetricsConsumer(tapID, mc))
 			if proc != nil {
 				mutatesConsumedData = mutatesConsumedData || proc.GetCapabilities().MutatesConsumedData
 			}
@@ -174,7 +192,7 @@ func (pb *PipelinesBuilder) buildPipeline(pipelineCfg *configmodels.Pipeline,
 
 		case configmodels.LogsDataType:
 			var proc component.LogsProcessor
-			proc, err = createLogsProcessor(factory, componentLogger, procCfg, lc)
+			proc, err = createLogsProcessor(factory, componentLogger, procCfg, datatap.WrapLogsConsumer(tapID, lc))
 			if proc != nil {
 				mutatesConsumedData = mutatesConsumedData || proc.GetCapabilities().MutatesConsumedData
 			}
@@ -201,40 +219,30 @@ func (pb *PipelinesBuilder) buildPipeline(pipelineCfg *configmodels.Pipeline,
 		zap.String("pipeline_datatype", string(pipelineCfg.InputType)))
 	pipelineLogger.Info("Pipeline is enabled.")
 
+	// Receivers are attached to the pipeline through an entry, so the pipeline can be rebuilt
+	// when the configuration is reloaded without rebuilding the receivers.
 	bp := &builtPipeline{
-		pipelineLogger,
-		tc,
-		mc,
-		lc,
-		mutatesConsumedData,
-		processors,
+		logger:              pipelineLogger,
+		firstTC:             newTraceEntry(tc),
+		firstMC:             newMetricsEntry(mc),
+		firstLC:             newLogsEntry(lc),
+		MutatesConsumedData: mutatesConsumedData,
+		processors:          processors,
 	}
 
 	return bp, nil
 }
 
-// Converts the list of exporter names to a list of corresponding builtExporters.
-func (pb *PipelinesBuilder) getBuiltExportersByNames(exporterNames []string) []*builtExporter {
-	var result []*builtExporter
+func (pb *PipelinesBuilder) buildFanoutExportersTraceConsumer(exporterNames []string) consumer.TraceConsumerBase {
+	var exporters []consumer.TraceConsumerBase
 	for _, name := range exporterNames {
-		exporter := pb.exporters[pb.config.Exporters[name]]
-		result = append(result, exporter)
+		builtExp := pb.exporters[pb.config.Exporters[name]]
+		exporters = append(exporters, datatap.WrapTraceConsumer(datatap.ExporterID(name), builtExp.te))
 	}
 
-	return result
-}
-
-func (pb *PipelinesBuilder) buildFanoutExportersTraceConsumer(exporterNames []string) consumer.TraceConsumerBase {
-	builtExporters := pb.getBuiltExportersByNames(exporterNames)
-
 	// Optimize for the case when there is only one exporter, no need to create junction point.
-	if len(builtExporters) == 1 {
-		return builtExporters[0].te
-	}
-
-	var exporters []consumer.TraceConsumerBase
-	for _, builtExp := range builtExporters {
-		exporters = append(exporters, builtExp.te)
+	if len(exporters) == 1 {
+		return exporters[0]
 	}
 
 	// Create a junction point that fans out to all exporters.
@@ -242,16 +250,15 @@ func (pb *PipelinesBuilder) buildFanoutExportersTraceConsumer(exporterNames []st
 }
 
 func (pb *PipelinesBuilder) buildFanoutExportersMetricsConsumer(exporterNames []string) consumer.MetricsConsumerBase {
-	builtExporters := pb.getBuiltExportersByNames(exporterNames)
-
-	// Optimize for the case when there is only one exporter, no need to create junction point.
-	if len(builtExporters) == 1 {
-		return builtExporters[0].me
+	var exporters []consumer.MetricsConsumerBase
+	for _, name := range exporterNames {
+		builtExp := pb.exporters[pb.config.Exporters[name]]
+		exporters = append(exporters, datatap.WrapMetricsConsumer(datatap.ExporterID(name), builtExp.me))
 	}
 
-	var exporters []consumer.MetricsConsumerBase
-	for _, builtExp := range builtExporters {
-		exporters = append(exporters, builtExp.me)
+	// Optimize for the case when there is only one exporter, no need to create junction point.
+	if len(exporters) == 1 {
+		return exporters[0]
 	}
 
 	// Create a junction point that fans out to all exporters.
@@ -261,16 +268,15 @@ func (pb *PipelinesBuilder) buildFanoutExportersMetricsConsumer(exporterNames []
 func (pb *PipelinesBuilder) buildFanoutExportersLogConsumer(
 	exporterNames []string,
 ) consumer.LogsConsumer {
-	builtExporters := pb.getBuiltExportersByNames(exporterNames)
-
-	// Optimize for the case when there is only one exporter, no need to create junction point.
-	if len(builtExporters) == 1 {
-		return builtExporters[0].le
+	exporters := make([]consumer.LogsConsumer, 0, len(exporterNames))
+	for _, name := range exporterNames {
+		builtExp := pb.exporters[pb.config.Exporters[name]]
+		exporters = append(exporters, datatap.WrapLogsConsumer(datatap.ExporterID(name), builtExp.le))
 	}
 
-	exporters := make([]consumer.LogsConsumer, len(builtExporters))
-	for _, builtExp := range builtExporters {
-		exporters = append(exporters, builtExp.le)
+	// Optimize for the case when there is only one exporter, no need to create junction point.
+	if len(exporters) == 1 {
+		return exporters[0]
 	}
 
 	// Create a junction point that fans out to all exporters.
@@ -353,17 +353,15 @@ func TestPipelinesBuilder_Error(t *testing.T) {
 	cfg, err := configtest.LoadConfigFile(t, "testdata/pipelines_builder.yaml", factories)
 	require.Nil(t, err)
 
-	// Corrupt the pipeline, change data type to metrics. We have to forcedly do it here
+	// Corrupt the processor config, remove the actions. We have to forcedly do it here
 	// since there is no way to have such config loaded by LoadConfigFile, it would not
 	// pass validation. We are doing this to test failure mode of PipelinesBuilder.
-	pipeline := cfg.Service.Pipelines["traces"]
-	pipeline.InputType = configmodels.MetricsDataType
+	cfg.Processors["attributes"].(*attributesprocessor.Config).Actions = nil
 
 	exporters, err := NewExportersBuilder(zap.NewNop(), cfg, factories.Exporters).Build()
 	assert.NoError(t, err)
 
-	// This should fail because "attributes" processor defined in the config does
-	// not support metrics data type.
+	// This should fail because "attributes" processor requires at least one action.
 	_, err = NewPipelinesBuilder(zap.NewNop(), cfg, exporters, factories.Processors).Build()
 
 	assert.NotNil(t, err)
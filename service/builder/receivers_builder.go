@@ -28,6 +28,7 @@ import (
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/converter"
 	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/service/internal/datatap"
 )
 
 var errUnusedReceiver = errors.New("receiver defined but not used by any pipeline")
@@ -175,21 +176,22 @@ func (rb *ReceiversBuilder) attachReceiverToPipelines(
 	// sure its output is fanned out to all attached pipelines.
 	var err error
 	var createdReceiver component.Receiver
+	tapID := datatap.ReceiverID(config.Name())
 
 	switch dataType {
 	case configmodels.TracesDataType:
 		// First, create the fan out junction point.
-		junction := buildFanoutTraceConsumer(builtPipelines)
+		junction := datatap.WrapTraceConsumer(tapID, buildFanoutTraceConsumer(builtPipelines))
 
 		// Now create the receiver and tell it to send to the junction point.
 		createdReceiver, err = createTraceReceiver(context.Background(), factory, logger, config, junction)
 
 	case configmodels.MetricsDataType:
-		junction := buildFanoutMetricConsumer(builtPipelines)
+		junction := datatap.WrapMetricsConsumer(tapID, buildFanoutMetricConsumer(builtPipelines))
 		createdReceiver, err = createMetricsReceiver(context.Background(), factory, logger, config, junction)
 
 	case configmodels.LogsDataType:
-		junction := buildFanoutLogConsumer(builtPipelines)
+		junction := datatap.WrapLogsConsumer(tapID, buildFanoutLogConsumer(builtPipelines))
 		createdReceiver, err = createLogsReceiver(context.Background(), factory, logger, config, junction)
 
 	default:
@@ -0,0 +1,372 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builder
+
+import (
+	"context"
+	"reflect"
+	"sort"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
+	"go.opentelemetry.io/collector/config/configmodels"
+)
+
+// Running holds the components built for a configuration.
+type Running struct {
+	Config    *configmodels.Config
+	Exporters Exporters
+	Pipelines BuiltPipelines
+	Receivers Receivers
+}
+
+// Reload holds the components for a new configuration. Components whose configuration did not
+// change are carried over from the running ones, the others are rebuilt. Nothing is started or
+// stopped until Apply is called.
+type Reload struct {
+	logger *zap.Logger
+	// Result contains all the components for the new configuration.
+	Result Running
+
+	startExporters    Exporters
+	startPipelines    BuiltPipelines
+	startReceivers    Receivers
+	shutdownExporters Exporters
+	shutdownPipelines BuiltPipelines
+	shutdownReceivers Receivers
+	// swaps are the entries of the running pipelines that must forward to the rebuilt pipelines.
+	swaps []pipelineSwap
+}
+
+// pipelineSwap makes the entry of a running pipeline forward to the consumer of a rebuilt pipeline entry.
+type pipelineSwap struct {
+	running swappableEntry
+	rebuilt interface{}
+}
+
+// BuildReload builds the receivers, pipelines and exporters that are affected by the differences
+// between the running configuration and newConfig:
+//  - an exporter is rebuilt if its configuration or the data types it must support changed;
+//  - a pipeline is rebuilt if its configuration, the configuration of one of its processors changed
+//    or if one of its exporters is rebuilt. It is also rebuilt if any exporter is rebuilt or removed
+//    and one of its processors looked up the exporters through the host, e.g. the routing processor;
+//  - a receiver is rebuilt if its configuration or the pipelines it is attached to changed. A receiver
+//    attached to a rebuilt pipeline keeps running, unless the rebuilt pipeline consumes a different
+//    type of data structure or changes whether it mutates the consumed data.
+func BuildReload(
+	logger *zap.Logger,
+	running Running,
+	newConfig *configmodels.Config,
+	factories component.Factories,
+) (*Reload, error) {
+	r := &Reload{
+		logger: logger,
+		Result: Running{
+			Config:    newConfig,
+			Exporters: make(Exporters),
+			Pipelines: make(BuiltPipelines),
+			Receivers: make(Receivers),
+		},
+		startExporters:    make(Exporters),
+		startPipelines:    make(BuiltPipelines),
+		startReceivers:    make(Receivers),
+		shutdownExporters: make(Exporters),
+		shutdownPipelines: make(BuiltPipelines),
+		shutdownReceivers: make(Receivers),
+	}
+
+	rebuiltExporters, err := r.reloadExporters(running, factories.Exporters)
+	if err != nil {
+		return nil, err
+	}
+	relinkedPipelines, err := r.reloadPipelines(running, factories.Processors, rebuiltExporters)
+	if err != nil {
+		return nil, err
+	}
+	if err = r.reloadReceivers(running, factories.Receivers, relinkedPipelines); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// HasChanges returns true if any component must be started or stopped.
+func (r *Reload) HasChanges() bool {
+	return len(r.startExporters) != 0 || len(r.startPipelines) != 0 || len(r.startReceivers) != 0 ||
+		len(r.shutdownExporters) != 0 || len(r.shutdownPipelines) != 0 || len(r.shutdownReceivers) != 0
+}
+
+// Apply starts the rebuilt components, routes the data to them and stops the replaced components.
+// Replaced receivers are stopped before the rebuilt ones are started since they usually listen on
+// the same endpoints. Replaced pipelines and exporters are stopped last, so they can flush their data.
+// The started components look up the exporters of the new configuration through the host.
+func (r *Reload) Apply(ctx context.Context, host component.Host) error {
+	host = &reloadHost{Host: host, exporters: r.Result.Exporters}
+
+	r.logger.Info("Starting rebuilt exporters...", zap.Int("count", len(r.startExporters)))
+	if err := r.startExporters.StartAll(ctx, host); err != nil {
+		return errors.Wrap(err, "cannot start exporters")
+	}
+
+	r.logger.Info("Starting rebuilt processors...", zap.Int("count", len(r.startPipelines)))
+	if err := r.startPipelines.StartProcessors(ctx, host); err != nil {
+		return errors.Wrap(err, "cannot start processors")
+	}
+
+	for _, s := range r.swaps {
+		s.running.swapWith(s.rebuilt)
+	}
+
+	var errs []error
+	r.logger.Info("Stopping replaced receivers...", zap.Int("count", len(r.shutdownReceivers)))
+	if err := r.shutdownReceivers.ShutdownAll(ctx); err != nil {
+		errs = append(errs, errors.Wrap(err, "failed to stop receivers"))
+	}
+
+	r.logger.Info("Starting rebuilt receivers...", zap.Int("count", len(r.startReceivers)))
+	if err := r.startReceivers.StartAll(ctx, host); err != nil {
+		return errors.Wrap(err, "cannot start receivers")
+	}
+
+	r.logger.Info("Stopping replaced processors...", zap.Int("count", len(r.shutdownPipelines)))
+	if err := r.shutdownPipelines.ShutdownProcessors(ctx); err != nil {
+		errs = append(errs, errors.Wrap(err, "failed to shutdown processors"))
+	}
+
+	r.logger.Info("Stopping replaced exporters...", zap.Int("count", len(r.shutdownExporters)))
+	if err := r.shutdownExporters.ShutdownAll(ctx); err != nil {
+		errs = append(errs, errors.Wrap(err, "failed to shutdown exporters"))
+	}
+
+	return componenterror.CombineErrors(errs)
+}
+
+// reloadHost returns the exporters of the new configuration.
+type reloadHost struct {
+	component.Host
+	exporters Exporters
+}
+
+func (h *reloadHost) GetExporters() map[configmodels.DataType]map[configmodels.Exporter]component.Exporter {
+	return h.exporters.ToMapByDataType()
+}
+
+// reloadExporters returns the names of the rebuilt exporters.
+func (r *Reload) reloadExporters(
+	running Running,
+	factories map[configmodels.Type]component.ExporterFactoryBase,
+) (map[string]bool, error) {
+	eb := NewExportersBuilder(r.logger, r.Result.Config, factories)
+	runningDataTypes := NewExportersBuilder(r.logger, running.Config, factories).calcExportersRequiredDataTypes()
+	newDataTypes := eb.calcExportersRequiredDataTypes()
+
+	reused := make(map[*builtExporter]bool)
+	rebuilt := make(map[string]bool)
+	for name, cfg := range r.Result.Config.Exporters {
+		runningCfg := running.Config.Exporters[name]
+		if exp, ok := running.Exporters[runningCfg]; ok && reflect.DeepEqual(runningCfg, cfg) &&
+			sameDataTypes(runningDataTypes[runningCfg], newDataTypes[cfg]) {
+			r.Result.Exporters[cfg] = exp
+			reused[exp] = true
+			continue
+		}
+
+		componentLogger := eb.logger.With(zap.String(typeLogKey, string(cfg.Type())), zap.String(nameLogKey, cfg.Name()))
+		exp, err := eb.buildExporter(componentLogger, cfg, newDataTypes)
+		if err != nil {
+			return nil, err
+		}
+		r.Result.Exporters[cfg] = exp
+		r.startExporters[cfg] = exp
+		rebuilt[name] = true
+	}
+
+	for cfg, exp := range running.Exporters {
+		if !reused[exp] {
+			r.shutdownExporters[cfg] = exp
+		}
+	}
+	return rebuilt, nil
+}
+
+// reloadPipelines returns the names of the pipelines that are new or cannot be swapped, the receivers
+// attached to them must be rebuilt.
+func (r *Reload) reloadPipelines(
+	running Running,
+	factories map[configmodels.Type]component.ProcessorFactoryBase,
+	rebuiltExporters map[string]bool,
+) (map[string]bool, error) {
+	pb := NewPipelinesBuilder(r.logger, r.Result.Config, r.Result.Exporters, factories)
+
+	reused := make(map[*builtPipeline]bool)
+	relinked := make(map[string]bool)
+	for name, cfg := range r.Result.Config.Service.Pipelines {
+		runningCfg := running.Config.Service.Pipelines[name]
+		runningBP, ok := running.Pipelines[runningCfg]
+		// Processors that looked up the exporters would keep using the replaced ones.
+		staleExporters := ok && runningBP.usesHostExporters && len(r.shutdownExporters) != 0
+		if ok && !staleExporters && !pipelineChanged(running.Config, runningCfg, r.Result.Config, cfg, rebuiltExporters) {
+			r.Result.Pipelines[cfg] = runningBP
+			reused[runningBP] = true
+			continue
+		}
+
+		bp, err := pb.buildPipeline(cfg)
+		if err != nil {
+			return nil, err
+		}
+		r.startPipelines[cfg] = bp
+
+		if ok && runningBP.canSwapWith(bp) {
+			// The attached receivers keep sending to the entries of the running pipeline.
+			r.addSwap(runningBP.firstTC, bp.firstTC)
+			r.addSwap(runningBP.firstMC, bp.firstMC)
+			r.addSwap(runningBP.firstLC, bp.firstLC)
+			bp.firstTC, bp.firstMC, bp.firstLC = runningBP.firstTC, runningBP.firstMC, runningBP.firstLC
+		} else {
+			relinked[name] = true
+		}
+		r.Result.Pipelines[cfg] = bp
+	}
+
+	for cfg, bp := range running.Pipelines {
+		if !reused[bp] {
+			r.shutdownPipelines[cfg] = bp
+		}
+	}
+	return relinked, nil
+}
+
+func (r *Reload) reloadReceivers(
+	running Running,
+	factories map[configmodels.Type]component.ReceiverFactoryBase,
+	relinkedPipelines map[string]bool,
+) error {
+	rb := NewReceiversBuilder(r.logger, r.Result.Config, r.Result.Pipelines, factories)
+
+	reused := make(map[*builtReceiver]bool)
+	for name, cfg := range r.Result.Config.Receivers {
+		runningCfg := running.Config.Receivers[name]
+		attached := attachedPipelineNames(r.Result.Config, name)
+		if rcv, ok := running.Receivers[runningCfg]; ok && reflect.DeepEqual(runningCfg, cfg) &&
+			reflect.DeepEqual(attachedPipelineNames(running.Config, name), attached) &&
+			!anyPipelineIn(attached, relinkedPipelines) {
+			r.Result.Receivers[cfg] = rcv
+			reused[rcv] = true
+			continue
+		}
+
+		logger := rb.logger.With(zap.String(typeLogKey, string(cfg.Type())), zap.String(nameLogKey, cfg.Name()))
+		rcv, err := rb.buildReceiver(logger, cfg)
+		if err != nil {
+			if err == errUnusedReceiver {
+				logger.Info("Ignoring receiver as it is not used by any pipeline", zap.String("receiver", cfg.Name()))
+				continue
+			}
+			return err
+		}
+		r.Result.Receivers[cfg] = rcv
+		r.startReceivers[cfg] = rcv
+	}
+
+	for cfg, rcv := range running.Receivers {
+		if !reused[rcv] {
+			r.shutdownReceivers[cfg] = rcv
+		}
+	}
+	return nil
+}
+
+// canSwapWith returns true if the receivers attached to bp can send to the rebuilt pipeline
+// without being rebuilt.
+func (bp *builtPipeline) canSwapWith(rebuilt *builtPipeline) bool {
+	return bp.MutatesConsumedData == rebuilt.MutatesConsumedData &&
+		canSwapEntry(bp.firstTC, rebuilt.firstTC) &&
+		canSwapEntry(bp.firstMC, rebuilt.firstMC) &&
+		canSwapEntry(bp.firstLC, rebuilt.firstLC)
+}
+
+func (r *Reload) addSwap(running, rebuilt interface{}) {
+	if running != nil {
+		r.swaps = append(r.swaps, pipelineSwap{running: running.(swappableEntry), rebuilt: rebuilt})
+	}
+}
+
+func canSwapEntry(running, rebuilt interface{}) bool {
+	if running == nil || rebuilt == nil {
+		return running == nil && rebuilt == nil
+	}
+	return reflect.TypeOf(running) == reflect.TypeOf(rebuilt)
+}
+
+func pipelineChanged(
+	runningConfig *configmodels.Config,
+	runningCfg *configmodels.Pipeline,
+	newConfig *configmodels.Config,
+	cfg *configmodels.Pipeline,
+	rebuiltExporters map[string]bool,
+) bool {
+	if !reflect.DeepEqual(runningCfg.Processors, cfg.Processors) ||
+		!reflect.DeepEqual(runningCfg.Exporters, cfg.Exporters) ||
+		runningCfg.InputType != cfg.InputType {
+		return true
+	}
+	for _, name := range cfg.Processors {
+		if !reflect.DeepEqual(runningConfig.Processors[name], newConfig.Processors[name]) {
+			return true
+		}
+	}
+	for _, name := range cfg.Exporters {
+		if rebuiltExporters[name] {
+			return true
+		}
+	}
+	return false
+}
+
+func sameDataTypes(a, b dataTypeRequirements) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for dt := range a {
+		if _, ok := b[dt]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// attachedPipelineNames returns the sorted names of the pipelines the receiver is attached to.
+func attachedPipelineNames(cfg *configmodels.Config, receiverName string) []string {
+	var names []string
+	for _, pipeline := range cfg.Service.Pipelines {
+		if hasReceiver(pipeline, receiverName) {
+			names = append(names, pipeline.Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func anyPipelineIn(names []string, set map[string]bool) bool {
+	for _, name := range names {
+		if set[name] {
+			return true
+		}
+	}
+	return false
+}
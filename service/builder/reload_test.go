@@ -0,0 +1,253 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builder
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/config/configtest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/processor/attributesprocessor"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+)
+
+func reloadTestFactories(t *testing.T) component.Factories {
+	factories, err := componenttest.ExampleComponents()
+	require.NoError(t, err)
+	attrFactory := attributesprocessor.NewFactory()
+	factories.Processors[attrFactory.Type()] = attrFactory
+	return factories
+}
+
+func startRunning(t *testing.T, factories component.Factories) Running {
+	cfg, err := configtest.LoadConfigFile(t, "testdata/pipelines_builder.yaml", factories)
+	require.NoError(t, err)
+	return startRunningConfig(t, factories, cfg)
+}
+
+func startRunningConfig(t *testing.T, factories component.Factories, cfg *configmodels.Config) Running {
+	exporters, err := NewExportersBuilder(zap.NewNop(), cfg, factories.Exporters).Build()
+	require.NoError(t, err)
+	pipelines, err := NewPipelinesBuilder(zap.NewNop(), cfg, exporters, factories.Processors).Build()
+	require.NoError(t, err)
+	receivers, err := NewReceiversBuilder(zap.NewNop(), cfg, pipelines, factories.Receivers).Build()
+	require.NoError(t, err)
+
+	host := componenttest.NewNopHost()
+	require.NoError(t, exporters.StartAll(context.Background(), host))
+	require.NoError(t, pipelines.StartProcessors(context.Background(), host))
+	require.NoError(t, receivers.StartAll(context.Background(), host))
+	return Running{Config: cfg, Exporters: exporters, Pipelines: pipelines, Receivers: receivers}
+}
+
+func TestReload_NoChanges(t *testing.T) {
+	factories := reloadTestFactories(t)
+	running := startRunning(t, factories)
+
+	newCfg, err := configtest.LoadConfigFile(t, "testdata/pipelines_builder.yaml", factories)
+	require.NoError(t, err)
+
+	reload, err := BuildReload(zap.NewNop(), running, newCfg, factories)
+	require.NoError(t, err)
+	assert.False(t, reload.HasChanges())
+	assert.Len(t, reload.Result.Exporters, len(running.Exporters))
+	assert.Len(t, reload.Result.Pipelines, len(running.Pipelines))
+	assert.Len(t, reload.Result.Receivers, len(running.Receivers))
+	for name, cfg := range newCfg.Receivers {
+		assert.Same(t, running.Receivers[running.Config.Receivers[name]], reload.Result.Receivers[cfg])
+	}
+}
+
+func TestReload_ExporterChanged(t *testing.T) {
+	factories := reloadTestFactories(t)
+	running := startRunning(t, factories)
+
+	newCfg, err := configtest.LoadConfigFile(t, "testdata/pipelines_builder.yaml", factories)
+	require.NoError(t, err)
+	newCfg.Exporters["exampleexporter/2"].(*componenttest.ExampleExporter).ExtraSetting = "changed"
+
+	reload, err := BuildReload(zap.NewNop(), running, newCfg, factories)
+	require.NoError(t, err)
+	assert.True(t, reload.HasChanges())
+	require.NoError(t, reload.Apply(context.Background(), componenttest.NewNopHost()))
+
+	// Unchanged exporter is carried over, the changed one is replaced.
+	assert.Same(t, running.Exporters[running.Config.Exporters["exampleexporter"]],
+		reload.Result.Exporters[newCfg.Exporters["exampleexporter"]])
+	oldExp := running.Exporters[running.Config.Exporters["exampleexporter/2"]]
+	newExp := reload.Result.Exporters[newCfg.Exporters["exampleexporter/2"]]
+	assert.NotSame(t, oldExp, newExp)
+	assert.True(t, oldExp.te.(*componenttest.ExampleExporterConsumer).ExporterShutdown)
+	assert.True(t, newExp.te.(*componenttest.ExampleExporterConsumer).ExporterStarted)
+
+	// Pipelines using the changed exporter are rebuilt, but all receivers keep running.
+	assert.Same(t, running.Pipelines[running.Config.Service.Pipelines["traces"]],
+		reload.Result.Pipelines[newCfg.Service.Pipelines["traces"]])
+	assert.NotSame(t, running.Pipelines[running.Config.Service.Pipelines["traces/2"]],
+		reload.Result.Pipelines[newCfg.Service.Pipelines["traces/2"]])
+	for name, cfg := range newCfg.Receivers {
+		rcv := running.Receivers[running.Config.Receivers[name]]
+		assert.Same(t, rcv, reload.Result.Receivers[cfg])
+		assert.False(t, rcv.receiver.(*componenttest.ExampleReceiverProducer).Stopped)
+	}
+
+	// Data sent by the running receiver reaches the rebuilt exporter.
+	producer := running.Receivers[running.Config.Receivers["examplereceiver/2"]].receiver.(*componenttest.ExampleReceiverProducer)
+	require.NoError(t, producer.TraceConsumer.ConsumeTraceData(context.Background(), generateTestTraceData()))
+	assert.Len(t, newExp.te.(*componenttest.ExampleExporterConsumer).Traces, 1)
+	assert.Len(t, oldExp.te.(*componenttest.ExampleExporterConsumer).Traces, 0)
+}
+
+func TestReload_ReceiverChanged(t *testing.T) {
+	factories := reloadTestFactories(t)
+	running := startRunning(t, factories)
+
+	newCfg, err := configtest.LoadConfigFile(t, "testdata/pipelines_builder.yaml", factories)
+	require.NoError(t, err)
+	newCfg.Receivers["examplereceiver/2"].(*componenttest.ExampleReceiver).ExtraSetting = "changed"
+	// Detach examplereceiver/3 from all the pipelines.
+	for _, pipeline := range newCfg.Service.Pipelines {
+		for i, name := range pipeline.Receivers {
+			if name == "examplereceiver/3" {
+				pipeline.Receivers = append(pipeline.Receivers[:i], pipeline.Receivers[i+1:]...)
+				break
+			}
+		}
+	}
+
+	reload, err := BuildReload(zap.NewNop(), running, newCfg, factories)
+	require.NoError(t, err)
+	require.NoError(t, reload.Apply(context.Background(), componenttest.NewNopHost()))
+
+	oldRcv := running.Receivers[running.Config.Receivers["examplereceiver/2"]]
+	newRcv := reload.Result.Receivers[newCfg.Receivers["examplereceiver/2"]]
+	require.NotNil(t, newRcv)
+	assert.NotSame(t, oldRcv, newRcv)
+	assert.True(t, oldRcv.receiver.(*componenttest.ExampleReceiverProducer).Stopped)
+	assert.True(t, newRcv.receiver.(*componenttest.ExampleReceiverProducer).Started)
+
+	unused := running.Receivers[running.Config.Receivers["examplereceiver/3"]]
+	assert.True(t, unused.receiver.(*componenttest.ExampleReceiverProducer).Stopped)
+	assert.NotContains(t, reload.Result.Receivers, newCfg.Receivers["examplereceiver/3"])
+
+	assert.Same(t, running.Receivers[running.Config.Receivers["examplereceiver"]],
+		reload.Result.Receivers[newCfg.Receivers["examplereceiver"]])
+}
+
+func TestReload_PipelineAdded(t *testing.T) {
+	factories := reloadTestFactories(t)
+	running := startRunning(t, factories)
+
+	newCfg, err := configtest.LoadConfigFile(t, "testdata/pipelines_builder.yaml", factories)
+	require.NoError(t, err)
+	newCfg.Service.Pipelines["traces/3"] = &configmodels.Pipeline{
+		Name:      "traces/3",
+		InputType: configmodels.TracesDataType,
+		Receivers: []string{"examplereceiver"},
+		Exporters: []string{"exampleexporter/2"},
+	}
+
+	reload, err := BuildReload(zap.NewNop(), running, newCfg, factories)
+	require.NoError(t, err)
+	require.NoError(t, reload.Apply(context.Background(), componenttest.NewNopHost()))
+
+	// The receiver is attached to a new pipeline so it is rebuilt.
+	assert.NotSame(t, running.Receivers[running.Config.Receivers["examplereceiver"]],
+		reload.Result.Receivers[newCfg.Receivers["examplereceiver"]])
+	assert.Contains(t, reload.Result.Pipelines, newCfg.Service.Pipelines["traces/3"])
+	// Other receivers are not affected.
+	assert.Same(t, running.Receivers[running.Config.Receivers["examplereceiver/2"]],
+		reload.Result.Receivers[newCfg.Receivers["examplereceiver/2"]])
+}
+
+// exportersLookupProcessor passes the traces through and keeps the exporters it looked up when started,
+// as the routing processor does.
+type exportersLookupProcessor struct {
+	exporters map[configmodels.DataType]map[configmodels.Exporter]component.Exporter
+}
+
+func (p *exportersLookupProcessor) ProcessTraces(_ context.Context, td pdata.Traces) (pdata.Traces, error) {
+	return td, nil
+}
+
+// newExportersLookupFactory returns a factory of exportersLookupProcessor, the created processors
+// are appended to started when they are started.
+func newExportersLookupFactory(started *[]*exportersLookupProcessor) component.ProcessorFactory {
+	const typeStr = "exporterslookup"
+	return processorhelper.NewFactory(
+		typeStr,
+		func() configmodels.Processor {
+			return &configmodels.ProcessorSettings{TypeVal: typeStr, NameVal: typeStr}
+		},
+		processorhelper.WithTraces(func(
+			_ context.Context,
+			_ component.ProcessorCreateParams,
+			cfg configmodels.Processor,
+			nextConsumer consumer.TraceConsumer,
+		) (component.TraceProcessor, error) {
+			p := &exportersLookupProcessor{}
+			return processorhelper.NewTraceProcessor(cfg, nextConsumer, p,
+				processorhelper.WithStart(func(_ context.Context, host component.Host) error {
+					p.exporters = host.GetExporters()
+					*started = append(*started, p)
+					return nil
+				}))
+		}))
+}
+
+func TestReload_ExporterChangedRebuildsExportersLookup(t *testing.T) {
+	var started []*exportersLookupProcessor
+	factories := reloadTestFactories(t)
+	lookupFactory := newExportersLookupFactory(&started)
+	factories.Processors[lookupFactory.Type()] = lookupFactory
+
+	loadConfig := func() *configmodels.Config {
+		cfg, err := configtest.LoadConfigFile(t, "testdata/pipelines_builder.yaml", factories)
+		require.NoError(t, err)
+		// The traces pipeline does not export to exampleexporter/2, but its processor may use it.
+		cfg.Processors["exporterslookup"] = lookupFactory.CreateDefaultConfig()
+		cfg.Service.Pipelines["traces"].Processors = append(cfg.Service.Pipelines["traces"].Processors, "exporterslookup")
+		return cfg
+	}
+	running := startRunningConfig(t, factories, loadConfig())
+	require.Len(t, started, 1)
+
+	newCfg := loadConfig()
+	newCfg.Exporters["exampleexporter/2"].(*componenttest.ExampleExporter).ExtraSetting = "changed"
+
+	reload, err := BuildReload(zap.NewNop(), running, newCfg, factories)
+	require.NoError(t, err)
+	require.NoError(t, reload.Apply(context.Background(), componenttest.NewNopHost()))
+
+	// The pipeline is rebuilt and its processor looked up the rebuilt exporter.
+	assert.NotSame(t, running.Pipelines[running.Config.Service.Pipelines["traces"]],
+		reload.Result.Pipelines[newCfg.Service.Pipelines["traces"]])
+	require.Len(t, started, 2)
+	newExp := reload.Result.Exporters[newCfg.Exporters["exampleexporter/2"]]
+	assert.Same(t, newExp.te, started[1].exporters[configmodels.TracesDataType][newCfg.Exporters["exampleexporter/2"]])
+
+	// Pipelines without such processors are not affected.
+	assert.Same(t, running.Pipelines[running.Config.Service.Pipelines["metrics"]],
+		reload.Result.Pipelines[newCfg.Service.Pipelines["metrics"]])
+}
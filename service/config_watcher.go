@@ -0,0 +1,98 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/fsnotify/fsnotify"
+	"go.uber.org/zap"
+)
+
+// configWatcher notifies when the config file changes.
+type configWatcher struct {
+	logger  *zap.Logger
+	file    string
+	watcher *fsnotify.Watcher
+	// changed receives a value when the config file changed, changes are coalesced while the
+	// previous one was not consumed.
+	changed chan struct{}
+	done    chan struct{}
+}
+
+// newConfigWatcher starts watching the given config file. The directory of the file is watched, since
+// editors and Kubernetes ConfigMaps usually replace the file instead of writing it.
+func newConfigWatcher(logger *zap.Logger, file string) (*configWatcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	file = filepath.Clean(file)
+	if err = watcher.Add(filepath.Dir(file)); err != nil {
+		watcher.Close()
+		return nil, err
+	}
+
+	cw := &configWatcher{
+		logger:  logger,
+		file:    file,
+		watcher: watcher,
+		changed: make(chan struct{}, 1),
+		done:    make(chan struct{}),
+	}
+	go cw.run()
+	return cw, nil
+}
+
+func (cw *configWatcher) run() {
+	defer close(cw.done)
+	for {
+		select {
+		case event, ok := <-cw.watcher.Events:
+			if !ok {
+				return
+			}
+			if !cw.isConfigEvent(event) {
+				continue
+			}
+			select {
+			case cw.changed <- struct{}{}:
+			default:
+			}
+		case err, ok := <-cw.watcher.Errors:
+			if !ok {
+				return
+			}
+			cw.logger.Warn("Error watching the config file", zap.Error(err))
+		}
+	}
+}
+
+func (cw *configWatcher) isConfigEvent(event fsnotify.Event) bool {
+	if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) == 0 {
+		return false
+	}
+	name := filepath.Clean(event.Name)
+	// Kubernetes updates mounted ConfigMaps by swapping the "..data" symlink.
+	return name == cw.file || strings.HasPrefix(filepath.Base(name), "..")
+}
+
+// close stops watching the config file.
+func (cw *configWatcher) close() error {
+	err := cw.watcher.Close()
+	<-cw.done
+	return err
+}
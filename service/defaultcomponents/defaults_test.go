@@ -40,6 +40,7 @@ func TestDefaultComponents(t *testing.T) {
 		"otlp",
 		"hostmetrics",
 		"fluentforward",
+		"kafka",
 	}
 	expectedProcessors := []configmodels.Type{
 		"attributes",
@@ -51,6 +52,9 @@ func TestDefaultComponents(t *testing.T) {
 		"probabilistic_sampler",
 		"span",
 		"filter",
+		"routing",
+		"spanmetrics",
+		"servicegraph",
 	}
 	expectedExporters := []configmodels.Type{
 		"opencensus",
@@ -61,6 +65,9 @@ func TestDefaultComponents(t *testing.T) {
 		"file",
 		"otlp",
 		"kafka",
+		"loadbalancing",
+		"otlphttp",
+		"prometheusremotewrite",
 	}
 
 	factories, err := Components()
@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datatap
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumerdata"
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/consumer/pdatautil"
+	"go.opentelemetry.io/collector/translator/internaldata"
+)
+
+// WrapTraceConsumer returns a consumer that sends the data to the tap with the given ID and then
+// to next. The returned consumer is of the same (old or new) type as next.
+func WrapTraceConsumer(id string, next consumer.TraceConsumerBase) consumer.TraceConsumerBase {
+	t := Get(id)
+	if tc, ok := next.(consumer.TraceConsumer); ok {
+		return &traceTap{tap: t, next: tc}
+	}
+	return &traceTapOld{tap: t, next: next.(consumer.TraceConsumerOld)}
+}
+
+// WrapMetricsConsumer returns a consumer that sends the data to the tap with the given ID and then
+// to next. The returned consumer is of the same (old or new) type as next.
+func WrapMetricsConsumer(id string, next consumer.MetricsConsumerBase) consumer.MetricsConsumerBase {
+	t := Get(id)
+	if mc, ok := next.(consumer.MetricsConsumer); ok {
+		return &metricsTap{tap: t, next: mc}
+	}
+	return &metricsTapOld{tap: t, next: next.(consumer.MetricsConsumerOld)}
+}
+
+// WrapLogsConsumer returns a consumer that sends the data to the tap with the given ID and then
+// to next.
+func WrapLogsConsumer(id string, next consumer.LogsConsumer) consumer.LogsConsumer {
+	return &logsTap{tap: Get(id), next: next}
+}
+
+type traceTap struct {
+	tap  *Tap
+	next consumer.TraceConsumer
+}
+
+func (tt *traceTap) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	if tt.tap.active() {
+		tt.tap.publishTraces(td)
+	}
+	return tt.next.ConsumeTraces(ctx, td)
+}
+
+type traceTapOld struct {
+	tap  *Tap
+	next consumer.TraceConsumerOld
+}
+
+func (tt *traceTapOld) ConsumeTraceData(ctx context.Context, td consumerdata.TraceData) error {
+	if tt.tap.active() {
+		tt.tap.publishTraces(internaldata.OCToTraceData(td))
+	}
+	return tt.next.ConsumeTraceData(ctx, td)
+}
+
+type metricsTap struct {
+	tap  *Tap
+	next consumer.MetricsConsumer
+}
+
+func (mt *metricsTap) ConsumeMetrics(ctx context.Context, md pdata.Metrics) error {
+	if mt.tap.active() {
+		mt.tap.publishMetrics(md)
+	}
+	return mt.next.ConsumeMetrics(ctx, md)
+}
+
+type metricsTapOld struct {
+	tap  *Tap
+	next consumer.MetricsConsumerOld
+}
+
+func (mt *metricsTapOld) ConsumeMetricsData(ctx context.Context, md consumerdata.MetricsData) error {
+	if mt.tap.active() {
+		mt.tap.publishMetrics(pdatautil.MetricsFromMetricsData([]consumerdata.MetricsData{md}))
+	}
+	return mt.next.ConsumeMetricsData(ctx, md)
+}
+
+type logsTap struct {
+	tap  *Tap
+	next consumer.LogsConsumer
+}
+
+func (lt *logsTap) ConsumeLogs(ctx context.Context, ld pdata.Logs) error {
+	if lt.tap.active() {
+		lt.tap.publishLogs(ld)
+	}
+	return lt.next.ConsumeLogs(ctx, ld)
+}
@@ -0,0 +1,148 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package datatap lets the zPages sample the data flowing through the components of the
+// pipelines. The pipelines builder wraps the consumers around each component with a tap, which
+// only encodes the data when someone is subscribed to it.
+package datatap
+
+import (
+	"bytes"
+	"encoding/json"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/gogo/protobuf/proto"
+
+	"go.opentelemetry.io/collector/consumer/pdata"
+	"go.opentelemetry.io/collector/consumer/pdatautil"
+	"go.opentelemetry.io/collector/internal/data"
+	otlplogs "go.opentelemetry.io/collector/internal/data/opentelemetry-proto-gen/collector/logs/v1"
+	otlpmetrics "go.opentelemetry.io/collector/internal/data/opentelemetry-proto-gen/collector/metrics/v1"
+	otlptrace "go.opentelemetry.io/collector/internal/data/opentelemetry-proto-gen/collector/trace/v1"
+)
+
+// ReceiverID returns the ID of the tap on the data sent by a receiver.
+func ReceiverID(receiver string) string {
+	return "receiver/" + receiver
+}
+
+// ProcessorID returns the ID of the tap on the data sent by a processor of a pipeline.
+func ProcessorID(pipeline, processor string) string {
+	return "processor/" + pipeline + "/" + processor
+}
+
+// ExporterID returns the ID of the tap on the data received by an exporter.
+func ExporterID(exporter string) string {
+	return "exporter/" + exporter
+}
+
+// Record is a sample of the data that went through a tap, encoded as OTLP JSON.
+type Record struct {
+	Time     time.Time       `json:"time"`
+	DataType string          `json:"data_type"`
+	Data     json.RawMessage `json:"data"`
+}
+
+// Tap distributes the data that goes through a component to its subscribers.
+type Tap struct {
+	// subscribed is the number of subscribers, read atomically so an unused tap costs a
+	// single atomic load.
+	subscribed  int32
+	mu          sync.Mutex
+	subscribers map[chan Record]struct{}
+}
+
+var (
+	mu   sync.Mutex
+	taps = make(map[string]*Tap)
+)
+
+// Get returns the tap with the given ID, creating it if needed.
+func Get(id string) *Tap {
+	mu.Lock()
+	defer mu.Unlock()
+	t, ok := taps[id]
+	if !ok {
+		t = &Tap{subscribers: make(map[chan Record]struct{})}
+		taps[id] = t
+	}
+	return t
+}
+
+// Lookup returns the tap with the given ID, if it was created by a pipeline.
+func Lookup(id string) (*Tap, bool) {
+	mu.Lock()
+	defer mu.Unlock()
+	t, ok := taps[id]
+	return t, ok
+}
+
+// Subscribe returns a channel receiving the records going through the tap. Records are dropped
+// when the channel buffer is full, so a slow subscriber only gets a sample of the data and never
+// slows down the pipeline. The returned function must be called to unsubscribe.
+func (t *Tap) Subscribe(buffer int) (<-chan Record, func()) {
+	ch := make(chan Record, buffer)
+	t.mu.Lock()
+	t.subscribers[ch] = struct{}{}
+	atomic.AddInt32(&t.subscribed, 1)
+	t.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			t.mu.Lock()
+			delete(t.subscribers, ch)
+			atomic.AddInt32(&t.subscribed, -1)
+			t.mu.Unlock()
+		})
+	}
+}
+
+func (t *Tap) active() bool {
+	return atomic.LoadInt32(&t.subscribed) > 0
+}
+
+func (t *Tap) publish(dataType string, msg proto.Message) {
+	var buf bytes.Buffer
+	if err := (&jsonpb.Marshaler{}).Marshal(&buf, msg); err != nil {
+		return
+	}
+	rec := Record{Time: time.Now(), DataType: dataType, Data: buf.Bytes()}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for ch := range t.subscribers {
+		select {
+		case ch <- rec:
+		default:
+		}
+	}
+}
+
+func (t *Tap) publishTraces(td pdata.Traces) {
+	t.publish("traces", &otlptrace.ExportTraceServiceRequest{ResourceSpans: pdata.TracesToOtlp(td)})
+}
+
+func (t *Tap) publishMetrics(md pdata.Metrics) {
+	t.publish("metrics", &otlpmetrics.ExportMetricsServiceRequest{
+		ResourceMetrics: data.MetricDataToOtlp(pdatautil.MetricsToInternalMetrics(md)),
+	})
+}
+
+func (t *Tap) publishLogs(ld pdata.Logs) {
+	t.publish("logs", &otlplogs.ExportLogsServiceRequest{ResourceLogs: pdata.LogsToOtlp(ld)})
+}
@@ -0,0 +1,129 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datatap
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumerdata"
+	"go.opentelemetry.io/collector/consumer/pdatautil"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/internal/data/testdata"
+)
+
+func TestIDs(t *testing.T) {
+	assert.Equal(t, "receiver/otlp", ReceiverID("otlp"))
+	assert.Equal(t, "processor/traces/batch", ProcessorID("traces", "batch"))
+	assert.Equal(t, "exporter/logging", ExporterID("logging"))
+}
+
+func TestLookup(t *testing.T) {
+	_, ok := Lookup("receiver/TestLookup")
+	assert.False(t, ok)
+
+	tap := Get("receiver/TestLookup")
+	found, ok := Lookup("receiver/TestLookup")
+	assert.True(t, ok)
+	assert.Same(t, tap, found)
+}
+
+func TestTraceTap(t *testing.T) {
+	sink := new(exportertest.SinkTraceExporter)
+	tc := WrapTraceConsumer("exporter/TestTraceTap", sink).(consumer.TraceConsumer)
+	td := testdata.GenerateTraceDataOneSpan()
+
+	// Without subscribers the data is only forwarded.
+	require.NoError(t, tc.ConsumeTraces(context.Background(), td))
+	assert.Equal(t, 1, sink.SpansCount())
+
+	records, unsubscribe := Get("exporter/TestTraceTap").Subscribe(1)
+	require.NoError(t, tc.ConsumeTraces(context.Background(), td))
+	// The buffer is full, the record of this call is dropped.
+	require.NoError(t, tc.ConsumeTraces(context.Background(), td))
+	assert.Equal(t, 3, sink.SpansCount())
+
+	rec := <-records
+	assert.Equal(t, "traces", rec.DataType)
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal(rec.Data, &body))
+	assert.Contains(t, body, "resourceSpans")
+	assert.Len(t, records, 0)
+
+	unsubscribe()
+	unsubscribe()
+	require.NoError(t, tc.ConsumeTraces(context.Background(), td))
+	assert.Len(t, records, 0)
+}
+
+func TestTraceTapOld(t *testing.T) {
+	sink := new(exportertest.SinkTraceExporterOld)
+	tc := WrapTraceConsumer("exporter/TestTraceTapOld", sink).(consumer.TraceConsumerOld)
+
+	records, unsubscribe := Get("exporter/TestTraceTapOld").Subscribe(1)
+	defer unsubscribe()
+	require.NoError(t, tc.ConsumeTraceData(context.Background(), consumerdata.TraceData{}))
+	assert.Len(t, sink.AllTraces(), 1)
+	assert.Equal(t, "traces", (<-records).DataType)
+}
+
+func TestMetricsTap(t *testing.T) {
+	sink := new(exportertest.SinkMetricsExporter)
+	mc := WrapMetricsConsumer("exporter/TestMetricsTap", sink).(consumer.MetricsConsumer)
+
+	records, unsubscribe := Get("exporter/TestMetricsTap").Subscribe(1)
+	defer unsubscribe()
+	md := pdatautil.MetricsFromInternalMetrics(testdata.GenerateMetricDataOneMetric())
+	require.NoError(t, mc.ConsumeMetrics(context.Background(), md))
+	assert.Len(t, sink.AllMetrics(), 1)
+
+	rec := <-records
+	assert.Equal(t, "metrics", rec.DataType)
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal(rec.Data, &body))
+	assert.Contains(t, body, "resourceMetrics")
+}
+
+func TestMetricsTapOld(t *testing.T) {
+	sink := new(exportertest.SinkMetricsExporterOld)
+	mc := WrapMetricsConsumer("exporter/TestMetricsTapOld", sink).(consumer.MetricsConsumerOld)
+
+	records, unsubscribe := Get("exporter/TestMetricsTapOld").Subscribe(1)
+	defer unsubscribe()
+	require.NoError(t, mc.ConsumeMetricsData(context.Background(), consumerdata.MetricsData{}))
+	assert.Len(t, sink.AllMetrics(), 1)
+	assert.Equal(t, "metrics", (<-records).DataType)
+}
+
+func TestLogsTap(t *testing.T) {
+	sink := new(exportertest.SinkLogsExporter)
+	lc := WrapLogsConsumer("exporter/TestLogsTap", sink)
+
+	records, unsubscribe := Get("exporter/TestLogsTap").Subscribe(1)
+	defer unsubscribe()
+	require.NoError(t, lc.ConsumeLogs(context.Background(), testdata.GenerateLogDataOneLog()))
+	assert.Equal(t, 1, sink.LogRecordsCount())
+
+	rec := <-records
+	assert.Equal(t, "logs", rec.DataType)
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal(rec.Data, &body))
+	assert.Contains(t, body, "resourceLogs")
+}
@@ -227,11 +227,11 @@ var _escData = map[string]*_escFile{
 		name:    "component_header.html",
 		local:   "templates/component_header.html",
 		size:    156,
-		modtime: 1588202772,
+		modtime: 1596576352,
 		compressed: `
 H4sIAAAAAAAC/1SMsQqDMBRFd7/iIq7q5lBiltKt9B8CPklQX6R1e9x/L6ZQ2vXcc65ZE3AZ0V3ztmcV
 PW467TnpQVZmzZp0Kfs96VJQizTjw1uyAgAXB+8C4lPmsT4fydqbdY+wCen64F0fB19iWV/yF/54X0en
-U3kHAAD//zT+SdCcAAAA
+U3kPADT+SdCcAAAA
 `,
 	},
 
@@ -239,12 +239,12 @@ U3kHAAD//zT+SdCcAAAA
 		name:    "extensions_table.html",
 		local:   "templates/extensions_table.html",
 		size:    353,
-		modtime: 1588201931,
+		modtime: 1596576352,
 		compressed: `
 H4sIAAAAAAAC/2SQwU7DMBBE7/2KlemRNJwjxxwQHDnwB248DRbOOnK2tGD531HTQIvqk1fzZjU7Wuw2
 gCb5CmjVNiaHVE2j7Tz3DT0osyIiynltqWlp8xSHMTJYntmN0bOUsgDJcg9ap3jw7HC8n7+z5y0epgU7
 oxX5HeETfMGv9NPTkv4i2e6jT3HPrqE7AEui8yaECbdWkzPYUXWlaHFkg++5VR1YkJTRlt4Tdq06HVfK
-4zeOAp58ZLYD2pw3L/sQXu2AUpT5N+raGl2Lu0TRtaTfqsCulJWu52bNTwAAAP//sz5qjmEBAAA=
+4zeOAp58ZLYD2pw3L/sQXu2AUpT5N+raGl2Lu0TRtaTfqsCulJWu52bNzwCzPmqOYQEAAA==
 `,
 	},
 
@@ -252,9 +252,9 @@ oxX5HeETfMGv9NPTkv4i2e6jT3HPrqE7AEui8yaECbdWkzPYUXWlaHFkg++5VR1YkJTRlt4Tdq06HVfK
 		name:    "footer.html",
 		local:   "templates/footer.html",
 		size:    15,
-		modtime: 1588172790,
+		modtime: 1596576352,
 		compressed: `
-H4sIAAAAAAAC/7LRT8pPqbTjstHPKMnNsQMEAAD//wEFevAPAAAA
+H4sIAAAAAAAC/7LRT8pPqbTjstHPKMnNsQMMAAEFevAPAAAA
 `,
 	},
 
@@ -262,13 +262,31 @@ H4sIAAAAAAAC/7LRT8pPqbTjstHPKMnNsQMEAAD//wEFevAPAAAA
 		name:    "header.html",
 		local:   "templates/header.html",
 		size:    467,
-		modtime: 1588201217,
+		modtime: 1596576352,
 		compressed: `
 H4sIAAAAAAAC/5TRMU8sIRAH8P4+BY/25eC9szGGxUItLIwW11giO7uMB8wG5rxsLvfdDdnTxNhoBeFP
 fpnM3/y5fbzZPj/dicAp2pVph4guj52ELK0J4Hq7EkIIk4Cd8MGVCtzJPQ/rS3mOGDmCPR7Vtl1OJ6OX
 lyWNmHeiQOxkDVTY71mgpyxFKDB0UuvD4aBogswQIQGXWSHpwb21Xwo9Sf1d4jlCDQD8wQTmqV5pPVDm
 qkaiMYKbsCpPSTfpenAJ49w9OIaCLv6995Sr/AXtqQc1Aqc+tgn/qwv1T6czpzD3ONJ6wrxTCbPy9ROv
-vuDEoocBiqjF/5RszGuV1uhFsCujl0bMC/Vz62vzZe1hY98DAAD//7qRGmLTAQAA
+vuDEoocBiqjF/5RszGuV1uhFsCujl0bMC/Vz62vzZe1hY98HALqRGmLTAQAA
+`,
+	},
+
+	"/templates/pipelines_graph.html": {
+		name:    "pipelines_graph.html",
+		local:   "templates/pipelines_graph.html",
+		size:    1225,
+		modtime: 1792210435,
+		compressed: `
+H4sIAAAAAAAC/8STP2/bMBDFd32Kg1oEyWCpRToYMqUMTQpkcYPCaOeTeLaJ0iRBUoYNgt+9kGTFUeMC
+6RQv5t+nx9+9C+GjRwNFCdkKzYPiRgvlY0xC4LQWiiBVmlMaYwIAwLjYg/NHSWVaa8vJFvDZHMBpKTh8
+mM/nCxjWZxa5aF0BX8xhAQY5F2rTz2DerezQboQ6bXPhjMRjAUJJoWhWS938XsCerBcNyhlKsVEF7ATn
+ktIqgdOP1VUI2VJzypa4oxhZXp93Q7CoNgTDga+6VZ6si/H5wCBhe43+egEhiDVkP1G2FGMI5xFJRzHO
+QiDFX0j8Pe/kGMLW0rpMQ5hCvRO8HP2u0Dzex5hWHg3LcXDNci72VTKKPj/gSRjqwIzm2fa2M/2tlXIw
+DtchZI/KtH51NBTjDcu3tydNj7WkadFmzmDT1+PTS5reVlM2nkOPvkwb6uCl0/0J5B/UkNi/Bnw+6Gln
+JPoxUnDd/f0SfrtCAxl0QbyJsSN44StTzAMsz6vkopcnqxtyTl80wzyvrlTtzOLKorWLYfxa7R8E3vyO
+C/YuPOKNZv6rFA8Ho61/t1KwfAwSy/vwVcnYP8OqqZYazJhpQEtgW6WE2mQsN+f8M3Pupbu1tjv0Jdc+
+re6/r2CYd63T3/kzALB2tcXJBAAA
 `,
 	},
 
@@ -276,16 +294,16 @@ vuDEoocBiqjF/5RszGuV1uhFsCujl0bMC/Vz62vzZe1hY98DAAD//7qRGmLTAQAA
 		name:    "pipelines_table.html",
 		local:   "templates/pipelines_table.html",
 		size:    1946,
-		modtime: 1588201931,
+		modtime: 1596576352,
 		compressed: `
-H4sIAAAAAAAC/7SVwXLTMBCG7zyFxnRyIjVcU1scSpnhAMN0eAFZ2gRNlZVmJbdujd+dsWyrTp0LtL5k
-rOjX/tlv/8hFEJUB5sOjgTKrLCmgrXdCajzs2MeMv2OMsSLQ8DAsFJPWeCew/MSE0QcsDewDLyr+tTbm
-hzhCkVe8yIM6OcU3WHl3NXz+mS8W0oWBBAxAvcU3dHX49ejW9PheBxHAX1v09RHUFxHEim63IEHfA/kV
-PX6SleC9XdXkpnGWwqKRIp/i07YXgu1Kdnltj84iYLhB5azG0HWjgAQegF2QfdCooPkQH+OZW/vgR9kg
-3TK9Z3AP+Cyf7Y+5TdEW8u5Atka1Y+8BIOOzSmA8LI/ytgVUbDvb6VG1bW93OUW962Kn/wZxKpLC/Koq
-Z+L6X/XGgWbDRGeETkcDMo0GZD+a+CNSil+AjLUF+02wL7M+AF33+clpB0YjoDhCuQC6eZJTPpIA5Mn3
-dxpVSaNlxidFkQu+dK/oZSuAaj7VN0a1IUF0dZ6eIzvRc2QTvef/5yr4HNklPjd5Rn5Rcpbf2XbWJZhw
-QeMmXNC4hCvdNKvQgsYtacFoGWFFxSvCNl+lu3HQFXl8JfO/AQAA//9We3KLmgcAAA==
+H4sIAAAAAAAC/7SVTW7bMBCF9z0FoQZe1VG7dSR2kaZAFy2KoBegyLFLhB4SQypRouruhX7IyJE3bSIv
+DNJ+nOf55pkqgqgMMB8eDZRZZUkBbb0TUuNhxz5m/B1jjBWBxsW4UUxa453A8hMTRh+wNLAPvKj419qY
+H+IIRV7xIg/q5BTfYOXd1fj+Z75ZSBcGEjAA9Rbf0NXh16Nb0+N7HUQAf23R10dQX0QQK7rdggR9D+RX
+9PhJVoL3dlWTm8ZZCotGijzGp20vBNuV7PLaHp1FwHCDylmNoesmAQk8ALsg+6BRQfNhWA5nbu2Dn2Sj
+dMv0nsE94LN89v2U2xRtIe8OZGtUO/YeADI+qwTGw/Iob1tAxbZdd4KqbXu7yxj1rhs6/TeIsUgK86uq
+nInrf9WbBpqNE50ROh0NyDQakP1ohh+RUvwCZP8qBPtNsC+zPgBd9/nJaQdGI6A4QrkAunmSMR9JAPLk
+8zuNqqTJMuNRUeSCL90retkKoJpP9Y1RbUgQXZ2n58hGeo5sovf8/1wFnyO7xOeiZ8aj5Cy/s+2sSzDh
+gsZFXNC4hCvdNKvQgsYtacFkmfGoeEXY5rt0N466Ih8eyfzvAFZ7couaBwAA
 `,
 	},
 
@@ -293,13 +311,12 @@ QeMmXNC4hCvdNKvQgsYtacFoGWFFxSvCNl+lu3HQFXl8JfO/AQAA//9We3KLmgcAAA==
 		name:    "properties_table.html",
 		local:   "templates/properties_table.html",
 		size:    420,
-		modtime: 1588200907,
+		modtime: 1596576352,
 		compressed: `
-H4sIAAAAAAAC/2SRwW7DIBBE7/6KVRr1VMc5u5gfqFT11Ds2U8sqWVuwqRoR/r1yTCpb4YAEO48ZDarV
-MR7ezQkp1apqdaHEtA4U5OLQ7NrRW/gyTKYbuK/puNMFEVGMtB/Y4pfqho6UUr71hnvk0Qvt4XACyyw6
-fPhxgpcBIasXoqThi/ADztRqOC8l/j+L6b57P57Z1vQEIEdZnoELeER1jGBL5WqixJJxQ89NBxZ4favg
-nvTaQ95wSWnuQlVi9RrUz9yG6XXZr+vDg3TrsTX4NO6M2WLDVOLv3YJtSoWqbl+h/wIAAP//aLmk3KQB
-AAA=
+H4sIAAAAAAAC/2SRwW6DMBBE73zFKo16KiFnavwDlaqeejd4ilCdBZlN1cjZf68IpCKJD5bsmecdjU1t
+U9q9uwNUS1PUNjPi6gAa5RRQbeo+esR8HFzTcVvSfmMzIqKUaNuxxy+VFe1JdbmNjlss0gttEXAAy2Ta
+fcR+QJQO4+KeiZy6L8IPeKFW4rSMxP8srvluY39kX9ITgCXK/AzCiEfUpgT2lK8UI55c6FquGrAg2ksF
+16TnFvKGk+rUhSnE2zVon7keh9d5P68PD9bbGbcDPl04QvWOKSReuwV71cwUl6+wfwMAaLmk3KQBAAA=
 `,
 	},
 
@@ -317,6 +334,7 @@ var _escDirs = map[string][]os.FileInfo{
 		_escData["/templates/extensions_table.html"],
 		_escData["/templates/footer.html"],
 		_escData["/templates/header.html"],
+		_escData["/templates/pipelines_graph.html"],
 		_escData["/templates/pipelines_table.html"],
 		_escData["/templates/properties_table.html"],
 	},
@@ -24,14 +24,16 @@ import (
 var (
 	fs                = FS(false)
 	templateFunctions = template.FuncMap{
-		"even":     even,
-		"getKey":   getKey,
-		"getValue": getValue,
+		"even":        even,
+		"getKey":      getKey,
+		"getValue":    getValue,
+		"nodeWithTap": nodeWithTap,
 	}
 	componentHeaderTemplate = parseTemplate("component_header")
 	extensionsTableTemplate = parseTemplate("extensions_table")
 	headerTemplate          = parseTemplate("header")
 	footerTemplate          = parseTemplate("footer")
+	pipelinesGraphTemplate  = parseTemplate("pipelines_graph")
 	pipelinesTableTemplate  = parseTemplate("pipelines_table")
 	propertiesTableTemplate = parseTemplate("properties_table")
 )
@@ -105,6 +107,43 @@ func WriteHTMLPipelinesSummaryTable(w io.Writer, spd SummaryPipelinesTableData)
 	}
 }
 
+// PipelinesGraphData contains data for the pipelines graph template.
+type PipelinesGraphData struct {
+	TapEndpoint string
+	Pipelines   []PipelineGraphData
+}
+
+// PipelineGraphData contains data for the graph of one pipeline.
+type PipelineGraphData struct {
+	FullName   string
+	InputType  string
+	Receivers  []GraphNodeData
+	Processors []GraphNodeData
+	Exporters  []GraphNodeData
+}
+
+// GraphNodeData contains data for one component in the pipelines graph template.
+type GraphNodeData struct {
+	Name     string
+	TapID    string
+	Counters []GraphCounterData
+}
+
+// GraphCounterData contains one counter of a component in the pipelines graph template.
+type GraphCounterData struct {
+	Name string
+	// Value is empty when the counter is not recorded, e.g. when the telemetry level is none.
+	Value string
+}
+
+// WriteHTMLPipelinesGraph writes the graph of the components of each pipeline with their counters.
+// It does not write the header or footer.
+func WriteHTMLPipelinesGraph(w io.Writer, pgd PipelinesGraphData) {
+	if err := pipelinesGraphTemplate.Execute(w, pgd); err != nil {
+		log.Printf("zpages: executing template: %v", err)
+	}
+}
+
 // ComponentHeaderData contains data for component header template.
 type ComponentHeaderData struct {
 	Name              string
@@ -150,3 +189,14 @@ func getKey(row [2]string) string {
 func getValue(row [2]string) string {
 	return row[1]
 }
+
+// graphNodeTemplateData is the data of the template of a node of the pipelines graph, which needs
+// the node and the endpoint of the tap page.
+type graphNodeTemplateData struct {
+	Node        GraphNodeData
+	TapEndpoint string
+}
+
+func nodeWithTap(node GraphNodeData, tapEndpoint string) graphNodeTemplateData {
+	return graphNodeTemplateData{Node: node, TapEndpoint: tapEndpoint}
+}
@@ -85,3 +85,33 @@ func TestNoCrash(t *testing.T) {
 	assert.NotPanics(t, func() { WriteHTMLFooter(buf) })
 	assert.NotPanics(t, func() { WriteHTMLFooter(buf) })
 }
+
+func TestWriteHTMLPipelinesGraph(t *testing.T) {
+	buf := new(bytes.Buffer)
+	WriteHTMLPipelinesGraph(buf, PipelinesGraphData{
+		TapEndpoint: "tapz",
+		Pipelines: []PipelineGraphData{{
+			FullName:  "traces",
+			InputType: "traces",
+			Receivers: []GraphNodeData{{
+				Name:     "otlp",
+				TapID:    "receiver/otlp",
+				Counters: []GraphCounterData{{Name: "accepted", Value: "10"}, {Name: "refused"}},
+			}},
+			Processors: []GraphNodeData{{Name: "batch", TapID: "processor/traces/batch"}},
+			Exporters:  []GraphNodeData{{Name: "logging", TapID: "exporter/logging"}},
+		}},
+	})
+	out := buf.String()
+	assert.Contains(t, out, "<h3>traces (traces)</h3>")
+	assert.Contains(t, out, "<b>otlp</b>")
+	assert.Contains(t, out, "accepted: 10")
+	assert.Contains(t, out, "refused: -")
+	assert.Contains(t, out, `<a href="tapz?id=receiver%2fotlp">tap</a>`)
+	assert.Contains(t, out, `<a href="tapz?id=processor%2ftraces%2fbatch">tap</a>`)
+	assert.Contains(t, out, "<b>logging</b>")
+
+	buf.Reset()
+	WriteHTMLPipelinesGraph(buf, PipelinesGraphData{})
+	assert.Contains(t, buf.String(), "No pipelines are running.")
+}
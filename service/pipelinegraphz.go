@@ -0,0 +1,276 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"go.opencensus.io/stats/view"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.opentelemetry.io/collector/service/internal"
+	"go.opentelemetry.io/collector/service/internal/datatap"
+)
+
+const (
+	// Defaults and limits of the number of records and of the duration of a tap.
+	defaultTapLimit   = 10
+	maxTapLimit       = 100
+	defaultTapTimeout = 30 * time.Second
+	maxTapTimeout     = 5 * time.Minute
+)
+
+// graphCounter describes a counter recorded by obsreport for the components of one kind.
+type graphCounter struct {
+	name     string
+	viewName string
+	tagKey   string
+}
+
+// componentCounters returns the obsreport counters shown for each kind of component and data type.
+func componentCounters(kind string, dataType configmodels.DataType) []graphCounter {
+	var accepted, refused, dropped, sent, failed string
+	switch dataType {
+	case configmodels.TracesDataType:
+		accepted, refused, dropped = obsreport.AcceptedSpansKey, obsreport.RefusedSpansKey, obsreport.DroppedSpansKey
+		sent, failed = obsreport.SentSpansKey, obsreport.FailedToSendSpansKey
+	case configmodels.MetricsDataType:
+		accepted, refused, dropped = obsreport.AcceptedMetricPointsKey, obsreport.RefusedMetricPointsKey, obsreport.DroppedMetricPointsKey
+		sent, failed = obsreport.SentMetricPointsKey, obsreport.FailedToSendMetricPointsKey
+	case configmodels.LogsDataType:
+		accepted, refused, dropped = obsreport.AcceptedLogRecordsKey, obsreport.RefusedLogRecordsKey, obsreport.DroppedLogRecordsKey
+		sent, failed = obsreport.SentLogRecordsKey, obsreport.FailedToSendLogRecordsKey
+	default:
+		return nil
+	}
+
+	switch kind {
+	case obsreport.ReceiverKey:
+		return []graphCounter{
+			{name: "accepted", viewName: kind + "/" + accepted, tagKey: kind},
+			{name: "refused", viewName: kind + "/" + refused, tagKey: kind},
+		}
+	case obsreport.ProcessorKey:
+		return []graphCounter{
+			{name: "accepted", viewName: kind + "/" + accepted, tagKey: kind},
+			{name: "refused", viewName: kind + "/" + refused, tagKey: kind},
+			{name: "dropped", viewName: kind + "/" + dropped, tagKey: kind},
+		}
+	case obsreport.ExporterKey:
+		return []graphCounter{
+			{name: "sent", viewName: kind + "/" + sent, tagKey: kind},
+			{name: "send failed", viewName: kind + "/" + failed, tagKey: kind},
+		}
+	}
+	return nil
+}
+
+// viewCounts returns the values of a sum view by value of the given tag, summed over the other
+// tags, or nil if the view is not registered.
+func viewCounts(viewName, tagKey string) map[string]int64 {
+	rows, err := view.RetrieveData(viewName)
+	if err != nil {
+		return nil
+	}
+	counts := make(map[string]int64)
+	for _, row := range rows {
+		sum, ok := row.Data.(*view.SumData)
+		if !ok {
+			continue
+		}
+		for _, t := range row.Tags {
+			if t.Key.Name() == tagKey {
+				counts[t.Value] += int64(sum.Value)
+			}
+		}
+	}
+	return counts
+}
+
+// graphNodes returns the nodes of the components of the given kind, with their counters.
+// The counters of a processor are shared by all the pipelines using it, as obsreport does not
+// record the pipeline.
+func graphNodes(kind string, dataType configmodels.DataType, names []string, tapID func(string) string) []internal.GraphNodeData {
+	counters := componentCounters(kind, dataType)
+	values := make([]map[string]int64, len(counters))
+	for i, c := range counters {
+		values[i] = viewCounts(c.viewName, c.tagKey)
+	}
+
+	nodes := make([]internal.GraphNodeData, 0, len(names))
+	for _, name := range names {
+		node := internal.GraphNodeData{Name: name, TapID: tapID(name)}
+		for i, c := range counters {
+			counter := internal.GraphCounterData{Name: c.name}
+			if values[i] != nil {
+				counter.Value = strconv.FormatInt(values[i][name], 10)
+			}
+			node.Counters = append(node.Counters, counter)
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+func (app *Application) getPipelinesGraphData() internal.PipelinesGraphData {
+	data := internal.PipelinesGraphData{TapEndpoint: tapzPath}
+	app.componentsMu.RLock()
+	defer app.componentsMu.RUnlock()
+	for c := range app.builtPipelines {
+		pipelineName := c.Name
+		data.Pipelines = append(data.Pipelines, internal.PipelineGraphData{
+			FullName:  c.Name,
+			InputType: string(c.InputType),
+			Receivers: graphNodes(obsreport.ReceiverKey, c.InputType, c.Receivers, datatap.ReceiverID),
+			Processors: graphNodes(obsreport.ProcessorKey, c.InputType, c.Processors, func(name string) string {
+				return datatap.ProcessorID(pipelineName, name)
+			}),
+			Exporters: graphNodes(obsreport.ExporterKey, c.InputType, c.Exporters, datatap.ExporterID),
+		})
+	}
+
+	sort.Slice(data.Pipelines, func(i, j int) bool {
+		return data.Pipelines[i].FullName < data.Pipelines[j].FullName
+	})
+	return data
+}
+
+// handlePipelineGraphzRequest renders the graph of the components of each pipeline with the
+// counters recorded by obsreport, as HTML or, with the "format=dot" query parameter, in the
+// Graphviz DOT language.
+func (app *Application) handlePipelineGraphzRequest(w http.ResponseWriter, r *http.Request) {
+	data := app.getPipelinesGraphData()
+	if r.URL.Query().Get("format") == "dot" {
+		w.Header().Set("Content-Type", "text/vnd.graphviz; charset=utf-8")
+		writePipelinesDOT(w, data)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	internal.WriteHTMLHeader(w, internal.HeaderData{Title: "Pipeline Graph"})
+	internal.WriteHTMLPipelinesGraph(w, data)
+	internal.WriteHTMLFooter(w)
+}
+
+// writePipelinesDOT writes the pipelines in the Graphviz DOT language, one subgraph per pipeline.
+func writePipelinesDOT(w io.Writer, data internal.PipelinesGraphData) {
+	fmt.Fprintln(w, "digraph pipelines {")
+	fmt.Fprintln(w, "  node [shape=box];")
+	for _, p := range data.Pipelines {
+		fmt.Fprintf(w, "  subgraph %q {\n", "cluster_"+p.FullName)
+		fmt.Fprintf(w, "    label=%q;\n", p.FullName)
+		var nodes []internal.GraphNodeData
+		nodes = append(nodes, p.Receivers...)
+		nodes = append(nodes, p.Processors...)
+		nodes = append(nodes, p.Exporters...)
+		for _, n := range nodes {
+			label := n.Name
+			for _, c := range n.Counters {
+				value := c.Value
+				if value == "" {
+					value = "-"
+				}
+				label += "\n" + c.Name + ": " + value
+			}
+			// Nodes are prefixed with the pipeline name, as receivers and exporters can be
+			// shared by several pipelines.
+			fmt.Fprintf(w, "    %q [label=%q];\n", p.FullName+"/"+n.TapID, label)
+		}
+
+		// Receivers are linked to the first processor, or to the exporters if there is none.
+		sources := p.Receivers
+		for _, proc := range p.Processors {
+			for _, src := range sources {
+				fmt.Fprintf(w, "    %q -> %q;\n", p.FullName+"/"+src.TapID, p.FullName+"/"+proc.TapID)
+			}
+			sources = []internal.GraphNodeData{proc}
+		}
+		for _, src := range sources {
+			for _, exp := range p.Exporters {
+				fmt.Fprintf(w, "    %q -> %q;\n", p.FullName+"/"+src.TapID, p.FullName+"/"+exp.TapID)
+			}
+		}
+		fmt.Fprintln(w, "  }")
+	}
+	fmt.Fprintln(w, "}")
+}
+
+// handleTapzRequest streams a sample of the data going through a component, one JSON record
+// per line, until "limit" records were sent, "timeout" expired or the client went away.
+func handleTapzRequest(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	id := query.Get("id")
+	tap, ok := datatap.Lookup(id)
+	if !ok {
+		http.Error(w, fmt.Sprintf("unknown tap %q", id), http.StatusNotFound)
+		return
+	}
+
+	limit := defaultTapLimit
+	if v := query.Get("limit"); v != "" {
+		l, err := strconv.Atoi(v)
+		if err != nil || l <= 0 || l > maxTapLimit {
+			http.Error(w, fmt.Sprintf("limit must be between 1 and %d", maxTapLimit), http.StatusBadRequest)
+			return
+		}
+		limit = l
+	}
+	timeout := defaultTapTimeout
+	if v := query.Get("timeout"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 || d > maxTapTimeout {
+			http.Error(w, fmt.Sprintf("timeout must be a duration between 0 and %s", maxTapTimeout), http.StatusBadRequest)
+			return
+		}
+		timeout = d
+	}
+
+	records, unsubscribe := tap.Subscribe(1)
+	defer unsubscribe()
+
+	// Served as text so the browser displays the records as they arrive.
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	flusher, _ := w.(http.Flusher)
+	if flusher != nil {
+		flusher.Flush()
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	enc := json.NewEncoder(w)
+	for sent := 0; sent < limit; sent++ {
+		select {
+		case rec := <-records:
+			if err := enc.Encode(rec); err != nil {
+				return
+			}
+			if flusher != nil {
+				flusher.Flush()
+			}
+		case <-timer.C:
+			return
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,221 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/internal/data/testdata"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.opentelemetry.io/collector/obsreport/obsreporttest"
+	"go.opentelemetry.io/collector/service/builder"
+	"go.opentelemetry.io/collector/service/internal"
+	"go.opentelemetry.io/collector/service/internal/datatap"
+)
+
+func TestGetPipelinesGraphData(t *testing.T) {
+	doneFn, err := obsreporttest.SetupRecordedMetricsTest()
+	require.NoError(t, err)
+	defer doneFn()
+
+	receiverCtx := obsreport.ReceiverContext(context.Background(), "otlp", "grpc", "")
+	ctx := obsreport.StartTraceDataReceiveOp(receiverCtx, "otlp", "grpc")
+	obsreport.EndTraceDataReceiveOp(ctx, "protobuf", 7, nil)
+	exporterCtx := obsreport.ExporterContext(context.Background(), "logging")
+	ctx = obsreport.StartTraceDataExportOp(exporterCtx, "logging")
+	obsreport.EndTraceDataExportOp(ctx, 5, 0, nil)
+
+	app := &Application{builtPipelines: builder.BuiltPipelines{
+		&configmodels.Pipeline{
+			Name:       "traces",
+			InputType:  configmodels.TracesDataType,
+			Receivers:  []string{"otlp"},
+			Processors: []string{"batch"},
+			Exporters:  []string{"logging"},
+		}: nil,
+		&configmodels.Pipeline{
+			Name:      "metrics",
+			InputType: configmodels.MetricsDataType,
+			Receivers: []string{"prometheus"},
+			Exporters: []string{"logging"},
+		}: nil,
+	}}
+
+	data := app.getPipelinesGraphData()
+	assert.Equal(t, tapzPath, data.TapEndpoint)
+	require.Len(t, data.Pipelines, 2)
+
+	metrics := data.Pipelines[0]
+	assert.Equal(t, "metrics", metrics.FullName)
+	assert.Empty(t, metrics.Processors)
+	assert.Equal(t, []internal.GraphNodeData{{
+		Name:     "prometheus",
+		TapID:    "receiver/prometheus",
+		Counters: []internal.GraphCounterData{{Name: "accepted", Value: "0"}, {Name: "refused", Value: "0"}},
+	}}, metrics.Receivers)
+
+	traces := data.Pipelines[1]
+	assert.Equal(t, "traces", traces.FullName)
+	assert.Equal(t, "traces", traces.InputType)
+	assert.Equal(t, []internal.GraphNodeData{{
+		Name:     "otlp",
+		TapID:    "receiver/otlp",
+		Counters: []internal.GraphCounterData{{Name: "accepted", Value: "7"}, {Name: "refused", Value: "0"}},
+	}}, traces.Receivers)
+	require.Len(t, traces.Processors, 1)
+	assert.Equal(t, "processor/traces/batch", traces.Processors[0].TapID)
+	assert.Len(t, traces.Processors[0].Counters, 3)
+	assert.Equal(t, []internal.GraphNodeData{{
+		Name:     "logging",
+		TapID:    "exporter/logging",
+		Counters: []internal.GraphCounterData{{Name: "sent", Value: "5"}, {Name: "send failed", Value: "0"}},
+	}}, traces.Exporters)
+}
+
+func TestGetPipelinesGraphDataWithoutViews(t *testing.T) {
+	app := &Application{builtPipelines: builder.BuiltPipelines{
+		&configmodels.Pipeline{
+			Name:      "logs",
+			InputType: configmodels.LogsDataType,
+			Receivers: []string{"fluentforward"},
+			Exporters: []string{"logging"},
+		}: nil,
+	}}
+
+	data := app.getPipelinesGraphData()
+	require.Len(t, data.Pipelines, 1)
+	// Counters without a registered view have no value.
+	assert.Equal(t, []internal.GraphCounterData{{Name: "accepted"}, {Name: "refused"}}, data.Pipelines[0].Receivers[0].Counters)
+}
+
+func TestWritePipelinesDOT(t *testing.T) {
+	data := internal.PipelinesGraphData{
+		Pipelines: []internal.PipelineGraphData{{
+			FullName:  "traces",
+			InputType: "traces",
+			Receivers: []internal.GraphNodeData{
+				{Name: "otlp", TapID: "receiver/otlp", Counters: []internal.GraphCounterData{{Name: "accepted", Value: "7"}}},
+				{Name: "jaeger", TapID: "receiver/jaeger", Counters: []internal.GraphCounterData{{Name: "accepted"}}},
+			},
+			Processors: []internal.GraphNodeData{{Name: "batch", TapID: "processor/traces/batch"}},
+			Exporters:  []internal.GraphNodeData{{Name: "logging", TapID: "exporter/logging"}},
+		}},
+	}
+
+	buf := new(bytes.Buffer)
+	writePipelinesDOT(buf, data)
+	assert.Equal(t, `digraph pipelines {
+  node [shape=box];
+  subgraph "cluster_traces" {
+    label="traces";
+    "traces/receiver/otlp" [label="otlp\naccepted: 7"];
+    "traces/receiver/jaeger" [label="jaeger\naccepted: -"];
+    "traces/processor/traces/batch" [label="batch"];
+    "traces/exporter/logging" [label="logging"];
+    "traces/receiver/otlp" -> "traces/processor/traces/batch";
+    "traces/receiver/jaeger" -> "traces/processor/traces/batch";
+    "traces/processor/traces/batch" -> "traces/exporter/logging";
+  }
+}
+`, buf.String())
+}
+
+func TestHandlePipelineGraphzRequest(t *testing.T) {
+	app := &Application{builtPipelines: builder.BuiltPipelines{
+		&configmodels.Pipeline{
+			Name:      "traces",
+			InputType: configmodels.TracesDataType,
+			Receivers: []string{"otlp"},
+			Exporters: []string{"logging"},
+		}: nil,
+	}}
+
+	rr := httptest.NewRecorder()
+	app.handlePipelineGraphzRequest(rr, httptest.NewRequest(http.MethodGet, "/debug/pipelinegraphz", nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), "Pipeline Graph")
+	assert.Contains(t, rr.Body.String(), "tapz?id=receiver%2fotlp")
+
+	rr = httptest.NewRecorder()
+	app.handlePipelineGraphzRequest(rr, httptest.NewRequest(http.MethodGet, "/debug/pipelinegraphz?format=dot", nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "text/vnd.graphviz; charset=utf-8", rr.Header().Get("Content-Type"))
+	assert.Contains(t, rr.Body.String(), `"traces/receiver/otlp" -> "traces/exporter/logging";`)
+}
+
+func TestHandleTapzRequestErrors(t *testing.T) {
+	datatap.Get("exporter/TestHandleTapzRequestErrors")
+
+	tests := []struct {
+		query string
+		code  int
+	}{
+		{query: "id=exporter/unknown", code: http.StatusNotFound},
+		{query: "id=exporter/TestHandleTapzRequestErrors&limit=0", code: http.StatusBadRequest},
+		{query: "id=exporter/TestHandleTapzRequestErrors&limit=1000", code: http.StatusBadRequest},
+		{query: "id=exporter/TestHandleTapzRequestErrors&limit=a", code: http.StatusBadRequest},
+		{query: "id=exporter/TestHandleTapzRequestErrors&timeout=-1s", code: http.StatusBadRequest},
+		{query: "id=exporter/TestHandleTapzRequestErrors&timeout=1h", code: http.StatusBadRequest},
+		{query: "id=exporter/TestHandleTapzRequestErrors&timeout=10ms", code: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			handleTapzRequest(rr, httptest.NewRequest(http.MethodGet, "/debug/tapz?"+tt.query, nil))
+			assert.Equal(t, tt.code, rr.Code)
+		})
+	}
+}
+
+func TestHandleTapzRequest(t *testing.T) {
+	sink := new(exportertest.SinkTraceExporter)
+	tc := datatap.WrapTraceConsumer("exporter/TestHandleTapzRequest", sink).(consumer.TraceConsumer)
+
+	done := make(chan struct{})
+	rr := httptest.NewRecorder()
+	go func() {
+		defer close(done)
+		handleTapzRequest(rr, httptest.NewRequest(http.MethodGet, "/debug/tapz?id=exporter/TestHandleTapzRequest&limit=1&timeout=10s", nil))
+	}()
+
+	// Send data until the handler subscribed and got a record.
+	td := testdata.GenerateTraceDataOneSpan()
+	for sent := false; !sent; {
+		require.NoError(t, tc.ConsumeTraces(context.Background(), td))
+		select {
+		case <-done:
+			sent = true
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+
+	assert.Equal(t, "text/plain; charset=utf-8", rr.Header().Get("Content-Type"))
+	var rec datatap.Record
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &rec))
+	assert.Equal(t, "traces", rec.DataType)
+	assert.Contains(t, string(rec.Data), "resourceSpans")
+}
@@ -24,8 +24,10 @@ import (
 	"os"
 	"os/signal"
 	"path"
+	"reflect"
 	"runtime"
 	"sort"
+	"sync"
 	"syscall"
 
 	"github.com/pkg/errors"
@@ -40,15 +42,18 @@ import (
 	"go.opentelemetry.io/collector/config/configcheck"
 	"go.opentelemetry.io/collector/config/configmodels"
 	"go.opentelemetry.io/collector/internal/collector/telemetry"
+	"go.opentelemetry.io/collector/internal/componentstatus"
 	"go.opentelemetry.io/collector/internal/version"
 	"go.opentelemetry.io/collector/service/builder"
 	"go.opentelemetry.io/collector/service/internal"
 )
 
 const (
-	servicezPath   = "servicez"
-	pipelinezPath  = "pipelinez"
-	extensionzPath = "extensionz"
+	servicezPath       = "servicez"
+	pipelinezPath      = "pipelinez"
+	pipelinegraphzPath = "pipelinegraphz"
+	tapzPath           = "tapz"
+	extensionzPath     = "extensionz"
 )
 
 // State defines Application's state.
@@ -72,14 +77,20 @@ type Application struct {
 	rootCmd         *cobra.Command
 	v               *viper.Viper
 	logger          *zap.Logger
-	builtExporters  builder.Exporters
-	builtReceivers  builder.Receivers
-	builtPipelines  builder.BuiltPipelines
 	builtExtensions builder.Extensions
 	stateChannel    chan State
 
-	factories component.Factories
-	config    *configmodels.Config
+	// componentsMu guards config and the built exporters, pipelines and receivers. They are only
+	// written by the goroutine running the application, which reads them without the lock, but
+	// they are replaced on reloads while the components and the zPages handlers read them.
+	componentsMu   sync.RWMutex
+	config         *configmodels.Config
+	builtExporters builder.Exporters
+	builtReceivers builder.Receivers
+	builtPipelines builder.BuiltPipelines
+
+	factories     component.Factories
+	configFactory ConfigFactory
 
 	// stopTestChan is used to terminate the application in end to end tests.
 	stopTestChan chan struct{}
@@ -89,6 +100,9 @@ type Application struct {
 
 	// asyncErrorChannel is used to signal a fatal error from any component.
 	asyncErrorChannel chan error
+
+	// configWatcher notifies changes of the config file, it is nil unless watching is enabled.
+	configWatcher *configWatcher
 }
 
 // Command returns Application's root command.
@@ -197,6 +211,7 @@ func New(params Parameters) (*Application, error) {
 // a fatal error (i.e.: an error that the instance can't recover from) after
 // its start function has already returned.
 func (app *Application) ReportFatalError(err error) {
+	componentstatus.SetStatus(componentstatus.KindService, "service", err)
 	app.asyncErrorChannel <- err
 }
 
@@ -225,12 +240,16 @@ func (app *Application) GetExtensions() map[configmodels.Extension]component.Ser
 }
 
 func (app *Application) GetExporters() map[configmodels.DataType]map[configmodels.Exporter]component.Exporter {
+	app.componentsMu.RLock()
+	defer app.componentsMu.RUnlock()
 	return app.builtExporters.ToMapByDataType()
 }
 
 func (app *Application) RegisterZPages(mux *http.ServeMux, pathPrefix string) {
 	mux.HandleFunc(path.Join(pathPrefix, servicezPath), app.handleServicezRequest)
 	mux.HandleFunc(path.Join(pathPrefix, pipelinezPath), app.handlePipelinezRequest)
+	mux.HandleFunc(path.Join(pathPrefix, pipelinegraphzPath), app.handlePipelineGraphzRequest)
+	mux.HandleFunc(path.Join(pathPrefix, tapzPath), handleTapzRequest)
 	mux.HandleFunc(path.Join(pathPrefix, extensionzPath), app.handleExtensionzRequest)
 }
 
@@ -263,28 +282,118 @@ func (app *Application) setupTelemetry(ballastSizeBytes uint64) error {
 	return nil
 }
 
-// runAndWaitForShutdownEvent waits for one of the shutdown events that can happen.
-func (app *Application) runAndWaitForShutdownEvent() {
+// runAndWaitForShutdownEvent waits for one of the shutdown events that can happen,
+// and reloads the configuration on SIGHUP or when the watched config file changes.
+func (app *Application) runAndWaitForShutdownEvent(ctx context.Context) {
 	app.logger.Info("Everything is ready. Begin running and processing data.")
 
-	// plug SIGTERM signal into a channel.
+	// plug SIGTERM and SIGHUP signals into a channel.
 	app.signalsChannel = make(chan os.Signal, 1)
-	signal.Notify(app.signalsChannel, os.Interrupt, syscall.SIGTERM)
+	signal.Notify(app.signalsChannel, os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
+
+	var configChanged chan struct{}
+	if app.configWatcher != nil {
+		configChanged = app.configWatcher.changed
+	}
 
 	// set the channel to stop testing.
 	app.stopTestChan = make(chan struct{})
 	app.stateChannel <- Running
-	select {
-	case err := <-app.asyncErrorChannel:
-		app.logger.Error("Asynchronous error received, terminating process", zap.Error(err))
-	case s := <-app.signalsChannel:
-		app.logger.Info("Received signal from OS", zap.String("signal", s.String()))
-	case <-app.stopTestChan:
-		app.logger.Info("Received stop test request")
+	for {
+		select {
+		case err := <-app.asyncErrorChannel:
+			app.logger.Error("Asynchronous error received, terminating process", zap.Error(err))
+		case s := <-app.signalsChannel:
+			app.logger.Info("Received signal from OS", zap.String("signal", s.String()))
+			if s == syscall.SIGHUP {
+				if app.reloadConfiguration(ctx) {
+					continue
+				}
+			}
+		case <-configChanged:
+			app.logger.Info("Config file changed")
+			if app.reloadConfiguration(ctx) {
+				continue
+			}
+		case <-app.stopTestChan:
+			app.logger.Info("Received stop test request")
+		}
+		break
 	}
 	app.stateChannel <- Closing
 }
 
+// reloadConfiguration loads the configuration again and rebuilds the receivers, processors and
+// exporters affected by the changes, extensions are left as they are. If the new configuration
+// cannot be loaded or built, the application keeps running with the current one. Returns false if
+// the application must terminate because the rebuilt components failed to start.
+func (app *Application) reloadConfiguration(ctx context.Context) bool {
+	app.logger.Info("Reloading configuration...")
+	cfg, err := app.configFactory(config.NewViper(), app.factories)
+	if err == nil {
+		err = config.ValidateConfig(cfg, app.logger)
+	}
+	if err != nil {
+		app.logger.Error("Cannot load configuration, keeping the current one", zap.Error(err))
+		return true
+	}
+
+	// Extensions are left as they are: the health_check, pprof and zpages extensions can only be
+	// created once per process, so they cannot be rebuilt. They keep their running configuration.
+	if !reflect.DeepEqual(app.config.Extensions, cfg.Extensions) ||
+		!reflect.DeepEqual(app.config.Service.Extensions, cfg.Service.Extensions) {
+		app.logger.Warn("Changes to extensions are applied only when the collector is restarted")
+	}
+	cfg.Extensions = app.config.Extensions
+	cfg.Service.Extensions = app.config.Service.Extensions
+
+	reload, err := builder.BuildReload(app.logger, builder.Running{
+		Config:    app.config,
+		Exporters: app.builtExporters,
+		Pipelines: app.builtPipelines,
+		Receivers: app.builtReceivers,
+	}, cfg, app.factories)
+	if err != nil {
+		app.logger.Error("Cannot build components for the new configuration, keeping the current one", zap.Error(err))
+		return true
+	}
+	if !reload.HasChanges() {
+		app.logger.Info("Configuration did not change.")
+		return true
+	}
+
+	err = reload.Apply(ctx, app)
+	forgetRemovedComponents(app.config, reload.Result.Config)
+	// Components are partially replaced even on errors, track all of them so they are stopped on shutdown.
+	app.componentsMu.Lock()
+	app.config = reload.Result.Config
+	app.builtExporters = reload.Result.Exporters
+	app.builtPipelines = reload.Result.Pipelines
+	app.builtReceivers = reload.Result.Receivers
+	app.componentsMu.Unlock()
+	if err != nil {
+		app.logger.Error("Cannot apply the new configuration, terminating process", zap.Error(err))
+		return false
+	}
+	app.logger.Info("Configuration reloaded.")
+	return true
+}
+
+// forgetRemovedComponents drops the status of the exporters and processors that are not part of
+// the new configuration, so they no longer affect the health of the collector.
+func forgetRemovedComponents(previous, current *configmodels.Config) {
+	for name := range previous.Exporters {
+		if _, ok := current.Exporters[name]; !ok {
+			componentstatus.Remove(componentstatus.KindExporter, name)
+		}
+	}
+	for name := range previous.Processors {
+		if _, ok := current.Processors[name]; !ok {
+			componentstatus.Remove(componentstatus.KindProcessor, name)
+		}
+	}
+}
+
 func (app *Application) setupConfigurationComponents(ctx context.Context, factory ConfigFactory) error {
 	if err := configcheck.ValidateConfigFromFactories(app.factories); err != nil {
 		return err
@@ -331,11 +440,13 @@ func (app *Application) setupPipelines(ctx context.Context) error {
 	// which are referenced before objects which reference them.
 
 	// First create exporters.
-	var err error
-	app.builtExporters, err = builder.NewExportersBuilder(app.logger, app.config, app.factories.Exporters).Build()
+	exporters, err := builder.NewExportersBuilder(app.logger, app.config, app.factories.Exporters).Build()
 	if err != nil {
 		return errors.Wrap(err, "cannot build builtExporters")
 	}
+	app.componentsMu.Lock()
+	app.builtExporters = exporters
+	app.componentsMu.Unlock()
 
 	app.logger.Info("Starting exporters...")
 	err = app.builtExporters.StartAll(ctx, app)
@@ -345,10 +456,13 @@ func (app *Application) setupPipelines(ctx context.Context) error {
 
 	// Create pipelines and their processors and plug exporters to the
 	// end of the pipelines.
-	app.builtPipelines, err = builder.NewPipelinesBuilder(app.logger, app.config, app.builtExporters, app.factories.Processors).Build()
+	pipelines, err := builder.NewPipelinesBuilder(app.logger, app.config, app.builtExporters, app.factories.Processors).Build()
 	if err != nil {
 		return errors.Wrap(err, "cannot build pipelines")
 	}
+	app.componentsMu.Lock()
+	app.builtPipelines = pipelines
+	app.componentsMu.Unlock()
 
 	app.logger.Info("Starting processors...")
 	err = app.builtPipelines.StartProcessors(ctx, app)
@@ -357,10 +471,13 @@ func (app *Application) setupPipelines(ctx context.Context) error {
 	}
 
 	// Create receivers and plug them into the start of the pipelines.
-	app.builtReceivers, err = builder.NewReceiversBuilder(app.logger, app.config, app.builtPipelines, app.factories.Receivers).Build()
+	receivers, err := builder.NewReceiversBuilder(app.logger, app.config, app.builtPipelines, app.factories.Receivers).Build()
 	if err != nil {
 		return errors.Wrap(err, "cannot build receivers")
 	}
+	app.componentsMu.Lock()
+	app.builtReceivers = receivers
+	app.componentsMu.Unlock()
 
 	app.logger.Info("Starting receivers...")
 	err = app.builtReceivers.StartAll(ctx, app)
@@ -409,6 +526,8 @@ func (app *Application) shutdownExtensions(ctx context.Context) error {
 }
 
 func (app *Application) execute(ctx context.Context, factory ConfigFactory) error {
+	app.configFactory = factory
+
 	app.logger.Info("Starting "+app.info.LongName+"...",
 		zap.String("Version", app.info.Version),
 		zap.String("GitHash", app.info.GitHash),
@@ -437,8 +556,15 @@ func (app *Application) execute(ctx context.Context, factory ConfigFactory) erro
 		return err
 	}
 
+	if builder.WatchConfigFile() && builder.GetConfigFile() != "" {
+		app.configWatcher, err = newConfigWatcher(app.logger, builder.GetConfigFile())
+		if err != nil {
+			return errors.Wrap(err, "cannot watch the config file")
+		}
+	}
+
 	// Everything is ready, now run until an event requiring shutdown happens.
-	app.runAndWaitForShutdownEvent()
+	app.runAndWaitForShutdownEvent(ctx)
 
 	// Accumulate errors and proceed with shutting down remaining components.
 	var errs []error
@@ -447,6 +573,12 @@ func (app *Application) execute(ctx context.Context, factory ConfigFactory) erro
 	runtime.KeepAlive(ballast)
 	app.logger.Info("Starting shutdown...")
 
+	if app.configWatcher != nil {
+		if err = app.configWatcher.close(); err != nil {
+			errs = append(errs, errors.Wrap(err, "failed to stop watching the config file"))
+		}
+	}
+
 	err = app.builtExtensions.NotifyPipelineNotReady()
 	if err != nil {
 		errs = append(errs, errors.Wrap(err, "failed to notify that pipeline is not ready"))
@@ -499,6 +631,11 @@ func (app *Application) handleServicezRequest(w http.ResponseWriter, r *http.Req
 		ComponentEndpoint: pipelinezPath,
 		Link:              true,
 	})
+	internal.WriteHTMLComponentHeader(w, internal.ComponentHeaderData{
+		Name:              "Pipeline Graph",
+		ComponentEndpoint: pipelinegraphzPath,
+		Link:              true,
+	})
 	internal.WriteHTMLComponentHeader(w, internal.ComponentHeaderData{
 		Name:              "Extensions",
 		ComponentEndpoint: extensionzPath,
@@ -549,6 +686,8 @@ func (app *Application) getPipelinesSummaryTableData() internal.SummaryPipelines
 		ComponentEndpoint: pipelinezPath,
 	}
 
+	app.componentsMu.RLock()
+	defer app.componentsMu.RUnlock()
 	data.Rows = make([]internal.SummaryPipelinesTableRowData, 0, len(app.builtExtensions))
 	for c, p := range app.builtPipelines {
 		row := internal.SummaryPipelinesTableRowData{
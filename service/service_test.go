@@ -38,7 +38,9 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configmodels"
+	"go.opentelemetry.io/collector/internal/componentstatus"
 	"go.opentelemetry.io/collector/internal/version"
+	"go.opentelemetry.io/collector/receiver/otlpreceiver"
 	"go.opentelemetry.io/collector/service/defaultcomponents"
 	"go.opentelemetry.io/collector/testutil"
 )
@@ -173,6 +175,88 @@ func TestApplication_StartAsGoRoutine(t *testing.T) {
 	assert.Equal(t, Closed, <-app.GetStateChannel())
 }
 
+func TestApplication_ReloadOnSIGHUP(t *testing.T) {
+	factories, err := defaultcomponents.Components()
+	require.NoError(t, err)
+
+	// Use a dedicated port so the receiver does not conflict with the ones of the other tests.
+	receiverEndpoint := testutil.GetAvailableLocalAddress(t)
+	var loaded []*configmodels.Config
+	params := Parameters{
+		ApplicationStartInfo: ApplicationStartInfo{},
+		ConfigFactory: func(v *viper.Viper, factories component.Factories) (*configmodels.Config, error) {
+			cfg := constructMimumalOpConfig(t, factories)
+			cfg.Receivers["otlp"].(*otlpreceiver.Config).GRPC.NetAddr.Endpoint = receiverEndpoint
+			if len(loaded) > 0 {
+				// Add a processor to the pipeline, the receiver and exporter are unchanged.
+				batchCfg := factories.Processors["batch"].CreateDefaultConfig()
+				batchCfg.SetName("batch/reload")
+				cfg.Processors[batchCfg.Name()] = batchCfg
+				cfg.Service.Pipelines["traces"].Processors = append(cfg.Service.Pipelines["traces"].Processors, batchCfg.Name())
+
+				// Enable an extension, which is ignored until the collector is restarted.
+				healthCheckCfg := factories.Extensions["health_check"].CreateDefaultConfig()
+				cfg.Extensions = map[string]configmodels.Extension{healthCheckCfg.Name(): healthCheckCfg}
+				cfg.Service.Extensions = []string{healthCheckCfg.Name()}
+			}
+			loaded = append(loaded, cfg)
+			return cfg, nil
+		},
+		Factories: factories,
+	}
+	app, err := New(params)
+	require.NoError(t, err)
+	app.Command().SetArgs([]string{
+		"--metrics-level=NONE",
+	})
+
+	appDone := make(chan struct{})
+	go func() {
+		defer close(appDone)
+		assert.NoError(t, app.Start())
+	}()
+
+	assert.Equal(t, Starting, <-app.GetStateChannel())
+	assert.Equal(t, Running, <-app.GetStateChannel())
+
+	receivers := app.builtReceivers
+	// The components and the zPages handlers read the exporters and pipelines while they are reloaded.
+	stopReading := make(chan struct{})
+	readingDone := make(chan struct{})
+	go func() {
+		defer close(readingDone)
+		for {
+			select {
+			case <-stopReading:
+				return
+			default:
+				app.GetExporters()
+				app.getPipelinesSummaryTableData()
+				app.getPipelinesGraphData()
+			}
+		}
+	}()
+	app.signalsChannel <- syscall.SIGHUP
+	app.signalsChannel <- syscall.SIGTERM
+	<-appDone
+	close(stopReading)
+	<-readingDone
+	assert.Equal(t, Closing, <-app.GetStateChannel())
+	assert.Equal(t, Closed, <-app.GetStateChannel())
+
+	require.Len(t, loaded, 2)
+	assert.Same(t, loaded[1], app.config)
+	assert.Equal(t, []string{"batch", "batch/reload"}, app.config.Service.Pipelines["traces"].Processors)
+	// The extensions are left as they are.
+	assert.Empty(t, app.config.Service.Extensions)
+	assert.Empty(t, app.config.Extensions)
+	assert.Empty(t, app.GetExtensions())
+	// The receiver is not affected by the change, so it kept running.
+	for cfg, rcv := range app.builtReceivers {
+		assert.Same(t, receivers[loaded[0].Receivers[cfg.Name()]], rcv)
+	}
+}
+
 // isAppAvailable checks if the healthcheck server at the given endpoint is
 // returning `available`.
 func isAppAvailable(t *testing.T, healthCheckEndPoint string) bool {
@@ -547,3 +631,32 @@ service:
 	assert.NoError(t, err)
 	return cfg
 }
+
+func TestForgetRemovedComponents(t *testing.T) {
+	componentstatus.Reset()
+	defer componentstatus.Reset()
+
+	componentstatus.RecordResult(componentstatus.KindExporter, "otlp", errors.New("err"))
+	componentstatus.RecordResult(componentstatus.KindExporter, "jaeger", errors.New("err"))
+	componentstatus.SetStatus(componentstatus.KindProcessor, "memory_limiter", errors.New("err"))
+
+	previous := &configmodels.Config{
+		Exporters: configmodels.Exporters{
+			"otlp":   &configmodels.ExporterSettings{},
+			"jaeger": &configmodels.ExporterSettings{},
+		},
+		Processors: configmodels.Processors{
+			"memory_limiter": &configmodels.ProcessorSettings{},
+		},
+	}
+	current := &configmodels.Config{
+		Exporters: configmodels.Exporters{
+			"otlp": &configmodels.ExporterSettings{},
+		},
+	}
+	forgetRemovedComponents(previous, current)
+
+	snapshot := componentstatus.Snapshot()
+	require.Len(t, snapshot, 1)
+	assert.Equal(t, "otlp", snapshot[0].Name)
+}